@@ -329,6 +329,47 @@ func (p *ConnPool) Update(table, key string, data interface{}) (string, *errors.
 	return saveID, nil
 }
 
+// UpdateAll updates the existing entries of the tables in a single transaction, so that either
+// all of them are written or none of them. data holds the new data of the entries by table and key.
+func (p *ConnPool) UpdateAll(data map[string]map[string]interface{}) *errors.Error {
+	var saveIDs []string
+	var values [][]byte
+	for table, entries := range data {
+		for key, value := range entries {
+			if _, readErr := p.Read(table, key); readErr != nil {
+				if errors.DBKeyNotFound == readErr.ErrNo() {
+					return errors.PackError(readErr.ErrNo(), "error: data with key ", key, " does not exist")
+				}
+				return readErr
+			}
+			jsondata, err := json.Marshal(value)
+			if err != nil {
+				return errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+			}
+			saveIDs = append(saveIDs, table+":"+key)
+			values = append(values, jsondata)
+		}
+	}
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return errors.PackError(errors.UndefinedErrorType, "write DB pool is nil ")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	writeConn.Send("MULTI")
+	for i, saveID := range saveIDs {
+		writeConn.Send("SET", saveID, values[i])
+	}
+	if _, err := writeConn.Do("EXEC"); err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool)), nil)
+			return errs
+		}
+		return errors.PackError(errors.UndefinedErrorType, "Write to DB failed : "+err.Error())
+	}
+	return nil
+}
+
 //Read is for getting singular data
 // Read takes "key" sting as input which acts as a unique ID to fetch specific data from DB
 func (p *ConnPool) Read(table, key string) (string, *errors.Error) {
@@ -527,6 +568,110 @@ func (p *ConnPool) Transaction(key string, cb func(string) error) *errors.Error
 	return nil
 }
 
+// modifyRetries is the number of times Modify retries the write when the data is changed by another client
+const modifyRetries = 10
+
+// Modify atomically updates the data with the given key from its current value, using an optimistic lock:
+// the key is watched while modify computes the new data from the current one, which is "" when there is none,
+// and the whole read-modify-write is retried when another client changes the key in between.
+// The entry is deleted when modify returns nil data, and nothing is written when modify returns an error.
+func (p *ConnPool) Modify(table, key string, modify func(data string) (interface{}, error)) *errors.Error {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return errors.PackError(errors.UndefinedErrorType, "error while trying to modify data: WritePool is nil")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	saveID := table + ":" + key
+	for retries := modifyRetries; retries > 0; retries-- {
+		if _, err := writeConn.Do("WATCH", saveID); err != nil {
+			if errs, aye := isDbConnectError(err); aye {
+				atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool)), nil)
+				return errs
+			}
+			return errors.PackError(errors.UndefinedErrorType, "error while trying to watch data: ", err)
+		}
+		value, err := redis.String(writeConn.Do("GET", saveID))
+		if err != nil && err != redis.ErrNil {
+			writeConn.Do("UNWATCH")
+			return errors.PackError(errors.DBKeyFetchFailed, errorCollectingData, err)
+		}
+		data, err := modify(value)
+		if err != nil {
+			writeConn.Do("UNWATCH")
+			if e, ok := err.(*errors.Error); ok {
+				return e
+			}
+			return errors.PackError(errors.UndefinedErrorType, err)
+		}
+		writeConn.Send("MULTI")
+		if data == nil {
+			writeConn.Send("DEL", saveID)
+		} else {
+			jsondata, err := json.Marshal(data)
+			if err != nil {
+				writeConn.Do("DISCARD")
+				return errors.PackError(errors.UndefinedErrorType, "Write to DB in json form failed: "+err.Error())
+			}
+			writeConn.Send("SET", saveID, jsondata)
+		}
+		reply, err := writeConn.Do("EXEC")
+		if err != nil {
+			return errors.PackError(errors.UndefinedErrorType, "error while trying to modify data: ", err)
+		}
+		// a nil reply means the transaction is aborted, as the key changed since it was watched
+		if reply != nil {
+			return nil
+		}
+	}
+	return errors.PackError(errors.UndefinedErrorType, "error: modification of data with key ", key, " reached max retries")
+}
+
+// AcquireLease takes the lease with the given key for the owner, or renews it when the owner already holds it,
+// it returns whether the owner holds the lease. The lease expires when its owner does not renew it within the
+// lease time, so that the work it guards is taken over by another instance of the service.
+func (p *ConnPool) AcquireLease(key, owner string, lease time.Duration) (bool, *errors.Error) {
+	writePool := (*redis.Pool)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool))))
+	if writePool == nil {
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to acquire lease: WritePool is nil")
+	}
+	writeConn := writePool.Get()
+	defer writeConn.Close()
+	saveID := "Lease:" + key
+	milliseconds := int64(lease / time.Millisecond)
+	reply, err := writeConn.Do("SET", saveID, owner, "NX", "PX", milliseconds)
+	if err != nil {
+		if errs, aye := isDbConnectError(err); aye {
+			atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&p.WritePool)), nil)
+			return false, errs
+		}
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to acquire lease: ", err)
+	}
+	if reply != nil {
+		return true, nil
+	}
+	// the lease is renewed only while the owner still holds it
+	if _, err := writeConn.Do("WATCH", saveID); err != nil {
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to watch lease: ", err)
+	}
+	holder, err := redis.String(writeConn.Do("GET", saveID))
+	if err != nil || holder != owner {
+		writeConn.Do("UNWATCH")
+		if err != nil && err != redis.ErrNil {
+			return false, errors.PackError(errors.DBKeyFetchFailed, errorCollectingData, err)
+		}
+		return false, nil
+	}
+	writeConn.Send("MULTI")
+	writeConn.Send("SET", saveID, owner, "PX", milliseconds)
+	reply, err = writeConn.Do("EXEC")
+	if err != nil {
+		return false, errors.PackError(errors.UndefinedErrorType, "error while trying to renew lease: ", err)
+	}
+	// a nil reply means the lease changed hands since it was watched
+	return reply != nil, nil
+}
+
 // isDbConnectError is for checking if error is dial connection error
 func isDbConnectError(err error) (*errors.Error, bool) {
 	if strings.HasSuffix(err.Error(), "connect: connection refused") || err.Error() == "EOF" {
@@ -195,6 +195,54 @@ func TestUpdate(t *testing.T) {
 
 }
 
+func TestUpdateAll(t *testing.T) {
+	c, err := MockDBConnection()
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	defer func() {
+		c.Delete("table", "key1")
+		c.Delete("otherTable", "key2")
+	}()
+	data := sample{Data1: "Value1", Data2: "Value2", Data3: "Value3"}
+	if cerr := c.Create("table", "key1", data); cerr != nil {
+		t.Fatalf("Error: %v\n", cerr.Error())
+	}
+	if cerr := c.Create("otherTable", "key2", data); cerr != nil {
+		t.Fatalf("Error: %v\n", cerr.Error())
+	}
+	updated := sample{Data1: "Value1", Data2: "Value2", Data3: "Value4"}
+
+	// nothing is written when one of the entries does not exist
+	uerr := c.UpdateAll(map[string]map[string]interface{}{
+		"table":      {"key1": updated},
+		"otherTable": {"nonExistingKey": updated},
+	})
+	if uerr == nil || uerr.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("UpdateAll() of a non existing entry error = %v, want DBKeyNotFound", uerr)
+	}
+	if got, _ := c.Read("table", "key1"); !strings.Contains(got, "Value3") {
+		t.Errorf("UpdateAll() wrote %v though it failed", got)
+	}
+
+	if uerr := c.UpdateAll(map[string]map[string]interface{}{
+		"table":      {"key1": updated},
+		"otherTable": {"key2": updated},
+	}); uerr != nil {
+		t.Fatalf("Error while updating data: %v\n", uerr.Error())
+	}
+	for table, key := range map[string]string{"table": "key1", "otherTable": "key2"} {
+		got, rerr := c.Read(table, key)
+		if rerr != nil {
+			t.Fatalf("Error while read data: %v\n", rerr.Error())
+		}
+		var res sample
+		if jerr := json.Unmarshal([]byte(got), &res); jerr != nil || res != updated {
+			t.Errorf("UpdateAll() saved %v in %v, want %v", got, table, updated)
+		}
+	}
+}
+
 func TestUpdate_invalidData(t *testing.T) {
 	c, err := MockDBConnection()
 	if err != nil {
@@ -493,6 +541,77 @@ func TestTransaction(t *testing.T) {
 	}()
 }
 
+func TestModify(t *testing.T) {
+	const threadCount = 10
+	c, err := MockDBConnection()
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	increment := func(data string) (interface{}, error) {
+		var count int
+		if data != "" {
+			if err := json.Unmarshal([]byte(data), &count); err != nil {
+				return nil, err
+			}
+		}
+		return count + 1, nil
+	}
+	var wg sync.WaitGroup
+	wg.Add(threadCount)
+	for i := 0; i < threadCount; i++ {
+		go func() {
+			defer wg.Done()
+			if err := c.Modify("table", "counter", increment); err != nil {
+				t.Errorf("Error while modifying data: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	got, rerr := c.Read("table", "counter")
+	if rerr != nil {
+		t.Fatal("Error while reading data:", rerr)
+	}
+	if got != strconv.Itoa(threadCount) {
+		t.Errorf("Modify() lost updates, counter = %v, want %v", got, threadCount)
+	}
+
+	// an error of modify leaves the data as it is
+	if err := c.Modify("table", "counter", func(string) (interface{}, error) {
+		return nil, errors.PackError(errors.DBKeyNotFound, "error")
+	}); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("Modify() error = %v, want the error of modify", err)
+	}
+	// nil data deletes the entry
+	if err := c.Modify("table", "counter", func(string) (interface{}, error) { return nil, nil }); err != nil {
+		t.Errorf("Error while modifying data: %v", err)
+	}
+	if _, rerr = c.Read("table", "counter"); rerr == nil || rerr.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("Modify() with nil data did not delete the entry: %v", rerr)
+	}
+}
+
+func TestAcquireLease(t *testing.T) {
+	c, err := MockDBConnection()
+	if err != nil {
+		t.Fatal("Error while making mock DB connection:", err)
+	}
+	defer c.Delete("Lease", "scheduler")
+	if held, err := c.AcquireLease("scheduler", "instance1", time.Second); err != nil || !held {
+		t.Fatalf("AcquireLease() of a free lease = %v, %v, want it held", held, err)
+	}
+	if held, err := c.AcquireLease("scheduler", "instance2", time.Second); err != nil || held {
+		t.Errorf("AcquireLease() of a lease held by another instance = %v, %v, want it not held", held, err)
+	}
+	if held, err := c.AcquireLease("scheduler", "instance1", time.Second); err != nil || !held {
+		t.Errorf("AcquireLease() renewal by the holder = %v, %v, want it held", held, err)
+	}
+	// an expired lease is taken by another instance
+	c.Delete("Lease", "scheduler")
+	if held, err := c.AcquireLease("scheduler", "instance2", time.Second); err != nil || !held {
+		t.Errorf("AcquireLease() of an expired lease = %v, %v, want it held", held, err)
+	}
+}
+
 func TestGetResourceDetails(t *testing.T) {
 
 	c, err := MockDBConnection()
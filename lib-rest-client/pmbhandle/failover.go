@@ -0,0 +1,345 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package pmbhandle
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+)
+
+// PluginInstanceStatusTable is the in-memory table in which the availability of every
+// instance of the plugins running several instances is recorded, keyed by the instance address
+const PluginInstanceStatusTable = "PluginInstanceStatus"
+
+const (
+	// instancesRefreshInterval is how often the instances of the plugins and their status are read again from DB
+	instancesRefreshInterval = 30 * time.Second
+	// unreachableInstanceBackoff is how long an instance which did not answer is tried only as a last resort
+	unreachableInstanceBackoff = time.Minute
+	// sessionPinTimeout is how long a plugin session not used anymore is kept pinned to its instance
+	sessionPinTimeout = 30 * time.Minute
+)
+
+// PluginInstanceStatus is the availability of an instance of a plugin, as found by the status polling
+type PluginInstanceStatus struct {
+	Address     string
+	Available   bool
+	LastChecked string
+}
+
+// pluginData is the part of the plugin data saved in DB which tells where the plugin instances are
+type pluginData struct {
+	ID        string
+	IP        string
+	Port      string
+	Instances []string
+}
+
+// sessionPin is the instance which created a plugin session, sessions are not shared between instances.
+// The login request of the session is kept, so that a session is created on the other instances
+// the calls made with the session fail over to, and the calls are sent there with its token.
+type sessionPin struct {
+	address    string
+	lastUsed   time.Time
+	login      []byte
+	peerTokens map[string]string
+}
+
+// pluginInstances keeps track of the instances of the plugins which run several instances
+// behind one PluginID, to load balance the plugin calls and fail over between them
+// The instances and their status are kept in memory and refreshed in the background, so that
+// the plugin calls do not read them from DB.
+type pluginInstances struct {
+	mutex       sync.Mutex
+	groups      map[string][]string
+	next        map[string]int
+	unreachable map[string]time.Time
+	unavailable map[string]bool
+	sessions    map[string]*sessionPin
+	refreshOnce sync.Once
+}
+
+var instances = pluginInstances{
+	groups:      make(map[string][]string),
+	next:        make(map[string]int),
+	unreachable: make(map[string]time.Time),
+	unavailable: make(map[string]bool),
+	sessions:    make(map[string]*sessionPin),
+}
+
+// PluginAddresses returns the addresses of all the instances of a plugin,
+// the address the plugin was added with being the first one
+func PluginAddresses(ip, port string, instanceAddresses []string) []string {
+	return append([]string{ip + ":" + port}, instanceAddresses...)
+}
+
+// SavePluginInstanceStatus records the availability of an instance of a plugin
+func SavePluginInstanceStatus(status PluginInstanceStatus) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connect to DB: %v", err.Error())
+	}
+	if _, err = conn.Update(PluginInstanceStatusTable, status.Address, status); err != nil {
+		if errors.DBKeyNotFound != err.ErrNo() {
+			return fmt.Errorf("error while trying to update the status of the plugin instance %v: %v", status.Address, err.Error())
+		}
+		if err = conn.Create(PluginInstanceStatusTable, status.Address, status); err != nil {
+			return fmt.Errorf("error while trying to save the status of the plugin instance %v: %v", status.Address, err.Error())
+		}
+	}
+	instances.checked(status)
+	return nil
+}
+
+// GetPluginInstanceStatus returns the last recorded availability of an instance of a plugin
+func GetPluginInstanceStatus(address string) (PluginInstanceStatus, error) {
+	var status PluginInstanceStatus
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return status, fmt.Errorf("error while trying to connect to DB: %v", err.Error())
+	}
+	data, err := conn.Read(PluginInstanceStatusTable, address)
+	if err != nil {
+		return status, fmt.Errorf("error while trying to get the status of the plugin instance %v: %v", address, err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &status); jerr != nil {
+		return status, fmt.Errorf("error while trying to unmarshal the status of the plugin instance %v: %v", address, jerr)
+	}
+	return status, nil
+}
+
+// CheckInstancesStatus checks the status of every given instance of a plugin in parallel and records
+// their availability. It returns true if at least one of the instances is available
+func CheckInstancesStatus(pluginStatus common.PluginStatus, addresses []string) bool {
+	var wg sync.WaitGroup
+	available := make([]bool, len(addresses))
+	for i, address := range addresses {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			instanceStatus := pluginStatus
+			instanceStatus.PluginIP, instanceStatus.PluginPort = splitAddress(address)
+			status, _, _, err := instanceStatus.CheckStatus()
+			if err != nil && !status {
+				log.Printf("warning: plugin instance %v is not available: %v", address, err)
+			}
+			available[i] = status
+			err = SavePluginInstanceStatus(PluginInstanceStatus{
+				Address:     address,
+				Available:   status,
+				LastChecked: time.Now().UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				log.Println("warning: " + err.Error())
+			}
+		}(i, address)
+	}
+	wg.Wait()
+	for _, status := range available {
+		if status {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAddress splits an instance address into its host and port
+func splitAddress(address string) (string, string) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address, ""
+	}
+	return host, port
+}
+
+// candidates returns the instances to send a plugin call made to the given address to,
+// in the order in which they have to be tried. The available instances are taken in turn,
+// the instance which created the session of the call being preferred, the ones found
+// unavailable are kept as a last resort.
+func (p *pluginInstances) candidates(address, token string) []string {
+	p.refreshOnce.Do(p.startRefresh)
+	p.mutex.Lock()
+	group := p.groups[address]
+	if len(group) < 2 {
+		p.mutex.Unlock()
+		return []string{address}
+	}
+	start := p.next[group[0]]
+	p.next[group[0]] = (start + 1) % len(group)
+	ordered := make([]string, 0, len(group))
+	if pin, ok := p.sessions[token]; ok && token != "" {
+		pin.lastUsed = time.Now()
+		ordered = append(ordered, pin.address)
+	}
+	for i := range group {
+		instance := group[(start+i)%len(group)]
+		if len(ordered) == 0 || instance != ordered[0] {
+			ordered = append(ordered, instance)
+		}
+	}
+
+	var available, unavailable []string
+	for _, instance := range ordered {
+		if p.isAvailable(instance) {
+			available = append(available, instance)
+		} else {
+			unavailable = append(unavailable, instance)
+		}
+	}
+	p.mutex.Unlock()
+	return append(available, unavailable...)
+}
+
+// isAvailable tells if an instance answered lately and was not found unavailable by the status polling,
+// an instance which was not checked yet is available. The caller must hold the mutex
+func (p *pluginInstances) isAvailable(address string) bool {
+	if until, ok := p.unreachable[address]; ok && time.Now().Before(until) {
+		return false
+	}
+	return !p.unavailable[address]
+}
+
+// served records that an instance answered a plugin call, and the session it created if any along with its login request
+func (p *pluginInstances) served(address, token string, login []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.unreachable, address)
+	if token != "" && len(p.groups[address]) > 1 {
+		p.sessions[token] = &sessionPin{address: address, lastUsed: time.Now(), login: login}
+	}
+}
+
+// sessionOn returns the token to send a call made with the given token to an instance with.
+// When the session of the token was created on another instance, a session is created on the instance
+// with the login request of the session, and login is called with the request to create it
+func (p *pluginInstances) sessionOn(address, token string, login func([]byte) (string, error)) (string, error) {
+	p.mutex.Lock()
+	pin, ok := p.sessions[token]
+	if !ok || token == "" || pin.address == address || pin.login == nil {
+		p.mutex.Unlock()
+		return token, nil
+	}
+	if peerToken, ok := pin.peerTokens[address]; ok {
+		p.mutex.Unlock()
+		return peerToken, nil
+	}
+	loginRequest := pin.login
+	p.mutex.Unlock()
+
+	peerToken, err := login(loginRequest)
+	if err != nil {
+		return "", err
+	}
+	p.mutex.Lock()
+	if pin.peerTokens == nil {
+		pin.peerTokens = make(map[string]string)
+	}
+	pin.peerTokens[address] = peerToken
+	p.mutex.Unlock()
+	return peerToken, nil
+}
+
+// checked records the availability of an instance found by the status polling
+func (p *pluginInstances) checked(status PluginInstanceStatus) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.unavailable[status.Address] = !status.Available
+	if status.Available {
+		delete(p.unreachable, status.Address)
+	}
+}
+
+// unreachableFor records that an instance did not answer a plugin call
+func (p *pluginInstances) unreachableFor(address string, backoff time.Duration) {
+	p.mutex.Lock()
+	p.unreachable[address] = time.Now().Add(backoff)
+	p.mutex.Unlock()
+}
+
+// startRefresh reads the instances of the plugins and their status, and keeps reading them in the background
+func (p *pluginInstances) startRefresh() {
+	p.refresh()
+	go func() {
+		for range time.Tick(instancesRefreshInterval) {
+			p.refresh()
+		}
+	}()
+}
+
+// refresh reads again the instances of the plugins and their status from DB
+func (p *pluginInstances) refresh() {
+	groups, err := getPluginGroups()
+	if err != nil {
+		log.Println("warning: unable to get the instances of the plugins: " + err.Error())
+		return
+	}
+	unavailable := make(map[string]bool)
+	for address := range groups {
+		status, err := GetPluginInstanceStatus(address)
+		if err != nil {
+			// the instance was not checked yet
+			continue
+		}
+		unavailable[address] = !status.Available
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.groups = groups
+	p.unavailable = unavailable
+	for token, pin := range p.sessions {
+		if time.Since(pin.lastUsed) > sessionPinTimeout || len(p.groups[pin.address]) < 2 {
+			delete(p.sessions, token)
+		}
+	}
+}
+
+// getPluginGroups returns, for every instance of the plugins running several instances,
+// the addresses of all the instances of its plugin
+func getPluginGroups() (map[string][]string, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to connect to DB: %v", err.Error())
+	}
+	keys, err := conn.GetAllDetails("Plugin")
+	if err != nil {
+		return nil, fmt.Errorf("error while trying to get the plugins: %v", err.Error())
+	}
+	groups := make(map[string][]string)
+	for _, key := range keys {
+		data, err := conn.Read("Plugin", key)
+		if err != nil {
+			return nil, fmt.Errorf("error while trying to get the plugin %v: %v", key, err.Error())
+		}
+		var plugin pluginData
+		if jerr := json.Unmarshal([]byte(data), &plugin); jerr != nil {
+			return nil, fmt.Errorf("error while trying to unmarshal the plugin %v: %v", key, jerr)
+		}
+		if len(plugin.Instances) == 0 {
+			continue
+		}
+		addresses := PluginAddresses(plugin.IP, plugin.Port, plugin.Instances)
+		for _, address := range addresses {
+			groups[address] = addresses
+		}
+	}
+	return groups, nil
+}
@@ -18,18 +18,107 @@ package pmbhandle
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"net"
 	"net/http"
+	neturl "net/url"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 )
 
-//ContactPlugin is used to send a request to plugin to add a resource
+//ContactPlugin is used to send a request to plugin to add a resource.
+// When the plugin runs several instances, the request is sent to one of its available
+// instances, and to the next one when an instance cannot be reached. A request which may
+// have been received by the instance is sent again to the next one only when it is a read.
 func ContactPlugin(url, method, token string, odataID string, body interface{}, basicAuth map[string]string) (*http.Response, error) {
 	jsonStr, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
+	pluginURL, err := neturl.Parse(url)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	addresses := instances.candidates(pluginURL.Host, token)
+	for i, address := range addresses {
+		pluginURL.Host = address
+		var resp *http.Response
+		resp, err = contactInstance(pluginURL, method, token, odataID, jsonStr, basicAuth)
+		if err == nil {
+			var login []byte
+			if method == http.MethodPost && pluginURL.Path == sessionsURI {
+				login = jsonStr
+			}
+			instances.served(address, resp.Header.Get("X-Auth-Token"), login)
+			return resp, nil
+		}
+		if len(addresses) > 1 {
+			instances.unreachableFor(address, unreachableInstanceBackoff)
+			if !retriable(method, err) {
+				log.Printf("warning: plugin instance %v failed to answer a %v request which may have been received, it is not sent to another instance: %v", address, method, err)
+				return nil, err
+			}
+			if i < len(addresses)-1 {
+				log.Printf("warning: plugin instance %v is unreachable, trying the next instance: %v", address, err)
+			}
+		}
+	}
+	return nil, err
+}
+
+// sessionsURI is the URI on which the sessions are created on the plugins
+const sessionsURI = "/ODIM/v1/Sessions"
+
+// contactInstance sends a request to an instance of a plugin, with a token valid on the instance
+func contactInstance(pluginURL *neturl.URL, method, token string, odataID string, jsonStr []byte, basicAuth map[string]string) (*http.Response, error) {
+	instanceToken, err := instances.sessionOn(pluginURL.Host, token, func(login []byte) (string, error) {
+		loginURL := *pluginURL
+		loginURL.Path = sessionsURI
+		resp, err := contactPluginInstance(loginURL.String(), http.MethodPost, "", "", login, nil)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("error while trying to create a session on the plugin instance %v: %v", pluginURL.Host, resp.Status)
+		}
+		return resp.Header.Get("X-Auth-Token"), nil
+	})
+	if err != nil {
+		return nil, &sessionError{err: err}
+	}
+	return contactPluginInstance(pluginURL.String(), method, instanceToken, odataID, jsonStr, basicAuth)
+}
+
+// sessionError is the failure to create a session on an instance, the request was not sent to the instance
+type sessionError struct {
+	err error
+}
+
+func (e *sessionError) Error() string {
+	return e.err.Error()
+}
+
+// retriable tells if a request which failed can be sent to another instance of the plugin:
+// reads are, and so is any request which the instance did not receive, as the connection to the instance
+// could not be established or no session could be created on it. The other requests may have been carried out by the instance.
+func retriable(method string, err error) bool {
+	if method == http.MethodGet || method == http.MethodHead {
+		return true
+	}
+	var sessionErr *sessionError
+	if errors.As(err, &sessionErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// contactPluginInstance sends a request to one instance of a plugin
+func contactPluginInstance(url, method, token string, odataID string, jsonStr []byte, basicAuth map[string]string) (*http.Response, error) {
 	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonStr))
 	if err != nil {
 		log.Println(err)
@@ -0,0 +1,75 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+
+// Package common ...
+package common
+
+import (
+	"encoding/json"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/google/uuid"
+)
+
+// the version of the credentials cached by the API gateway is kept in the in-memory DB,
+// so that all the instances of the gateway and the account service share it
+const (
+	authCacheTable      = "AuthCache"
+	authCacheVersionKey = "Version"
+)
+
+// InvalidateAuthCache changes the version of the credentials cached by the API gateway, every instance
+// of the gateway drops the credentials it cached with the previous versions. It is called when an account,
+// a role or a session changes, so that an old password or a deleted session is never accepted from a cache.
+func InvalidateAuthCache() *errors.Error {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connect to DB: ", err.Error())
+	}
+	// every invalidation sets a new version, the concurrent ones all change it
+	version := uuid.New().String()
+	if _, err = conn.Update(authCacheTable, authCacheVersionKey, version); err != nil && err.ErrNo() == errors.DBKeyNotFound {
+		err = conn.Create(authCacheTable, authCacheVersionKey, version)
+		if err != nil && err.ErrNo() == errors.DBKeyAlreadyExist {
+			_, err = conn.Update(authCacheTable, authCacheVersionKey, version)
+		}
+	}
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to invalidate auth cache: ", err.Error())
+	}
+	return nil
+}
+
+// AuthCacheVersion returns the version of the credentials cached by the API gateway,
+// the credentials cached with another version are not to be used
+func AuthCacheVersion() (string, *errors.Error) {
+	conn, err := GetDBConnection(InMemory)
+	if err != nil {
+		return "", errors.PackError(err.ErrNo(), "error while trying to connect to DB: ", err.Error())
+	}
+	data, err := conn.Read(authCacheTable, authCacheVersionKey)
+	if err != nil {
+		// the credentials are cached with an empty version until the first invalidation
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	var version string
+	if jerr := json.Unmarshal([]byte(data), &version); jerr != nil {
+		return "", errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal auth cache version: ", jerr)
+	}
+	return version, nil
+}
@@ -0,0 +1,41 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+
+package common
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+)
+
+func TestInvalidateAuthCache(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer TruncateDB(InMemory)
+	version, err := AuthCacheVersion()
+	if err != nil {
+		t.Fatalf("AuthCacheVersion() error = %v", err)
+	}
+	if err := InvalidateAuthCache(); err != nil {
+		t.Fatalf("InvalidateAuthCache() error = %v", err)
+	}
+	newVersion, err := AuthCacheVersion()
+	if err != nil {
+		t.Fatalf("AuthCacheVersion() error = %v", err)
+	}
+	if newVersion == version {
+		t.Errorf("AuthCacheVersion() after InvalidateAuthCache() = %v, want another version than %v", newVersion, version)
+	}
+}
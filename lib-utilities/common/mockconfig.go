@@ -26,6 +26,7 @@ const localhost = "127.0.0.1"
 // SetUpMockConfig set ups a mock configuration for unit testing
 func SetUpMockConfig() error {
 	workingDir, _ := os.Getwd()
+	respondToUnauthenticatedClients := true
 
 	path := strings.SplitAfter(workingDir, "ODIM")
 	var basePath string
@@ -49,6 +50,12 @@ func SetUpMockConfig() error {
 	config.Data.AuthConf = &config.AuthConf{
 		SessionTimeOutInMins:            30,
 		ExpiredSessionCleanUpTimeInMins: 15,
+		BasicAuthCacheTimeOutInSecs:     60,
+		SessionLimitAction:              "EvictOldest",
+		ClientCertificate: &config.ClientCertificate{
+			RespondToUnauthenticatedClients: &respondToUnauthenticatedClients,
+			CertificateMappingAttribute:     "CommonName",
+		},
 	}
 	config.Data.APIGatewayConf = &config.APIGatewayConf{
 		Port: "9090",
@@ -84,6 +91,8 @@ func SetUpMockConfig() error {
 		MinPasswordLength:       12,
 		MaxPasswordLength:       16,
 		AllowedSpecialCharcters: "~!@#$%^&*-+_|(){}:;<>,.?/",
+		PasswordExpirationDays:  0,
+		PasswordHistoryDepth:    0,
 	}
 	config.Data.RootServiceUUID = "3bd1f589-117a-4cf9-89f2-da44ee8e012b"
 	config.Data.FirmwareVersion = "1.0"
@@ -93,6 +102,15 @@ func SetUpMockConfig() error {
 		MaxResetPriority:    10,
 		MaxResetDelayInSecs: 36000,
 	}
+	config.Data.InventoryCrawlConf = &config.InventoryCrawlConf{
+		MaxConcurrentRequestsPerBMC: 4,
+		MaxConcurrentRequests:       16,
+	}
+	config.Data.InventoryRefreshConf = &config.InventoryRefreshConf{
+		IntervalInMinutes:  0,
+		MaxJitterInSeconds: 0,
+		MaxConcurrentBMCs:  2,
+	}
 	config.Data.PluginStatusPolling = &config.PluginStatusPolling{
 		MaxRetryAttempt:         1,
 		RetryIntervalInMins:     1,
@@ -16,6 +16,7 @@
 package config
 
 import (
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -48,6 +49,8 @@ type configModel struct {
 	URLTranslation                 *URLTranslation          `json:"URLTranslation"`
 	PluginStatusPolling            *PluginStatusPolling     `json:"PluginStatusPolling"`
 	ExecPriorityDelayConf          *ExecPriorityDelayConf   `json:"ExecPriorityDelayConf"`
+	InventoryCrawlConf             *InventoryCrawlConf      `json:"InventoryCrawlConf"`
+	InventoryRefreshConf           *InventoryRefreshConf    `json:"InventoryRefreshConf"`
 	TLSConf                        *TLSConf                 `json:"TLSConf"`
 	SupportedPluginTypes           []string                 `json:"SupportedPluginTypes"`
 	ConnectionMethodConf           []ConnectionMethodConf   `json:"ConnectionMethodConf"`
@@ -85,9 +88,23 @@ type KeyCertConf struct {
 
 // AuthConf holds all authentication related configurations
 type AuthConf struct {
-	SessionTimeOutInMins            float64        `json:"SessionTimeOutInMins"`
-	ExpiredSessionCleanUpTimeInMins float64        `json:"ExpiredSessionCleanUpTimeInMins"`
-	PasswordRules                   *PasswordRules `json:"PasswordRules"`
+	SessionTimeOutInMins            float64            `json:"SessionTimeOutInMins"`
+	ExpiredSessionCleanUpTimeInMins float64            `json:"ExpiredSessionCleanUpTimeInMins"`
+	BasicAuthCacheTimeOutInSecs     int                `json:"BasicAuthCacheTimeOutInSecs"` // holds the duration for which API gateway caches a verified basic auth credential
+	MaxSessionsPerUser              int                `json:"MaxSessionsPerUser"`          // holds the max number of active sessions of a user, 0 means no limit
+	SessionLimitAction              string             `json:"SessionLimitAction"`          // holds the action taken when a user exceeds MaxSessionsPerUser, EvictOldest or Reject
+	PasswordRules                   *PasswordRules     `json:"PasswordRules"`
+	ClientCertificate               *ClientCertificate `json:"ClientCertificate"`
+}
+
+// ClientCertificate defines the client certificate authentication of the northbound API
+type ClientCertificate struct {
+	Enabled                         bool   `json:"Enabled"`                         // holds whether the API gateway requests a client certificate from the clients
+	RespondToUnauthenticatedClients *bool  `json:"RespondToUnauthenticatedClients"` // holds whether the API gateway serves the clients without a certificate, true by default
+	CertificateMappingAttribute     string `json:"CertificateMappingAttribute"`     // holds the client certificate field mapped to the account, CommonName or UserPrincipalName
+	CACertificatePath               string `json:"CACertificatePath"`               // holds the path of the CA certificates the client certificates are issued by
+	CertificateRevocationListPath   string `json:"CertificateRevocationListPath"`   // holds the path of the CRLs of the client CAs, empty means no revocation check
+	CACertificate                   []byte
 }
 
 // PasswordRules defines rules for password complexity
@@ -95,6 +112,8 @@ type PasswordRules struct {
 	MinPasswordLength       int    `json:"MinPasswordLength"`       // holds the value  of min password length
 	MaxPasswordLength       int    `json:"MaxPasswordLength"`       // holds the value of max password length
 	AllowedSpecialCharcters string `json:"AllowedSpecialCharcters"` // holds all value of  all sppecial charcters
+	PasswordExpirationDays  int    `json:"PasswordExpirationDays"`  // holds the number of days after which a password expires, 0 means never
+	PasswordHistoryDepth    int    `json:"PasswordHistoryDepth"`    // holds the number of most recent passwords, including the current one, which can not be reused, 0 means no check
 }
 
 // APIGatewayConf holds API gateway related configurations
@@ -127,6 +146,8 @@ type PluginStatusPolling struct {
 	RetryIntervalInMins     int `json:"RetryIntervalInMins"`    // holds value of  duration in which retry of status polling to be intiated,value will be in minutes
 	ResponseTimeoutInSecs   int `json:"ResponseTimeoutInSecs"`  // holds value of duation in which it need wait for resposne ,value will be in seconds
 	StartUpResouceBatchSize int `json:"StartUpResouceBatchSize"`
+	// holds value of duration in which the event subscriptions of the servers are reconciled, value will be in minutes
+	SubscriptionReconciliationIntervalInMins int `json:"SubscriptionReconciliationIntervalInMins"`
 }
 
 // ExecPriorityDelayConf holds priority and delay configurations for exec actions
@@ -136,6 +157,19 @@ type ExecPriorityDelayConf struct {
 	MaxResetDelayInSecs int `json:"MaxResetDelayInSecs"`
 }
 
+// InventoryCrawlConf holds the concurrency limits of the inventory retrieval of the added servers
+type InventoryCrawlConf struct {
+	MaxConcurrentRequestsPerBMC int `json:"MaxConcurrentRequestsPerBMC"` // holds the max number of parallel plugin requests made for the inventory of a server
+	MaxConcurrentRequests       int `json:"MaxConcurrentRequests"`       // holds the max number of parallel plugin requests made for the inventory of all the servers
+}
+
+// InventoryRefreshConf holds the schedule of the periodic inventory refresh of the added servers
+type InventoryRefreshConf struct {
+	IntervalInMinutes  int `json:"IntervalInMinutes"`  // holds the time between two inventory refreshes of a server, 0 disables the refresh
+	MaxJitterInSeconds int `json:"MaxJitterInSeconds"` // holds the max random delay added before the refresh of each server
+	MaxConcurrentBMCs  int `json:"MaxConcurrentBMCs"`  // holds the max number of servers refreshed in parallel
+}
+
 // TLSConf holds TLS confifurations used in https queries
 type TLSConf struct {
 	VerifyPeer            bool     `json:"VerifyPeer"`
@@ -192,11 +226,15 @@ func ValidateConfiguration() error {
 	if err = checkConnectionMethodConf(); err != nil {
 		return err
 	}
-	checkAuthConf()
+	if err = checkAuthConf(); err != nil {
+		return err
+	}
 	checkAddComputeSkipResources()
 	checkURLTranslation()
 	checkPluginStatusPolling()
 	checkExecPriorityDelayConf()
+	checkInventoryCrawlConf()
+	checkInventoryRefreshConf()
 
 	return nil
 }
@@ -308,19 +346,26 @@ func checkKeyCertConf() error {
 	return nil
 }
 
-func checkAuthConf() {
+func checkAuthConf() error {
 	if Data.AuthConf == nil {
 		log.Println("warn: no value found for AuthConf, setting default value")
+		respondToUnauthenticatedClients := DefaultRespondToUnauthenticatedClients
 		Data.AuthConf = &AuthConf{
 			SessionTimeOutInMins:            DefaultSessionTimeOutInMins,
 			ExpiredSessionCleanUpTimeInMins: DefaultExpiredSessionCleanUpTimeInMins,
+			BasicAuthCacheTimeOutInSecs:     DefaultBasicAuthCacheTimeOutInSecs,
+			SessionLimitAction:              DefaultSessionLimitAction,
 			PasswordRules: &PasswordRules{
 				MinPasswordLength:       DefaultMinPasswordLength,
 				MaxPasswordLength:       DefaultMaxPasswordLength,
 				AllowedSpecialCharcters: DefaultAllowedSpecialCharcters,
 			},
+			ClientCertificate: &ClientCertificate{
+				RespondToUnauthenticatedClients: &respondToUnauthenticatedClients,
+				CertificateMappingAttribute:     DefaultCertificateMappingAttribute,
+			},
 		}
-		return
+		return nil
 	}
 	if Data.AuthConf.SessionTimeOutInMins == 0 {
 		log.Println("warn: no value set for SessionTimeOutInMin, setting default value")
@@ -330,7 +375,62 @@ func checkAuthConf() {
 		log.Println("warn: no value set for ExpiredSessionCleanUpTimeInMins, setting default value")
 		Data.AuthConf.ExpiredSessionCleanUpTimeInMins = DefaultExpiredSessionCleanUpTimeInMins
 	}
+	if Data.AuthConf.BasicAuthCacheTimeOutInSecs <= 0 {
+		log.Println("warn: no value set for BasicAuthCacheTimeOutInSecs, setting default value")
+		Data.AuthConf.BasicAuthCacheTimeOutInSecs = DefaultBasicAuthCacheTimeOutInSecs
+	}
+	if Data.AuthConf.MaxSessionsPerUser < 0 {
+		log.Println("warn: invalid value set for MaxSessionsPerUser, disabling the session limit")
+		Data.AuthConf.MaxSessionsPerUser = 0
+	}
+	switch Data.AuthConf.SessionLimitAction {
+	case SessionLimitActionEvictOldest, SessionLimitActionReject:
+	case "":
+		log.Println("warn: no value set for SessionLimitAction, setting default value")
+		Data.AuthConf.SessionLimitAction = DefaultSessionLimitAction
+	default:
+		log.Println("warn: invalid value set for SessionLimitAction, setting default value")
+		Data.AuthConf.SessionLimitAction = DefaultSessionLimitAction
+	}
 	checkPasswordRulesConf()
+	return checkClientCertificateConf()
+}
+
+func checkClientCertificateConf() error {
+	respondToUnauthenticatedClients := DefaultRespondToUnauthenticatedClients
+	if Data.AuthConf.ClientCertificate == nil {
+		log.Println("warn: ClientCertificate configuration is found empty, client certificate authentication is disabled")
+		Data.AuthConf.ClientCertificate = &ClientCertificate{
+			RespondToUnauthenticatedClients: &respondToUnauthenticatedClients,
+			CertificateMappingAttribute:     DefaultCertificateMappingAttribute,
+		}
+		return nil
+	}
+	if Data.AuthConf.ClientCertificate.RespondToUnauthenticatedClients == nil {
+		log.Println("warn: no value set for RespondToUnauthenticatedClients, setting default value")
+		Data.AuthConf.ClientCertificate.RespondToUnauthenticatedClients = &respondToUnauthenticatedClients
+	}
+	switch Data.AuthConf.ClientCertificate.CertificateMappingAttribute {
+	case CertificateMappingCommonName, CertificateMappingUserPrincipalName:
+	case "":
+		log.Println("warn: no value set for CertificateMappingAttribute, setting default value")
+		Data.AuthConf.ClientCertificate.CertificateMappingAttribute = DefaultCertificateMappingAttribute
+	default:
+		log.Println("warn: invalid value set for CertificateMappingAttribute, setting default value")
+		Data.AuthConf.ClientCertificate.CertificateMappingAttribute = DefaultCertificateMappingAttribute
+	}
+	if !Data.AuthConf.ClientCertificate.Enabled {
+		return nil
+	}
+	// the client certificates are issued by a CA of the clients, not by the CA of the ODIMRA services
+	var err error
+	if Data.AuthConf.ClientCertificate.CACertificate, err = ioutil.ReadFile(Data.AuthConf.ClientCertificate.CACertificatePath); err != nil {
+		return fmt.Errorf("error: value check failed for CACertificatePath:%s with %v", Data.AuthConf.ClientCertificate.CACertificatePath, err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(Data.AuthConf.ClientCertificate.CACertificate) {
+		return fmt.Errorf("error: value check failed for CACertificatePath:%s, no certificate found", Data.AuthConf.ClientCertificate.CACertificatePath)
+	}
+	return nil
 }
 
 func checkPasswordRulesConf() {
@@ -355,6 +455,14 @@ func checkPasswordRulesConf() {
 		log.Println("warn: no value set for AllowedSpecialCharcters, setting default value")
 		Data.AuthConf.PasswordRules.AllowedSpecialCharcters = DefaultAllowedSpecialCharcters
 	}
+	if Data.AuthConf.PasswordRules.PasswordExpirationDays < 0 {
+		log.Println("warn: invalid value set for PasswordExpirationDays, passwords will not expire")
+		Data.AuthConf.PasswordRules.PasswordExpirationDays = 0
+	}
+	if Data.AuthConf.PasswordRules.PasswordHistoryDepth < 0 {
+		log.Println("warn: invalid value set for PasswordHistoryDepth, password history will not be checked")
+		Data.AuthConf.PasswordRules.PasswordHistoryDepth = 0
+	}
 }
 
 func checkAPIGatewayConf() error {
@@ -432,11 +540,12 @@ func checkPluginStatusPolling() {
 	if Data.PluginStatusPolling == nil {
 		log.Println("warn: PluginStatusPolling not provided, setting default value")
 		Data.PluginStatusPolling = &PluginStatusPolling{
-			PollingFrequencyInMins:  DefaultPollingFrequencyInMins,
-			MaxRetryAttempt:         DefaultMaxRetryAttempt,
-			RetryIntervalInMins:     DefaultRetryIntervalInMins,
-			ResponseTimeoutInSecs:   DefaultResponseTimeoutInSecs,
-			StartUpResouceBatchSize: DefaultStartUpResouceBatchSize,
+			PollingFrequencyInMins:                   DefaultPollingFrequencyInMins,
+			MaxRetryAttempt:                          DefaultMaxRetryAttempt,
+			RetryIntervalInMins:                      DefaultRetryIntervalInMins,
+			ResponseTimeoutInSecs:                    DefaultResponseTimeoutInSecs,
+			StartUpResouceBatchSize:                  DefaultStartUpResouceBatchSize,
+			SubscriptionReconciliationIntervalInMins: DefaultSubscriptionReconciliationIntervalInMins,
 		}
 		return
 	}
@@ -460,6 +569,10 @@ func checkPluginStatusPolling() {
 		log.Println("warn: no value found for StartUpResouceBatchSize, setting default value")
 		Data.PluginStatusPolling.StartUpResouceBatchSize = DefaultStartUpResouceBatchSize
 	}
+	if Data.PluginStatusPolling.SubscriptionReconciliationIntervalInMins <= 0 {
+		log.Println("warn: no value found for SubscriptionReconciliationIntervalInMins, setting default value")
+		Data.PluginStatusPolling.SubscriptionReconciliationIntervalInMins = DefaultSubscriptionReconciliationIntervalInMins
+	}
 }
 
 func checkExecPriorityDelayConf() {
@@ -487,6 +600,51 @@ func checkExecPriorityDelayConf() {
 	}
 }
 
+func checkInventoryCrawlConf() {
+	if Data.InventoryCrawlConf == nil {
+		log.Println("warn: InventoryCrawlConf not provided, setting default value")
+		Data.InventoryCrawlConf = &InventoryCrawlConf{
+			MaxConcurrentRequestsPerBMC: DefaultMaxConcurrentRequestsPerBMC,
+			MaxConcurrentRequests:       DefaultMaxConcurrentRequests,
+		}
+		return
+	}
+	if Data.InventoryCrawlConf.MaxConcurrentRequestsPerBMC <= 0 {
+		log.Println("warn: no value found for MaxConcurrentRequestsPerBMC, setting default value")
+		Data.InventoryCrawlConf.MaxConcurrentRequestsPerBMC = DefaultMaxConcurrentRequestsPerBMC
+	}
+	if Data.InventoryCrawlConf.MaxConcurrentRequests <= 0 {
+		log.Println("warn: no value found for MaxConcurrentRequests, setting default value")
+		Data.InventoryCrawlConf.MaxConcurrentRequests = DefaultMaxConcurrentRequests
+	}
+	if Data.InventoryCrawlConf.MaxConcurrentRequestsPerBMC > Data.InventoryCrawlConf.MaxConcurrentRequests {
+		log.Println("warn: MaxConcurrentRequestsPerBMC is more than MaxConcurrentRequests, setting it to MaxConcurrentRequests")
+		Data.InventoryCrawlConf.MaxConcurrentRequestsPerBMC = Data.InventoryCrawlConf.MaxConcurrentRequests
+	}
+}
+
+func checkInventoryRefreshConf() {
+	if Data.InventoryRefreshConf == nil {
+		log.Println("warn: InventoryRefreshConf not provided, periodic inventory refresh is disabled")
+		Data.InventoryRefreshConf = &InventoryRefreshConf{
+			MaxConcurrentBMCs: DefaultInventoryRefreshMaxConcurrentBMCs,
+		}
+		return
+	}
+	if Data.InventoryRefreshConf.IntervalInMinutes < 0 {
+		log.Println("warn: invalid value found for IntervalInMinutes, periodic inventory refresh is disabled")
+		Data.InventoryRefreshConf.IntervalInMinutes = 0
+	}
+	if Data.InventoryRefreshConf.MaxJitterInSeconds < 0 {
+		log.Println("warn: invalid value found for MaxJitterInSeconds, setting it to 0")
+		Data.InventoryRefreshConf.MaxJitterInSeconds = 0
+	}
+	if Data.InventoryRefreshConf.MaxConcurrentBMCs <= 0 {
+		log.Println("warn: no value found for MaxConcurrentBMCs, setting default value")
+		Data.InventoryRefreshConf.MaxConcurrentBMCs = DefaultInventoryRefreshMaxConcurrentBMCs
+	}
+}
+
 func checkTLSConf() error {
 	if Data.TLSConf == nil {
 		log.Println("warn: TLSConf not provided, setting default value")
@@ -470,3 +470,43 @@ func TestValidateConfigurationGroup3(t *testing.T) {
 	}
 	os.Remove(sampleFileForTest)
 }
+
+func TestCheckClientCertificateConf(t *testing.T) {
+	caFile := filepath.Join(cwdDir, "client_ca_for_ut.test")
+	createFile(t, caFile, string(hostCA))
+	notCAFile := filepath.Join(cwdDir, sampleFileName)
+	createFile(t, notCAFile, sampleFileContent)
+	defer func() {
+		os.Remove(caFile)
+		os.Remove(notCAFile)
+		Data.AuthConf = nil
+	}()
+
+	Data.AuthConf = &AuthConf{ClientCertificate: &ClientCertificate{}}
+	if err := checkClientCertificateConf(); err != nil {
+		t.Fatalf("checkClientCertificateConf() error = %v", err)
+	}
+	clientCertificate := Data.AuthConf.ClientCertificate
+	if clientCertificate.RespondToUnauthenticatedClients == nil || !*clientCertificate.RespondToUnauthenticatedClients {
+		t.Errorf("checkClientCertificateConf() RespondToUnauthenticatedClients = %v, want the default value", clientCertificate.RespondToUnauthenticatedClients)
+	}
+	if clientCertificate.CertificateMappingAttribute != DefaultCertificateMappingAttribute {
+		t.Errorf("checkClientCertificateConf() CertificateMappingAttribute = %v, want the default value", clientCertificate.CertificateMappingAttribute)
+	}
+
+	clientCertificate.Enabled = true
+	if err := checkClientCertificateConf(); err == nil {
+		t.Errorf("checkClientCertificateConf() should fail without CACertificatePath")
+	}
+	clientCertificate.CACertificatePath = notCAFile
+	if err := checkClientCertificateConf(); err == nil {
+		t.Errorf("checkClientCertificateConf() should fail when CACertificatePath has no certificate")
+	}
+	clientCertificate.CACertificatePath = caFile
+	if err := checkClientCertificateConf(); err != nil {
+		t.Fatalf("checkClientCertificateConf() error = %v", err)
+	}
+	if string(clientCertificate.CACertificate) != string(hostCA) {
+		t.Errorf("checkClientCertificateConf() should load the client CA certificate")
+	}
+}
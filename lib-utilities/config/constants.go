@@ -29,6 +29,21 @@ const (
 	Client
 )
 
+const (
+	// SessionLimitActionEvictOldest deletes the oldest session of the user when the session limit is reached
+	SessionLimitActionEvictOldest = "EvictOldest"
+	// SessionLimitActionReject fails the session creation when the session limit is reached
+	SessionLimitActionReject = "Reject"
+)
+
+const (
+	// CertificateMappingCommonName maps the common name in the subject of the client certificate to the account
+	CertificateMappingCommonName = "CommonName"
+	// CertificateMappingUserPrincipalName maps the user principal name in the subject alternative names
+	// of the client certificate to the account
+	CertificateMappingUserPrincipalName = "UserPrincipalName"
+)
+
 const (
 	// DefaultFirmwareVersion - default FirmwareVersion value
 	DefaultFirmwareVersion = "1.0"
@@ -36,6 +51,14 @@ const (
 	DefaultSessionTimeOutInMins = 30
 	// DefaultExpiredSessionCleanUpTimeInMins - default ExpiredSessionCleanUpTimeInMins value
 	DefaultExpiredSessionCleanUpTimeInMins = 15
+	// DefaultBasicAuthCacheTimeOutInSecs - default BasicAuthCacheTimeOutInSecs value
+	DefaultBasicAuthCacheTimeOutInSecs = 60
+	// DefaultSessionLimitAction - default SessionLimitAction value
+	DefaultSessionLimitAction = SessionLimitActionEvictOldest
+	// DefaultCertificateMappingAttribute - default CertificateMappingAttribute value
+	DefaultCertificateMappingAttribute = CertificateMappingCommonName
+	// DefaultRespondToUnauthenticatedClients - default RespondToUnauthenticatedClients value
+	DefaultRespondToUnauthenticatedClients = true
 	// DefaultDBProtocol - default Protocol value
 	DefaultDBProtocol = "tcp"
 	// DefaultDBMaxActiveConns - default MaxActiveConns value
@@ -66,10 +89,18 @@ const (
 	DefaultResponseTimeoutInSecs = 3
 	// DefaultStartUpResouceBatchSize - default StartUpResouceBatchSize value
 	DefaultStartUpResouceBatchSize = 10
+	// DefaultSubscriptionReconciliationIntervalInMins - default SubscriptionReconciliationIntervalInMins value
+	DefaultSubscriptionReconciliationIntervalInMins = 60
 	// DefaultMinResetPriority - default MinResetPriority value
 	DefaultMinResetPriority = 1
 	// DefaultMaxResetDelay - maximum delay in seconds a reset action can wait
 	DefaultMaxResetDelay = 36000
+	// DefaultMaxConcurrentRequestsPerBMC - default MaxConcurrentRequestsPerBMC value
+	DefaultMaxConcurrentRequestsPerBMC = 8
+	// DefaultMaxConcurrentRequests - default MaxConcurrentRequests value
+	DefaultMaxConcurrentRequests = 64
+	// DefaultInventoryRefreshMaxConcurrentBMCs - default MaxConcurrentBMCs value
+	DefaultInventoryRefreshMaxConcurrentBMCs = 4
 	// DefaultHTTPConnTimeout - default HTTPConnTimeout value
 	DefaultHTTPConnTimeout = 10
 	// DefaultHTTPMaxIdleConns - default HTTPMaxIdleConns value
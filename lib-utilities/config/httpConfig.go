@@ -38,6 +38,11 @@ type HTTPConfig struct {
 	ServerAddress string
 	// ServerPort contains the port of the server
 	ServerPort string
+	// ClientAuth contains the policy for requesting and verifying the client certificates in server mode
+	ClientAuth tls.ClientAuthType
+	// ClientCACertificate contains the CA certificates the client certificates are verified against in server mode,
+	// the CA certificate is used when it is not set
+	ClientCACertificate *[]byte
 	// loadCertificates is for marking to load CA cert only or not
 	loadCertificates bool
 }
@@ -92,6 +97,14 @@ func (config *HTTPConfig) GetHTTPServerObj() (*http.Server, error) {
 		return nil, err
 	}
 	Server.SetTLSConfig(tlsConfig)
+	tlsConfig.ClientAuth = config.ClientAuth
+	if config.ClientCACertificate != nil {
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(*config.ClientCACertificate) {
+			return nil, fmt.Errorf("error: failed to load client CA certificate")
+		}
+		tlsConfig.ClientCAs = clientCAs
+	}
 
 	return &http.Server{
 		Addr:      net.JoinHostPort(config.ServerAddress, config.ServerPort),
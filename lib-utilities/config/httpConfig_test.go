@@ -225,6 +225,29 @@ func TestGetHTTPServerObj(t *testing.T) {
 	}
 }
 
+func TestGetHTTPServerObjClientCA(t *testing.T) {
+	SetUpMockConfig(t)
+	clientCA := Data.KeyCertConf.RootCACertificate
+	httpConf := &HTTPConfig{
+		Certificate:         &Data.APIGatewayConf.Certificate,
+		PrivateKey:          &Data.APIGatewayConf.PrivateKey,
+		CACertificate:       &Data.KeyCertConf.RootCACertificate,
+		ClientAuth:          tls.VerifyClientCertIfGiven,
+		ClientCACertificate: &clientCA,
+	}
+	httpServer, err := httpConf.GetHTTPServerObj()
+	if err != nil {
+		t.Fatalf("GetHTTPServerObj() error = %v", err)
+	}
+	if httpServer.TLSConfig.ClientAuth != tls.VerifyClientCertIfGiven || httpServer.TLSConfig.ClientCAs == httpServer.TLSConfig.RootCAs {
+		t.Errorf("GetHTTPServerObj() should verify the client certificates against the client CA")
+	}
+	httpConf.ClientCACertificate = &nonX509Certificate
+	if _, err := httpConf.GetHTTPServerObj(); err == nil {
+		t.Errorf("GetHTTPServerObj() should fail with an invalid client CA certificate")
+	}
+}
+
 func TestSetDefaultTLSConf(t *testing.T) {
 	configuredTLSMinVersion = uint16(0)
 	configuredTLSMaxVersion = uint16(0)
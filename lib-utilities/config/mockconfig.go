@@ -154,6 +154,7 @@ Unp8ML/h2HhCKMSREj/BilROK+Om3ZA/baCngtEvna2pXKuHFKHiHILpyaGrlhRm
 // SetUpMockConfig set ups a mock ration for unit testing
 func SetUpMockConfig(t *testing.T) error {
 	workingDir, _ := os.Getwd()
+	respondToUnauthenticatedClients := true
 
 	Data.RootServiceUUID = "3bd1f589-117a-4cf9-89f2-da44ee8e012b"
 	Data.FirmwareVersion = "1.0"
@@ -190,10 +191,18 @@ func SetUpMockConfig(t *testing.T) error {
 	Data.AuthConf = &AuthConf{
 		SessionTimeOutInMins:            30,
 		ExpiredSessionCleanUpTimeInMins: 15,
+		BasicAuthCacheTimeOutInSecs:     60,
+		SessionLimitAction:              "EvictOldest",
 		PasswordRules: &PasswordRules{
 			MinPasswordLength:       12,
 			MaxPasswordLength:       16,
 			AllowedSpecialCharcters: "~!@#$%^&*-+_|(){}:;<>,.?/",
+			PasswordExpirationDays:  0,
+			PasswordHistoryDepth:    0,
+		},
+		ClientCertificate: &ClientCertificate{
+			RespondToUnauthenticatedClients: &respondToUnauthenticatedClients,
+			CertificateMappingAttribute:     "CommonName",
 		},
 	}
 	Data.APIGatewayConf = &APIGatewayConf{
@@ -228,17 +237,27 @@ func SetUpMockConfig(t *testing.T) error {
 		},
 	}
 	Data.PluginStatusPolling = &PluginStatusPolling{
-		MaxRetryAttempt:         1,
-		RetryIntervalInMins:     1,
-		ResponseTimeoutInSecs:   1,
-		StartUpResouceBatchSize: 1,
-		PollingFrequencyInMins:  1,
+		MaxRetryAttempt:                          1,
+		RetryIntervalInMins:                      1,
+		ResponseTimeoutInSecs:                    1,
+		StartUpResouceBatchSize:                  1,
+		PollingFrequencyInMins:                   1,
+		SubscriptionReconciliationIntervalInMins: 1,
 	}
 	Data.ExecPriorityDelayConf = &ExecPriorityDelayConf{
 		MinResetPriority:    1,
 		MaxResetPriority:    10,
 		MaxResetDelayInSecs: 36000,
 	}
+	Data.InventoryCrawlConf = &InventoryCrawlConf{
+		MaxConcurrentRequestsPerBMC: 4,
+		MaxConcurrentRequests:       16,
+	}
+	Data.InventoryRefreshConf = &InventoryRefreshConf{
+		IntervalInMinutes:  0,
+		MaxJitterInSeconds: 0,
+		MaxConcurrentBMCs:  2,
+	}
 	Data.TLSConf = &TLSConf{
 		VerifyPeer: true,
 		MinVersion: "TLS_1.2",
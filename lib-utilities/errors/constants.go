@@ -66,6 +66,10 @@ const (
 	JSONUnmarshalFailed
 	// DecryptionFailed indicates decryption of data failed
 	DecryptionFailed
+	// SessionLimitExceeded indicates the user already has the maximum number of sessions allowed
+	SessionLimitExceeded
+	// PasswordChangeRequired indicates the session is only allowed to change the password of its user
+	PasswordChangeRequired
 )
 
 // constants defined for matching partial strings in error returned
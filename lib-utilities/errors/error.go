@@ -233,6 +233,10 @@ func (err *Error) GetAuthStatusCodeAndMessage() (int32, string) {
 		return http.StatusServiceUnavailable, response.CouldNotEstablishConnection
 	case InvalidAuthToken:
 		return http.StatusUnauthorized, response.NoValidSession
+	case SessionLimitExceeded:
+		return http.StatusServiceUnavailable, response.SessionLimitExceeded
+	case PasswordChangeRequired:
+		return http.StatusForbidden, response.PasswordChangeRequired
 	}
 	return http.StatusUnauthorized, response.NoValidSession
 }
@@ -0,0 +1,361 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package conformance checks that a plugin follows the contract between ODIM and its southbound plugins,
+// by calling the plugin the way ODIM does from a mock ODIM.
+package conformance
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+)
+
+// Plugin describes the plugin under test
+type Plugin struct {
+	// Handler is the router of the plugin, an iris application is built before it is used
+	Handler http.Handler
+	// UserName and Password are the credentials ODIM authenticates with on the plugin
+	UserName string
+	Password string
+	// SendEvent makes a device of the plugin send an event, the event is posted to the listener when it is nil.
+	// The event checks are skipped when the plugin has neither
+	SendEvent func() error
+	// Listener is the router the devices send their events to, on ListenerURI,
+	// Event is an event it accepts when it is sent with the EventHeaders
+	Listener     http.Handler
+	ListenerURI  string
+	Event        interface{}
+	EventHeaders map[string]string
+	// Subscription is the device the plugin subscribes to the events of,
+	// only the rejected subscriptions are checked when it is nil
+	Subscription *pluginsdk.Device
+}
+
+// MockODIM plays the part of ODIM for the plugin: it calls the plugin and receives the events it publishes
+type MockODIM struct {
+	plugin   Plugin
+	server   *httptest.Server
+	listener *httptest.Server
+	lock     sync.Mutex
+	events   []common.Events
+	notify   chan struct{}
+}
+
+// NewMockODIM returns a mock ODIM which receives the published events, it is attached to its plugin with Start
+func NewMockODIM() *MockODIM {
+	return &MockODIM{notify: make(chan struct{}, 1)}
+}
+
+// Start serves the plugin, the mock ODIM calls it from then on
+func (odim *MockODIM) Start(plugin Plugin) {
+	if app, ok := plugin.Handler.(interface{ Build() error }); ok {
+		app.Build()
+	}
+	odim.plugin = plugin
+	odim.server = httptest.NewServer(plugin.Handler)
+	if plugin.Listener != nil {
+		if app, ok := plugin.Listener.(interface{ Build() error }); ok {
+			app.Build()
+		}
+		odim.listener = httptest.NewServer(plugin.Listener)
+	}
+}
+
+// Close stops serving the plugin
+func (odim *MockODIM) Close() {
+	odim.server.Close()
+	if odim.listener != nil {
+		odim.listener.Close()
+	}
+}
+
+// Publish receives the events published by the plugin, the mock ODIM is the publisher of the plugin
+func (odim *MockODIM) Publish(event common.Events) bool {
+	odim.lock.Lock()
+	odim.events = append(odim.events, event)
+	odim.lock.Unlock()
+	select {
+	case odim.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// WaitEvent returns the first event published by the plugin and not returned yet,
+// it returns false when no event is published within the timeout
+func (odim *MockODIM) WaitEvent(timeout time.Duration) (common.Events, bool) {
+	deadline := time.After(timeout)
+	for {
+		odim.lock.Lock()
+		if len(odim.events) > 0 {
+			event := odim.events[0]
+			odim.events = odim.events[1:]
+			odim.lock.Unlock()
+			return event, true
+		}
+		odim.lock.Unlock()
+		select {
+		case <-odim.notify:
+		case <-deadline:
+			return common.Events{}, false
+		}
+	}
+}
+
+// Call sends a request to the plugin with the given headers and JSON body, a nil body sends no body
+func (odim *MockODIM) Call(method, uri string, headers map[string]string, body interface{}) (*http.Response, []byte, error) {
+	return send(odim.server, method, uri, headers, body)
+}
+
+// PostEvent sends an event to the listener of the plugin the way a device does, with the given headers
+func (odim *MockODIM) PostEvent(headers map[string]string, event interface{}) (*http.Response, []byte, error) {
+	if odim.listener == nil {
+		return nil, nil, fmt.Errorf("the plugin has no event listener")
+	}
+	return send(odim.listener, http.MethodPost, odim.plugin.ListenerURI, headers, event)
+}
+
+// send sends a request to the server with the given headers and JSON body
+func send(server *httptest.Server, method, uri string, headers map[string]string, body interface{}) (*http.Response, []byte, error) {
+	var reqBody []byte
+	switch data := body.(type) {
+	case nil:
+	case []byte:
+		reqBody = data
+	default:
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return nil, nil, err
+		}
+	}
+	req, err := http.NewRequest(method, server.URL+uri, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	return resp, respBody, err
+}
+
+// CreateSession creates a session on the plugin, it returns the token and the location of the session
+func (odim *MockODIM) CreateSession(userName, password string) (int, string, string, error) {
+	resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/Sessions", nil, map[string]string{
+		"UserName": userName,
+		"Password": password,
+	})
+	if err != nil {
+		return 0, "", "", err
+	}
+	return resp.StatusCode, resp.Header.Get("X-Auth-Token"), resp.Header.Get("Location"), nil
+}
+
+// BasicAuth returns the basic authorization of the credentials
+func BasicAuth(userName, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(userName+":"+password))
+}
+
+// Run checks that the plugin follows the plugin contract. The plugin is built by newPlugin,
+// which publishes the events of the plugin with the given publisher.
+func Run(t *testing.T, newPlugin func(publisher pluginsdk.Publisher) Plugin) {
+	odim := NewMockODIM()
+	odim.Start(newPlugin(odim))
+	defer odim.Close()
+	plugin := odim.plugin
+
+	var token, location string
+	t.Run("session creation", func(t *testing.T) {
+		statusCode, _, _, err := odim.CreateSession(plugin.UserName, plugin.Password+"invalid")
+		if err != nil || statusCode != http.StatusUnauthorized {
+			t.Errorf("session creation with invalid credentials = %v, %v, want %v", statusCode, err, http.StatusUnauthorized)
+		}
+		statusCode, token, location, err = odim.CreateSession(plugin.UserName, plugin.Password)
+		if err != nil || statusCode != http.StatusCreated {
+			t.Fatalf("session creation = %v, %v, want %v", statusCode, err, http.StatusCreated)
+		}
+		if token == "" || !strings.HasPrefix(location, "/ODIM/v1/Sessions/") {
+			t.Errorf("session creation returned the token %q and the location %q", token, location)
+		}
+	})
+	if token == "" {
+		t.Fatal("the other checks need a session on the plugin")
+	}
+	tokenHeader := map[string]string{"X-Auth-Token": token}
+	invalidToken := map[string]string{"X-Auth-Token": "invalidToken"}
+
+	t.Run("status", func(t *testing.T) {
+		resp, body, err := odim.Call(http.MethodGet, "/ODIM/v1/Status", tokenHeader, nil)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %v, %v, want %v", resp, err, http.StatusOK)
+		}
+		var status struct {
+			Status          pluginsdk.Status
+			EventMessageBus map[string]interface{}
+		}
+		if err := json.Unmarshal(body, &status); err != nil {
+			t.Fatalf("status response %s is invalid: %v", body, err)
+		}
+		if status.Status.Available != "yes" {
+			t.Errorf("status Available = %q, want yes", status.Status.Available)
+		}
+		for _, date := range []string{status.Status.Uptime, status.Status.TimeStamp} {
+			if _, err := time.Parse(time.RFC3339, date); err != nil {
+				t.Errorf("status date %q is invalid: %v", date, err)
+			}
+		}
+		if status.EventMessageBus == nil {
+			t.Errorf("status response %s has no EventMessageBus", body)
+		}
+		if resp, _, err := odim.Call(http.MethodGet, "/ODIM/v1/Status", invalidToken, nil); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("status with an invalid token = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("startup", func(t *testing.T) {
+		resp, body, err := odim.Call(http.MethodPost, "/ODIM/v1/Startup", tokenHeader, []pluginsdk.StartupDevice{})
+		if err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("startup = %v, %v, want %v", resp, err, http.StatusOK)
+		}
+		var locations map[string]string
+		if err := json.Unmarshal(body, &locations); err != nil {
+			t.Errorf("startup response %s is invalid: %v", body, err)
+		}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/Startup", tokenHeader, []byte("startup")); err != nil || resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("startup with a malformed body = %v, %v, want %v", resp, err, http.StatusBadRequest)
+		}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/Startup", invalidToken, []pluginsdk.StartupDevice{}); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("startup with an invalid token = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		device := pluginsdk.Device{Host: "localhost:1", Username: "admin", Password: []byte("password")}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/validate", nil, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("validate without authorization = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/validate", invalidToken, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("validate with an invalid token = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+		invalidAuth := map[string]string{"Authorization": BasicAuth(plugin.UserName, plugin.Password+"invalid")}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/validate", invalidAuth, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("validate with invalid credentials = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+		if resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/validate", tokenHeader, []byte("device")); err != nil || resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("validate with a malformed body = %v, %v, want %v", resp, err, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("subscriptions", func(t *testing.T) {
+		device := pluginsdk.Device{Host: "localhost:1", Username: "admin", Password: []byte("password")}
+		invalidAuth := map[string]string{"Authorization": BasicAuth(plugin.UserName, plugin.Password+"invalid")}
+		for _, method := range []string{http.MethodPost, http.MethodDelete} {
+			if resp, _, err := odim.Call(method, "/ODIM/v1/Subscriptions", nil, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("%v subscription without authorization = %v, %v, want %v", method, resp, err, http.StatusUnauthorized)
+			}
+			if resp, _, err := odim.Call(method, "/ODIM/v1/Subscriptions", invalidToken, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("%v subscription with an invalid token = %v, %v, want %v", method, resp, err, http.StatusUnauthorized)
+			}
+			if resp, _, err := odim.Call(method, "/ODIM/v1/Subscriptions", invalidAuth, device); err != nil || resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("%v subscription with invalid credentials = %v, %v, want %v", method, resp, err, http.StatusUnauthorized)
+			}
+			if resp, _, err := odim.Call(method, "/ODIM/v1/Subscriptions", tokenHeader, []byte("device")); err != nil || resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%v subscription with a malformed body = %v, %v, want %v", method, resp, err, http.StatusBadRequest)
+			}
+		}
+		if plugin.Subscription == nil {
+			return
+		}
+		resp, _, err := odim.Call(http.MethodPost, "/ODIM/v1/Subscriptions", tokenHeader, plugin.Subscription)
+		if err != nil || resp.StatusCode != http.StatusCreated {
+			t.Fatalf("subscription = %v, %v, want %v", resp, err, http.StatusCreated)
+		}
+		subscribed := *plugin.Subscription
+		subscribed.Location = resp.Header.Get("Location")
+		if subscribed.Location == "" {
+			t.Errorf("subscription returned no location")
+		}
+		if resp, _, err := odim.Call(http.MethodDelete, "/ODIM/v1/Subscriptions", tokenHeader, subscribed); err != nil || resp.StatusCode >= http.StatusMultipleChoices {
+			t.Errorf("subscription deletion = %v, %v, want a success", resp, err)
+		}
+	})
+
+	t.Run("events", func(t *testing.T) {
+		sendEvent := plugin.SendEvent
+		if sendEvent == nil && plugin.Listener != nil {
+			sendEvent = func() error {
+				resp, body, err := odim.PostEvent(plugin.EventHeaders, plugin.Event)
+				if err != nil {
+					return err
+				}
+				if resp.StatusCode >= http.StatusMultipleChoices {
+					return fmt.Errorf("the listener rejected the event with %v: %s", resp.StatusCode, body)
+				}
+				return nil
+			}
+		}
+		if sendEvent == nil {
+			t.Skip("the plugin sends no event to check")
+		}
+		if plugin.Listener != nil {
+			if resp, _, err := odim.PostEvent(plugin.EventHeaders, []byte("event")); err != nil || resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("malformed event = %v, %v, want %v", resp, err, http.StatusBadRequest)
+			}
+		}
+		if err := sendEvent(); err != nil {
+			t.Fatalf("error while sending the event: %v", err)
+		}
+		event, ok := odim.WaitEvent(10 * time.Second)
+		if !ok {
+			t.Fatal("the event is not published")
+		}
+		if event.IP == "" || !json.Valid(event.Request) {
+			t.Errorf("the event published from %q is invalid: %s", event.IP, event.Request)
+		}
+	})
+
+	t.Run("session deletion", func(t *testing.T) {
+		if resp, _, err := odim.Call(http.MethodDelete, location, nil, nil); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("session deletion without authorization = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+		if resp, _, err := odim.Call(http.MethodDelete, location, tokenHeader, nil); err != nil || resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("session deletion = %v, %v, want %v", resp, err, http.StatusNoContent)
+		}
+		if resp, _, err := odim.Call(http.MethodGet, "/ODIM/v1/Status", tokenHeader, nil); err != nil || resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("status with the token of the deleted session = %v, %v, want %v", resp, err, http.StatusUnauthorized)
+		}
+		basicAuth := map[string]string{"Authorization": BasicAuth(plugin.UserName, plugin.Password)}
+		if resp, _, err := odim.Call(http.MethodDelete, location, basicAuth, nil); err != nil || resp.StatusCode != http.StatusNotFound {
+			t.Errorf("deletion of the deleted session = %v, %v, want %v", resp, err, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package conformance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+	iris "github.com/kataras/iris/v12"
+)
+
+type samplePlugin struct{}
+
+func (samplePlugin) Authenticate(userName, password string) bool {
+	return userName == "admin" && password == "password"
+}
+
+func (samplePlugin) ValidateDevice(device pluginsdk.Device) (int, interface{}) {
+	return http.StatusOK, map[string]string{"ServerIP": device.Host}
+}
+
+func (samplePlugin) Startup(devices []pluginsdk.StartupDevice) map[string]string {
+	return map[string]string{}
+}
+
+func (samplePlugin) Status(status pluginsdk.Status) interface{} {
+	return map[string]interface{}{
+		"Status":          status,
+		"EventMessageBus": map[string]string{"EmbType": "Kafka"},
+	}
+}
+
+func subscribe(ctx iris.Context) {
+	var device pluginsdk.Device
+	if err := ctx.ReadJSON(&device); err != nil {
+		ctx.StatusCode(http.StatusBadRequest)
+		return
+	}
+	ctx.Header("Location", "/redfish/v1/EventService/Subscriptions/1")
+	ctx.StatusCode(http.StatusCreated)
+}
+
+func unsubscribe(ctx iris.Context) {
+	var device pluginsdk.Device
+	if err := ctx.ReadJSON(&device); err != nil || device.Location == "" {
+		ctx.StatusCode(http.StatusBadRequest)
+		return
+	}
+	ctx.StatusCode(http.StatusNoContent)
+}
+
+func TestRun(t *testing.T) {
+	Run(t, func(publisher pluginsdk.Publisher) Plugin {
+		tokens := pluginsdk.NewFileTokenStore(func() string { return "" }, func() time.Duration { return time.Minute })
+		server := pluginsdk.NewServer(samplePlugin{}, samplePlugin{}, tokens)
+		app := server.Application()
+		server.HandleSubscriptions(app, subscribe, unsubscribe)
+		events := pluginsdk.NewEventQueue(publisher, &pluginsdk.URLTranslation{})
+		return Plugin{
+			Handler:  app,
+			UserName: "admin",
+			Password: "password",
+			Listener: pluginsdk.EventListener("/events", func(ctx iris.Context) {
+				var event interface{}
+				if err := ctx.ReadJSON(&event); err != nil {
+					ctx.StatusCode(http.StatusBadRequest)
+					return
+				}
+				events.Queue("10.24.0.12", event)
+				ctx.StatusCode(http.StatusOK)
+			}),
+			ListenerURI:  "/events",
+			Event:        map[string]string{"EventType": "Alert"},
+			Subscription: &pluginsdk.Device{Host: "10.24.0.12", Username: "admin", Password: []byte("password")},
+		}
+	})
+}
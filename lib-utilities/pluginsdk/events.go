@@ -0,0 +1,66 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package pluginsdk ...
+package pluginsdk
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// EventQueue buffers the events of the devices and publishes them to ODIM in the background
+type EventQueue struct {
+	in         chan<- interface{}
+	translator Translator
+}
+
+// NewEventQueue starts the worker publishing the queued events with the publisher,
+// the URLs of the events are translated with the translator before they are queued
+func NewEventQueue(publisher Publisher, translator Translator) *EventQueue {
+	// CreateJobQueue defines the queue which will act as an infinite buffer
+	in, out := common.CreateJobQueue()
+	go common.RunReadWorkers(out, func(data interface{}) bool {
+		event, ok := data.(common.Events)
+		if !ok {
+			log.Println("error: invalid data on publishing events")
+			return false
+		}
+		return publisher.Publish(event)
+	}, 1)
+	return &EventQueue{
+		in:         in,
+		translator: translator,
+	}
+}
+
+// Queue translates the URLs of the event received from the device of the given IP address and queues it
+func (queue *EventQueue) Queue(eventSourceIP string, event interface{}) {
+	request, err := json.Marshal(event)
+	if err != nil {
+		log.Println("error while trying to marshal the event: " + err.Error())
+		return
+	}
+	reqData := string(request)
+	if queue.translator != nil {
+		reqData = queue.translator.NorthBound(reqData)
+	}
+	done := make(chan bool, 1)
+	go common.RunWriteWorkers(queue.in, []interface{}{common.Events{
+		IP:      eventSourceIP,
+		Request: []byte(reqData),
+	}}, 1, done)
+}
@@ -0,0 +1,119 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package pluginsdk implements the contract between ODIM and its southbound plugins:
+// the /ODIM/v1 routes of the contract, the authentication of ODIM on the plugin,
+// the status and startup handshake, the publishing of the events and the URL translation.
+// A plugin implements the calls depending on its devices behind the interfaces of the package.
+package pluginsdk
+
+import (
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+// Plugin holds the calls of the plugin contract which depend on the devices the plugin manages
+type Plugin interface {
+	// ValidateDevice checks that the plugin can manage the device with its credentials,
+	// it returns the status code and the body of the response to ODIM
+	ValidateDevice(device Device) (int, interface{})
+	// Startup is called by ODIM on its startup with the devices the plugin manages,
+	// it returns the location of the event subscription of the devices, by their address
+	Startup(devices []StartupDevice) map[string]string
+	// Status returns the body of the status response to ODIM, from the status of the plugin
+	Status(status Status) interface{}
+}
+
+// Authenticator checks the credentials ODIM authenticates with on the plugin
+type Authenticator interface {
+	Authenticate(userName, password string) bool
+}
+
+// TokenStore holds the sessions ODIM creates on the plugin
+type TokenStore interface {
+	// Add saves a new session with its token
+	Add(sessionID, token string)
+	// Validate checks that the token belongs to a session which did not time out
+	Validate(token string) bool
+	// Delete removes the session, it returns false when there is no such session
+	Delete(sessionID string) bool
+}
+
+// Publisher publishes the events of the devices to ODIM
+type Publisher interface {
+	Publish(event common.Events) bool
+}
+
+// PublisherFunc is an adapter to use a function as a Publisher
+type PublisherFunc func(event common.Events) bool
+
+// Publish calls the function with the event
+func (publish PublisherFunc) Publish(event common.Events) bool {
+	return publish(event)
+}
+
+// Translator translates the URLs between the ones of ODIM and the ones of the devices
+type Translator interface {
+	// NorthBound translates the URLs of the data sent by the devices to ODIM
+	NorthBound(data string) string
+	// SouthBound translates the URLs of the data sent by ODIM to the devices
+	SouthBound(data string) string
+}
+
+// URLTranslation is the Translator replacing the keys of its maps by their value
+type URLTranslation struct {
+	NorthBoundURL map[string]string `json:"NorthBoundURL"` // holds value of NorthBound Translation
+	SouthBoundURL map[string]string `json:"SouthBoundURL"` // holds value of SouthBound Translation
+}
+
+// NorthBound replaces the keys of NorthBoundURL by their value in the data
+func (translation *URLTranslation) NorthBound(data string) string {
+	return translate(data, translation.NorthBoundURL)
+}
+
+// SouthBound replaces the keys of SouthBoundURL by their value in the data
+func (translation *URLTranslation) SouthBound(data string) string {
+	return translate(data, translation.SouthBoundURL)
+}
+
+func translate(data string, urls map[string]string) string {
+	for key, value := range urls {
+		data = strings.Replace(data, key, value, -1)
+	}
+	return data
+}
+
+// Device holds the address and the credentials of a device, as ODIM sends them to the plugin
+type Device struct {
+	Host     string `json:"ManagerAddress"`
+	Username string `json:"UserName"`
+	Password []byte `json:"Password"`
+	PostBody []byte `json:"PostBody"`
+	Location string `json:"Location"`
+}
+
+// StartupDevice holds a device ODIM sends on the startup call, with its event subscription
+type StartupDevice struct {
+	Location   string   `json:"Location"`
+	EventTypes []string `json:"EventTypes,omitempty"`
+	Device     Device   `json:"Device"`
+}
+
+// Status holds the availability of the plugin, as it is reported on the status calls
+type Status struct {
+	Available string `json:"Available"`
+	Uptime    string `json:"Uptime"`
+	TimeStamp string `json:"TimeStamp"`
+}
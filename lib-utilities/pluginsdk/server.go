@@ -0,0 +1,268 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package pluginsdk ...
+package pluginsdk
+
+import (
+	"encoding/base64"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/google/uuid"
+	iris "github.com/kataras/iris/v12"
+)
+
+// Server serves the routes of the plugin contract, on top of the calls of the plugin
+type Server struct {
+	plugin        Plugin
+	authenticator Authenticator
+	tokens        TokenStore
+	uptime        string
+}
+
+// loginRequest holds the credentials ODIM creates a session with
+type loginRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
+
+// NewServer returns the server of the plugin contract, the uptime of the plugin starts with it
+func NewServer(plugin Plugin, authenticator Authenticator, tokens TokenStore) *Server {
+	return &Server{
+		plugin:        plugin,
+		authenticator: authenticator,
+		tokens:        tokens,
+		uptime:        time.Now().Format(time.RFC3339),
+	}
+}
+
+// Application returns the router of the plugin with the routes of the plugin contract,
+// the plugin adds the routes of its resources under /ODIM/v1, behind the BasicAuth middleware
+func (s *Server) Application() *iris.Application {
+	app := iris.New()
+	app.WrapRouter(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		path := r.URL.Path
+		if len(path) > 1 && path[len(path)-1] == '/' && path[len(path)-2] != '/' {
+			path = path[:len(path)-1]
+			r.RequestURI = path
+			r.URL.Path = path
+		}
+		next(w, r)
+	})
+
+	pluginRoutes := app.Party("/ODIM/v1")
+	pluginRoutes.Post("/validate", s.BasicAuth, s.Validate)
+	pluginRoutes.Post("/Sessions", s.CreateSession)
+	pluginRoutes.Delete("/Sessions/{id}", s.BasicAuth, s.DeleteSession)
+	pluginRoutes.Get("/Status", s.Status)
+	pluginRoutes.Post("/Startup", s.BasicAuth, s.Startup)
+	return app
+}
+
+// HandleSubscriptions adds the routes ODIM subscribes to the events of the devices with to the application,
+// the handlers of the plugin are only called for the requests with a valid authorization
+func (s *Server) HandleSubscriptions(app *iris.Application, subscribe, unsubscribe iris.Handler) {
+	pluginRoutes := app.Party("/ODIM/v1")
+	pluginRoutes.Post("/Subscriptions", s.BasicAuth, s.authorized, subscribe)
+	pluginRoutes.Delete("/Subscriptions", s.BasicAuth, s.authorized, unsubscribe)
+}
+
+// EventListener returns the application the devices send their events to, on the destination URI of their subscriptions
+func EventListener(destURI string, listener iris.Handler) *iris.Application {
+	app := iris.New()
+	app.Post(destURI, listener)
+	return app
+}
+
+// Run serves the application on the server described by the HTTP configuration, until the server is closed
+func Run(app *iris.Application, conf *config.HTTPConfig) error {
+	server, err := conf.GetHTTPServerObj()
+	if err != nil {
+		return err
+	}
+	if err := app.Run(iris.Server(server)); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// ValidToken checks that the token belongs to a session created on the plugin
+func (s *Server) ValidToken(token string) bool {
+	return s.tokens.Validate(token)
+}
+
+// checkToken validates the X-Auth-Token of the request when it has one, it writes the error response when it is invalid
+func (s *Server) checkToken(ctx iris.Context) bool {
+	token := ctx.GetHeader("X-Auth-Token")
+	if token != "" && !s.tokens.Validate(token) {
+		log.Println("Invalid/Expired X-Auth-Token")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Invalid/Expired X-Auth-Token")
+		return false
+	}
+	return true
+}
+
+// authorized is the middleware rejecting the requests without a valid token or basic authorization,
+// the basic authorization is validated by the BasicAuth middleware before
+func (s *Server) authorized(ctx iris.Context) {
+	if ctx.GetHeader("X-Auth-Token") == "" && ctx.GetHeader("Authorization") == "" {
+		log.Println("No valid authorization")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("No valid authorization")
+		return
+	}
+	if !s.checkToken(ctx) {
+		return
+	}
+	ctx.Next()
+}
+
+// BasicAuth is the middleware validating the basic authorization of the requests which have one
+func (s *Server) BasicAuth(ctx iris.Context) {
+	basicAuth := ctx.GetHeader("Authorization")
+	if basicAuth != "" {
+		spl := strings.SplitN(basicAuth, " ", 2)
+		if len(spl) < 2 || spl[0] != "Basic" {
+			log.Println("error: not a valid basic auth")
+			ctx.StatusCode(http.StatusUnauthorized)
+			ctx.WriteString("error: not a valid basic auth")
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(spl[1])
+		if err != nil {
+			log.Println("error: " + err.Error())
+			ctx.StatusCode(http.StatusInternalServerError)
+			ctx.WriteString(err.Error())
+			return
+		}
+		userCred := strings.SplitN(string(data), ":", 2)
+		if len(userCred) < 2 {
+			log.Println("error: not a valid basic auth")
+			ctx.StatusCode(http.StatusUnauthorized)
+			ctx.WriteString("error: not a valid basic auth")
+			return
+		}
+		if !s.authenticator.Authenticate(userCred[0], userCred[1]) {
+			ctx.StatusCode(http.StatusUnauthorized)
+			ctx.WriteString("Invalid Username/Password")
+			return
+		}
+	}
+	ctx.Next()
+}
+
+// CreateSession is used to create session for odimra to interact with plugin
+func (s *Server) CreateSession(ctx iris.Context) {
+	var userCreds loginRequest
+	if err := ctx.ReadJSON(&userCreds); err != nil {
+		errorMessage := "Error while trying to unmarshal user details: " + err.Error()
+		log.Println(errorMessage)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	if !s.authenticator.Authenticate(userCreds.UserName, userCreds.Password) {
+		errorMessage := "Invalid credentials for session creation"
+		log.Println(errorMessage)
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	token := uuid.New().String()
+	sessionID := uuid.New().String()
+	s.tokens.Add(sessionID, token)
+	ctx.StatusCode(http.StatusCreated)
+	ctx.Header("X-Auth-Token", token)
+	ctx.Header("Location", "/ODIM/v1/Sessions/"+sessionID)
+}
+
+// DeleteSession is used to log out a session of odimra from the plugin
+func (s *Server) DeleteSession(ctx iris.Context) {
+	if ctx.GetHeader("X-Auth-Token") == "" && ctx.GetHeader("Authorization") == "" {
+		log.Println("No valid authorization")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("No valid authorization")
+		return
+	}
+	if !s.checkToken(ctx) {
+		return
+	}
+	sessionID := ctx.Params().Get("id")
+	if !s.tokens.Delete(sessionID) {
+		errorMessage := "Session " + sessionID + " not found"
+		log.Println(errorMessage)
+		ctx.StatusCode(http.StatusNotFound)
+		ctx.WriteString(errorMessage)
+		return
+	}
+	ctx.StatusCode(http.StatusNoContent)
+}
+
+// Validate checks the credentials of the device of the request with the plugin
+func (s *Server) Validate(ctx iris.Context) {
+	if ctx.GetHeader("X-Auth-Token") == "" && ctx.GetHeader("Authorization") == "" {
+		log.Println("No valid authorization")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("No valid authorization")
+		return
+	}
+	if !s.checkToken(ctx) {
+		return
+	}
+	var device Device
+	if err := ctx.ReadJSON(&device); err != nil {
+		log.Println("Error while trying to collect data from request: ", err)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return
+	}
+	statusCode, body := s.plugin.ValidateDevice(device)
+	ctx.StatusCode(statusCode)
+	ctx.JSON(body)
+}
+
+// Status returns the status of the plugin
+func (s *Server) Status(ctx iris.Context) {
+	if !s.checkToken(ctx) {
+		return
+	}
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(s.plugin.Status(Status{
+		Available: "yes",
+		Uptime:    s.uptime,
+		TimeStamp: time.Now().Format(time.RFC3339),
+	}))
+}
+
+// Startup passes the devices of the startup call of ODIM to the plugin,
+// and returns the locations of their event subscriptions
+func (s *Server) Startup(ctx iris.Context) {
+	if !s.checkToken(ctx) {
+		return
+	}
+	var devices []StartupDevice
+	if err := ctx.ReadJSON(&devices); err != nil {
+		log.Println("Error while trying to collect data from request: ", err)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return
+	}
+	ctx.StatusCode(http.StatusOK)
+	ctx.JSON(s.plugin.Startup(devices))
+}
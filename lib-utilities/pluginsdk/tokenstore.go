@@ -0,0 +1,189 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package pluginsdk ...
+package pluginsdk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Session is a session created on the plugin, Token is the hash of its token
+type Session struct {
+	Token    string
+	LastUsed time.Time
+}
+
+// FileTokenStore is the TokenStore keeping the sessions in memory, and in a file when its path is set.
+// Only the hash of the tokens is kept, so that the persisted sessions do not disclose them.
+// The path and the timeout are read on each use, so that they follow the configuration of the plugin.
+type FileTokenStore struct {
+	lock     sync.Mutex
+	sessions map[string]*Session
+	path     func() string
+	timeout  func() time.Duration
+	// saveLock orders the saves, so that the sessions are never overwritten by an older copy of them
+	saveLock sync.Mutex
+}
+
+// NewFileTokenStore returns an empty token store, the sessions are saved in the file of the given path when it is not empty
+func NewFileTokenStore(path func() string, timeout func() time.Duration) *FileTokenStore {
+	return &FileTokenStore{
+		sessions: make(map[string]*Session),
+		path:     path,
+		timeout:  timeout,
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Add saves a new session with its token
+func (store *FileTokenStore) Add(sessionID, token string) {
+	store.lock.Lock()
+	store.sessions[sessionID] = &Session{
+		Token:    hashToken(token),
+		LastUsed: time.Now(),
+	}
+	store.lock.Unlock()
+	store.save()
+}
+
+// Validate checks that the token belongs to a session which did not time out, the session is kept alive by its use
+func (store *FileTokenStore) Validate(token string) bool {
+	tokenHash := hashToken(token)
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	for _, session := range store.sessions {
+		if session.Token != tokenHash {
+			continue
+		}
+		if time.Since(session.LastUsed) > store.timeout() {
+			return false
+		}
+		session.LastUsed = time.Now()
+		return true
+	}
+	return false
+}
+
+// Delete removes the session, it returns false when there is no such session
+func (store *FileTokenStore) Delete(sessionID string) bool {
+	store.lock.Lock()
+	_, ok := store.sessions[sessionID]
+	delete(store.sessions, sessionID)
+	store.lock.Unlock()
+	if ok {
+		store.save()
+	}
+	return ok
+}
+
+// Prune removes the sessions which timed out
+func (store *FileTokenStore) Prune() {
+	store.lock.Lock()
+	for sessionID, session := range store.sessions {
+		if time.Since(session.LastUsed) > store.timeout() {
+			delete(store.sessions, sessionID)
+		}
+	}
+	store.lock.Unlock()
+	store.save()
+}
+
+// save writes the sessions to the session store file, when one is configured
+func (store *FileTokenStore) save() {
+	path := store.path()
+	if path == "" {
+		return
+	}
+	store.saveLock.Lock()
+	defer store.saveLock.Unlock()
+	store.lock.Lock()
+	data, err := json.Marshal(store.sessions)
+	store.lock.Unlock()
+	if err != nil {
+		log.Println("error while trying to marshal the plugin sessions: " + err.Error())
+		return
+	}
+	if err := WriteFile(path, data); err != nil {
+		log.Println("error while trying to save the plugin sessions: " + err.Error())
+	}
+}
+
+// Load reads the sessions saved before the plugin restarted, when a session store file is configured
+func (store *FileTokenStore) Load() {
+	path := store.path()
+	if path == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println("error while trying to read the plugin sessions: " + err.Error())
+		}
+		return
+	}
+	sessions := make(map[string]*Session)
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		log.Println("error while trying to unmarshal the plugin sessions: " + err.Error())
+		return
+	}
+	store.lock.Lock()
+	store.sessions = sessions
+	store.lock.Unlock()
+	store.Prune()
+}
+
+// Start restores the sessions saved before the plugin restarted and
+// removes the sessions which timed out every minute
+func (store *FileTokenStore) Start() {
+	store.Load()
+	go func() {
+		for range time.Tick(time.Minute) {
+			store.Prune()
+		}
+	}()
+}
+
+// WriteFile writes the file aside and renames it, so that a crash never leaves it half written.
+// Every call writes its own temporary file, so that concurrent writers never mix their data
+func WriteFile(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := file.Name()
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
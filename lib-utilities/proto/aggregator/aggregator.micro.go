@@ -40,6 +40,7 @@ type AggregatorService interface {
 	RediscoverSystemInventory(ctx context.Context, in *RediscoverSystemInventoryRequest, opts ...client.CallOption) (*RediscoverSystemInventoryResponse, error)
 	UpdateSystemState(ctx context.Context, in *UpdateSystemStateRequest, opts ...client.CallOption) (*UpdateSystemStateResponse, error)
 	AddAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	AddAggregationSources(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	GetAllAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	GetAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	UpdateAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
@@ -52,6 +53,11 @@ type AggregatorService interface {
 	RemoveElementsFromAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	ResetElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	SetDefaultBootOrderElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	RediscoverAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	RediscoverElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	InsertMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	EjectMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
+	DrainAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	GetAllConnectionMethods(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 	GetConnectionMethod(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error)
 }
@@ -134,6 +140,16 @@ func (c *aggregatorService) AddAggregationSource(ctx context.Context, in *Aggreg
 	return out, nil
 }
 
+func (c *aggregatorService) AddAggregationSources(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.AddAggregationSources", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aggregatorService) GetAllAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
 	req := c.c.NewRequest(c.name, "Aggregator.GetAllAggregationSource", in)
 	out := new(AggregatorResponse)
@@ -254,6 +270,56 @@ func (c *aggregatorService) SetDefaultBootOrderElementsOfAggregate(ctx context.C
 	return out, nil
 }
 
+func (c *aggregatorService) RediscoverAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.RediscoverAggregationSource", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorService) RediscoverElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.RediscoverElementsOfAggregate", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorService) InsertMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.InsertMediaElementsOfAggregate", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorService) EjectMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.EjectMediaElementsOfAggregate", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorService) DrainAggregationSource(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
+	req := c.c.NewRequest(c.name, "Aggregator.DrainAggregationSource", in)
+	out := new(AggregatorResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aggregatorService) GetAllConnectionMethods(ctx context.Context, in *AggregatorRequest, opts ...client.CallOption) (*AggregatorResponse, error) {
 	req := c.c.NewRequest(c.name, "Aggregator.GetAllConnectionMethods", in)
 	out := new(AggregatorResponse)
@@ -283,6 +349,7 @@ type AggregatorHandler interface {
 	RediscoverSystemInventory(context.Context, *RediscoverSystemInventoryRequest, *RediscoverSystemInventoryResponse) error
 	UpdateSystemState(context.Context, *UpdateSystemStateRequest, *UpdateSystemStateResponse) error
 	AddAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	AddAggregationSources(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	GetAllAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	GetAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	UpdateAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
@@ -295,6 +362,11 @@ type AggregatorHandler interface {
 	RemoveElementsFromAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	ResetElementsOfAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	SetDefaultBootOrderElementsOfAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	RediscoverAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	RediscoverElementsOfAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	InsertMediaElementsOfAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	EjectMediaElementsOfAggregate(context.Context, *AggregatorRequest, *AggregatorResponse) error
+	DrainAggregationSource(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	GetAllConnectionMethods(context.Context, *AggregatorRequest, *AggregatorResponse) error
 	GetConnectionMethod(context.Context, *AggregatorRequest, *AggregatorResponse) error
 }
@@ -307,6 +379,7 @@ func RegisterAggregatorHandler(s server.Server, hdlr AggregatorHandler, opts ...
 		RediscoverSystemInventory(ctx context.Context, in *RediscoverSystemInventoryRequest, out *RediscoverSystemInventoryResponse) error
 		UpdateSystemState(ctx context.Context, in *UpdateSystemStateRequest, out *UpdateSystemStateResponse) error
 		AddAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		AddAggregationSources(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		GetAllAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		GetAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		UpdateAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
@@ -319,6 +392,11 @@ func RegisterAggregatorHandler(s server.Server, hdlr AggregatorHandler, opts ...
 		RemoveElementsFromAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		ResetElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		SetDefaultBootOrderElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		RediscoverAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		RediscoverElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		InsertMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		EjectMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
+		DrainAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		GetAllConnectionMethods(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 		GetConnectionMethod(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error
 	}
@@ -357,6 +435,10 @@ func (h *aggregatorHandler) AddAggregationSource(ctx context.Context, in *Aggreg
 	return h.AggregatorHandler.AddAggregationSource(ctx, in, out)
 }
 
+func (h *aggregatorHandler) AddAggregationSources(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.AddAggregationSources(ctx, in, out)
+}
+
 func (h *aggregatorHandler) GetAllAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
 	return h.AggregatorHandler.GetAllAggregationSource(ctx, in, out)
 }
@@ -405,6 +487,26 @@ func (h *aggregatorHandler) SetDefaultBootOrderElementsOfAggregate(ctx context.C
 	return h.AggregatorHandler.SetDefaultBootOrderElementsOfAggregate(ctx, in, out)
 }
 
+func (h *aggregatorHandler) RediscoverAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.RediscoverAggregationSource(ctx, in, out)
+}
+
+func (h *aggregatorHandler) RediscoverElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.RediscoverElementsOfAggregate(ctx, in, out)
+}
+
+func (h *aggregatorHandler) InsertMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.InsertMediaElementsOfAggregate(ctx, in, out)
+}
+
+func (h *aggregatorHandler) EjectMediaElementsOfAggregate(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.EjectMediaElementsOfAggregate(ctx, in, out)
+}
+
+func (h *aggregatorHandler) DrainAggregationSource(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
+	return h.AggregatorHandler.DrainAggregationSource(ctx, in, out)
+}
+
 func (h *aggregatorHandler) GetAllConnectionMethods(ctx context.Context, in *AggregatorRequest, out *AggregatorResponse) error {
 	return h.AggregatorHandler.GetAllConnectionMethods(ctx, in, out)
 }
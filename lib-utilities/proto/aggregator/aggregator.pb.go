@@ -347,44 +347,47 @@ func init() {
 func init() { proto.RegisterFile("aggregator.proto", fileDescriptor_60785b04c84bec7e) }
 
 var fileDescriptor_60785b04c84bec7e = []byte{
-	// 618 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdd, 0x6e, 0xda, 0x4c,
-	0x10, 0x8d, 0x21, 0xe4, 0xfb, 0x18, 0xa8, 0x4a, 0x36, 0xb4, 0x35, 0x6e, 0x95, 0x12, 0xab, 0xaa,
-	0xb8, 0xf2, 0x05, 0x55, 0xd5, 0xa6, 0x6a, 0xa4, 0xf2, 0xd7, 0x24, 0x6a, 0xa2, 0x48, 0x36, 0xe4,
-	0xaa, 0x37, 0x1b, 0x3c, 0x21, 0x08, 0xe3, 0xa5, 0xbb, 0x0b, 0x12, 0x6f, 0xd5, 0x67, 0xea, 0x6b,
-	0xf4, 0xa6, 0xf2, 0x1f, 0x98, 0x00, 0xa5, 0x86, 0xbb, 0xdd, 0xb3, 0x3b, 0x67, 0xce, 0x9c, 0x19,
-	0xaf, 0x0c, 0x05, 0xda, 0xeb, 0x71, 0xec, 0x51, 0xc9, 0xb8, 0x31, 0xe2, 0x4c, 0x32, 0x7d, 0x00,
-	0x87, 0xb5, 0x19, 0x66, 0xe2, 0x8f, 0x31, 0x0a, 0x49, 0x74, 0xc8, 0x5b, 0x28, 0x44, 0x9f, 0xb9,
-	0x6d, 0x36, 0x40, 0x57, 0x55, 0xca, 0x4a, 0x25, 0x6b, 0x2e, 0x60, 0xa4, 0x0c, 0xb9, 0xf0, 0x7a,
-	0x9d, 0xd9, 0x53, 0x35, 0x55, 0x56, 0x2a, 0x79, 0x33, 0x0e, 0x91, 0x02, 0xa4, 0x3b, 0xe6, 0x95,
-	0x9a, 0xf6, 0x83, 0xbd, 0xa5, 0xfe, 0x4b, 0x01, 0x12, 0xcf, 0x26, 0x46, 0xcc, 0x15, 0x48, 0x8e,
-	0x01, 0x84, 0xa4, 0x72, 0x2c, 0x1a, 0xcc, 0x46, 0x3f, 0x59, 0xc6, 0x8c, 0x21, 0xe4, 0x0d, 0x3c,
-	0x09, 0x76, 0xd7, 0x28, 0x04, 0xed, 0xa1, 0x9f, 0x2c, 0x6b, 0x2e, 0x82, 0xe4, 0x03, 0x1c, 0x3c,
-	0x20, 0xb5, 0x91, 0xab, 0xe9, 0x72, 0xba, 0x92, 0xab, 0xbe, 0x36, 0x96, 0x53, 0x19, 0x17, 0xfe,
-	0x8d, 0x96, 0x2b, 0xf9, 0xd4, 0x0c, 0xaf, 0x13, 0x02, 0xfb, 0x77, 0x5e, 0x09, 0xfb, 0x7e, 0x09,
-	0xfe, 0x5a, 0x3b, 0x85, 0x5c, 0xec, 0xaa, 0x57, 0xca, 0x00, 0xa7, 0xa1, 0x0f, 0xde, 0x92, 0x14,
-	0x21, 0x33, 0xa1, 0xce, 0x38, 0xd2, 0x12, 0x6c, 0x3e, 0xa5, 0x3e, 0x2a, 0xfa, 0x77, 0x28, 0x9b,
-	0x68, 0xf7, 0x45, 0x97, 0x4d, 0x90, 0x5b, 0x53, 0x21, 0x71, 0x78, 0xe9, 0x4e, 0xd0, 0x95, 0x8c,
-	0x4f, 0x23, 0x83, 0x35, 0xf8, 0x3f, 0x3c, 0x69, 0x86, 0xa4, 0xb3, 0x3d, 0x79, 0x05, 0xd9, 0x60,
-	0xed, 0x99, 0x17, 0xb0, 0xcf, 0x01, 0xfd, 0x0c, 0x4e, 0xfe, 0xc2, 0x1e, 0x1a, 0xaa, 0xc2, 0x7f,
-	0x6d, 0x2a, 0x06, 0x1e, 0x41, 0xc0, 0x1e, 0x6d, 0xf5, 0x9f, 0x0a, 0xa8, 0x9d, 0x91, 0x4d, 0x25,
-	0x06, 0xb1, 0x96, 0xa4, 0x12, 0x23, 0x55, 0xc7, 0x00, 0x61, 0xa2, 0xce, 0x4c, 0x57, 0x0c, 0x59,
-	0x50, 0x9d, 0x5a, 0xaf, 0xfa, 0x32, 0x6c, 0xf9, 0x1c, 0xf0, 0x4e, 0x83, 0xac, 0xdf, 0x30, 0xf0,
-	0x39, 0x6b, 0xce, 0x81, 0xf9, 0xe9, 0x2d, 0x75, 0xd4, 0x4c, 0xfc, 0xf4, 0x96, 0x3a, 0xfa, 0x7b,
-	0x28, 0xad, 0x50, 0xbc, 0xa9, 0xd2, 0xea, 0x6f, 0x00, 0x98, 0x0f, 0x00, 0xa9, 0xc3, 0xb3, 0x73,
-	0x94, 0x11, 0xd0, 0x67, 0xae, 0x85, 0x7c, 0xd2, 0xef, 0x22, 0x21, 0xc6, 0xd2, 0xfc, 0x6b, 0x47,
-	0x2b, 0x46, 0x47, 0xdf, 0x23, 0x55, 0xc8, 0x98, 0x28, 0x50, 0x26, 0x89, 0xf9, 0x02, 0x47, 0x16,
-	0xca, 0x26, 0xde, 0xd3, 0xb1, 0x23, 0xeb, 0x8c, 0xc9, 0x1b, 0xee, 0xcf, 0xdc, 0xbf, 0x33, 0xd8,
-	0x50, 0x5a, 0xdb, 0x71, 0x72, 0x62, 0x6c, 0x9a, 0x35, 0x4d, 0x37, 0x36, 0x0e, 0x8c, 0xbe, 0x47,
-	0xae, 0xe0, 0x70, 0xc9, 0x65, 0x52, 0x32, 0xd6, 0xcd, 0x8a, 0xa6, 0x19, 0x6b, 0x9b, 0xa2, 0xef,
-	0x91, 0x1a, 0x14, 0x6b, 0xb6, 0x1d, 0x77, 0x9b, 0x8d, 0x79, 0x32, 0xb3, 0x9b, 0xf0, 0xc2, 0x6b,
-	0x98, 0xe3, 0xec, 0xc4, 0x52, 0x83, 0xe2, 0xa3, 0xb6, 0x6f, 0x23, 0x24, 0x28, 0x75, 0x57, 0x96,
-	0x26, 0x3a, 0xb8, 0x23, 0xcb, 0x67, 0x78, 0xda, 0xe0, 0x18, 0xd3, 0x92, 0x28, 0xfa, 0x0c, 0x0a,
-	0x8b, 0x96, 0xa2, 0x48, 0x12, 0x7e, 0x0a, 0xf9, 0x98, 0x97, 0x49, 0x75, 0x2f, 0x56, 0x9f, 0x28,
-	0xba, 0x01, 0xcf, 0x6b, 0xb6, 0xdd, 0x72, 0x70, 0x88, 0xae, 0x14, 0x6d, 0xb6, 0x15, 0xc9, 0x05,
-	0xbc, 0x34, 0x71, 0xc8, 0x26, 0x18, 0xf1, 0x7c, 0xe5, 0x6c, 0xb8, 0x15, 0x53, 0x0b, 0x54, 0xff,
-	0x19, 0x88, 0x88, 0x6e, 0xee, 0xb7, 0xa2, 0xb1, 0xe0, 0xed, 0x8a, 0x97, 0x61, 0x47, 0xd2, 0xd9,
-	0x57, 0xd3, 0x60, 0xae, 0x8b, 0x5d, 0x6f, 0xca, 0xae, 0x51, 0x3e, 0x30, 0x5b, 0x24, 0x7c, 0xb4,
-	0xce, 0x51, 0x3e, 0xa6, 0x48, 0xc0, 0x70, 0x77, 0xe0, 0xff, 0x5d, 0xbc, 0xfb, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x78, 0x2b, 0x7e, 0x3d, 0x71, 0x08, 0x00, 0x00,
+	// 668 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x96, 0x5b, 0x4f, 0x13, 0x41,
+	0x14, 0xc7, 0x29, 0xa5, 0x68, 0x0f, 0xa8, 0x30, 0xdc, 0x96, 0x2a, 0x58, 0x36, 0xc6, 0xf0, 0xb4,
+	0x0f, 0x18, 0xa3, 0x18, 0x49, 0xec, 0x4d, 0xee, 0x21, 0xd9, 0x02, 0x4f, 0xbe, 0x0c, 0x9d, 0x43,
+	0x59, 0xbb, 0x9d, 0xc1, 0x99, 0x69, 0x93, 0x7e, 0x2b, 0x13, 0xbf, 0x91, 0x9f, 0xc4, 0xec, 0x8d,
+	0xdd, 0x42, 0x2b, 0x6e, 0xd7, 0xb7, 0x99, 0x33, 0x33, 0xbf, 0xf3, 0x3f, 0xff, 0x39, 0x3b, 0x2d,
+	0x2c, 0xd0, 0x76, 0x5b, 0x62, 0x9b, 0x6a, 0x21, 0xad, 0x5b, 0x29, 0xb4, 0x30, 0x3b, 0xb0, 0x58,
+	0xb9, 0x8b, 0xd9, 0xf8, 0xa3, 0x87, 0x4a, 0x13, 0x13, 0xe6, 0x9b, 0xa8, 0x94, 0x23, 0xf8, 0xb9,
+	0xe8, 0x20, 0x37, 0x72, 0xe5, 0xdc, 0x76, 0xd1, 0x1e, 0x8a, 0x91, 0x32, 0xcc, 0x85, 0xdb, 0xab,
+	0x82, 0x0d, 0x8c, 0xe9, 0x72, 0x6e, 0x7b, 0xde, 0x4e, 0x86, 0xc8, 0x02, 0xe4, 0x2f, 0xec, 0x13,
+	0x23, 0xef, 0x1f, 0xf6, 0x86, 0xe6, 0xef, 0x1c, 0x90, 0x64, 0x36, 0x75, 0x2b, 0xb8, 0x42, 0xb2,
+	0x09, 0xa0, 0x34, 0xd5, 0x3d, 0x55, 0x13, 0x0c, 0xfd, 0x64, 0x05, 0x3b, 0x11, 0x21, 0x6f, 0xe0,
+	0x59, 0x30, 0x3b, 0x45, 0xa5, 0x68, 0x1b, 0xfd, 0x64, 0x45, 0x7b, 0x38, 0x48, 0x3e, 0xc0, 0xec,
+	0x0d, 0x52, 0x86, 0xd2, 0xc8, 0x97, 0xf3, 0xdb, 0x73, 0x3b, 0xaf, 0xad, 0x87, 0xa9, 0xac, 0x03,
+	0x7f, 0x47, 0x83, 0x6b, 0x39, 0xb0, 0xc3, 0xed, 0x84, 0xc0, 0xcc, 0x95, 0x57, 0xc2, 0x8c, 0x5f,
+	0x82, 0x3f, 0x2e, 0xed, 0xc2, 0x5c, 0x62, 0xab, 0x57, 0x4a, 0x07, 0x07, 0xa1, 0x0f, 0xde, 0x90,
+	0x2c, 0x43, 0xa1, 0x4f, 0xdd, 0x5e, 0xa4, 0x25, 0x98, 0x7c, 0x9a, 0xfe, 0x98, 0x33, 0xbf, 0x41,
+	0xd9, 0x46, 0xe6, 0xa8, 0x96, 0xe8, 0xa3, 0x6c, 0x0e, 0x94, 0xc6, 0xee, 0x21, 0xef, 0x23, 0xd7,
+	0x42, 0x0e, 0x22, 0x83, 0x4b, 0xf0, 0x34, 0x5c, 0xa9, 0x87, 0xd0, 0xbb, 0x39, 0x79, 0x05, 0xc5,
+	0x60, 0xec, 0x99, 0x17, 0xd0, 0xe3, 0x80, 0xb9, 0x07, 0x5b, 0x7f, 0xa1, 0x87, 0x86, 0x1a, 0xf0,
+	0xe4, 0x9c, 0xaa, 0x8e, 0x07, 0x08, 0xe8, 0xd1, 0xd4, 0xfc, 0x99, 0x03, 0xe3, 0xe2, 0x96, 0x51,
+	0x8d, 0xc1, 0xd9, 0xa6, 0xa6, 0x1a, 0x23, 0x55, 0x9b, 0x00, 0x61, 0xa2, 0x8b, 0x3b, 0x5d, 0x89,
+	0xc8, 0x90, 0xea, 0xe9, 0xf1, 0xaa, 0x0f, 0xc3, 0x2b, 0x8f, 0x03, 0xde, 0x6a, 0x90, 0xf5, 0x18,
+	0x03, 0x9f, 0x8b, 0x76, 0x1c, 0x88, 0x57, 0x2f, 0xa9, 0x6b, 0x14, 0x92, 0xab, 0x97, 0xd4, 0x35,
+	0xdf, 0xc3, 0xfa, 0x08, 0xc5, 0x8f, 0x55, 0xba, 0xf3, 0xeb, 0x39, 0x40, 0xdc, 0x00, 0xa4, 0x0a,
+	0x2b, 0xfb, 0xa8, 0xa3, 0x80, 0x23, 0x78, 0x13, 0x65, 0xdf, 0x69, 0x21, 0x21, 0xd6, 0x83, 0xfe,
+	0x2f, 0x2d, 0x8d, 0x68, 0x1d, 0x73, 0x8a, 0xec, 0x40, 0xc1, 0x46, 0x85, 0x3a, 0xcd, 0x99, 0x2f,
+	0xb0, 0xd4, 0x44, 0x5d, 0xc7, 0x6b, 0xda, 0x73, 0x75, 0x55, 0x08, 0x7d, 0x26, 0xfd, 0x9e, 0xfb,
+	0x77, 0x02, 0x83, 0xf5, 0xb1, 0x37, 0x4e, 0xb6, 0xac, 0xc7, 0x7a, 0xad, 0x64, 0x5a, 0x8f, 0x36,
+	0x8c, 0x39, 0x45, 0x4e, 0x60, 0xf1, 0x81, 0xcb, 0x64, 0xdd, 0x1a, 0xd7, 0x2b, 0xa5, 0x92, 0x35,
+	0xf6, 0x52, 0xcc, 0x29, 0x52, 0x81, 0xe5, 0x0a, 0x63, 0x49, 0xb7, 0x45, 0x4f, 0xa6, 0x33, 0xbb,
+	0x0a, 0x2b, 0xa3, 0x10, 0x2a, 0x0d, 0xa3, 0x0e, 0x6b, 0xde, 0xa5, 0xbb, 0x6e, 0x26, 0x25, 0x15,
+	0x58, 0xbe, 0xd7, 0x3a, 0xa9, 0x11, 0x75, 0x58, 0x0b, 0xec, 0xca, 0x4a, 0xa9, 0xa3, 0x8b, 0x19,
+	0x29, 0x9f, 0xe1, 0x45, 0x4d, 0x62, 0x42, 0x4b, 0xaa, 0xd3, 0x7b, 0xb0, 0x30, 0x6c, 0x69, 0xba,
+	0x1b, 0xd9, 0x85, 0xf9, 0x84, 0x97, 0x69, 0x75, 0x0f, 0x57, 0x9f, 0xea, 0x74, 0x0d, 0x56, 0x2b,
+	0x8c, 0x35, 0x5c, 0xec, 0x22, 0xd7, 0xea, 0x5c, 0x4c, 0x04, 0x39, 0x80, 0x97, 0x36, 0x76, 0x45,
+	0x1f, 0x23, 0xce, 0x57, 0x29, 0xba, 0x13, 0x91, 0x1a, 0x60, 0xf8, 0x4f, 0x49, 0x04, 0x3a, 0xbb,
+	0x9e, 0x08, 0xd3, 0x84, 0xb7, 0x23, 0x5e, 0x97, 0x8c, 0x50, 0xbf, 0xca, 0xe8, 0xc5, 0xc8, 0xd4,
+	0x6a, 0x47, 0xb0, 0x11, 0x93, 0x32, 0xaa, 0x3a, 0x86, 0xcd, 0x43, 0xae, 0x50, 0xea, 0x53, 0x64,
+	0x0e, 0xcd, 0x08, 0x3b, 0x82, 0x8d, 0xc6, 0x77, 0x6c, 0xfd, 0x17, 0x56, 0x0d, 0x56, 0xeb, 0x92,
+	0x3a, 0x3c, 0xeb, 0xa7, 0x1d, 0x7c, 0x56, 0x35, 0xc1, 0x39, 0xb6, 0x3c, 0xc8, 0x29, 0xea, 0x1b,
+	0xc1, 0x54, 0xca, 0x1f, 0x9b, 0x7d, 0xd4, 0xf7, 0x11, 0x29, 0x08, 0x57, 0xb3, 0xfe, 0xbf, 0xc2,
+	0x77, 0x7f, 0x06, 0x00, 0xed, 0x02, 0x90, 0x3a, 0x29, 0x0a, 0x00, 0x00,
 }
@@ -35,6 +35,8 @@ var _ server.Option
 
 type AuthorizationService interface {
 	IsAuthorized(ctx context.Context, in *AuthRequest, opts ...client.CallOption) (*AuthResponse, error)
+	AuthenticateBasicAuth(ctx context.Context, in *BasicAuthRequest, opts ...client.CallOption) (*BasicAuthResponse, error)
+	AuthenticateClientCertificate(ctx context.Context, in *ClientCertificateRequest, opts ...client.CallOption) (*ClientCertificateResponse, error)
 }
 
 type authorizationService struct {
@@ -65,15 +67,39 @@ func (c *authorizationService) IsAuthorized(ctx context.Context, in *AuthRequest
 	return out, nil
 }
 
+func (c *authorizationService) AuthenticateBasicAuth(ctx context.Context, in *BasicAuthRequest, opts ...client.CallOption) (*BasicAuthResponse, error) {
+	req := c.c.NewRequest(c.name, "Authorization.AuthenticateBasicAuth", in)
+	out := new(BasicAuthResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorizationService) AuthenticateClientCertificate(ctx context.Context, in *ClientCertificateRequest, opts ...client.CallOption) (*ClientCertificateResponse, error) {
+	req := c.c.NewRequest(c.name, "Authorization.AuthenticateClientCertificate", in)
+	out := new(ClientCertificateResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Authorization service
 
 type AuthorizationHandler interface {
 	IsAuthorized(context.Context, *AuthRequest, *AuthResponse) error
+	AuthenticateBasicAuth(context.Context, *BasicAuthRequest, *BasicAuthResponse) error
+	AuthenticateClientCertificate(context.Context, *ClientCertificateRequest, *ClientCertificateResponse) error
 }
 
 func RegisterAuthorizationHandler(s server.Server, hdlr AuthorizationHandler, opts ...server.HandlerOption) error {
 	type authorization interface {
 		IsAuthorized(ctx context.Context, in *AuthRequest, out *AuthResponse) error
+		AuthenticateBasicAuth(ctx context.Context, in *BasicAuthRequest, out *BasicAuthResponse) error
+		AuthenticateClientCertificate(ctx context.Context, in *ClientCertificateRequest, out *ClientCertificateResponse) error
 	}
 	type Authorization struct {
 		authorization
@@ -89,3 +115,11 @@ type authorizationHandler struct {
 func (h *authorizationHandler) IsAuthorized(ctx context.Context, in *AuthRequest, out *AuthResponse) error {
 	return h.AuthorizationHandler.IsAuthorized(ctx, in, out)
 }
+
+func (h *authorizationHandler) AuthenticateBasicAuth(ctx context.Context, in *BasicAuthRequest, out *BasicAuthResponse) error {
+	return h.AuthorizationHandler.AuthenticateBasicAuth(ctx, in, out)
+}
+
+func (h *authorizationHandler) AuthenticateClientCertificate(ctx context.Context, in *ClientCertificateRequest, out *ClientCertificateResponse) error {
+	return h.AuthorizationHandler.AuthenticateClientCertificate(ctx, in, out)
+}
@@ -122,26 +122,243 @@ func (m *AuthResponse) GetStatusMessage() string {
 	return ""
 }
 
+type BasicAuthRequest struct {
+	UserName             string   `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
+	Password             string   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BasicAuthRequest) Reset()         { *m = BasicAuthRequest{} }
+func (m *BasicAuthRequest) String() string { return proto.CompactTextString(m) }
+func (*BasicAuthRequest) ProtoMessage()    {}
+func (*BasicAuthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{2}
+}
+
+func (m *BasicAuthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BasicAuthRequest.Unmarshal(m, b)
+}
+func (m *BasicAuthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BasicAuthRequest.Marshal(b, m, deterministic)
+}
+func (m *BasicAuthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BasicAuthRequest.Merge(m, src)
+}
+func (m *BasicAuthRequest) XXX_Size() int {
+	return xxx_messageInfo_BasicAuthRequest.Size(m)
+}
+func (m *BasicAuthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BasicAuthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BasicAuthRequest proto.InternalMessageInfo
+
+func (m *BasicAuthRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
+func (m *BasicAuthRequest) GetPassword() string {
+	if m != nil {
+		return m.Password
+	}
+	return ""
+}
+
+type BasicAuthResponse struct {
+	StatusCode           int32    `protobuf:"varint,1,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	StatusMessage        string   `protobuf:"bytes,2,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
+	SessionToken         string   `protobuf:"bytes,3,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BasicAuthResponse) Reset()         { *m = BasicAuthResponse{} }
+func (m *BasicAuthResponse) String() string { return proto.CompactTextString(m) }
+func (*BasicAuthResponse) ProtoMessage()    {}
+func (*BasicAuthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{3}
+}
+
+func (m *BasicAuthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BasicAuthResponse.Unmarshal(m, b)
+}
+func (m *BasicAuthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BasicAuthResponse.Marshal(b, m, deterministic)
+}
+func (m *BasicAuthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BasicAuthResponse.Merge(m, src)
+}
+func (m *BasicAuthResponse) XXX_Size() int {
+	return xxx_messageInfo_BasicAuthResponse.Size(m)
+}
+func (m *BasicAuthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BasicAuthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BasicAuthResponse proto.InternalMessageInfo
+
+func (m *BasicAuthResponse) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *BasicAuthResponse) GetStatusMessage() string {
+	if m != nil {
+		return m.StatusMessage
+	}
+	return ""
+}
+
+func (m *BasicAuthResponse) GetSessionToken() string {
+	if m != nil {
+		return m.SessionToken
+	}
+	return ""
+}
+
+type ClientCertificateRequest struct {
+	Certificate          []byte   `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	Intermediates        [][]byte `protobuf:"bytes,2,rep,name=intermediates,proto3" json:"intermediates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientCertificateRequest) Reset()         { *m = ClientCertificateRequest{} }
+func (m *ClientCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*ClientCertificateRequest) ProtoMessage()    {}
+func (*ClientCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{4}
+}
+
+func (m *ClientCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientCertificateRequest.Unmarshal(m, b)
+}
+func (m *ClientCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *ClientCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientCertificateRequest.Merge(m, src)
+}
+func (m *ClientCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_ClientCertificateRequest.Size(m)
+}
+func (m *ClientCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientCertificateRequest proto.InternalMessageInfo
+
+func (m *ClientCertificateRequest) GetCertificate() []byte {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (m *ClientCertificateRequest) GetIntermediates() [][]byte {
+	if m != nil {
+		return m.Intermediates
+	}
+	return nil
+}
+
+type ClientCertificateResponse struct {
+	StatusCode           int32    `protobuf:"varint,1,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
+	StatusMessage        string   `protobuf:"bytes,2,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
+	SessionToken         string   `protobuf:"bytes,3,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClientCertificateResponse) Reset()         { *m = ClientCertificateResponse{} }
+func (m *ClientCertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*ClientCertificateResponse) ProtoMessage()    {}
+func (*ClientCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8bbd6f3875b0e874, []int{5}
+}
+
+func (m *ClientCertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClientCertificateResponse.Unmarshal(m, b)
+}
+func (m *ClientCertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClientCertificateResponse.Marshal(b, m, deterministic)
+}
+func (m *ClientCertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClientCertificateResponse.Merge(m, src)
+}
+func (m *ClientCertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_ClientCertificateResponse.Size(m)
+}
+func (m *ClientCertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClientCertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClientCertificateResponse proto.InternalMessageInfo
+
+func (m *ClientCertificateResponse) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *ClientCertificateResponse) GetStatusMessage() string {
+	if m != nil {
+		return m.StatusMessage
+	}
+	return ""
+}
+
+func (m *ClientCertificateResponse) GetSessionToken() string {
+	if m != nil {
+		return m.SessionToken
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*AuthRequest)(nil), "AuthRequest")
 	proto.RegisterType((*AuthResponse)(nil), "AuthResponse")
+	proto.RegisterType((*BasicAuthRequest)(nil), "BasicAuthRequest")
+	proto.RegisterType((*BasicAuthResponse)(nil), "BasicAuthResponse")
+	proto.RegisterType((*ClientCertificateRequest)(nil), "ClientCertificateRequest")
+	proto.RegisterType((*ClientCertificateResponse)(nil), "ClientCertificateResponse")
 }
 
 func init() { proto.RegisterFile("auth.proto", fileDescriptor_8bbd6f3875b0e874) }
 
 var fileDescriptor_8bbd6f3875b0e874 = []byte{
-	// 201 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0x41, 0x4b, 0xc4, 0x30,
-	0x10, 0x85, 0x6d, 0x8b, 0x42, 0xc7, 0xf4, 0x92, 0x53, 0xf1, 0x20, 0x25, 0x78, 0xe8, 0xc5, 0x1e,
-	0xf4, 0x2e, 0x88, 0x27, 0x0f, 0x5e, 0x42, 0xff, 0x40, 0xa4, 0x43, 0x1b, 0x74, 0x33, 0xdd, 0x4e,
-	0xb2, 0x0b, 0xfb, 0xeb, 0x97, 0xa6, 0x2c, 0xa4, 0xc7, 0x7c, 0x3c, 0xbe, 0xf7, 0x32, 0x00, 0x26,
-	0xf8, 0xa9, 0x9b, 0x17, 0xf2, 0xa4, 0xce, 0xf0, 0xf8, 0x19, 0xfc, 0xa4, 0xf1, 0x18, 0x90, 0xbd,
-	0x54, 0x20, 0x18, 0x99, 0x2d, 0xb9, 0x9e, 0xfe, 0xd0, 0xd5, 0x59, 0x93, 0xb5, 0xa5, 0xde, 0x31,
-	0xf9, 0x0c, 0x30, 0x2f, 0xf6, 0x64, 0xff, 0x71, 0x44, 0xae, 0xf3, 0xa6, 0x68, 0x4b, 0x9d, 0x10,
-	0xf9, 0x02, 0x15, 0xe1, 0x21, 0x89, 0x14, 0x31, 0xb2, 0x87, 0xaa, 0x07, 0xb1, 0x15, 0xf3, 0x4c,
-	0x8e, 0x71, 0xb5, 0xb2, 0x37, 0x3e, 0xf0, 0x17, 0x0d, 0x18, 0x7b, 0xef, 0x75, 0x42, 0x56, 0xeb,
-	0xf6, 0xfa, 0x41, 0x66, 0x33, 0x62, 0x9d, 0xc7, 0x69, 0x7b, 0xf8, 0xf6, 0x01, 0xd5, 0x6a, 0xa5,
-	0xc5, 0x5e, 0x8c, 0xb7, 0xe4, 0xe4, 0x2b, 0x88, 0x6f, 0xbe, 0x21, 0x1c, 0xa4, 0xe8, 0x92, 0xef,
-	0x3e, 0x55, 0x5d, 0xba, 0x41, 0xdd, 0xfd, 0x3e, 0xc4, 0xab, 0xbc, 0x5f, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0x4a, 0xe1, 0x18, 0x25, 0x23, 0x01, 0x00, 0x00,
+	// 348 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x93, 0xcf, 0x4a, 0xf3, 0x40,
+	0x14, 0xc5, 0x9b, 0x96, 0xef, 0xc3, 0xde, 0xa6, 0x60, 0x07, 0x84, 0x34, 0xa0, 0x94, 0xc1, 0x45,
+	0x37, 0xce, 0x42, 0x5f, 0x40, 0xed, 0x4a, 0x41, 0x17, 0xa1, 0x0b, 0xb7, 0xd3, 0xe6, 0xda, 0x0e,
+	0xb6, 0x33, 0x71, 0xee, 0xc4, 0x82, 0xe0, 0xd2, 0x97, 0xf4, 0x69, 0x24, 0xe9, 0x1f, 0x27, 0xc6,
+	0xee, 0xc4, 0xe5, 0xfd, 0x75, 0x38, 0xf7, 0x9e, 0x73, 0x1a, 0x00, 0x99, 0xbb, 0xb9, 0xc8, 0xac,
+	0x71, 0x86, 0xaf, 0xa0, 0x73, 0x95, 0xbb, 0x79, 0x82, 0xcf, 0x39, 0x92, 0x63, 0x1c, 0x42, 0x42,
+	0x22, 0x65, 0xf4, 0xd8, 0x3c, 0xa1, 0x8e, 0x82, 0x41, 0x30, 0x6c, 0x27, 0x15, 0xc6, 0x4e, 0x00,
+	0x32, 0xab, 0x5e, 0xd4, 0x02, 0x67, 0x48, 0x51, 0x73, 0xd0, 0x1a, 0xb6, 0x13, 0x8f, 0xb0, 0x53,
+	0xe8, 0x1a, 0x5c, 0x7a, 0x4f, 0x5a, 0xe5, 0x93, 0x2a, 0xe4, 0x63, 0x08, 0xd7, 0x8b, 0x29, 0x33,
+	0x9a, 0xb0, 0x50, 0x25, 0x27, 0x5d, 0x4e, 0x23, 0x93, 0x62, 0xb9, 0xf7, 0x5f, 0xe2, 0x91, 0x42,
+	0x75, 0x3d, 0xdd, 0x21, 0x91, 0x9c, 0x61, 0xd4, 0x2c, 0x4f, 0xab, 0x42, 0x7e, 0x0b, 0x87, 0xd7,
+	0x92, 0xd4, 0xd4, 0xf7, 0x14, 0xc3, 0x41, 0x4e, 0x68, 0xef, 0xe5, 0x12, 0x37, 0x7e, 0x76, 0x73,
+	0xf1, 0x5b, 0x26, 0x89, 0x56, 0xc6, 0xa6, 0x1b, 0xc1, 0xdd, 0xcc, 0xdf, 0xa0, 0xe7, 0x69, 0xfd,
+	0xe6, 0x99, 0xb5, 0x98, 0x5b, 0xf5, 0x98, 0xf9, 0x04, 0xa2, 0xd1, 0x42, 0xa1, 0x76, 0x23, 0xb4,
+	0x4e, 0x3d, 0xaa, 0xa9, 0x74, 0xb8, 0xb5, 0x34, 0x80, 0xce, 0xf4, 0x8b, 0x96, 0x67, 0x84, 0x89,
+	0x8f, 0x8a, 0x3b, 0x94, 0x76, 0x68, 0x97, 0x98, 0x2a, 0xe9, 0x36, 0x3d, 0x85, 0x49, 0x15, 0xf2,
+	0xf7, 0x00, 0xfa, 0x3f, 0x2c, 0xf9, 0x6b, 0xaf, 0xe7, 0x1f, 0x01, 0x74, 0x8b, 0x98, 0x8d, 0x55,
+	0xaf, 0xd2, 0x29, 0xa3, 0xd9, 0x19, 0x84, 0x37, 0xb4, 0x45, 0x98, 0xb2, 0x50, 0x78, 0x95, 0xc6,
+	0x5d, 0xe1, 0x97, 0xc2, 0x1b, 0xec, 0x12, 0x8e, 0x0a, 0x82, 0xda, 0x95, 0x16, 0x76, 0xbd, 0xb1,
+	0x9e, 0xf8, 0xfe, 0x7f, 0x88, 0x99, 0xa8, 0xd5, 0xca, 0x1b, 0xec, 0x01, 0x8e, 0x7d, 0x85, 0x5a,
+	0x2a, 0xac, 0x2f, 0xf6, 0xd5, 0x11, 0xc7, 0x62, 0x6f, 0x88, 0xbc, 0x31, 0xf9, 0x5f, 0x7e, 0x69,
+	0x17, 0x9f, 0x03, 0x00, 0x86, 0xbd, 0xac, 0x6c, 0x77, 0x03, 0x00, 0x00,
 }
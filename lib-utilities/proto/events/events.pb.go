@@ -397,6 +397,53 @@ func (m *SubscribeEMBResponse) GetStatus() bool {
 	return false
 }
 
+type MoveEventSubscriptionsRequest struct {
+	UUID                 string   `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
+	PluginID             string   `protobuf:"bytes,2,opt,name=PluginID,proto3" json:"PluginID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MoveEventSubscriptionsRequest) Reset()         { *m = MoveEventSubscriptionsRequest{} }
+func (m *MoveEventSubscriptionsRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveEventSubscriptionsRequest) ProtoMessage()    {}
+func (*MoveEventSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8f22242cb04491f9, []int{7}
+}
+
+func (m *MoveEventSubscriptionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MoveEventSubscriptionsRequest.Unmarshal(m, b)
+}
+func (m *MoveEventSubscriptionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MoveEventSubscriptionsRequest.Marshal(b, m, deterministic)
+}
+func (m *MoveEventSubscriptionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveEventSubscriptionsRequest.Merge(m, src)
+}
+func (m *MoveEventSubscriptionsRequest) XXX_Size() int {
+	return xxx_messageInfo_MoveEventSubscriptionsRequest.Size(m)
+}
+func (m *MoveEventSubscriptionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveEventSubscriptionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveEventSubscriptionsRequest proto.InternalMessageInfo
+
+func (m *MoveEventSubscriptionsRequest) GetUUID() string {
+	if m != nil {
+		return m.UUID
+	}
+	return ""
+}
+
+func (m *MoveEventSubscriptionsRequest) GetPluginID() string {
+	if m != nil {
+		return m.PluginID
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*EventSubRequest)(nil), "EventSubRequest")
 	proto.RegisterType((*EventSubResponse)(nil), "EventSubResponse")
@@ -406,47 +453,50 @@ func init() {
 	proto.RegisterType((*DefaultEventSubResponse)(nil), "DefaultEventSubResponse")
 	proto.RegisterType((*SubscribeEMBRequest)(nil), "SubscribeEMBRequest")
 	proto.RegisterType((*SubscribeEMBResponse)(nil), "SubscribeEMBResponse")
+	proto.RegisterType((*MoveEventSubscriptionsRequest)(nil), "MoveEventSubscriptionsRequest")
 }
 
 func init() { proto.RegisterFile("events.proto", fileDescriptor_8f22242cb04491f9) }
 
 var fileDescriptor_8f22242cb04491f9 = []byte{
-	// 579 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x6d, 0x8f, 0xd2, 0x40,
-	0x10, 0xa6, 0xbc, 0xc9, 0x0d, 0x5c, 0x0e, 0xf7, 0xf0, 0x68, 0x9a, 0x78, 0x92, 0xc6, 0x0f, 0x7c,
-	0x6a, 0xcc, 0x5d, 0x4c, 0xce, 0x8b, 0x26, 0x06, 0x68, 0x94, 0x44, 0xcc, 0x5d, 0x81, 0x1f, 0x50,
-	0xca, 0x78, 0x36, 0x94, 0x2e, 0x76, 0xb7, 0x44, 0x7e, 0x88, 0x7f, 0xc5, 0x1f, 0xe7, 0x27, 0xb3,
-	0xdb, 0x2d, 0x14, 0xa8, 0x09, 0x7e, 0xeb, 0x33, 0xb3, 0xf3, 0xcc, 0x33, 0x33, 0x4f, 0x0a, 0x0d,
-	0x5c, 0x63, 0xc8, 0x99, 0xb5, 0x8a, 0x28, 0xa7, 0xe6, 0x23, 0x5c, 0xd8, 0x02, 0x8f, 0xe3, 0x99,
-	0x83, 0x3f, 0x62, 0x64, 0x9c, 0x98, 0xd0, 0x18, 0x23, 0x63, 0x3e, 0x0d, 0x27, 0x74, 0x81, 0xa1,
-	0xae, 0x75, 0xb4, 0xee, 0x99, 0xb3, 0x17, 0x23, 0x06, 0xd4, 0x1e, 0x28, 0xe3, 0x3d, 0x3a, 0xdf,
-	0xe8, 0xc5, 0x8e, 0xd6, 0x6d, 0x38, 0x5b, 0x6c, 0xfe, 0xd1, 0xa0, 0xb9, 0xe3, 0x64, 0x2b, 0x1a,
-	0x32, 0x24, 0xd7, 0x00, 0x8c, 0xbb, 0x3c, 0x66, 0x7d, 0x3a, 0x47, 0x49, 0x59, 0x71, 0x32, 0x11,
-	0xf2, 0x1a, 0xce, 0x13, 0x34, 0x42, 0xc6, 0xdc, 0x27, 0x94, 0xac, 0x67, 0xce, 0x7e, 0x50, 0xb4,
-	0x0d, 0xa8, 0xe7, 0x72, 0x9f, 0x86, 0x7a, 0x49, 0x3e, 0xd8, 0x62, 0x42, 0xa0, 0x3c, 0x13, 0x72,
-	0xca, 0x52, 0x8e, 0xfc, 0x26, 0x6f, 0xa1, 0xfa, 0x1d, 0xdd, 0x39, 0x46, 0x7a, 0xa5, 0x53, 0xea,
-	0xd6, 0x6f, 0x5e, 0x5a, 0x87, 0xc2, 0xac, 0xcf, 0x32, 0x6f, 0x87, 0x3c, 0xda, 0x38, 0xea, 0xb1,
-	0xf1, 0x0e, 0xea, 0x99, 0x30, 0x69, 0x42, 0x69, 0x81, 0x1b, 0xb5, 0x07, 0xf1, 0x49, 0x5a, 0x50,
-	0x59, 0xbb, 0x41, 0x9c, 0xaa, 0x4c, 0xc0, 0x7d, 0xf1, 0x4e, 0x33, 0x7f, 0x42, 0x43, 0xb6, 0xf8,
-	0x9f, 0x65, 0xbe, 0x81, 0xcb, 0x54, 0x16, 0xf3, 0x22, 0x7f, 0x25, 0xc6, 0x19, 0x0e, 0x14, 0x77,
-	0x5e, 0x4a, 0xcc, 0x3a, 0x9d, 0x0e, 0x07, 0x6a, 0x07, 0xf2, 0xdb, 0xfc, 0xad, 0xc1, 0xd5, 0x00,
-	0xbf, 0xb9, 0x71, 0xc0, 0x0f, 0x2f, 0x6a, 0x40, 0x6d, 0xbc, 0x61, 0x1c, 0x97, 0xc3, 0x81, 0xae,
-	0x75, 0x4a, 0x62, 0x6d, 0x29, 0x16, 0x87, 0x91, 0xcf, 0x27, 0x9b, 0x15, 0x32, 0xbd, 0x28, 0xb3,
-	0x99, 0x88, 0xc8, 0xab, 0xed, 0x0f, 0x07, 0x4c, 0x2f, 0x25, 0xf9, 0x5d, 0x44, 0x1c, 0xce, 0x41,
-	0x46, 0xe3, 0xc8, 0xc3, 0x84, 0xa2, 0x2c, 0x9f, 0xec, 0x07, 0xa5, 0x5f, 0x84, 0xdf, 0x3c, 0x1a,
-	0xe8, 0x95, 0xe4, 0x70, 0x29, 0x36, 0x6f, 0xa1, 0x7d, 0xa4, 0x5b, 0xb9, 0x46, 0x87, 0x67, 0x13,
-	0x97, 0x2d, 0xa6, 0xce, 0x17, 0xb5, 0xb8, 0x14, 0x9a, 0x14, 0x2e, 0xd5, 0x4e, 0x66, 0x68, 0x8f,
-	0x7a, 0x99, 0x49, 0x1f, 0x82, 0xf8, 0xc9, 0x0f, 0xe5, 0xa4, 0x49, 0x1f, 0x85, 0x05, 0x99, 0x3d,
-	0xea, 0x09, 0x3d, 0x6a, 0xb5, 0x29, 0x14, 0x47, 0xb2, 0x47, 0xbd, 0xc7, 0x18, 0x63, 0xfc, 0xea,
-	0x2e, 0x51, 0x4d, 0xb9, 0x17, 0x33, 0x2d, 0x68, 0xed, 0x37, 0x54, 0x12, 0xaf, 0xa0, 0x3a, 0x96,
-	0x1e, 0x95, 0xfd, 0x6a, 0x8e, 0x42, 0x37, 0xbf, 0xca, 0x50, 0x95, 0xf3, 0x30, 0x72, 0x07, 0x17,
-	0x9f, 0x50, 0x0d, 0x87, 0xd1, 0xda, 0xf7, 0x90, 0x34, 0xad, 0x83, 0x1b, 0x19, 0xcf, 0x8f, 0xac,
-	0x69, 0x16, 0x44, 0xe5, 0x38, 0x9e, 0x2d, 0x7d, 0x3e, 0x41, 0x96, 0x10, 0x9c, 0x5a, 0xf9, 0x11,
-	0xda, 0xfd, 0x08, 0x5d, 0x8e, 0x47, 0xf6, 0x39, 0x95, 0xe1, 0x1e, 0x5a, 0x5b, 0xd5, 0xd9, 0xf2,
-	0x73, 0x2b, 0x6b, 0xf0, 0xfc, 0xda, 0x0f, 0xe2, 0xa4, 0x01, 0xe6, 0x75, 0x3f, 0xa5, 0x7c, 0x0a,
-	0xd7, 0x89, 0xf8, 0x03, 0x5f, 0xec, 0x58, 0xda, 0x56, 0xbe, 0xd5, 0x0d, 0xdd, 0xfa, 0x87, 0x97,
-	0xcc, 0x02, 0xb1, 0xe1, 0x55, 0xde, 0x44, 0xac, 0x4f, 0x83, 0x00, 0xbd, 0x93, 0xd5, 0xbd, 0x87,
-	0xba, 0x28, 0x57, 0x46, 0x20, 0x2d, 0x2b, 0xc7, 0x88, 0xc6, 0x0b, 0x2b, 0xcf, 0x2d, 0x66, 0x61,
-	0x56, 0x95, 0xff, 0xdd, 0xdb, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x33, 0x1c, 0x3c, 0x08, 0x87,
-	0x05, 0x00, 0x00,
+	// 611 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0xad, 0x73, 0xa3, 0x9d, 0xa6, 0x6a, 0xd9, 0x86, 0xd6, 0x8a, 0xd4, 0x12, 0xad, 0x78, 0xe8,
+	0x93, 0x85, 0x5a, 0x21, 0x95, 0x0a, 0x24, 0x94, 0xda, 0x82, 0x48, 0x18, 0x5a, 0x27, 0xf9, 0x00,
+	0xc7, 0x1d, 0x8a, 0x55, 0xd7, 0x1b, 0xbc, 0xeb, 0x88, 0xfc, 0x18, 0x5f, 0xc4, 0x57, 0xf0, 0x84,
+	0x76, 0xbd, 0x4e, 0xec, 0xc4, 0xa0, 0xc0, 0xdb, 0x9e, 0x99, 0x9d, 0xb3, 0x67, 0x66, 0x8e, 0x65,
+	0x68, 0xe3, 0x0c, 0x63, 0xc1, 0xad, 0x69, 0xc2, 0x04, 0xa3, 0xb7, 0xb0, 0xef, 0x48, 0x3c, 0x4c,
+	0x27, 0x1e, 0x7e, 0x4b, 0x91, 0x0b, 0x42, 0xa1, 0x3d, 0x44, 0xce, 0x43, 0x16, 0x8f, 0xd8, 0x03,
+	0xc6, 0xa6, 0xd1, 0x33, 0xce, 0x76, 0xbc, 0x52, 0x8c, 0x74, 0x61, 0xfb, 0x86, 0x71, 0xd1, 0x67,
+	0x77, 0x73, 0xb3, 0xd6, 0x33, 0xce, 0xda, 0xde, 0x02, 0xd3, 0x5f, 0x06, 0x1c, 0x2c, 0x39, 0xf9,
+	0x94, 0xc5, 0x1c, 0xc9, 0x29, 0x00, 0x17, 0xbe, 0x48, 0xf9, 0x35, 0xbb, 0x43, 0x45, 0xd9, 0xf4,
+	0x0a, 0x11, 0xf2, 0x02, 0xf6, 0x32, 0xe4, 0x22, 0xe7, 0xfe, 0x3d, 0x2a, 0xd6, 0x1d, 0xaf, 0x1c,
+	0x94, 0xcf, 0x46, 0x2c, 0xf0, 0x45, 0xc8, 0x62, 0xb3, 0xae, 0x2e, 0x2c, 0x30, 0x21, 0xd0, 0x98,
+	0x48, 0x39, 0x0d, 0x25, 0x47, 0x9d, 0xc9, 0x2b, 0x68, 0x7d, 0x45, 0xff, 0x0e, 0x13, 0xb3, 0xd9,
+	0xab, 0x9f, 0xed, 0x9e, 0x9f, 0x58, 0xab, 0xc2, 0xac, 0x0f, 0x2a, 0xef, 0xc4, 0x22, 0x99, 0x7b,
+	0xfa, 0x72, 0xf7, 0x35, 0xec, 0x16, 0xc2, 0xe4, 0x00, 0xea, 0x0f, 0x38, 0xd7, 0x73, 0x90, 0x47,
+	0xd2, 0x81, 0xe6, 0xcc, 0x8f, 0xd2, 0x5c, 0x65, 0x06, 0xae, 0x6a, 0x97, 0x06, 0xfd, 0x0e, 0x6d,
+	0xf5, 0xc4, 0xbf, 0x0c, 0xf3, 0x25, 0x1c, 0xe6, 0xb2, 0x78, 0x90, 0x84, 0x53, 0xd9, 0xce, 0xc0,
+	0xd6, 0xdc, 0x55, 0x29, 0xd9, 0xeb, 0x78, 0x3c, 0xb0, 0xf5, 0x0c, 0xd4, 0x99, 0xfe, 0x30, 0xe0,
+	0xc8, 0xc6, 0x2f, 0x7e, 0x1a, 0x89, 0xd5, 0x8d, 0x76, 0x61, 0x7b, 0x38, 0xe7, 0x02, 0x1f, 0x07,
+	0xb6, 0x69, 0xf4, 0xea, 0x72, 0x6c, 0x39, 0x96, 0x8b, 0x51, 0xd7, 0x47, 0xf3, 0x29, 0x72, 0xb3,
+	0xa6, 0xb2, 0x85, 0x88, 0xcc, 0xeb, 0xe9, 0x0f, 0x6c, 0x6e, 0xd6, 0xb3, 0xfc, 0x32, 0x22, 0x17,
+	0xe7, 0x21, 0x67, 0x69, 0x12, 0x60, 0x46, 0xd1, 0x50, 0x57, 0xca, 0x41, 0xe5, 0x17, 0xe9, 0xb7,
+	0x80, 0x45, 0x66, 0x33, 0x5b, 0x5c, 0x8e, 0xe9, 0x05, 0x1c, 0xaf, 0xe9, 0xd6, 0xae, 0x31, 0xe1,
+	0xc9, 0xc8, 0xe7, 0x0f, 0x63, 0xef, 0xa3, 0x1e, 0x5c, 0x0e, 0x29, 0x83, 0x43, 0x3d, 0x93, 0x09,
+	0x3a, 0x6e, 0xbf, 0xd0, 0xe9, 0x4d, 0x94, 0xde, 0x87, 0xf1, 0xc0, 0xd6, 0x15, 0x0b, 0x2c, 0xc9,
+	0x1c, 0xb7, 0x2f, 0xf5, 0xe8, 0xd1, 0xe6, 0x50, 0x2e, 0xc9, 0x71, 0xfb, 0xb7, 0x29, 0xa6, 0xf8,
+	0xc9, 0x7f, 0x44, 0xdd, 0x65, 0x29, 0x46, 0x2d, 0xe8, 0x94, 0x1f, 0xd4, 0x12, 0x8f, 0xa0, 0x35,
+	0x54, 0x1e, 0x55, 0xef, 0x6d, 0x7b, 0x1a, 0xd1, 0xcf, 0x70, 0xe2, 0xb2, 0x19, 0xae, 0x6d, 0x8f,
+	0xe7, 0x52, 0xf3, 0x1d, 0x1a, 0xcb, 0x1d, 0x96, 0xe4, 0xd7, 0xca, 0xf2, 0xcf, 0x7f, 0x36, 0xa0,
+	0xa5, 0xd8, 0x38, 0xb9, 0x84, 0xfd, 0xf7, 0xa8, 0xa7, 0x85, 0xc9, 0x2c, 0x0c, 0x90, 0x1c, 0x58,
+	0x2b, 0x4b, 0xef, 0x3e, 0x5d, 0xf3, 0x3a, 0xdd, 0x92, 0x95, 0xc3, 0x74, 0xf2, 0x18, 0x8a, 0x11,
+	0xf2, 0x8c, 0x60, 0xd3, 0xca, 0x77, 0x70, 0x7c, 0x9d, 0xa0, 0x2f, 0xd6, 0x3b, 0xda, 0x94, 0xe1,
+	0x0a, 0x3a, 0x0b, 0xd5, 0xc5, 0xf2, 0x3d, 0xab, 0xf8, 0xc5, 0x54, 0xd7, 0xbe, 0x95, 0x1e, 0x89,
+	0x50, 0xe0, 0xff, 0x95, 0x8f, 0xe1, 0x34, 0x13, 0xbf, 0x62, 0xb4, 0x25, 0xcb, 0xb1, 0x55, 0xfd,
+	0xed, 0x74, 0x4d, 0xeb, 0x0f, 0xe6, 0xa4, 0x5b, 0xc4, 0x81, 0xe7, 0x55, 0x1d, 0xf1, 0x6b, 0x16,
+	0x45, 0x18, 0x6c, 0xac, 0xee, 0x0d, 0xec, 0xca, 0x72, 0xed, 0x2c, 0xd2, 0xb1, 0x2a, 0x9c, 0xdd,
+	0x7d, 0x66, 0x55, 0xd9, 0x8f, 0x6e, 0x11, 0x17, 0x8e, 0xaa, 0x8d, 0x46, 0x4e, 0xad, 0xbf, 0x3a,
+	0xb0, 0x52, 0xcc, 0xa4, 0xa5, 0xfe, 0x0b, 0x17, 0xbf, 0x07, 0x00, 0xd9, 0x05, 0x2a, 0xed, 0x27,
+	0x06, 0x00, 0x00,
 }
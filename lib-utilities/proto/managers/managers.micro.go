@@ -37,6 +37,10 @@ type ManagersService interface {
 	GetManagersCollection(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
 	GetManager(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
 	GetManagersResource(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
+	ResetManager(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
+	UpdateManagersResource(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
+	VirtualMediaInsert(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
+	VirtualMediaEject(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error)
 }
 
 type managersService struct {
@@ -87,12 +91,56 @@ func (c *managersService) GetManagersResource(ctx context.Context, in *ManagerRe
 	return out, nil
 }
 
+func (c *managersService) ResetManager(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error) {
+	req := c.c.NewRequest(c.name, "Managers.ResetManager", in)
+	out := new(ManagerResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managersService) UpdateManagersResource(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error) {
+	req := c.c.NewRequest(c.name, "Managers.UpdateManagersResource", in)
+	out := new(ManagerResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managersService) VirtualMediaInsert(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error) {
+	req := c.c.NewRequest(c.name, "Managers.VirtualMediaInsert", in)
+	out := new(ManagerResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *managersService) VirtualMediaEject(ctx context.Context, in *ManagerRequest, opts ...client.CallOption) (*ManagerResponse, error) {
+	req := c.c.NewRequest(c.name, "Managers.VirtualMediaEject", in)
+	out := new(ManagerResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Managers service
 
 type ManagersHandler interface {
 	GetManagersCollection(context.Context, *ManagerRequest, *ManagerResponse) error
 	GetManager(context.Context, *ManagerRequest, *ManagerResponse) error
 	GetManagersResource(context.Context, *ManagerRequest, *ManagerResponse) error
+	ResetManager(context.Context, *ManagerRequest, *ManagerResponse) error
+	UpdateManagersResource(context.Context, *ManagerRequest, *ManagerResponse) error
+	VirtualMediaInsert(context.Context, *ManagerRequest, *ManagerResponse) error
+	VirtualMediaEject(context.Context, *ManagerRequest, *ManagerResponse) error
 }
 
 func RegisterManagersHandler(s server.Server, hdlr ManagersHandler, opts ...server.HandlerOption) error {
@@ -100,6 +148,10 @@ func RegisterManagersHandler(s server.Server, hdlr ManagersHandler, opts ...serv
 		GetManagersCollection(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
 		GetManager(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
 		GetManagersResource(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
+		ResetManager(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
+		UpdateManagersResource(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
+		VirtualMediaInsert(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
+		VirtualMediaEject(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error
 	}
 	type Managers struct {
 		managers
@@ -123,3 +175,19 @@ func (h *managersHandler) GetManager(ctx context.Context, in *ManagerRequest, ou
 func (h *managersHandler) GetManagersResource(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error {
 	return h.ManagersHandler.GetManagersResource(ctx, in, out)
 }
+
+func (h *managersHandler) ResetManager(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error {
+	return h.ManagersHandler.ResetManager(ctx, in, out)
+}
+
+func (h *managersHandler) UpdateManagersResource(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error {
+	return h.ManagersHandler.UpdateManagersResource(ctx, in, out)
+}
+
+func (h *managersHandler) VirtualMediaInsert(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error {
+	return h.ManagersHandler.VirtualMediaInsert(ctx, in, out)
+}
+
+func (h *managersHandler) VirtualMediaEject(ctx context.Context, in *ManagerRequest, out *ManagerResponse) error {
+	return h.ManagersHandler.VirtualMediaEject(ctx, in, out)
+}
@@ -25,6 +25,7 @@ type ManagerRequest struct {
 	ManagerID            string   `protobuf:"bytes,2,opt,name=managerID,proto3" json:"managerID,omitempty"`
 	URL                  string   `protobuf:"bytes,3,opt,name=URL,proto3" json:"URL,omitempty"`
 	ResourceID           string   `protobuf:"bytes,4,opt,name=resourceID,proto3" json:"resourceID,omitempty"`
+	RequestBody          []byte   `protobuf:"bytes,5,opt,name=requestBody,proto3" json:"requestBody,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -83,6 +84,13 @@ func (m *ManagerRequest) GetResourceID() string {
 	return ""
 }
 
+func (m *ManagerRequest) GetRequestBody() []byte {
+	if m != nil {
+		return m.RequestBody
+	}
+	return nil
+}
+
 type ManagerResponse struct {
 	StatusCode           int32             `protobuf:"varint,1,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
 	StatusMessage        string            `protobuf:"bytes,2,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
@@ -155,25 +163,28 @@ func init() {
 func init() { proto.RegisterFile("managers.proto", fileDescriptor_49f5910ae72958ed) }
 
 var fileDescriptor_49f5910ae72958ed = []byte{
-	// 309 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x4f, 0xf2, 0x40,
-	0x10, 0xc6, 0xdf, 0xf2, 0x2f, 0x2f, 0x03, 0x02, 0x19, 0x35, 0x69, 0x08, 0x31, 0xa4, 0xf1, 0xc0,
-	0xa9, 0x89, 0xe8, 0x41, 0x89, 0x37, 0x30, 0x4a, 0x22, 0x97, 0x8d, 0x7e, 0x80, 0x05, 0x26, 0x48,
-	0xc0, 0x5d, 0xdc, 0xd9, 0x9a, 0x70, 0xf7, 0x3b, 0xf9, 0x65, 0xfc, 0x30, 0xa6, 0xcb, 0x22, 0x85,
-	0x13, 0xb7, 0x99, 0xdf, 0xcc, 0xd3, 0xe7, 0xe9, 0xb4, 0x50, 0x7b, 0x97, 0x4a, 0xce, 0xc8, 0x70,
-	0xbc, 0x32, 0xda, 0xea, 0xe8, 0x2b, 0x80, 0xda, 0x68, 0x83, 0x04, 0x7d, 0x24, 0xc4, 0x16, 0x23,
-	0xa8, 0x32, 0x31, 0xcf, 0xb5, 0x7a, 0xd1, 0x0b, 0x52, 0x61, 0xd0, 0x0e, 0x3a, 0x65, 0xb1, 0xc7,
-	0xb0, 0x05, 0x65, 0xff, 0xa0, 0xe1, 0x20, 0xcc, 0xb9, 0x85, 0x1d, 0xc0, 0x06, 0xe4, 0x5f, 0xc5,
-	0x73, 0x98, 0x77, 0x3c, 0x2d, 0xf1, 0x02, 0xc0, 0x10, 0xeb, 0xc4, 0x4c, 0x68, 0x38, 0x08, 0x0b,
-	0x6e, 0x90, 0x21, 0xd1, 0x4f, 0x00, 0xf5, 0xbf, 0x18, 0xbc, 0xd2, 0x8a, 0x29, 0xd5, 0xb0, 0x95,
-	0x36, 0xe1, 0xbe, 0x9e, 0x92, 0x4b, 0x51, 0x14, 0x19, 0x82, 0x97, 0x70, 0xb2, 0xe9, 0x46, 0xc4,
-	0x2c, 0x67, 0xe4, 0x73, 0xec, 0x43, 0x44, 0x28, 0x8c, 0xf5, 0x74, 0xed, 0x3c, 0xab, 0xc2, 0xd5,
-	0x78, 0x03, 0xa5, 0x37, 0x92, 0x53, 0x32, 0x61, 0xb1, 0x9d, 0xef, 0x54, 0xba, 0xad, 0xf8, 0xc0,
-	0x3b, 0x7e, 0x72, 0xe3, 0x07, 0x65, 0xcd, 0x5a, 0xf8, 0xdd, 0xe6, 0x1d, 0x54, 0x32, 0x38, 0x7d,
-	0xc9, 0x05, 0xad, 0xfd, 0x75, 0xd2, 0x12, 0xcf, 0xa0, 0xf8, 0x29, 0x97, 0xc9, 0x36, 0xc8, 0xa6,
-	0xe9, 0xe5, 0x6e, 0x83, 0xee, 0x77, 0x00, 0xff, 0xbd, 0x05, 0xe3, 0x3d, 0x9c, 0x3f, 0x92, 0xdd,
-	0xb6, 0x7d, 0xbd, 0x5c, 0xd2, 0xc4, 0xce, 0xb5, 0xc2, 0x7a, 0xbc, 0xff, 0x25, 0x9a, 0x8d, 0xc3,
-	0x5c, 0xd1, 0x3f, 0xbc, 0x02, 0xd8, 0xa9, 0x8f, 0x93, 0xf4, 0xe0, 0x34, 0x63, 0x28, 0xfc, 0xd5,
-	0x8f, 0xd2, 0x8e, 0x4b, 0xee, 0x37, 0xb9, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xf1, 0xe1, 0x96,
-	0xea, 0x38, 0x02, 0x00, 0x00,
+	// 368 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0xcf, 0x6e, 0xe2, 0x30,
+	0x10, 0xc6, 0x37, 0x84, 0xa0, 0x65, 0x60, 0x81, 0xf5, 0xfe, 0x51, 0x84, 0xd0, 0x2a, 0x8a, 0xf6,
+	0xc0, 0x29, 0xd2, 0xc2, 0x1e, 0x00, 0xb5, 0x97, 0x02, 0x6a, 0x91, 0xca, 0xc5, 0x2a, 0xbd, 0x1b,
+	0x32, 0xa2, 0x29, 0x69, 0x4c, 0x6d, 0xa7, 0x12, 0x6f, 0xd3, 0x53, 0x9f, 0xaa, 0x0f, 0x53, 0xc5,
+	0x09, 0x25, 0x70, 0x4a, 0x7b, 0x9b, 0xf9, 0xd9, 0x9f, 0xbf, 0x4f, 0x33, 0x32, 0x34, 0x1e, 0x58,
+	0xc4, 0xd6, 0x28, 0xa4, 0xb7, 0x15, 0x5c, 0x71, 0xf7, 0xc5, 0x80, 0xc6, 0x3c, 0x45, 0x14, 0x1f,
+	0x63, 0x94, 0x8a, 0xb8, 0x50, 0x97, 0x28, 0x65, 0xc0, 0xa3, 0x1b, 0xbe, 0xc1, 0xc8, 0x36, 0x1c,
+	0xa3, 0x5b, 0xa5, 0x47, 0x8c, 0x74, 0xa0, 0x9a, 0x3d, 0x34, 0x9b, 0xd8, 0x25, 0x7d, 0xe1, 0x00,
+	0x48, 0x0b, 0xcc, 0x05, 0xbd, 0xb6, 0x4d, 0xcd, 0x93, 0x92, 0xfc, 0x01, 0x10, 0x28, 0x79, 0x2c,
+	0x56, 0x38, 0x9b, 0xd8, 0x65, 0x7d, 0x90, 0x23, 0xc4, 0x81, 0x9a, 0x48, 0xed, 0x2f, 0xb8, 0xbf,
+	0xb3, 0x2d, 0xc7, 0xe8, 0xd6, 0x69, 0x1e, 0xb9, 0xaf, 0x06, 0x34, 0xdf, 0x83, 0xca, 0x2d, 0x8f,
+	0x24, 0x26, 0xaf, 0x4a, 0xc5, 0x54, 0x2c, 0xc7, 0xdc, 0x47, 0x9d, 0xd3, 0xa2, 0x39, 0x42, 0xfe,
+	0xc2, 0xb7, 0xb4, 0x9b, 0xa3, 0x94, 0x6c, 0x8d, 0x59, 0xd2, 0x63, 0x48, 0x08, 0x94, 0x97, 0x89,
+	0x69, 0x59, 0x9b, 0xea, 0x9a, 0xfc, 0x87, 0xca, 0x1d, 0x32, 0x1f, 0x85, 0x6d, 0x39, 0x66, 0xb7,
+	0xd6, 0xeb, 0x78, 0x27, 0xde, 0xde, 0x95, 0x3e, 0x9e, 0x46, 0x4a, 0xec, 0x68, 0x76, 0xb7, 0x3d,
+	0x84, 0x5a, 0x0e, 0x27, 0x63, 0xd8, 0xe0, 0x2e, 0x9b, 0x5f, 0x52, 0x92, 0x9f, 0x60, 0x3d, 0xb1,
+	0x30, 0xde, 0x07, 0x49, 0x9b, 0x51, 0x69, 0x60, 0xf4, 0x9e, 0x4d, 0xf8, 0x9a, 0x59, 0x48, 0x72,
+	0x06, 0xbf, 0x2e, 0x51, 0xed, 0xdb, 0x31, 0x0f, 0x43, 0x5c, 0xa9, 0x80, 0x47, 0xa4, 0xe9, 0x1d,
+	0xef, 0xaa, 0xdd, 0x3a, 0xcd, 0xe5, 0x7e, 0x21, 0xff, 0x00, 0x0e, 0xea, 0x62, 0x92, 0x11, 0xfc,
+	0xc8, 0x19, 0xd2, 0x6c, 0x2f, 0xc5, 0xb4, 0x7d, 0xa8, 0x53, 0x94, 0x1f, 0x34, 0x3c, 0x87, 0xdf,
+	0x8b, 0xad, 0xcf, 0x14, 0x7e, 0xce, 0x73, 0x08, 0xe4, 0x36, 0x10, 0x2a, 0x66, 0xe1, 0x1c, 0xfd,
+	0x80, 0xcd, 0x22, 0x89, 0x42, 0x15, 0x93, 0x0e, 0xe0, 0x7b, 0x5e, 0x3a, 0xbd, 0xc7, 0x55, 0x31,
+	0xe5, 0xb2, 0xa2, 0x7f, 0x4c, 0xff, 0x6d, 0x00, 0x54, 0xb7, 0xc8, 0xfe, 0x43, 0x03, 0x00, 0x00,
 }
@@ -40,6 +40,7 @@ type SessionService interface {
 	GetSession(ctx context.Context, in *SessionRequest, opts ...client.CallOption) (*SessionResponse, error)
 	GetSessionUserName(ctx context.Context, in *SessionRequest, opts ...client.CallOption) (*SessionUserName, error)
 	GetSessionService(ctx context.Context, in *SessionRequest, opts ...client.CallOption) (*SessionResponse, error)
+	DeleteUserSessions(ctx context.Context, in *UserSessionsRequest, opts ...client.CallOption) (*SessionResponse, error)
 }
 
 type sessionService struct {
@@ -120,6 +121,16 @@ func (c *sessionService) GetSessionService(ctx context.Context, in *SessionReque
 	return out, nil
 }
 
+func (c *sessionService) DeleteUserSessions(ctx context.Context, in *UserSessionsRequest, opts ...client.CallOption) (*SessionResponse, error) {
+	req := c.c.NewRequest(c.name, "Session.DeleteUserSessions", in)
+	out := new(SessionResponse)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Session service
 
 type SessionHandler interface {
@@ -129,6 +140,7 @@ type SessionHandler interface {
 	GetSession(context.Context, *SessionRequest, *SessionResponse) error
 	GetSessionUserName(context.Context, *SessionRequest, *SessionUserName) error
 	GetSessionService(context.Context, *SessionRequest, *SessionResponse) error
+	DeleteUserSessions(context.Context, *UserSessionsRequest, *SessionResponse) error
 }
 
 func RegisterSessionHandler(s server.Server, hdlr SessionHandler, opts ...server.HandlerOption) error {
@@ -139,6 +151,7 @@ func RegisterSessionHandler(s server.Server, hdlr SessionHandler, opts ...server
 		GetSession(ctx context.Context, in *SessionRequest, out *SessionResponse) error
 		GetSessionUserName(ctx context.Context, in *SessionRequest, out *SessionUserName) error
 		GetSessionService(ctx context.Context, in *SessionRequest, out *SessionResponse) error
+		DeleteUserSessions(ctx context.Context, in *UserSessionsRequest, out *SessionResponse) error
 	}
 	type Session struct {
 		session
@@ -174,3 +187,7 @@ func (h *sessionHandler) GetSessionUserName(ctx context.Context, in *SessionRequ
 func (h *sessionHandler) GetSessionService(ctx context.Context, in *SessionRequest, out *SessionResponse) error {
 	return h.SessionHandler.GetSessionService(ctx, in, out)
 }
+
+func (h *sessionHandler) DeleteUserSessions(ctx context.Context, in *UserSessionsRequest, out *SessionResponse) error {
+	return h.SessionHandler.DeleteUserSessions(ctx, in, out)
+}
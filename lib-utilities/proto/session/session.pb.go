@@ -22,6 +22,8 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type SessionCreateRequest struct {
 	RequestBody          []byte   `protobuf:"bytes,1,opt,name=RequestBody,proto3" json:"RequestBody,omitempty"`
+	ClientIP             string   `protobuf:"bytes,2,opt,name=clientIP,proto3" json:"clientIP,omitempty"`
+	UserAgent            string   `protobuf:"bytes,3,opt,name=userAgent,proto3" json:"userAgent,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -59,6 +61,20 @@ func (m *SessionCreateRequest) GetRequestBody() []byte {
 	return nil
 }
 
+func (m *SessionCreateRequest) GetClientIP() string {
+	if m != nil {
+		return m.ClientIP
+	}
+	return ""
+}
+
+func (m *SessionCreateRequest) GetUserAgent() string {
+	if m != nil {
+		return m.UserAgent
+	}
+	return ""
+}
+
 type SessionUserName struct {
 	UserName             string   `protobuf:"bytes,1,opt,name=userName,proto3" json:"userName,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -172,6 +188,7 @@ func (m *SessionCreateResponse) GetHeader() map[string]string {
 type SessionRequest struct {
 	SessionId            string   `protobuf:"bytes,1,opt,name=sessionId,proto3" json:"sessionId,omitempty"`
 	SessionToken         string   `protobuf:"bytes,2,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	Filter               string   `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -216,6 +233,60 @@ func (m *SessionRequest) GetSessionToken() string {
 	return ""
 }
 
+func (m *SessionRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+type UserSessionsRequest struct {
+	SessionToken         string   `protobuf:"bytes,1,opt,name=sessionToken,proto3" json:"sessionToken,omitempty"`
+	UserName             string   `protobuf:"bytes,2,opt,name=userName,proto3" json:"userName,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserSessionsRequest) Reset()         { *m = UserSessionsRequest{} }
+func (m *UserSessionsRequest) String() string { return proto.CompactTextString(m) }
+func (*UserSessionsRequest) ProtoMessage()    {}
+func (*UserSessionsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_090d37272f6e4da6, []int{4}
+}
+
+func (m *UserSessionsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserSessionsRequest.Unmarshal(m, b)
+}
+func (m *UserSessionsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserSessionsRequest.Marshal(b, m, deterministic)
+}
+func (m *UserSessionsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserSessionsRequest.Merge(m, src)
+}
+func (m *UserSessionsRequest) XXX_Size() int {
+	return xxx_messageInfo_UserSessionsRequest.Size(m)
+}
+func (m *UserSessionsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserSessionsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserSessionsRequest proto.InternalMessageInfo
+
+func (m *UserSessionsRequest) GetSessionToken() string {
+	if m != nil {
+		return m.SessionToken
+	}
+	return ""
+}
+
+func (m *UserSessionsRequest) GetUserName() string {
+	if m != nil {
+		return m.UserName
+	}
+	return ""
+}
+
 type SessionResponse struct {
 	StatusCode           int32             `protobuf:"varint,1,opt,name=statusCode,proto3" json:"statusCode,omitempty"`
 	StatusMessage        string            `protobuf:"bytes,2,opt,name=statusMessage,proto3" json:"statusMessage,omitempty"`
@@ -230,7 +301,7 @@ func (m *SessionResponse) Reset()         { *m = SessionResponse{} }
 func (m *SessionResponse) String() string { return proto.CompactTextString(m) }
 func (*SessionResponse) ProtoMessage()    {}
 func (*SessionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_090d37272f6e4da6, []int{4}
+	return fileDescriptor_090d37272f6e4da6, []int{5}
 }
 
 func (m *SessionResponse) XXX_Unmarshal(b []byte) error {
@@ -285,6 +356,7 @@ func init() {
 	proto.RegisterType((*SessionCreateResponse)(nil), "SessionCreateResponse")
 	proto.RegisterMapType((map[string]string)(nil), "SessionCreateResponse.HeaderEntry")
 	proto.RegisterType((*SessionRequest)(nil), "SessionRequest")
+	proto.RegisterType((*UserSessionsRequest)(nil), "UserSessionsRequest")
 	proto.RegisterType((*SessionResponse)(nil), "SessionResponse")
 	proto.RegisterMapType((map[string]string)(nil), "SessionResponse.HeaderEntry")
 }
@@ -292,31 +364,35 @@ func init() {
 func init() { proto.RegisterFile("proto/session/session.proto", fileDescriptor_090d37272f6e4da6) }
 
 var fileDescriptor_090d37272f6e4da6 = []byte{
-	// 406 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xc1, 0x6e, 0xda, 0x40,
-	0x10, 0xc5, 0x36, 0xd0, 0x32, 0x40, 0xa1, 0x2b, 0xa8, 0x2c, 0x17, 0x55, 0x68, 0xd5, 0x03, 0x97,
-	0xba, 0x2a, 0xe5, 0x00, 0xf4, 0x52, 0x4a, 0x2b, 0xda, 0x43, 0x7b, 0x30, 0xcd, 0x07, 0x18, 0x3c,
-	0x4a, 0x10, 0x8e, 0x4d, 0xbc, 0x6b, 0x24, 0x7e, 0x20, 0x5f, 0x99, 0x73, 0xbe, 0x23, 0x62, 0xbd,
-	0xb6, 0xb1, 0xe5, 0x48, 0x44, 0xc9, 0xc9, 0x33, 0x4f, 0xf3, 0xe6, 0xcd, 0xbc, 0xb1, 0x0d, 0xef,
-	0x77, 0x81, 0xcf, 0xfd, 0xcf, 0x0c, 0x19, 0xdb, 0xf8, 0x5e, 0xfc, 0x34, 0x05, 0x4a, 0xc7, 0xd0,
-	0x59, 0x46, 0xc0, 0x3c, 0x40, 0x9b, 0xa3, 0x85, 0x37, 0x21, 0x32, 0x4e, 0xfa, 0x50, 0x97, 0xe1,
-	0x0f, 0xdf, 0x39, 0xe8, 0x4a, 0x5f, 0x19, 0x34, 0xac, 0x53, 0x88, 0x7e, 0x82, 0x96, 0x64, 0x5e,
-	0x30, 0x0c, 0xfe, 0xd9, 0xd7, 0x48, 0x0c, 0x78, 0x1d, 0xca, 0x58, 0x30, 0x6a, 0x56, 0x92, 0xd3,
-	0x5b, 0x15, 0xba, 0x39, 0x25, 0xb6, 0xf3, 0x3d, 0x86, 0xe4, 0x03, 0x00, 0xe3, 0x36, 0x0f, 0xd9,
-	0xdc, 0x77, 0x22, 0x5e, 0xc5, 0x3a, 0x41, 0xc8, 0x47, 0x68, 0x46, 0xd9, 0x5f, 0x64, 0xcc, 0xbe,
-	0x44, 0x5d, 0x15, 0xad, 0xb3, 0x20, 0xe9, 0x41, 0x4d, 0x6e, 0xf6, 0xc7, 0xd1, 0x35, 0x51, 0x91,
-	0x02, 0x84, 0x40, 0x79, 0x75, 0xdc, 0xa3, 0x2c, 0xf6, 0x10, 0x31, 0x99, 0x42, 0xf5, 0x0a, 0x6d,
-	0x07, 0x03, 0xbd, 0xd2, 0xd7, 0x06, 0xf5, 0x21, 0x35, 0x0b, 0xe7, 0x33, 0x7f, 0x8b, 0xa2, 0x5f,
-	0x1e, 0x0f, 0x0e, 0x96, 0x64, 0x18, 0x13, 0xa8, 0x9f, 0xc0, 0xa4, 0x0d, 0xda, 0x16, 0x0f, 0x72,
-	0xe7, 0x63, 0x48, 0x3a, 0x50, 0xd9, 0xdb, 0x6e, 0x18, 0x0f, 0x1b, 0x25, 0x53, 0x75, 0xac, 0x50,
-	0x0b, 0xde, 0x48, 0x9d, 0xd8, 0xeb, 0xcc, 0xe8, 0x4a, 0x7e, 0x74, 0x0a, 0x0d, 0x99, 0xfc, 0xf7,
-	0xb7, 0xe8, 0xc9, 0x86, 0x19, 0x8c, 0xde, 0x29, 0xc9, 0x31, 0x5e, 0xd8, 0xd6, 0x51, 0x62, 0x92,
-	0x26, 0x4c, 0xea, 0x99, 0x39, 0x9d, 0x22, 0x7b, 0x8a, 0xec, 0x7e, 0x86, 0x65, 0xc3, 0x7b, 0x15,
-	0x5e, 0x49, 0x59, 0xf2, 0x1d, 0x9a, 0xd1, 0x7d, 0x62, 0xa0, 0x6b, 0x16, 0xbd, 0xc0, 0xc6, 0xbb,
-	0xe2, 0x6b, 0xd2, 0x12, 0x19, 0x41, 0xf3, 0x27, 0xba, 0x98, 0x76, 0x68, 0x99, 0xd9, 0x83, 0x18,
-	0xed, 0xfc, 0x92, 0xb4, 0x44, 0xbe, 0x41, 0x67, 0x81, 0x7c, 0xe6, 0xba, 0xb3, 0x35, 0xdf, 0xec,
-	0x63, 0x2e, 0x3b, 0x8f, 0xfc, 0x05, 0x60, 0x81, 0xfc, 0x49, 0x7a, 0x13, 0x20, 0x29, 0x25, 0xf9,
-	0xc2, 0x1e, 0xa7, 0xc6, 0x25, 0xb4, 0x44, 0xc6, 0xf0, 0x36, 0xa5, 0x2e, 0x31, 0xd8, 0x6f, 0xd6,
-	0x78, 0x96, 0xe8, 0xaa, 0x2a, 0x7e, 0x0a, 0x5f, 0x1f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa9, 0xa3,
-	0xb8, 0xee, 0x33, 0x04, 0x00, 0x00,
+	// 476 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xdb, 0x6e, 0xd3, 0x40,
+	0x10, 0x8d, 0xe3, 0x26, 0x90, 0x49, 0x43, 0xcb, 0x90, 0x56, 0x51, 0xa8, 0x50, 0xb4, 0xe2, 0x21,
+	0x2f, 0x18, 0x51, 0xfa, 0xd0, 0x16, 0x09, 0x11, 0x0a, 0x2a, 0x7d, 0x00, 0x21, 0x97, 0x7e, 0x80,
+	0x1b, 0x0f, 0xc5, 0xd4, 0xd8, 0x65, 0x77, 0x1d, 0x29, 0x3f, 0xc0, 0xa7, 0xf0, 0x55, 0x7c, 0x0c,
+	0xf2, 0x7a, 0xd7, 0x37, 0x2d, 0x52, 0x11, 0x3c, 0x65, 0xe7, 0xc4, 0x67, 0xce, 0xd9, 0x39, 0x63,
+	0xc3, 0xc3, 0x1b, 0x9e, 0xca, 0xf4, 0xa9, 0x20, 0x21, 0xa2, 0x34, 0x31, 0xbf, 0x9e, 0x42, 0x19,
+	0x87, 0xf1, 0x79, 0x01, 0x9c, 0x70, 0x0a, 0x24, 0xf9, 0xf4, 0x3d, 0x23, 0x21, 0x71, 0x06, 0x43,
+	0x7d, 0x7c, 0x9d, 0x86, 0xeb, 0x89, 0x33, 0x73, 0xe6, 0x9b, 0x7e, 0x1d, 0xc2, 0x29, 0xdc, 0x5d,
+	0xc6, 0x11, 0x25, 0xf2, 0xec, 0xe3, 0xa4, 0x3b, 0x73, 0xe6, 0x03, 0xbf, 0xac, 0x71, 0x0f, 0x06,
+	0x99, 0x20, 0xbe, 0xb8, 0xa2, 0x44, 0x4e, 0x5c, 0xf5, 0x67, 0x05, 0xb0, 0x27, 0xb0, 0xa5, 0x35,
+	0x2f, 0x04, 0xf1, 0x0f, 0xc1, 0x37, 0xca, 0x9b, 0x65, 0xfa, 0xac, 0xb4, 0x06, 0x7e, 0x59, 0xb3,
+	0x1f, 0x5d, 0xd8, 0x69, 0x79, 0x14, 0x37, 0x69, 0x22, 0x08, 0x1f, 0x01, 0x08, 0x19, 0xc8, 0x4c,
+	0x9c, 0xa4, 0x61, 0xc1, 0xeb, 0xf9, 0x35, 0x04, 0x1f, 0xc3, 0xa8, 0xa8, 0xde, 0x93, 0x10, 0xc1,
+	0x15, 0x69, 0x9f, 0x4d, 0x30, 0x37, 0xab, 0x67, 0x72, 0x16, 0x1a, 0xb3, 0x25, 0x80, 0x08, 0x1b,
+	0x97, 0xf9, 0x04, 0x36, 0xd4, 0x04, 0xd4, 0x19, 0x8f, 0xa1, 0xff, 0x85, 0x82, 0x90, 0xf8, 0xa4,
+	0x37, 0x73, 0xe7, 0xc3, 0x7d, 0xe6, 0x59, 0xfd, 0x79, 0xef, 0xd4, 0x43, 0x6f, 0x13, 0xc9, 0xd7,
+	0xbe, 0x66, 0x4c, 0x8f, 0x60, 0x58, 0x83, 0x71, 0x1b, 0xdc, 0x6b, 0x5a, 0xeb, 0x3b, 0xe7, 0x47,
+	0x1c, 0x43, 0x6f, 0x15, 0xc4, 0x99, 0x31, 0x5b, 0x14, 0xc7, 0xdd, 0x43, 0x87, 0x7d, 0x85, 0x7b,
+	0x5a, 0xc7, 0xa4, 0xd4, 0xb0, 0xee, 0xb4, 0xad, 0x33, 0xd8, 0xd4, 0xc5, 0xa7, 0xf4, 0x9a, 0x12,
+	0xdd, 0xb0, 0x81, 0xe1, 0x2e, 0xf4, 0x3f, 0x47, 0xb1, 0x24, 0xae, 0x6f, 0xae, 0x2b, 0x76, 0x01,
+	0x0f, 0xf2, 0x70, 0xb4, 0x9e, 0x30, 0x82, 0xed, 0x96, 0x8e, 0xa5, 0x65, 0x3d, 0xcb, 0x6e, 0x2b,
+	0xcb, 0x5f, 0x4e, 0x99, 0xfd, 0x7f, 0x4e, 0xf1, 0xa0, 0xcc, 0xc4, 0x55, 0x99, 0xec, 0x79, 0x2d,
+	0x1d, 0x5b, 0x1a, 0xb6, 0x74, 0xff, 0x21, 0xa1, 0xfd, 0x9f, 0x2e, 0xdc, 0xd1, 0xb2, 0xf8, 0x0a,
+	0x46, 0xc5, 0x3a, 0x18, 0x60, 0xc7, 0xb3, 0xbd, 0x69, 0xd3, 0x5d, 0xfb, 0xf2, 0xb0, 0x0e, 0x1e,
+	0xc0, 0xe8, 0x0d, 0xc5, 0x54, 0x75, 0xd8, 0xf2, 0x9a, 0xf9, 0x4f, 0xb7, 0xdb, 0x97, 0x64, 0x1d,
+	0x7c, 0x01, 0xe3, 0x53, 0x92, 0x8b, 0x38, 0x5e, 0x2c, 0x65, 0xb4, 0x32, 0x5c, 0x71, 0x3b, 0xf2,
+	0x33, 0x80, 0x53, 0x92, 0x7f, 0xa5, 0x77, 0x04, 0x58, 0x51, 0xca, 0x17, 0xfa, 0xcf, 0x54, 0xf3,
+	0x08, 0xeb, 0xe0, 0x21, 0xdc, 0xaf, 0xa8, 0xe7, 0xc4, 0x57, 0xd1, 0x92, 0x6e, 0x27, 0xfa, 0x12,
+	0xb0, 0x18, 0x4d, 0x7d, 0x49, 0x71, 0xec, 0x59, 0x76, 0xd6, 0xc6, 0xbf, 0xec, 0xab, 0xaf, 0xdf,
+	0xf3, 0xdf, 0x03, 0x00, 0x9e, 0x78, 0x29, 0x7f, 0x1c, 0x05, 0x00, 0x00,
 }
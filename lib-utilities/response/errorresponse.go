@@ -190,6 +190,24 @@ func (a *Args) CreateGenericErrorResponse() CommonError {
 					Severity:   "Critical",
 					Resolution: "Establish a session before attempting any operations.",
 				})
+		case SessionLimitExceeded:
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:  ErrorMessageOdataType,
+					MessageID:  errArg.StatusMessage,
+					Message:    "The session establishment failed due to the number of simultaneous sessions exceeding the limit of the implementation." + errArg.ErrorMessage,
+					Severity:   "Critical",
+					Resolution: "Reduce the number of other sessions before trying to establish the session or increase the limit of simultaneous sessions (if supported).",
+				})
+		case PasswordChangeRequired:
+			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
+				Msg{
+					OdataType:  ErrorMessageOdataType,
+					MessageID:  errArg.StatusMessage,
+					Message:    "The password provided for this account must be changed before access is granted." + errArg.ErrorMessage,
+					Severity:   "Critical",
+					Resolution: "Change the password for this account using a PATCH to the Password property of the account resource.",
+				})
 		case ResourceInUse:
 			e.Error.MessageExtendedInfo = append(e.Error.MessageExtendedInfo,
 				Msg{
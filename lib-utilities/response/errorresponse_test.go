@@ -284,6 +284,62 @@ func TestCreateErrorResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: SessionLimitExceeded,
+			args: Args{
+				Code:    SessionLimitExceeded,
+				Message: SessionLimitExceeded,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: SessionLimitExceeded,
+						ErrorMessage:  errMsg,
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    SessionLimitExceeded,
+					Message: SessionLimitExceeded,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:  ErrorMessageOdataType,
+							MessageID:  SessionLimitExceeded,
+							Message:    "The session establishment failed due to the number of simultaneous sessions exceeding the limit of the implementation." + errMsg,
+							Severity:   "Critical",
+							Resolution: "Reduce the number of other sessions before trying to establish the session or increase the limit of simultaneous sessions (if supported).",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: PasswordChangeRequired,
+			args: Args{
+				Code:    PasswordChangeRequired,
+				Message: PasswordChangeRequired,
+				ErrorArgs: []ErrArgs{
+					ErrArgs{
+						StatusMessage: PasswordChangeRequired,
+						ErrorMessage:  errMsg,
+					},
+				},
+			},
+			want: CommonError{
+				Error: ErrorClass{
+					Code:    PasswordChangeRequired,
+					Message: PasswordChangeRequired,
+					MessageExtendedInfo: []Msg{
+						Msg{
+							OdataType:  ErrorMessageOdataType,
+							MessageID:  PasswordChangeRequired,
+							Message:    "The password provided for this account must be changed before access is granted." + errMsg,
+							Severity:   "Critical",
+							Resolution: "Change the password for this account using a PATCH to the Password property of the account resource.",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: ResourceInUse,
 			args: Args{
@@ -71,6 +71,10 @@ const (
 	ResourceCannotBeDeleted = "Base.1.6.1.ResourceCannotBeDeleted"
 	// PropertyValueConflict indicates that the requested write of a property value could not be completed, because of a conflict with another property value.
 	PropertyValueConflict = "Base.1.6.1.PropertyValueConflict"
+	// SessionLimitExceeded indicates that the session establishment failed due to the number of simultaneous sessions exceeding the limit
+	SessionLimitExceeded = "Base.1.6.1.SessionLimitExceeded"
+	// PasswordChangeRequired indicates that the password of the account must be changed before access is granted
+	PasswordChangeRequired = "Base.1.6.1.PasswordChangeRequired"
 )
 
 // Response holds the generic response from odimra
@@ -43,3 +43,15 @@ func DeleteSubscription(uuid string) (*eventsproto.EventSubResponse, error) {
 
 	return events.DeleteEventSubscription(context.TODO(), &req)
 }
+
+// MoveEventSubscriptions calls the event service to subscribe again to the events of the server
+// through the plugin which now manages it, pluginID being the plugin the server was managed by
+func MoveEventSubscriptions(uuid, pluginID string) (*eventsproto.EventSubResponse, error) {
+	req := eventsproto.MoveEventSubscriptionsRequest{
+		UUID:     uuid,
+		PluginID: pluginID,
+	}
+	events := eventsproto.NewEventsService(Events, Service.Client())
+
+	return events.MoveEventSubscriptions(context.TODO(), &req)
+}
@@ -23,6 +23,7 @@ import (
 	"os"
 
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
 )
 
 // Data will have the configuration data from config file
@@ -33,6 +34,7 @@ type configModel struct {
 	FirmwareVersion         string            `json:"FirmwareVersion"` //FirmwareVersion of plugin of the plugin
 	RootServiceUUID         string            `json:"RootServiceUUID"`
 	SessionTimeoutInMinutes float64           `json:"SessionTimeoutInMinutes"` //plugin token time out in minutes
+	SessionStorePath        string            `json:"SessionStorePath"`        //file the plugin sessions are saved to, to survive restarts
 	PluginConf              *PluginConf       `json:"PluginConf"`
 	LoadBalancerConf        *LoadBalancerConf `json:"LoadBalancerConf"`
 	EventConf               *EventConf        `json:"EventConf"`
@@ -40,6 +42,8 @@ type configModel struct {
 	KeyCertConf             *KeyCertConf      `json:"KeyCertConf"`
 	URLTranslation          *URLTranslation   `json:"URLTranslation"`
 	TLSConf                 *TLSConf          `json:"TLSConf"`
+	QuirkProfilesPath       string            `json:"QuirkProfilesPath"` //file of the profiles of the devices deviating from the Redfish specification
+	QuirkProfiles           []*QuirkProfile   `json:"-"`
 }
 
 //PluginConf is for holding all the plugin related configurations
@@ -62,6 +66,15 @@ type EventConf struct {
 	DestURI      string `json:"DestinationURI"`
 	ListenerHost string `json:"ListenerHost"`
 	ListenerPort string `json:"ListenerPort"`
+	// MaxEventSizeInBytes is the size limit of the events received on the listener
+	MaxEventSizeInBytes int64 `json:"MaxEventSizeInBytes"`
+	// RequireEventSecret rejects the events which do not carry the shared secret of their device
+	RequireEventSecret bool `json:"RequireEventSecret"`
+	// EventSourceStorePath is the file the devices which sent the shared secret in their events
+	// and the devices whose subscription was deleted are saved to
+	EventSourceStorePath string `json:"EventSourceStorePath"`
+	// PollingIntervalInSecs is the interval the devices which can not be subscribed to the events are polled at
+	PollingIntervalInSecs int `json:"PollingIntervalInSecs"`
 }
 
 // MessageBusConf will have configuration data of MessageBusConf
@@ -81,11 +94,8 @@ type KeyCertConf struct {
 	Certificate           []byte
 }
 
-// URLTranslation ...
-type URLTranslation struct {
-	NorthBoundURL map[string]string `json:"NorthBoundURL"` // holds value of NorthBound Translation
-	SouthBoundURL map[string]string `json:"SouthBoundURL"` // holds value of SouthBound Translation
-}
+// URLTranslation holds the translation of the URLs between ODIM and the devices
+type URLTranslation = pluginsdk.URLTranslation
 
 // TLSConf holds TLS confifurations used in https queries
 type TLSConf struct {
@@ -144,6 +154,9 @@ func ValidateConfiguration() error {
 	if err := checkTLSConf(); err != nil {
 		return err
 	}
+	if err := checkQuirkProfilesConf(); err != nil {
+		return err
+	}
 	checkLBConf()
 	checkURLTranslationConf()
 	return nil
@@ -202,6 +215,14 @@ func checkEventConf() error {
 	if Data.EventConf.ListenerPort == "" {
 		return fmt.Errorf("error: no value set for ListenerPort")
 	}
+	if Data.EventConf.MaxEventSizeInBytes == 0 {
+		log.Println("warn: no value set for MaxEventSizeInBytes, setting default value")
+		Data.EventConf.MaxEventSizeInBytes = 1048576
+	}
+	if Data.EventConf.PollingIntervalInSecs == 0 {
+		log.Println("warn: no value set for PollingIntervalInSecs, setting default value")
+		Data.EventConf.PollingIntervalInSecs = 60
+	}
 	return nil
 }
 
@@ -171,9 +171,11 @@ func SetUpMockConfig(t *testing.T) error {
 		Port: "45002",
 	}
 	Data.EventConf = &EventConf{
-		DestURI:      "/redfishEventListener",
-		ListenerHost: localhost,
-		ListenerPort: "45002",
+		DestURI:               "/redfishEventListener",
+		ListenerHost:          localhost,
+		ListenerPort:          "45002",
+		MaxEventSizeInBytes:   1048576,
+		PollingIntervalInSecs: 60,
 	}
 	Data.MessageBusConf = &MessageBusConf{
 		EmbType:  "Kafka",
@@ -0,0 +1,113 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+)
+
+// values of QuirkProfile.AuthMethod
+const (
+	// AuthMethodBasicAuth sends basic auth requests to the device, without opening a session
+	AuthMethodBasicAuth = "BasicAuth"
+	// AuthMethodSession always opens a session on the device, without falling back to basic auth
+	AuthMethodSession = "Session"
+)
+
+// values of QuirkProfile.SettingsObject
+const (
+	// SettingsObjectFollow patches the settings object the resource links to in its @Redfish.Settings
+	SettingsObjectFollow = "Follow"
+	// SettingsObjectDirect patches the resource itself, for the devices without settings object
+	SettingsObjectDirect = "Direct"
+)
+
+// QuirkProfile holds the deviations of a family of devices from the Redfish specification,
+// the devices are matched by the Vendor and the Product of their service root and by the
+// FirmwareVersion of their manager, each of them a regular expression matching anything when empty
+type QuirkProfile struct {
+	Name            string `json:"Name"`
+	Vendor          string `json:"Vendor"`
+	Product         string `json:"Product"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	// AuthMethod is BasicAuth or Session, the plugin opens a session and falls back to basic auth when it is empty
+	AuthMethod string `json:"AuthMethod"`
+	// URITranslation replaces the URIs of the requests to the device, like URLTranslation
+	URITranslation map[string]string `json:"URITranslation"`
+	// SettingsObject is Follow or Direct, the settings are patched on the URI requested when it is empty
+	SettingsObject string `json:"SettingsObject"`
+	// Headers are set on all the requests to the device
+	Headers map[string]string `json:"Headers"`
+	// BadProperties are the properties the device gets wrong, they are removed from the requests
+	// and from the responses, as paths of property names joined by dots
+	BadProperties []string `json:"BadProperties"`
+
+	vendor          *regexp.Regexp
+	product         *regexp.Regexp
+	firmwareVersion *regexp.Regexp
+}
+
+// Matches checks the profile against the vendor, the product and the firmware version of a device
+func (profile *QuirkProfile) Matches(vendor, product, firmwareVersion string) bool {
+	return profile.vendor.MatchString(vendor) && profile.product.MatchString(product) &&
+		profile.firmwareVersion.MatchString(firmwareVersion)
+}
+
+// LoadQuirkProfiles reads the quirk profiles from the file, the first profile matching a device applies to it
+func LoadQuirkProfiles(path string) ([]*QuirkProfile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error: value check failed for QuirkProfilesPath:%s with %v", path, err)
+	}
+	var profiles []*QuirkProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("error: failed to unmarshal the quirk profiles: %v", err)
+	}
+	for _, profile := range profiles {
+		if profile.vendor, err = regexp.Compile(profile.Vendor); err != nil {
+			return nil, fmt.Errorf("error: invalid Vendor of quirk profile %v: %v", profile.Name, err)
+		}
+		if profile.product, err = regexp.Compile(profile.Product); err != nil {
+			return nil, fmt.Errorf("error: invalid Product of quirk profile %v: %v", profile.Name, err)
+		}
+		if profile.firmwareVersion, err = regexp.Compile(profile.FirmwareVersion); err != nil {
+			return nil, fmt.Errorf("error: invalid FirmwareVersion of quirk profile %v: %v", profile.Name, err)
+		}
+		switch profile.AuthMethod {
+		case "", AuthMethodBasicAuth, AuthMethodSession:
+		default:
+			return nil, fmt.Errorf("error: invalid AuthMethod %v of quirk profile %v", profile.AuthMethod, profile.Name)
+		}
+		switch profile.SettingsObject {
+		case "", SettingsObjectFollow, SettingsObjectDirect:
+		default:
+			return nil, fmt.Errorf("error: invalid SettingsObject %v of quirk profile %v", profile.SettingsObject, profile.Name)
+		}
+	}
+	return profiles, nil
+}
+
+// checkQuirkProfilesConf loads the quirk profiles, when a quirk profiles file is configured
+func checkQuirkProfilesConf() error {
+	if Data.QuirkProfilesPath == "" {
+		return nil
+	}
+	var err error
+	Data.QuirkProfiles, err = LoadQuirkProfiles(Data.QuirkProfilesPath)
+	return err
+}
@@ -15,19 +15,15 @@ package main
 
 import (
 	"log"
-	"net/http"
 	"os"
-	"time"
 
 	dc "github.com/ODIM-Project/ODIM/lib-messagebus/datacommunicator"
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfphandler"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmessagebus"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmiddleware"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -53,15 +49,12 @@ func main() {
 		log.Fatalf("error while trying to set messagebus configuration: %v", err)
 	}
 
-	// CreateJobQueue defines the queue which will act as an infinite buffer
-	// In channel is an entry or input channel and the Out channel is an exit or output channel
-	rfphandler.In, rfphandler.Out = common.CreateJobQueue()
+	// the events received from the devices are published to the message bus in the background
+	rfphandler.StartEventQueue(pluginsdk.PublisherFunc(rfpmessagebus.Publish))
 
-	// RunReadWorkers will create a worker pool for doing a specific task
-	// which is passed to it as Publish method after reading the data from the channel.
-	go common.RunReadWorkers(rfphandler.Out, rfpmessagebus.Publish, 1)
-
-	intializePluginStatus()
+	rfphandler.StartTokenStore()
+	rfphandler.StartEventSourceStore()
+	rfphandler.StartEventPolling()
 	app()
 }
 
@@ -77,34 +70,22 @@ func app() {
 		ServerAddress: config.Data.PluginConf.Host,
 		ServerPort:    config.Data.PluginConf.Port,
 	}
-	pluginServer, err := conf.GetHTTPServerObj()
-	if err != nil {
+	if err := pluginsdk.Run(app, conf); err != nil {
 		log.Fatalf("fatal: error while initializing plugin server: %v", err)
 	}
-	app.Run(iris.Server(pluginServer))
 }
 
+// routers returns the routes of the plugin contract served by the plugin SDK,
+// along with the routes of the Redfish resources of the plugin
 func routers() *iris.Application {
-	app := iris.New()
-	app.WrapRouter(func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-		path := r.URL.Path
-		if len(path) > 1 && path[len(path)-1] == '/' && path[len(path)-2] != '/' {
-			path = path[:len(path)-1]
-			r.RequestURI = path
-			r.URL.Path = path
-		}
-		next(w, r)
-	})
+	pluginServer := rfphandler.NewPluginServer()
+	app := pluginServer.Application()
+	pluginServer.HandleSubscriptions(app, rfphandler.CreateEventSubscription, rfphandler.DeleteEventSubscription)
 
 	pluginRoutes := app.Party("/ODIM/v1")
 	{
-		pluginRoutes.Post("/validate", rfpmiddleware.BasicAuth, rfphandler.Validate)
-		pluginRoutes.Post("/Sessions", rfphandler.CreateSession)
-		pluginRoutes.Post("/Subscriptions", rfpmiddleware.BasicAuth, rfphandler.CreateEventSubscription)
-		pluginRoutes.Delete("/Subscriptions", rfpmiddleware.BasicAuth, rfphandler.DeleteEventSubscription)
-
 		//Adding routes related to all system gets
-		systems := pluginRoutes.Party("/Systems", rfpmiddleware.BasicAuth)
+		systems := pluginRoutes.Party("/Systems", pluginServer.BasicAuth)
 		systems.Get("", rfphandler.GetResource)
 		systems.Get("/{id}", rfphandler.GetResource)
 		systems.Get("/{id}/Storage", rfphandler.GetResource)
@@ -158,36 +139,45 @@ func routers() *iris.Application {
 		chassisThermal.Get("#Temperatures/{id1}", rfphandler.GetResource)
 
 		// Manager routers
-		managers := pluginRoutes.Party("/Managers", rfpmiddleware.BasicAuth)
+		managers := pluginRoutes.Party("/Managers", pluginServer.BasicAuth)
 		managers.Get("", rfphandler.GetManagersCollection)
 		managers.Get("/{id}", rfphandler.GetManagersInfo)
 		managers.Get("/{id}/EthernetInterfaces", rfphandler.GetResource)
 		managers.Get("/{id}/EthernetInterfaces/{rid}", rfphandler.GetResource)
 		managers.Get("/{id}/NetworkProtocol", rfphandler.GetResource)
 		managers.Get("/{id}/NetworkProtocol/{rid}", rfphandler.GetResource)
+		managers.Patch("/{id}/NetworkProtocol", rfphandler.UpdateManagersResource)
+		managers.Patch("/{id}/EthernetInterfaces/{rid}", rfphandler.UpdateManagersResource)
+		managers.Post("/{id}/Actions/Manager.Reset", rfphandler.ResetManager)
 		managers.Get("/{id}/HostInterfaces", rfphandler.GetResource)
 		managers.Get("/{id}/HostInterfaces/{rid}", rfphandler.GetResource)
 		managers.Get("/{id}/VirtualMedia", rfphandler.GetResource)
 		managers.Get("/{id}/VirtualMedia/{rid}", rfphandler.GetResource)
+		managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.InsertMedia", rfphandler.InsertVirtualMedia)
+		managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.EjectMedia", rfphandler.EjectVirtualMedia)
 		managers.Get("/{id}/LogServices", rfphandler.GetResource)
 		managers.Get("/{id}/LogServices/{rid}", rfphandler.GetResource)
 		managers.Get("/{id}/LogServices/{rid}/Entries", rfphandler.GetResource)
 		managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", rfphandler.GetResource)
 		managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", rfphandler.GetResource)
 
+		// the tasks of the devices answering the manager actions with a task
+		taskService := pluginRoutes.Party("/TaskService", pluginServer.BasicAuth)
+		taskService.Get("/Tasks/{id}", rfphandler.GetResource)
+
 		//Registries routers
-		registries := pluginRoutes.Party("/Registries", rfpmiddleware.BasicAuth)
+		registries := pluginRoutes.Party("/Registries", pluginServer.BasicAuth)
 		registries.Get("", rfphandler.GetResource)
 		registries.Get("/{id}", rfphandler.GetResource)
 
-		registryStore := pluginRoutes.Party("/registrystore", rfpmiddleware.BasicAuth)
+		registryStore := pluginRoutes.Party("/registrystore", pluginServer.BasicAuth)
 		registryStore.Get("/registries/en/{id}", rfphandler.GetResource)
 
-		registryStoreCap := pluginRoutes.Party("/RegistryStore", rfpmiddleware.BasicAuth)
+		registryStoreCap := pluginRoutes.Party("/RegistryStore", pluginServer.BasicAuth)
 		registryStoreCap.Get("/registries/en/{id}", rfphandler.GetResource)
 
 		// Routes related to Update service
-		update := pluginRoutes.Party("/UpdateService", rfpmiddleware.BasicAuth)
+		update := pluginRoutes.Party("/UpdateService", pluginServer.BasicAuth)
 		update.Post("/Actions/UpdateService.SimpleUpdate", rfphandler.SimpleUpdate)
 		update.Post("/Actions/UpdateService.StartUpdate", rfphandler.StartUpdate)
 		update.Get("/FirmwareInventory", rfphandler.GetResource)
@@ -195,14 +185,16 @@ func routers() *iris.Application {
 		update.Get("/SoftwareInventory", rfphandler.GetResource)
 		update.Get("/SoftwareInventory/{id}", rfphandler.GetResource)
 	}
-	pluginRoutes.Get("/Status", rfphandler.GetPluginStatus)
-	pluginRoutes.Post("/Startup", rfpmiddleware.BasicAuth, rfphandler.GetPluginStartup)
 	return app
 }
 
+// eventsApp returns the listener the devices send their events to
+func eventsApp() *iris.Application {
+	return pluginsdk.EventListener(config.Data.EventConf.DestURI, rfphandler.RedfishEvents)
+}
+
 func eventsrouters() {
-	app := iris.New()
-	app.Post(config.Data.EventConf.DestURI, rfphandler.RedfishEvents)
+	app := eventsApp()
 	conf := &lutilconf.HTTPConfig{
 		Certificate:   &config.Data.KeyCertConf.Certificate,
 		PrivateKey:    &config.Data.KeyCertConf.PrivateKey,
@@ -210,16 +202,7 @@ func eventsrouters() {
 		ServerAddress: config.Data.EventConf.ListenerHost,
 		ServerPort:    config.Data.EventConf.ListenerPort,
 	}
-	evtServer, err := conf.GetHTTPServerObj()
-	if err != nil {
+	if err := pluginsdk.Run(app, conf); err != nil {
 		log.Fatalf("fatal: error while initializing event server: %v", err)
 	}
-	app.Run(iris.Server(evtServer))
-}
-
-// intializePluginStatus sets plugin status
-func intializePluginStatus() {
-	rfputilities.Status.Available = "yes"
-	rfputilities.Status.Uptime = time.Now().Format(time.RFC3339)
-
 }
@@ -0,0 +1,53 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package main
+
+import (
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk/conformance"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfphandler"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+)
+
+func TestPluginConformance(t *testing.T) {
+	config.SetUpMockConfig(t)
+	conformance.Run(t, func(publisher pluginsdk.Publisher) conformance.Plugin {
+		rfphandler.StartEventQueue(publisher)
+		return conformance.Plugin{
+			Handler:     routers(),
+			UserName:    "admin",
+			Password:    "Od!m12$4",
+			Listener:    eventsApp(),
+			ListenerURI: config.Data.EventConf.DestURI,
+			Event: map[string]interface{}{
+				"@odata.type": "#Event.v1_2_1.Event",
+				"Id":          "1",
+				"Name":        "Event Array",
+				"Context":     rfputilities.EventContext("127.0.0.1"),
+				"Events": []map[string]interface{}{{
+					"EventType":         "Alert",
+					"EventId":           "1",
+					"Severity":          "OK",
+					"Message":           "The system powered on",
+					"MessageId":         "Base.1.0.Success",
+					"OriginOfCondition": map[string]string{"@odata.id": "/redfish/v1/Systems/1"},
+				}},
+			},
+			EventHeaders: map[string]string{rfputilities.EventSecretHeader: rfputilities.EventSecret("127.0.0.1")},
+		}
+	})
+}
@@ -19,60 +19,19 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"time"
 
-	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
-	iris "github.com/kataras/iris/v12"
 )
 
 //TokenValidation validates sent token with the list of plugin generated tokens
 func TokenValidation(token string) bool {
-	var flag bool
-	flag = false
-	for _, v := range tokenDetails {
-		if token == v.Token {
-			flag = true
-			log.Println(time.Since(v.LastUsed).Minutes())
-			if time.Since(v.LastUsed).Minutes() > pluginConfig.Data.SessionTimeoutInMinutes {
-				return flag
-			}
-		}
-	}
-	return flag
+	return pluginTokens.Validate(token)
 }
 
-//Validate does Basic authentication with device and returns UUID of device in response
-func Validate(ctx iris.Context) {
-	//Get token from Request
-	if ctx.GetHeader("X-Auth-Token") == "" && ctx.GetHeader("Authorization") == "" {
-		ctx.StatusCode(http.StatusUnauthorized)
-		log.Println("No valid authorization")
-		ctx.WriteString("No valid authorization")
-		return
-	}
-	token := ctx.GetHeader("X-Auth-Token")
-	//Validating the token
-	if token != "" {
-		flag := TokenValidation(token)
-		if !flag {
-			log.Println("Invalid/Expired X-Auth-Token")
-			ctx.StatusCode(http.StatusUnauthorized)
-			ctx.WriteString("Invalid/Expired X-Auth-Token")
-			return
-		}
-	}
-	var deviceDetails rfpmodel.Device
-	//Get device details from request
-	err := ctx.ReadJSON(&deviceDetails)
-	if err != nil {
-		log.Println("Error while trying to collect data from request: ", err)
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.WriteString("Error: bad request.")
-		return
-	}
+//ValidateDevice does Basic authentication with device and returns UUID of device in response
+func (redfishPlugin) ValidateDevice(deviceDetails rfpmodel.Device) (int, interface{}) {
 	device := &rfputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
@@ -82,37 +41,31 @@ func Validate(ctx iris.Context) {
 	redfishClient, err := rfputilities.GetRedfishClient()
 	if err != nil {
 		log.Println(err.Error())
-		rfpresponse.SetErrorResponse(ctx, err.Error(), http.StatusInternalServerError)
-		return
+		return http.StatusInternalServerError, rfpresponse.CreateErrorResponse(err.Error())
 	}
 
 	//Get ServiceRoot of the device
 	err = redfishClient.GetRootService(device)
 	if err != nil {
 		log.Println(err.Error())
-		rfpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
-		return
+		return http.StatusBadRequest, rfpresponse.CreateErrorResponse(err.Error())
 	}
 	//Doing Get on device using basic Authentication
 	resp, err := redfishClient.BasicAuthWithDevice(device, device.RootNode.Systems.Oid)
 	if err != nil {
 		log.Printf(err.Error())
-		rfpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
-		return
+		return http.StatusBadRequest, rfpresponse.CreateErrorResponse(err.Error())
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		log.Printf(err.Error())
-		rfpresponse.SetErrorResponse(ctx, err.Error(), http.StatusBadRequest)
-		return
+		return http.StatusBadRequest, rfpresponse.CreateErrorResponse(err.Error())
 	}
 
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusUnauthorized {
-		ctx.StatusCode(resp.StatusCode)
-		ctx.JSON(string(body))
-		return
+		return resp.StatusCode, string(body)
 	}
 	if resp.StatusCode >= 300 {
 		log.Printf("Could not retreive ComputerSystems for %s: \n%s\n\n", device.Host, body)
@@ -123,6 +76,5 @@ func Validate(ctx iris.Context) {
 		Username:   device.Username,
 		DeviceUUID: device.RootNode.UUID,
 	}
-	ctx.StatusCode(resp.StatusCode)
-	ctx.JSON(response)
+	return resp.StatusCode, response
 }
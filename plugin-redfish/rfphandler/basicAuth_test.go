@@ -63,7 +63,7 @@ func TestBasicAuth(t *testing.T) {
 
 	mockApp := iris.New()
 	pluginRoutes := mockApp.Party("/ODIM")
-	pluginRoutes.Post("/validate", Validate)
+	pluginRoutes.Post("/validate", NewPluginServer().Validate)
 
 	requestBody := map[string]interface{}{
 		"ManagerAddress": fmt.Sprintf("%s:%s", deviceHost, devicePort),
@@ -25,7 +25,6 @@ import (
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
-	"strings"
 )
 
 // DeviceClient struct to call device for the operation
@@ -42,9 +41,7 @@ func ChangeSettings(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
@@ -81,7 +78,7 @@ func ChangeSettings(ctx iris.Context) {
 		ctx.WriteString(errMsg)
 		return
 	}
-	resp, err := redfishClient.DeviceCall(device, uri, http.MethodPatch)
+	resp, err := redfishClient.DeviceCall(device, redfishClient.SettingsURI(device, uri), http.MethodPatch)
 	if err != nil {
 		errorMessage := err.Error()
 		fmt.Println(err)
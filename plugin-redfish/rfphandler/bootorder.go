@@ -32,7 +32,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 )
 
 // SetDefaultBootOrder : sets the defult boot order
@@ -54,9 +53,7 @@ func SetDefaultBootOrder(ctx iris.Context) {
 	var deviceDetails rfpmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -17,67 +17,24 @@ package rfphandler
 
 import (
 	"encoding/base64"
-	"encoding/json"
+	"log"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	iris "github.com/kataras/iris/v12"
-	"github.com/satori/go.uuid"
 	"golang.org/x/crypto/sha3"
-	"io/ioutil"
-	"log"
-	"net/http"
-	"time"
 )
 
-//TokenMap is used to define the plugin generated tokens
-type TokenMap struct {
-	Token    string
-	LastUsed time.Time
-}
-
-var tokenDetails []TokenMap
-var tokenSpec TokenMap
+// redfishPlugin implements the calls of the plugin contract on the Redfish devices
+type redfishPlugin struct{}
 
-//CreateSession is used to create session for odimra to interact with plugin
-func CreateSession(ctx iris.Context) {
-	var userCreds rfpmodel.Users
-	rawBodyAsBytes, err := ioutil.ReadAll(ctx.Request().Body)
-	if err != nil {
-		errorMessage := "Error while trying to validate the credentials: " + err.Error()
-		log.Println(errorMessage)
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.WriteString(errorMessage)
-	}
-	err = json.Unmarshal(rawBodyAsBytes, &userCreds)
-	if err != nil {
-		errorMessage := "Error while trying to unmarshal user details: " + err.Error()
-		log.Println(errorMessage)
-		ctx.StatusCode(http.StatusInternalServerError)
-		ctx.WriteString(errorMessage)
-	}
-	//Validate the credentials
-	userName := userCreds.Username
-	password := userCreds.Password
-	validateResponse := validate(userName, password)
-	if !validateResponse {
-		errorMessage := "Invalid credentials for session creation"
-		log.Println(errorMessage)
-		ctx.StatusCode(http.StatusUnauthorized)
-		ctx.WriteString(errorMessage)
-		return
-	}
-	//Create token
-	token := createToken()
-	currentTime := time.Now()
-	tokenSpec.Token = token
-	tokenSpec.LastUsed = currentTime
-	tokenDetails = append(tokenDetails, tokenSpec)
-	ctx.StatusCode(http.StatusCreated)
-	ctx.Header("X-Auth-Token", token)
+// NewPluginServer returns the server of the plugin contract of the Redfish plugin,
+// it serves the sessions, the status, the startup and the device validation calls of ODIM
+func NewPluginServer() *pluginsdk.Server {
+	return pluginsdk.NewServer(redfishPlugin{}, redfishPlugin{}, pluginTokens)
 }
 
-func validate(userName, password string) bool {
-	//var err error
+// Authenticate checks the credentials ODIM authenticates with against the ones of the configuration
+func (redfishPlugin) Authenticate(userName, password string) bool {
 	username := pluginConfig.Data.PluginConf.UserName
 	passwd := pluginConfig.Data.PluginConf.Password
 	if username != userName {
@@ -94,8 +51,3 @@ func validate(userName, password string) bool {
 
 	return true
 }
-
-func createToken() string {
-	token := uuid.NewV4().String()
-	return token
-}
@@ -0,0 +1,311 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+//Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+)
+
+// pollingSubscriptionURI is the subscription location returned for the devices whose events are polled
+const pollingSubscriptionURI = "/redfish/v1/EventService/Subscriptions/ODIMRA-Polling"
+
+// resourceGetter is the part of the Redfish client reading the resources of the devices
+type resourceGetter interface {
+	GetWithSession(device *rfputilities.RedfishDevice, requestURI string) (*http.Response, error)
+}
+
+// systemState is the state of a system compared between two polls
+type systemState struct {
+	health     string
+	powerState string
+}
+
+// polledDevice is a device which can not be subscribed to the events, its systems and their logs
+// are read periodically and the events are made from the changes found
+type polledDevice struct {
+	device  rfputilities.RedfishDevice
+	polling bool
+	// polled is set after the first poll, the state found by the first poll is not reported
+	polled     bool
+	systems    map[string]systemState
+	logEntries map[string]map[string]bool
+}
+
+// eventPollerStore holds the polled devices, keyed by their host
+type eventPollerStore struct {
+	lock    sync.Mutex
+	devices map[string]*polledDevice
+}
+
+var eventPollers = &eventPollerStore{devices: make(map[string]*polledDevice)}
+
+// pollingLocation returns the subscription location of a polled device
+func pollingLocation(host string) string {
+	return "https://" + host + pollingSubscriptionURI
+}
+
+// add starts polling the device, the credentials of a device already polled are updated
+func (store *eventPollerStore) add(device *rfputilities.RedfishDevice) {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	if polled, ok := store.devices[device.Host]; ok {
+		polled.device.Username = device.Username
+		polled.device.Password = device.Password
+		return
+	}
+	log.Println("info: the events of " + device.Host + " are polled")
+	store.devices[device.Host] = &polledDevice{
+		device: rfputilities.RedfishDevice{
+			Host:     device.Host,
+			Username: device.Username,
+			Password: device.Password,
+		},
+		systems:    make(map[string]systemState),
+		logEntries: make(map[string]map[string]bool),
+	}
+}
+
+// remove stops polling the device, it returns false when the device was not polled
+func (store *eventPollerStore) remove(host string) bool {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	_, ok := store.devices[host]
+	delete(store.devices, host)
+	return ok
+}
+
+// start returns the devices to poll, the devices still polled by the previous poll are skipped
+func (store *eventPollerStore) start() []*polledDevice {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	var devices []*polledDevice
+	for _, device := range store.devices {
+		if !device.polling {
+			device.polling = true
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+func (store *eventPollerStore) done(device *polledDevice) {
+	store.lock.Lock()
+	device.polling = false
+	store.lock.Unlock()
+}
+
+// StartEventPolling polls the devices which can not be subscribed to the events every PollingIntervalInSecs,
+// the events made from the changes found are published like the ones received from the devices
+func StartEventPolling() {
+	go func() {
+		for range time.Tick(time.Duration(pluginConfig.Data.EventConf.PollingIntervalInSecs) * time.Second) {
+			for _, device := range eventPollers.start() {
+				go func(device *polledDevice) {
+					defer eventPollers.done(device)
+					redfishClient, err := rfputilities.GetRedfishClient()
+					if err != nil {
+						log.Println("error: internal processing error: " + err.Error())
+						return
+					}
+					// the credentials are updated by the startup calls while the device is polled
+					eventPollers.lock.Lock()
+					target := device.device
+					eventPollers.lock.Unlock()
+					events, err := device.poll(redfishClient, &target)
+					if err != nil {
+						log.Println("error while polling the events of " + device.device.Host + ": " + err.Error())
+						return
+					}
+					if len(events) == 0 {
+						return
+					}
+					eventSourceIP, err := lookupEventSourceIP(device.device.Host)
+					if err != nil {
+						log.Println("error while polling the events: " + err.Error())
+						return
+					}
+					eventQueue.Queue(eventSourceIP, common.MessageData{
+						OdataType: "#Event.v1_1_0.Event",
+						Name:      "Events",
+						Events:    events,
+					})
+				}(device)
+			}
+		}
+	}()
+}
+
+// poll reads the health and the power state of the systems of the device and the entries of their logs,
+// it returns the events of the changes found since the previous poll
+func (device *polledDevice) poll(client resourceGetter, target *rfputilities.RedfishDevice) ([]common.Event, error) {
+	var collection collectionResource
+	if err := getPolledResource(client, target, "/redfish/v1/Systems", &collection); err != nil {
+		return nil, err
+	}
+	var events []common.Event
+	now := time.Now().Format(time.RFC3339)
+	for _, member := range collection.Members {
+		var system struct {
+			PowerState string `json:"PowerState"`
+			Status     struct {
+				Health string `json:"Health"`
+			} `json:"Status"`
+		}
+		if err := getPolledResource(client, target, member.OdataID, &system); err != nil {
+			return nil, err
+		}
+		state := systemState{health: system.Status.Health, powerState: system.PowerState}
+		previous, known := device.systems[member.OdataID]
+		device.systems[member.OdataID] = state
+		if device.polled && known && previous.powerState != state.powerState {
+			events = append(events, common.Event{
+				EventType:         "ResourceUpdated",
+				EventID:           fmt.Sprintf("%v", time.Now().UnixNano()),
+				Severity:          "OK",
+				EventTimestamp:    now,
+				Message:           fmt.Sprintf("The power state of the system changed from %v to %v.", previous.powerState, state.powerState),
+				MessageID:         "ResourceEvent.1.0.ResourceChanged",
+				OriginOfCondition: &common.Link{Oid: member.OdataID},
+			})
+		}
+		if device.polled && known && previous.health != state.health && state.health != "" {
+			events = append(events, common.Event{
+				EventType:         "Alert",
+				EventID:           fmt.Sprintf("%v", time.Now().UnixNano()),
+				Severity:          state.health,
+				EventTimestamp:    now,
+				Message:           fmt.Sprintf("The health of the system changed from %v to %v.", previous.health, state.health),
+				MessageID:         "ResourceEvent.1.0.ResourceStatusChanged" + state.health,
+				OriginOfCondition: &common.Link{Oid: member.OdataID},
+			})
+		}
+		events = append(events, device.pollLogs(client, target, member.OdataID)...)
+	}
+	device.polled = true
+	return events, nil
+}
+
+// pollLogs reads the entries of the logs of the system, it returns an alert for each new entry.
+// The logs are optional, the errors while reading them are ignored.
+func (device *polledDevice) pollLogs(client resourceGetter, target *rfputilities.RedfishDevice, systemURI string) []common.Event {
+	var logServices collectionResource
+	if err := getPolledResource(client, target, systemURI+"/LogServices", &logServices); err != nil {
+		return nil
+	}
+	var events []common.Event
+	for _, member := range logServices.Members {
+		var logService struct {
+			Entries struct {
+				OdataID string `json:"@odata.id"`
+			} `json:"Entries"`
+		}
+		if err := getPolledResource(client, target, member.OdataID, &logService); err != nil || logService.Entries.OdataID == "" {
+			continue
+		}
+		entries, err := getLogEntries(client, target, logService.Entries.OdataID)
+		if err != nil {
+			continue
+		}
+		// the entries are remembered while they are in the log, the entries of a log read for the first time are not reported
+		seen, known := device.logEntries[logService.Entries.OdataID]
+		current := make(map[string]bool)
+		for _, entry := range entries {
+			current[entry.OdataID] = true
+			if !device.polled || !known || seen[entry.OdataID] {
+				continue
+			}
+			event := common.Event{
+				EventType:         "Alert",
+				EventID:           entry.ID,
+				Severity:          entry.Severity,
+				EventTimestamp:    entry.Created,
+				Message:           entry.Message,
+				MessageArgs:       entry.MessageArgs,
+				MessageID:         entry.MessageID,
+				OriginOfCondition: entry.OriginOfCondition,
+			}
+			if event.OriginOfCondition == nil {
+				event.OriginOfCondition = &common.Link{Oid: systemURI}
+			}
+			events = append(events, event)
+		}
+		device.logEntries[logService.Entries.OdataID] = current
+	}
+	return events
+}
+
+// logEntry is an entry of a log of a system
+type logEntry struct {
+	OdataID           string       `json:"@odata.id"`
+	ID                string       `json:"Id"`
+	Created           string       `json:"Created"`
+	Severity          string       `json:"Severity"`
+	Message           string       `json:"Message"`
+	MessageID         string       `json:"MessageId"`
+	MessageArgs       []string     `json:"MessageArgs"`
+	OriginOfCondition *common.Link `json:"OriginOfCondition"`
+}
+
+// getLogEntries reads all the entries of the log, the pages of the entries collection are followed by their next link
+func getLogEntries(client resourceGetter, target *rfputilities.RedfishDevice, entriesURI string) ([]logEntry, error) {
+	var entries []logEntry
+	visited := make(map[string]bool)
+	for uri := entriesURI; uri != "" && !visited[uri]; {
+		visited[uri] = true
+		var page struct {
+			Members  []logEntry `json:"Members"`
+			NextLink string     `json:"Members@odata.nextLink"`
+		}
+		if err := getPolledResource(client, target, uri, &page); err != nil {
+			return nil, err
+		}
+		entries = append(entries, page.Members...)
+		uri = page.NextLink
+	}
+	return entries, nil
+}
+
+type collectionResource struct {
+	Members []struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"Members"`
+}
+
+func getPolledResource(client resourceGetter, device *rfputilities.RedfishDevice, uri string, resource interface{}) error {
+	resp, err := client.GetWithSession(device, uri)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("while getting %v got %v", uri, resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, resource)
+}
@@ -0,0 +1,100 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfphandler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+)
+
+// mockResourceGetter serves the resources of a device without event service, by their URI
+type mockResourceGetter map[string]interface{}
+
+func (getter mockResourceGetter) GetWithSession(device *rfputilities.RedfishDevice, requestURI string) (*http.Response, error) {
+	resource, ok := getter[requestURI]
+	if !ok {
+		return mockResponse(http.StatusNotFound, nil), nil
+	}
+	return mockResponse(http.StatusOK, resource), nil
+}
+
+func TestPollEvents(t *testing.T) {
+	system := map[string]interface{}{
+		"PowerState": "On",
+		"Status":     map[string]string{"Health": "OK"},
+	}
+	entries := []map[string]interface{}{
+		{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/1", "Id": "1", "Severity": "OK", "Message": "System started"},
+	}
+	getter := mockResourceGetter{
+		"/redfish/v1/Systems":                           map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1"}}},
+		"/redfish/v1/Systems/1":                         system,
+		"/redfish/v1/Systems/1/LogServices":             map[string]interface{}{"Members": []map[string]string{{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL"}}},
+		"/redfish/v1/Systems/1/LogServices/SEL":         map[string]interface{}{"Entries": map[string]string{"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries"}},
+		"/redfish/v1/Systems/1/LogServices/SEL/Entries": map[string]interface{}{"Members": entries},
+	}
+	device := &rfputilities.RedfishDevice{Host: "10.24.0.12"}
+	eventPollers.add(device)
+	defer eventPollers.remove(device.Host)
+	polled := eventPollers.start()
+	if len(polled) != 1 || len(eventPollers.start()) != 0 {
+		t.Fatalf("start() returned %v devices, then the device again", len(polled))
+	}
+	defer eventPollers.done(polled[0])
+
+	// the state found by the first poll is not reported
+	events, err := polled[0].poll(getter, device)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("first poll() = %v, %v, want no event", events, err)
+	}
+	events, err = polled[0].poll(getter, device)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("poll() without change = %v, %v, want no event", events, err)
+	}
+
+	system["PowerState"] = "Off"
+	system["Status"] = map[string]string{"Health": "Critical"}
+	// the new entry is on the next page of the entries
+	getter["/redfish/v1/Systems/1/LogServices/SEL/Entries"] = map[string]interface{}{
+		"Members":                entries,
+		"Members@odata.nextLink": "/redfish/v1/Systems/1/LogServices/SEL/Entries?$skip=1",
+	}
+	getter["/redfish/v1/Systems/1/LogServices/SEL/Entries?$skip=1"] = map[string]interface{}{"Members": []map[string]interface{}{{
+		"@odata.id": "/redfish/v1/Systems/1/LogServices/SEL/Entries/2", "Id": "2", "Severity": "Critical",
+		"Message": "Power supply failure", "MessageId": "Event.1.0.PowerSupplyFailure",
+	}}}
+	events, err = polled[0].poll(getter, device)
+	if err != nil {
+		t.Fatalf("poll() error = %v", err)
+	}
+	want := []struct{ eventType, messageID, severity string }{
+		{"ResourceUpdated", "ResourceEvent.1.0.ResourceChanged", "OK"},
+		{"Alert", "ResourceEvent.1.0.ResourceStatusChangedCritical", "Critical"},
+		{"Alert", "Event.1.0.PowerSupplyFailure", "Critical"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("poll() = %+v, want %v events", events, len(want))
+	}
+	for i, event := range events {
+		if event.EventType != want[i].eventType || event.MessageID != want[i].messageID || event.Severity != want[i].severity {
+			t.Errorf("poll() event %v = %+v, want %+v", i, event, want[i])
+		}
+		if event.OriginOfCondition == nil || event.OriginOfCondition.Oid != "/redfish/v1/Systems/1" {
+			t.Errorf("poll() event %v origin = %+v, want /redfish/v1/Systems/1", i, event.OriginOfCondition)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+//Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
+)
+
+// reasons the events are rejected for, reported in the plugin status
+const (
+	eventTooLarge      = "TooLarge"
+	eventInvalid       = "InvalidPayload"
+	eventUnknownSource = "UnknownSource"
+	eventInvalidSecret = "InvalidSecret"
+)
+
+// eventSource is a device which sent events to the plugin. The events are accepted from the signed
+// Context of the subscription, which every instance of the plugin verifies, so the event sources
+// only hold what the instance learnt from the events and the subscriptions it deleted.
+type eventSource struct {
+	// SendsSecret is set once the device sent the shared secret in an event,
+	// its events without the secret are rejected from then on
+	SendsSecret bool `json:"SendsSecret"`
+	// RevokedBefore is the time the subscription of the device was deleted at,
+	// its events with a Context issued before are rejected
+	RevokedBefore time.Time `json:"RevokedBefore"`
+}
+
+// eventSourceStore holds the devices which sent events or were removed, keyed by their host
+type eventSourceStore struct {
+	lock    sync.Mutex
+	sources map[string]*eventSource
+}
+
+var eventSources = &eventSourceStore{sources: make(map[string]*eventSource)}
+
+// revoke forgets what was learnt from the events of the device and rejects the events with the
+// Contexts issued until now, once its subscription is deleted. The later subscriptions of the device
+// get a new Context, their events are accepted.
+func (store *eventSourceStore) revoke(host string) {
+	store.lock.Lock()
+	store.sources[host] = &eventSource{RevokedBefore: time.Now()}
+	store.lock.Unlock()
+	store.save()
+}
+
+// revoked returns whether the Context issued at the given time belongs to a deleted subscription of the device
+func (store *eventSourceStore) revoked(host string, issued time.Time) bool {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	source, ok := store.sources[host]
+	return ok && !issued.After(source.RevokedBefore)
+}
+
+// sendsSecret returns whether the device sent the shared secret in its events
+func (store *eventSourceStore) sendsSecret(host string) bool {
+	store.lock.Lock()
+	defer store.lock.Unlock()
+	source, ok := store.sources[host]
+	return ok && source.SendsSecret
+}
+
+// secretSent records that the device sends the shared secret in its events
+func (store *eventSourceStore) secretSent(host string) {
+	store.lock.Lock()
+	source, ok := store.sources[host]
+	if ok && source.SendsSecret {
+		store.lock.Unlock()
+		return
+	}
+	if !ok {
+		source = &eventSource{}
+		store.sources[host] = source
+	}
+	source.SendsSecret = true
+	store.lock.Unlock()
+	store.save()
+}
+
+// save writes the event sources to the event source store file, when one is configured
+func (store *eventSourceStore) save() {
+	path := pluginConfig.Data.EventConf.EventSourceStorePath
+	if path == "" {
+		return
+	}
+	store.lock.Lock()
+	data, err := json.Marshal(store.sources)
+	store.lock.Unlock()
+	if err != nil {
+		log.Println("error while trying to marshal the event sources: " + err.Error())
+		return
+	}
+	if err := pluginsdk.WriteFile(path, data); err != nil {
+		log.Println("error while trying to save the event sources: " + err.Error())
+	}
+}
+
+// load reads the event sources saved before the plugin restarted, when an event source store file is configured
+func (store *eventSourceStore) load() {
+	path := pluginConfig.Data.EventConf.EventSourceStorePath
+	if path == "" {
+		return
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Println("error while trying to read the event sources: " + err.Error())
+		}
+		return
+	}
+	sources := make(map[string]*eventSource)
+	if err := json.Unmarshal(data, &sources); err != nil {
+		log.Println("error while trying to unmarshal the event sources: " + err.Error())
+		return
+	}
+	store.lock.Lock()
+	store.sources = sources
+	store.lock.Unlock()
+}
+
+// StartEventSourceStore restores the devices which sent events before the plugin restarted
+func StartEventSourceStore() {
+	eventSources.load()
+}
+
+// eventListenerStats counts the events received by the listener
+type eventListenerStats struct {
+	lock     sync.Mutex
+	received uint64
+	rejected map[string]uint64
+}
+
+var eventStats = &eventListenerStats{rejected: make(map[string]uint64)}
+
+func (stats *eventListenerStats) receive() {
+	stats.lock.Lock()
+	stats.received++
+	stats.lock.Unlock()
+}
+
+func (stats *eventListenerStats) reject(reason string) {
+	stats.lock.Lock()
+	stats.rejected[reason]++
+	stats.lock.Unlock()
+}
+
+// status returns the counts reported in the plugin status
+func (stats *eventListenerStats) status() rfpresponse.EventListener {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	status := rfpresponse.EventListener{
+		ReceivedEvents:         stats.received,
+		RejectedEventsByReason: make(map[string]uint64),
+	}
+	for reason, count := range stats.rejected {
+		status.RejectedEvents += count
+		status.RejectedEventsByReason[reason] = count
+	}
+	return status
+}
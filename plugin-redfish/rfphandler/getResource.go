@@ -20,7 +20,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
@@ -34,9 +33,8 @@ func GetResource(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the reuest url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	} //Validating the token
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
+	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
 		if !flag {
@@ -75,7 +73,7 @@ func GetResource(ctx iris.Context) {
 	}
 
 	//Fetching generic resource details from the device
-	resp, err := redfishClient.GetWithBasicAuth(device, uri)
+	resp, err := redfishClient.GetWithSession(device, uri)
 	if err != nil {
 		errMsg := "error: authentication failed: " + err.Error()
 		log.Println(errMsg)
@@ -102,9 +100,7 @@ func GetResource(ctx iris.Context) {
 	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
-	}
+	respData = pluginConfig.Data.URLTranslation.NorthBound(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
@@ -16,11 +16,11 @@
 package rfphandler
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
@@ -118,9 +118,7 @@ func GetManagersInfo(ctx iris.Context) {
 
 func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Context) {
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	device := &rfputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
 		Username: deviceDetails.Username,
@@ -136,7 +134,7 @@ func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Conte
 	}
 
 	//Fetching generic resource details from the device
-	resp, err := redfishClient.GetWithBasicAuth(device, uri)
+	resp, err := redfishClient.GetWithSession(device, uri)
 	if err != nil {
 		errMsg := "error: authentication failed: " + err.Error()
 		log.Println(errMsg)
@@ -161,11 +159,134 @@ func getInfoFromDevice(uri string, deviceDetails rfpmodel.Device, ctx iris.Conte
 	if resp.StatusCode >= 300 {
 		fmt.Printf("Could not retreive generic resource for %s: \n%s\n\n", device.Host, body)
 	}
+	if resp.StatusCode == http.StatusOK {
+		rfputilities.CheckFirmwareVersion(device.Host, body)
+	}
 	respData := string(body)
 	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		respData = strings.Replace(respData, key, value, -1)
+	respData = pluginConfig.Data.URLTranslation.NorthBound(respData)
+	ctx.StatusCode(resp.StatusCode)
+	ctx.Write([]byte(respData))
+}
+
+//ResetManager resets the manager of the device with the ResetType given in the request
+func ResetManager(ctx iris.Context) {
+	deviceDetails, ok := readManagerRequest(ctx)
+	if !ok {
+		return
+	}
+	var request rfpmodel.ResetPostRequest
+	if err := json.Unmarshal(deviceDetails.PostBody, &request); err != nil || request.ResetType == "" {
+		log.Println("error: ResetType missing in the manager reset request")
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request, ResetType is required.")
+		return
+	}
+	deviceDetails.PostBody, _ = json.Marshal(request)
+	callManagerAction(ctx, deviceDetails, http.MethodPost)
+}
+
+//UpdateManagersResource patches the network protocol or an ethernet interface of the manager of the device
+func UpdateManagersResource(ctx iris.Context) {
+	deviceDetails, ok := readManagerRequest(ctx)
+	if !ok {
+		return
+	}
+	callManagerAction(ctx, deviceDetails, http.MethodPatch)
+}
+
+//InsertVirtualMedia inserts the media given in the request in the virtual media of the manager of the device
+func InsertVirtualMedia(ctx iris.Context) {
+	deviceDetails, ok := readManagerRequest(ctx)
+	if !ok {
+		return
+	}
+	var request struct {
+		Image string `json:"Image"`
+	}
+	if err := json.Unmarshal(deviceDetails.PostBody, &request); err != nil || request.Image == "" {
+		log.Println("error: Image missing in the insert media request")
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request, Image is required.")
+		return
+	}
+	callManagerAction(ctx, deviceDetails, http.MethodPost)
+}
+
+//EjectVirtualMedia ejects the media inserted in the virtual media of the manager of the device
+func EjectVirtualMedia(ctx iris.Context) {
+	deviceDetails, ok := readManagerRequest(ctx)
+	if !ok {
+		return
+	}
+	// the eject action has no parameters
+	deviceDetails.PostBody = []byte("{}")
+	callManagerAction(ctx, deviceDetails, http.MethodPost)
+}
+
+// readManagerRequest validates the token and reads the device details of a request changing a manager
+func readManagerRequest(ctx iris.Context) (rfpmodel.Device, bool) {
+	var deviceDetails rfpmodel.Device
+	token := ctx.GetHeader("X-Auth-Token")
+	if token != "" && !TokenValidation(token) {
+		log.Println("Invalid/Expired X-Auth-Token")
+		ctx.StatusCode(http.StatusUnauthorized)
+		ctx.WriteString("Invalid/Expired X-Auth-Token")
+		return deviceDetails, false
+	}
+	if err := ctx.ReadJSON(&deviceDetails); err != nil || deviceDetails.Host == "" {
+		log.Println("Error while trying to collect data from request: ", err)
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Error: bad request.")
+		return deviceDetails, false
+	}
+	return deviceDetails, true
+}
+
+// callManagerAction sends the body of the request to the device on the URI requested, with the method given
+func callManagerAction(ctx iris.Context, deviceDetails rfpmodel.Device, method string) {
+	uri := ctx.Request().RequestURI
+	//replacing the request url with south bound translation URL
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
+	device := &rfputilities.RedfishDevice{
+		Host:     deviceDetails.Host,
+		Username: deviceDetails.Username,
+		Password: string(deviceDetails.Password),
+		PostBody: deviceDetails.PostBody,
+	}
+	redfishClient, err := rfputilities.GetRedfishClient()
+	if err != nil {
+		errMsg := "error: internal processing error: " + err.Error()
+		log.Println(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
 	}
+	resp, err := redfishClient.DeviceCall(device, uri, method)
+	if err != nil {
+		errMsg := "error while trying to contact the manager of " + device.Host + ": " + err.Error()
+		log.Println(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		errMsg := "error while trying to read the response of " + device.Host + ": " + err.Error()
+		log.Println(errMsg)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.WriteString(errMsg)
+		return
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		ctx.StatusCode(http.StatusBadRequest)
+		ctx.WriteString("Authtication with the device failed")
+		return
+	}
+	respData := string(body)
+	//replacing the resposne with north bound translation URL
+	respData = pluginConfig.Data.URLTranslation.NorthBound(respData)
 	ctx.StatusCode(resp.StatusCode)
 	ctx.Write([]byte(respData))
 }
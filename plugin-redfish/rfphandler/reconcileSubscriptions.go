@@ -0,0 +1,299 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+//Package rfphandler ...
+package rfphandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+)
+
+// subscriptionClient is the part of the Redfish client managing the event subscriptions of the devices
+type subscriptionClient interface {
+	GetSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error)
+	DeleteSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error)
+	SubscribeForEvents(device *rfputilities.RedfishDevice) (*http.Response, error)
+}
+
+// subscriptionDrift is the difference found between the event subscriptions of a device and the expected one
+type subscriptionDrift struct {
+	missing    bool
+	mismatched int
+	duplicates int
+}
+
+func (drift subscriptionDrift) String() string {
+	var changes []string
+	if drift.missing {
+		changes = append(changes, "subscription missing")
+	}
+	if drift.mismatched > 0 {
+		changes = append(changes, fmt.Sprintf("%v subscriptions with unexpected filters", drift.mismatched))
+	}
+	if drift.duplicates > 0 {
+		changes = append(changes, fmt.Sprintf("%v duplicated subscriptions", drift.duplicates))
+	}
+	return strings.Join(changes, ", ")
+}
+
+// reconciliationStats counts the drifts found by the subscription reconciliations
+type reconciliationStats struct {
+	lock   sync.Mutex
+	status rfpresponse.SubscriptionReconciliation
+}
+
+var subscriptionStats = &reconciliationStats{}
+
+func (stats *reconciliationStats) record(drift subscriptionDrift, err error) {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	stats.status.LastReconciliation = time.Now().Format(time.RFC3339)
+	if err != nil {
+		stats.status.FailedDevices++
+		return
+	}
+	stats.status.ReconciledDevices++
+	if drift.missing {
+		stats.status.MissingSubscriptions++
+	}
+	stats.status.MismatchedSubscriptions += uint64(drift.mismatched)
+	stats.status.DuplicateSubscriptions += uint64(drift.duplicates)
+}
+
+func (stats *reconciliationStats) get() rfpresponse.SubscriptionReconciliation {
+	stats.lock.Lock()
+	defer stats.lock.Unlock()
+	return stats.status
+}
+
+// reconcileSubscriptions checks that the device has exactly one subscription of the plugin with the expected
+// event types and context. The subscriptions of the plugin with other filters and the duplicated ones are deleted,
+// and the subscription is created when the device has none. It returns the location of the subscription.
+func reconcileSubscriptions(client subscriptionClient, device *rfputilities.RedfishDevice, eventTypes []string, location string) (string, subscriptionDrift, error) {
+	var drift subscriptionDrift
+	// some devices require the event types, the subscriptions are created for the alerts when none is given
+	if len(eventTypes) == 0 {
+		eventTypes = []string{"Alert"}
+	}
+	subscriptions, err := getPluginSubscriptions(client, device)
+	if err != nil {
+		return "", drift, err
+	}
+	// the subscription known by the events service is kept when several ones match
+	sort.SliceStable(subscriptions, func(i, j int) bool {
+		return subscriptions[i].location == location && subscriptions[j].location != location
+	})
+	keptLocation := ""
+	for _, subscription := range subscriptions {
+		source, err := currentEventSource(subscription.Context)
+		matching := sameEventTypes(subscription.EventTypes, eventTypes) && err == nil && source == device.Host
+		if matching && keptLocation == "" {
+			keptLocation = subscription.location
+			continue
+		}
+		if matching {
+			drift.duplicates++
+		} else {
+			drift.mismatched++
+		}
+		device.Location = subscription.location
+		resp, err := client.DeleteSubscriptionDetail(device)
+		if err != nil {
+			return "", drift, err
+		}
+		resp.Body.Close()
+	}
+	if keptLocation != "" {
+		return keptLocation, drift, nil
+	}
+
+	drift.missing = drift.mismatched == 0
+	req := rfpmodel.EvtSubPost{
+		Destination: "https://" + pluginConfig.Data.LoadBalancerConf.Host + ":" + pluginConfig.Data.LoadBalancerConf.Port + pluginConfig.Data.EventConf.DestURI,
+		EventTypes:  eventTypes,
+		Context:     rfputilities.EventContext(device.Host),
+		HTTPHeaders: eventSecretHeaders(device.Host),
+		Protocol:    "Redfish",
+	}
+	device.PostBody, err = json.Marshal(req)
+	if err != nil {
+		return "", drift, err
+	}
+	resp, err := client.SubscribeForEvents(device)
+	if err != nil {
+		return "", drift, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", drift, &subscriptionStatusError{request: "creating subscription on " + device.Host, statusCode: resp.StatusCode, body: body}
+	}
+	return resp.Header.Get("location"), drift, nil
+}
+
+// subscriptionStatusError is the error status the device answered a subscription request with
+type subscriptionStatusError struct {
+	request    string
+	statusCode int
+	body       []byte
+}
+
+func (err *subscriptionStatusError) Error() string {
+	return fmt.Sprintf("while %v got %v %s", err.request, err.statusCode, err.body)
+}
+
+// subscriptionLimitMessages are the messages of the Base registry answered by the devices out of subscriptions
+var subscriptionLimitMessages = []string{"EventSubscriptionLimitExceeded", "CreateLimitReachedForResource"}
+
+// subscriptionsUnsupported tells whether the answer of the device to a subscription request means that
+// the device can not be subscribed to, because it has no event service or it is out of subscriptions.
+// The other failures, the device failing or refusing this request, are not, the request is to be retried.
+func subscriptionsUnsupported(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	case http.StatusOK, http.StatusCreated:
+		return false
+	}
+	var errResp rfpresponse.ErrorResopnse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return false
+	}
+	for _, info := range errResp.Error.MessageExtendedInfo {
+		// the message ID is <registry>.<version>.<message>
+		message := info.MessageID[strings.LastIndex(info.MessageID, ".")+1:]
+		for _, limitMessage := range subscriptionLimitMessages {
+			if message == limitMessage {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type pluginSubscription struct {
+	rfpmodel.EvtSubPost
+	location string
+}
+
+// getPluginSubscriptions returns the event subscriptions of the plugin on the device
+func getPluginSubscriptions(client subscriptionClient, device *rfputilities.RedfishDevice) ([]pluginSubscription, error) {
+	device.Location = "https://" + device.Host + "/redfish/v1/EventService/Subscriptions"
+	var collection struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := getSubscriptionResource(client, device, &collection); err != nil {
+		return nil, err
+	}
+	var subscriptions []pluginSubscription
+	for _, member := range collection.Members {
+		subscription := pluginSubscription{location: "https://" + device.Host + member.OdataID}
+		device.Location = subscription.location
+		if err := getSubscriptionResource(client, device, &subscription.EvtSubPost); err != nil {
+			// the subscription was deleted meanwhile
+			if statusErr, ok := err.(*subscriptionStatusError); ok && statusErr.statusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+		// the subscriptions of the plugin have the load balancer as destination
+		if strings.Contains(subscription.Destination, pluginConfig.Data.LoadBalancerConf.Host+":"+pluginConfig.Data.LoadBalancerConf.Port) {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func getSubscriptionResource(client subscriptionClient, device *rfputilities.RedfishDevice, resource interface{}) error {
+	resp, err := client.GetSubscriptionDetail(device)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &subscriptionStatusError{request: "getting subscription details for URI: " + device.Location, statusCode: resp.StatusCode}
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, resource)
+}
+
+// sameEventTypes compares the event types whatever their order
+func sameEventTypes(actual, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	types := make(map[string]bool)
+	for _, eventType := range actual {
+		types[eventType] = true
+	}
+	for _, eventType := range expected {
+		if !types[eventType] {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileDevice reconciles the event subscriptions of a device given in the plugin startup, it logs and counts the drift found
+func reconcileDevice(startup rfpmodel.Startup) (string, error) {
+	redfishClient, err := rfputilities.GetRedfishClient()
+	if err != nil {
+		return "", err
+	}
+	return reconcileDeviceSubscriptions(redfishClient, startup)
+}
+
+func reconcileDeviceSubscriptions(client subscriptionClient, startup rfpmodel.Startup) (string, error) {
+	device := &rfputilities.RedfishDevice{
+		Host:     startup.Device.Host,
+		Username: startup.Device.Username,
+		Password: string(startup.Device.Password),
+	}
+	location, drift, err := reconcileSubscriptions(client, device, startup.EventTypes, startup.Location)
+	subscriptionStats.record(drift, err)
+	if err != nil {
+		// the devices without event service or out of subscriptions are polled until they can be subscribed,
+		// the other failures, the devices unreachable, failing or refusing the request, are retried at the next reconciliation
+		statusErr, ok := err.(*subscriptionStatusError)
+		if !ok || !subscriptionsUnsupported(statusErr.statusCode, statusErr.body) {
+			return "", err
+		}
+		log.Println("warn: event subscription of " + device.Host + " failed, its events are polled: " + err.Error())
+		eventPollers.add(device)
+		return pollingLocation(device.Host), nil
+	}
+	eventPollers.remove(device.Host)
+	if changes := drift.String(); changes != "" {
+		log.Printf("warn: event subscriptions of %v drifted: %v, they are reconciled", device.Host, changes)
+	}
+	return location, nil
+}
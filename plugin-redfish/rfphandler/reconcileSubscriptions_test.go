@@ -0,0 +1,231 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+)
+
+// mockSubscriptionClient holds the event subscriptions of a device, by their location
+type mockSubscriptionClient struct {
+	host          string
+	subscriptions map[string]rfpmodel.EvtSubPost
+	lastID        int
+}
+
+func mockResponse(statusCode int, body interface{}) *http.Response {
+	data, _ := json.Marshal(body)
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(bytes.NewBuffer(data)),
+	}
+}
+
+func (client *mockSubscriptionClient) GetSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	if device.Location == "https://"+client.host+"/redfish/v1/EventService/Subscriptions" {
+		var members []map[string]string
+		for id := 1; id <= client.lastID; id++ {
+			location := "/redfish/v1/EventService/Subscriptions/" + strconv.Itoa(id)
+			if _, ok := client.subscriptions["https://"+client.host+location]; ok {
+				members = append(members, map[string]string{"@odata.id": location})
+			}
+		}
+		return mockResponse(http.StatusOK, map[string]interface{}{"Members": members}), nil
+	}
+	subscription, ok := client.subscriptions[device.Location]
+	if !ok {
+		return mockResponse(http.StatusNotFound, nil), nil
+	}
+	return mockResponse(http.StatusOK, subscription), nil
+}
+
+func (client *mockSubscriptionClient) DeleteSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	delete(client.subscriptions, device.Location)
+	return mockResponse(http.StatusOK, nil), nil
+}
+
+func (client *mockSubscriptionClient) SubscribeForEvents(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	var subscription rfpmodel.EvtSubPost
+	json.Unmarshal(device.PostBody, &subscription)
+	client.lastID++
+	location := "https://" + client.host + "/redfish/v1/EventService/Subscriptions/" + strconv.Itoa(client.lastID)
+	client.subscriptions[location] = subscription
+	resp := mockResponse(http.StatusCreated, nil)
+	resp.Header.Set("Location", location)
+	return resp, nil
+}
+
+func (client *mockSubscriptionClient) add(subscription rfpmodel.EvtSubPost) string {
+	device := &rfputilities.RedfishDevice{Host: client.host}
+	device.PostBody, _ = json.Marshal(subscription)
+	resp, _ := client.SubscribeForEvents(device)
+	return resp.Header.Get("Location")
+}
+
+func TestReconcileSubscriptions(t *testing.T) {
+	config.SetUpMockConfig(t)
+	host := "10.24.0.12"
+	destination := "https://" + config.Data.LoadBalancerConf.Host + ":" + config.Data.LoadBalancerConf.Port + config.Data.EventConf.DestURI
+	// the subscriptions of the device deleted before are not the ones of the plugin anymore
+	deleted := rfpmodel.EvtSubPost{Destination: destination, EventTypes: []string{"Alert", "StatusChange"}, Context: rfputilities.EventContext(host)}
+	eventSources.revoke(host)
+	defer func() {
+		eventSources = &eventSourceStore{sources: make(map[string]*eventSource)}
+	}()
+	expected := rfpmodel.EvtSubPost{
+		Destination: destination,
+		EventTypes:  []string{"Alert", "StatusChange"},
+		Context:     rfputilities.EventContext(host),
+	}
+	other := rfpmodel.EvtSubPost{Destination: "https://10.24.1.1:8080/events", EventTypes: []string{"Alert"}}
+	legacy := rfpmodel.EvtSubPost{Destination: destination, EventTypes: []string{"Alert", "StatusChange"}, Context: "ODIMRA_Event"}
+
+	tests := []struct {
+		name          string
+		subscriptions []rfpmodel.EvtSubPost
+		known         int
+		want          subscriptionDrift
+		wantKept      int
+	}{
+		{name: "subscription in place", subscriptions: []rfpmodel.EvtSubPost{other, expected}, known: 2, wantKept: 2},
+		{name: "subscription lost", subscriptions: []rfpmodel.EvtSubPost{other}, known: 1, want: subscriptionDrift{missing: true}},
+		{name: "subscription with another context", subscriptions: []rfpmodel.EvtSubPost{legacy}, known: 1, want: subscriptionDrift{mismatched: 1}},
+		{name: "subscription deleted before", subscriptions: []rfpmodel.EvtSubPost{deleted}, known: 1, want: subscriptionDrift{mismatched: 1}},
+		{name: "duplicated subscriptions", subscriptions: []rfpmodel.EvtSubPost{expected, legacy, expected}, known: 3, want: subscriptionDrift{mismatched: 1, duplicates: 1}, wantKept: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &mockSubscriptionClient{host: host, subscriptions: make(map[string]rfpmodel.EvtSubPost)}
+			var locations []string
+			for _, subscription := range tt.subscriptions {
+				locations = append(locations, client.add(subscription))
+			}
+			device := &rfputilities.RedfishDevice{Host: host}
+			// the event types are given in another order than the ones of the device
+			location, drift, err := reconcileSubscriptions(client, device, []string{"StatusChange", "Alert"}, locations[tt.known-1])
+			if err != nil {
+				t.Fatalf("reconcileSubscriptions() error = %v", err)
+			}
+			if drift != tt.want {
+				t.Errorf("reconcileSubscriptions() drift = %+v, want %+v", drift, tt.want)
+			}
+			if tt.wantKept > 0 && location != locations[tt.wantKept-1] {
+				t.Errorf("reconcileSubscriptions() location = %v, want %v", location, locations[tt.wantKept-1])
+			}
+			var plugin []rfpmodel.EvtSubPost
+			for _, subscription := range client.subscriptions {
+				if strings.HasPrefix(subscription.Destination, destination) {
+					plugin = append(plugin, subscription)
+				}
+			}
+			if len(plugin) != 1 {
+				t.Fatalf("subscriptions of the plugin after the reconciliation = %+v, want one %+v", plugin, expected)
+			}
+			if source, err := currentEventSource(plugin[0].Context); err != nil || source != host || !sameEventTypes(plugin[0].EventTypes, expected.EventTypes) {
+				t.Errorf("subscription of the plugin after the reconciliation = %+v, want %+v", plugin[0], expected)
+			}
+			if _, ok := client.subscriptions[locations[0]]; !ok && tt.subscriptions[0].Destination == other.Destination {
+				t.Errorf("reconcileSubscriptions() deleted a subscription of another destination")
+			}
+		})
+	}
+}
+
+// failingSubscriptionClient fails all the subscription requests with the status and body or the error
+type failingSubscriptionClient struct {
+	statusCode int
+	body       interface{}
+	err        error
+}
+
+func (client *failingSubscriptionClient) GetSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	if client.err != nil {
+		return nil, client.err
+	}
+	return mockResponse(client.statusCode, client.body), nil
+}
+
+func (client *failingSubscriptionClient) DeleteSubscriptionDetail(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	return client.GetSubscriptionDetail(device)
+}
+
+func (client *failingSubscriptionClient) SubscribeForEvents(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	return client.GetSubscriptionDetail(device)
+}
+
+// fullSubscriptionClient is a device out of subscriptions, it refuses the subscription requests
+type fullSubscriptionClient struct {
+	mockSubscriptionClient
+}
+
+func (client *fullSubscriptionClient) SubscribeForEvents(device *rfputilities.RedfishDevice) (*http.Response, error) {
+	return mockResponse(http.StatusBadRequest, rfpresponse.ErrorResopnse{
+		Error: rfpresponse.Error{
+			Code:    "Base.1.8.GeneralError",
+			Message: "See @Message.ExtendedInfo for more information.",
+			MessageExtendedInfo: []rfpresponse.MsgExtendedInfo{
+				{MessageID: "Base.1.8.EventSubscriptionLimitExceeded"},
+			},
+		},
+	}), nil
+}
+
+func TestReconcileDeviceSubscriptions(t *testing.T) {
+	config.SetUpMockConfig(t)
+	startup := rfpmodel.Startup{Location: "https://10.24.0.13/redfish/v1/EventService/Subscriptions/1"}
+	startup.Device.Host = "10.24.0.13"
+	defer eventPollers.remove(startup.Device.Host)
+
+	tests := []struct {
+		name        string
+		client      subscriptionClient
+		wantPolled  bool
+		wantFailure bool
+	}{
+		{name: "device unreachable", client: &failingSubscriptionClient{err: errors.New("connection refused")}, wantFailure: true},
+		{name: "authentication failed", client: &failingSubscriptionClient{statusCode: http.StatusUnauthorized}, wantFailure: true},
+		{name: "device failing", client: &failingSubscriptionClient{statusCode: http.StatusServiceUnavailable}, wantFailure: true},
+		{name: "request conflicting", client: &failingSubscriptionClient{statusCode: http.StatusConflict}, wantFailure: true},
+		{name: "request refused", client: &failingSubscriptionClient{statusCode: http.StatusBadRequest, body: rfpresponse.CreateErrorResponse("bad request")}, wantFailure: true},
+		{name: "device without event service", client: &failingSubscriptionClient{statusCode: http.StatusNotFound}, wantPolled: true},
+		{name: "event service not implemented", client: &failingSubscriptionClient{statusCode: http.StatusNotImplemented}, wantPolled: true},
+		{name: "device out of subscriptions", client: &fullSubscriptionClient{mockSubscriptionClient{host: startup.Device.Host, subscriptions: map[string]rfpmodel.EvtSubPost{}}}, wantPolled: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := reconcileDeviceSubscriptions(tt.client, startup)
+			if (err != nil) != tt.wantFailure {
+				t.Fatalf("reconcileDeviceSubscriptions() error = %v, wantFailure %v", err, tt.wantFailure)
+			}
+			polled := eventPollers.remove(startup.Device.Host)
+			if polled != tt.wantPolled || (tt.wantPolled && location != pollingLocation(startup.Device.Host)) {
+				t.Errorf("reconcileDeviceSubscriptions() = %v, device polled %v, want polled %v", location, polled, tt.wantPolled)
+			}
+		})
+	}
+}
@@ -17,58 +17,191 @@ package rfphandler
 
 import (
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"net"
 	"net/http"
 
-	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
 	iris "github.com/kataras/iris/v12"
 	"strings"
 )
 
-var (
-	// In Channel
-	In chan<- interface{}
-	// Out Channel
-	Out <-chan interface{}
-)
+// eventQueue publishes the events of the devices to ODIM
+var eventQueue *pluginsdk.EventQueue
+
+// StartEventQueue starts publishing the events received from the devices with the publisher
+func StartEventQueue(publisher pluginsdk.Publisher) {
+	eventQueue = pluginsdk.NewEventQueue(publisher, pluginConfig.Data.URLTranslation)
+}
 
 // RedfishEvents receives the subscribed events from the south bound system
 // Then it will send the received data and ip to publish method
 func RedfishEvents(ctx iris.Context) {
-	var req interface{}
-	err := ctx.ReadJSON(&req)
+	eventStats.receive()
+	ctx.SetMaxRequestBodySize(pluginConfig.Data.EventConf.MaxEventSizeInBytes)
+	body, err := ioutil.ReadAll(ctx.Request().Body)
 	if err != nil {
+		log.Println("error: event rejected: " + err.Error())
+		rejectEvent(ctx, eventTooLarge, http.StatusRequestEntityTooLarge, "error: event too large")
+		return
+	}
+	var req interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
 		log.Println(err)
-		ctx.StatusCode(http.StatusBadRequest)
-		ctx.WriteString("error: bad request")
+		rejectEvent(ctx, eventInvalid, http.StatusBadRequest, "error: bad request")
+		return
+	}
+	if err := validateEvent(req); err != nil {
+		log.Println("error: event rejected: " + err.Error())
+		rejectEvent(ctx, eventInvalid, http.StatusBadRequest, "error: invalid event: "+err.Error())
 		return
 	}
 	log.Println("Event Request", req)
-	remoteAddr := ctx.RemoteAddr()
-	request, _ := json.Marshal(req)
+	// the device is identified by the Context of its subscription, not by the address the event comes from.
+	// The Context is signed with a key all the instances of the plugin share, so that the events are
+	// accepted whichever instance subscribed the device, but for the Contexts of the deleted subscriptions.
+	host, err := getEventSource(req)
+	if err != nil {
+		log.Println("error: event rejected: " + err.Error())
+		rejectEvent(ctx, eventUnknownSource, http.StatusUnauthorized, "error: unknown event source")
+		return
+	}
+	sendsSecret := eventSources.sendsSecret(host)
+	// the shared secret is required once the device sent it, the devices which do not support
+	// the subscription HttpHeaders are only accepted without it when it is not required by the configuration
+	secret := ctx.GetHeader(rfputilities.EventSecretHeader)
+	if secret == "" && (sendsSecret || pluginConfig.Data.EventConf.RequireEventSecret) ||
+		secret != "" && !rfputilities.ValidEventSecret(host, secret) {
+		log.Println("error: event rejected: invalid event secret for " + host)
+		rejectEvent(ctx, eventInvalidSecret, http.StatusUnauthorized, "error: unknown event source")
+		return
+	}
+	if secret != "" {
+		eventSources.secretSent(host)
+	}
+	eventSourceIP, err := lookupEventSourceIP(host)
+	if err != nil {
+		log.Println("error: event rejected: " + err.Error())
+		rejectEvent(ctx, eventUnknownSource, http.StatusUnauthorized, "error: unknown event source")
+		return
+	}
+	eventQueue.Queue(eventSourceIP, req)
+	ctx.StatusCode(http.StatusOK)
 
-	reqData := string(request)
-	//replacing the resposne with north bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.NorthBoundURL {
-		reqData = strings.Replace(reqData, key, value, -1)
+}
+
+func rejectEvent(ctx iris.Context, reason string, statusCode int, message string) {
+	eventStats.reject(reason)
+	ctx.StatusCode(statusCode)
+	ctx.WriteString(message)
+}
+
+// validateEvent checks the event against the Redfish Event schema, the properties
+// the devices commonly leave out are not required
+func validateEvent(event interface{}) error {
+	eventData, ok := event.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("event is not an object")
+	}
+	if odataType, ok := eventData["@odata.type"]; ok {
+		if value, ok := odataType.(string); !ok || !strings.HasPrefix(value, "#Event.") {
+			return fmt.Errorf("@odata.type %v is not the one of an event", odataType)
+		}
 	}
-	event := common.Events{
-		IP:      remoteAddr,
-		Request: []byte(reqData),
+	if err := validateStrings(eventData, "Id", "Name", "Context", "Description"); err != nil {
+		return err
 	}
+	records, ok := eventData["Events"].([]interface{})
+	if !ok || len(records) == 0 {
+		return fmt.Errorf("Events is not a non empty array")
+	}
+	for i, record := range records {
+		recordData, ok := record.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("Events[%v] is not an object", i)
+		}
+		if err := validateStrings(recordData, "EventType", "EventId", "EventTimestamp", "MemberId",
+			"Message", "MessageId", "Severity", "Context", "EventGroupId"); err != nil {
+			return fmt.Errorf("Events[%v]: %v", i, err)
+		}
+		if args, ok := recordData["MessageArgs"]; ok && args != nil {
+			argList, ok := args.([]interface{})
+			if !ok {
+				return fmt.Errorf("Events[%v]: MessageArgs is not an array", i)
+			}
+			for _, arg := range argList {
+				if _, ok := arg.(string); !ok {
+					return fmt.Errorf("Events[%v]: MessageArgs is not an array of strings", i)
+				}
+			}
+		}
+		if origin, ok := recordData["OriginOfCondition"]; ok && origin != nil {
+			originData, ok := origin.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("Events[%v]: OriginOfCondition is not a link", i)
+			}
+			if _, ok := originData["@odata.id"].(string); !ok {
+				return fmt.Errorf("Events[%v]: OriginOfCondition is not a link", i)
+			}
+		}
+	}
+	return nil
+}
 
-	// Call writeEventToJobQueue to write events to worker pool
-	writeEventToJobQueue(event)
-	ctx.StatusCode(http.StatusOK)
+// validateStrings checks that the properties are strings, when they are present
+func validateStrings(data map[string]interface{}, properties ...string) error {
+	for _, property := range properties {
+		if value, ok := data[property]; ok && value != nil {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("%v is not a string", property)
+			}
+		}
+	}
+	return nil
+}
 
+// getEventSource returns the host of the device which sent the event, from the Context of the event.
+// An error is returned when the Context belongs to a deleted subscription of the device.
+func getEventSource(event interface{}) (string, error) {
+	eventData, ok := event.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("event is not an object")
+	}
+	context, _ := eventData["Context"].(string)
+	if context == "" {
+		// some devices only send back the Context in the event records
+		if records, ok := eventData["Events"].([]interface{}); ok && len(records) > 0 {
+			if record, ok := records[0].(map[string]interface{}); ok {
+				context, _ = record["Context"].(string)
+			}
+		}
+	}
+	return currentEventSource(context)
 }
 
-// writeEventToJobQueue will write events to worker pool
-func writeEventToJobQueue(event common.Events) {
-	var events []interface{}
-	//events := make([]interface{}, 0)
-	events = append(events, event)
-	done := make(chan bool)
-	go common.RunWriteWorkers(In, events, 1, done)
+// currentEventSource returns the host of the device the Context of a subscription was issued for,
+// when the subscription was not deleted since
+func currentEventSource(context string) (string, error) {
+	host, issued, err := rfputilities.EventSource(context)
+	if err != nil {
+		return "", err
+	}
+	if eventSources.revoked(host, issued) {
+		return "", fmt.Errorf("event context %v belongs to a deleted subscription of %v", context, host)
+	}
+	return host, nil
+}
+
+// lookupEventSourceIP returns the IP address of the device which sent the event.
+// The address is the one the events service resolved from the device address when it subscribed to the events.
+func lookupEventSourceIP(host string) (string, error) {
+	addr, err := net.LookupIP(host)
+	if err != nil || len(addr) < 1 {
+		return "", fmt.Errorf("can't lookup the ip of the event source %v: %v", host, err)
+	}
+	return addr[0].String(), nil
 }
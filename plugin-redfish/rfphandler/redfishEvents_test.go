@@ -0,0 +1,124 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfphandler
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
+	iris "github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/httptest"
+)
+
+func TestRedfishEvents(t *testing.T) {
+	config.SetUpMockConfig(t)
+	events := make(chan common.Events, 4)
+	eventQueue = pluginsdk.NewEventQueue(pluginsdk.PublisherFunc(func(event common.Events) bool {
+		events <- event
+		return true
+	}), config.Data.URLTranslation)
+	defer func() {
+		eventSources = &eventSourceStore{sources: make(map[string]*eventSource)}
+		eventStats = &eventListenerStats{rejected: make(map[string]uint64)}
+	}()
+
+	mockApp := iris.New()
+	mockApp.Post("/redfishEventListener", RedfishEvents)
+	mockApp.Delete("/EventSubscriptions", DeleteEventSubscription)
+	e := httptest.New(t, mockApp)
+
+	event := map[string]interface{}{
+		"Context": rfputilities.EventContext("10.24.0.12"),
+		"Events": []map[string]interface{}{
+			{
+				"EventType": "Alert",
+				"OriginOfCondition": map[string]string{
+					"@odata.id": "/redfish/v1/Systems/1",
+				},
+			},
+		},
+	}
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusOK)
+	if got := (<-events).IP; got != "10.24.0.12" {
+		t.Errorf("RedfishEvents() event source = %v, want 10.24.0.12", got)
+	}
+
+	// the context can be sent back in the event records only
+	delete(event, "Context")
+	event["Events"].([]map[string]interface{})[0]["Context"] = rfputilities.EventContext("10.24.0.13")
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusOK)
+	if got := (<-events).IP; got != "10.24.0.13" {
+		t.Errorf("RedfishEvents() event source = %v, want 10.24.0.13", got)
+	}
+
+	event["Context"] = "ODIMRA_Event"
+	delete(event["Events"].([]map[string]interface{})[0], "Context")
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusUnauthorized)
+
+	// the events are accepted from the signed Context, whichever instance of the plugin subscribed the device
+	event["Context"] = rfputilities.EventContext("10.24.0.14")
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusOK)
+	if got := (<-events).IP; got != "10.24.0.14" {
+		t.Errorf("RedfishEvents() event source = %v, want 10.24.0.14", got)
+	}
+
+	// the events of a deleted device are rejected, the ones of its later subscriptions are accepted
+	e.DELETE("/EventSubscriptions").WithJSON(pluginsdk.Device{Host: "10.24.0.14", Location: pollingLocation("10.24.0.14")}).
+		Expect().Status(http.StatusNoContent)
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusUnauthorized)
+	event["Context"] = rfputilities.EventContext("10.24.0.14")
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusOK)
+	<-events
+
+	// the shared secret is required once the device sent it
+	event["Context"] = rfputilities.EventContext("10.24.0.12")
+	e.POST("/redfishEventListener").WithJSON(event).WithHeader(rfputilities.EventSecretHeader, rfputilities.EventSecret("10.24.0.13")).
+		Expect().Status(http.StatusUnauthorized)
+	e.POST("/redfishEventListener").WithJSON(event).WithHeader(rfputilities.EventSecretHeader, rfputilities.EventSecret("10.24.0.12")).
+		Expect().Status(http.StatusOK)
+	<-events
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusUnauthorized)
+	event["Context"] = rfputilities.EventContext("10.24.0.13")
+	config.Data.EventConf.RequireEventSecret = true
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusUnauthorized)
+	config.Data.EventConf.RequireEventSecret = false
+
+	// the events are validated against the Redfish Event schema
+	e.POST("/redfishEventListener").WithJSON("event").Expect().Status(http.StatusBadRequest)
+	e.POST("/redfishEventListener").WithBytes([]byte("{")).Expect().Status(http.StatusBadRequest)
+	e.POST("/redfishEventListener").WithJSON(map[string]interface{}{"Context": event["Context"], "Events": []interface{}{}}).
+		Expect().Status(http.StatusBadRequest)
+	event["Events"].([]map[string]interface{})[0]["MessageArgs"] = []int{1}
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusBadRequest)
+	delete(event["Events"].([]map[string]interface{})[0], "MessageArgs")
+	event["Events"].([]map[string]interface{})[0]["OriginOfCondition"] = "/redfish/v1/Systems/1"
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusBadRequest)
+
+	// the events larger than the limit are rejected
+	config.Data.EventConf.MaxEventSizeInBytes = 16
+	e.POST("/redfishEventListener").WithJSON(event).Expect().Status(http.StatusRequestEntityTooLarge)
+	config.Data.EventConf.MaxEventSizeInBytes = 1048576
+
+	status := eventStats.status()
+	wantRejected := map[string]uint64{eventUnknownSource: 2, eventInvalidSecret: 3, eventInvalid: 5, eventTooLarge: 1}
+	if status.ReceivedEvents != 16 || status.RejectedEvents != 11 || !reflect.DeepEqual(status.RejectedEventsByReason, wantRejected) {
+		t.Errorf("event listener status = %+v, want 16 received and rejected %v", status, wantRejected)
+	}
+}
@@ -28,7 +28,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfputilities"
@@ -55,9 +54,7 @@ func ResetComputerSystem(ctx iris.Context) {
 	var deviceDetails rfpmodel.Device
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	//Get device details from request
 	err := ctx.ReadJSON(&deviceDetails)
 	if err != nil {
@@ -20,7 +20,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 
 	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
@@ -34,9 +33,7 @@ func CreateVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
@@ -101,9 +98,7 @@ func DeleteVolume(ctx iris.Context) {
 	token := ctx.GetHeader("X-Auth-Token")
 	uri := ctx.Request().RequestURI
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
 	//Validating the token
 	if token != "" {
 		flag := TokenValidation(token)
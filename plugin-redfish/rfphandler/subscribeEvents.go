@@ -16,6 +16,7 @@
 package rfphandler
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -45,9 +46,7 @@ func CreateEventSubscription(ctx iris.Context) {
 	var reqData string
 
 	//replacing the reruest  with south bound translation URL
-	for key, value := range evtConfig.Data.URLTranslation.SouthBoundURL {
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
+	reqData = evtConfig.Data.URLTranslation.SouthBound(string(deviceDetails.PostBody))
 
 	err = json.Unmarshal([]byte(reqData), &reqPostBody)
 	if err != nil {
@@ -57,12 +56,13 @@ func CreateEventSubscription(ctx iris.Context) {
 	}
 
 	// remove the mesaageids, resourcestypes and originresources from the request and post it to device
-	// since some of device doesnt support these
+	// since some of device doesnt support these. The context identifies the device in its events,
+	// and the device sends the shared secret in their HTTP headers.
 	req := rfpmodel.EvtSubPost{
 		Destination: "https://" + evtConfig.Data.LoadBalancerConf.Host + ":" + evtConfig.Data.LoadBalancerConf.Port + evtConfig.Data.EventConf.DestURI,
 		EventTypes:  reqPostBody.EventTypes,
-		Context:     reqPostBody.Context,
-		HTTPHeaders: reqPostBody.HTTPHeaders,
+		Context:     rfputilities.EventContext(device.Host),
+		HTTPHeaders: append(reqPostBody.HTTPHeaders, eventSecretHeaders(device.Host)...),
 		Protocol:    reqPostBody.Protocol,
 	}
 	device.PostBody, err = json.Marshal(req)
@@ -91,9 +91,28 @@ func CreateEventSubscription(ctx iris.Context) {
 		return
 	}
 	defer resp.Body.Close()
+	// the devices without event service or out of subscriptions are polled, the other
+	// failures are returned for the subscription to be retried
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if subscriptionsUnsupported(resp.StatusCode, body) {
+		log.Printf("warn: event subscription of %v failed with %v, its events are polled: %s", device.Host, resp.StatusCode, body)
+		eventPollers.add(device)
+		ctx.ResponseWriter().Header().Set("Location", pollingLocation(device.Host))
+		ctx.StatusCode(http.StatusCreated)
+		return
+	}
 	if err := validateResponse(ctx, device, resp, http.MethodPost); err != nil {
 		return
 	}
+	if ctx.GetStatusCode() < http.StatusMultipleChoices {
+		eventPollers.remove(device.Host)
+	}
+}
+
+// eventSecretHeaders returns the HTTP headers carrying the shared secret of the device in its events
+func eventSecretHeaders(host string) []rfpmodel.HTTPHeaders {
+	return []rfpmodel.HTTPHeaders{{rfputilities.EventSecretHeader: rfputilities.EventSecret(host)}}
 }
 
 // Delete match subscription from device
@@ -123,16 +142,22 @@ func deleteMatchingSubscriptions(device *rfputilities.RedfishDevice) {
 		log.Println(err.Error())
 		return
 	}
-	var subscriptionCollectionBody interface{}
+	// the devices answering with other property types are not trusted with a type assertion
+	var subscriptionCollectionBody struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
 	err = json.Unmarshal(body, &subscriptionCollectionBody)
 	if err != nil {
 		log.Println(err.Error())
 		return
 	}
-	members := subscriptionCollectionBody.(map[string]interface{})["Members"]
-	for _, member := range members.([]interface{}) {
-		device.Location = member.(map[string]interface{})["@odata.id"].(string)
-		device.Location = "https://" + device.Host + device.Location
+	for _, member := range subscriptionCollectionBody.Members {
+		if member.OdataID == "" {
+			continue
+		}
+		device.Location = "https://" + device.Host + member.OdataID
 		if isOurSubscription(device) {
 			resp, err = redfishClient.DeleteSubscriptionDetail(device)
 			if err != nil {
@@ -168,13 +193,15 @@ func isOurSubscription(device *rfputilities.RedfishDevice) bool {
 		log.Println(err.Error())
 		return false
 	}
-	var subscriptionBody interface{}
+	var subscriptionBody struct {
+		Destination string `json:"Destination"`
+	}
 	err = json.Unmarshal(body, &subscriptionBody)
 	if err != nil {
 		log.Println(err.Error())
 		return false
 	}
-	subscriptionDestinationFromDevice := subscriptionBody.(map[string]interface{})["Destination"].(string)
+	subscriptionDestinationFromDevice := subscriptionBody.Destination
 	// if the subscription is ours then the destination should match with LBHOST:LBPORT.
 	//If it is not matching then retrun with MethodNotAllowed
 	if !strings.Contains(subscriptionDestinationFromDevice, evtConfig.Data.LoadBalancerConf.Host+":"+evtConfig.Data.LoadBalancerConf.Port) {
@@ -189,6 +216,15 @@ func DeleteEventSubscription(ctx iris.Context) {
 	if err != nil {
 		return
 	}
+	// the events of the device are rejected from now on, even when its subscription can not be deleted
+	eventSources.revoke(device.Host)
+	// the polled devices have no subscription to delete
+	eventPollers.remove(device.Host)
+	if strings.HasSuffix(device.Location, pollingSubscriptionURI) {
+		rfputilities.ForgetQuirkProfile(device.Host)
+		ctx.StatusCode(http.StatusNoContent)
+		return
+	}
 	redfishClient, err := rfputilities.GetRedfishClient()
 	if err != nil {
 		errMsg := "error: internal processing error: " + err.Error()
@@ -208,6 +244,11 @@ func DeleteEventSubscription(ctx iris.Context) {
 	}
 
 	defer resp.Body.Close()
+	// the subscription is deleted when the device is removed, its session and its quirk profile are not needed anymore
+	if err := redfishClient.DeleteSession(device); err != nil {
+		log.Println("error while trying to delete the session of the device: " + err.Error())
+	}
+	rfputilities.ForgetQuirkProfile(device.Host)
 	if err := validateResponse(ctx, device, resp, http.MethodDelete); err != nil {
 		return
 	}
@@ -0,0 +1,40 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+//Package rfphandler ...
+package rfphandler
+
+import (
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+	pluginConfig "github.com/ODIM-Project/ODIM/plugin-redfish/config"
+)
+
+// pluginTokens holds the sessions created on the plugin, in the session store file when one is configured
+var pluginTokens = pluginsdk.NewFileTokenStore(sessionStorePath, sessionTimeout)
+
+func sessionStorePath() string {
+	return pluginConfig.Data.SessionStorePath
+}
+
+func sessionTimeout() time.Duration {
+	return time.Duration(pluginConfig.Data.SessionTimeoutInMinutes * float64(time.Minute))
+}
+
+// StartTokenStore restores the sessions saved before the plugin restarted and
+// removes the sessions which timed out every minute
+func StartTokenStore() {
+	pluginTokens.Start()
+}
@@ -24,7 +24,6 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 )
 
 // SimpleUpdate updates the BMC resources
@@ -54,10 +53,8 @@ func SimpleUpdate(ctx iris.Context) {
 
 	var reqData string
 	//replacing the request url with south bound translation URL
-	for key, value := range pluginConfig.Data.URLTranslation.SouthBoundURL {
-		uri = strings.Replace(uri, key, value, -1)
-		reqData = strings.Replace(string(deviceDetails.PostBody), key, value, -1)
-	}
+	uri = pluginConfig.Data.URLTranslation.SouthBound(uri)
+	reqData = pluginConfig.Data.URLTranslation.SouthBound(string(deviceDetails.PostBody))
 
 	device := &rfputilities.RedfishDevice{
 		Host:     deviceDetails.Host,
@@ -25,14 +25,8 @@ import (
 	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
 )
 
-// Publish ...
-func Publish(data interface{}) bool {
-	if data == nil {
-		log.Printf("Error: Invalid data on publishing events")
-		return false
-	}
-	event := data.(common.Events)
-
+// Publish publishes the event to the event queue of ODIM on the message bus
+func Publish(event common.Events) bool {
 	K, err := dc.Communicator(dc.KAFKA, config.Data.MessageBusConf.MessageQueueConfigFilePath)
 	if err != nil {
 		fmt.Println("Unable communicate with kafka", err)
@@ -15,14 +15,10 @@
 //Package rfpmodel ...
 package rfpmodel
 
+import "github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+
 //Device struct definition
-type Device struct {
-	Host     string `json:"ManagerAddress"`
-	Username string `json:"UserName"`
-	Password []byte `json:"Password"`
-	PostBody []byte `json:"PostBody"`
-	Location string `json:"Location"`
-}
+type Device = pluginsdk.Device
 
 //EvtSubPost ...
 type EvtSubPost struct {
@@ -30,15 +26,13 @@ type EvtSubPost struct {
 	EventTypes    []string      `json:"EventTypes,omitempty"`
 	MessageIds    []string      `json:"MessageIds,omitempty"`
 	ResourceTypes []string      `json:"ResourceTypes,omitempty"`
-	HTTPHeaders   []HTTPHeaders `json:"HttpHeaders"`
+	HTTPHeaders   []HTTPHeaders `json:"HttpHeaders,omitempty"`
 	Context       string        `json:"Context"`
 	Protocol      string        `json:"Protocol"`
 }
 
-//HTTPHeaders ...
-type HTTPHeaders struct {
-	ContentType string `json:"Content-Type"`
-}
+//HTTPHeaders holds the HTTP headers the device sends with the events, by their name
+type HTTPHeaders map[string]string
 
 //EvtOem ...
 type EvtOem struct {
@@ -54,8 +48,4 @@ type Hpe struct {
 }
 
 // Startup struct recieve request on Startup call
-type Startup struct {
-	Location   string   `json:"Location"`
-	EventTypes []string `json:"EventTypes,omitempty"`
-	Device     Device   `json:"Device"`
-}
+type Startup = pluginsdk.StartupDevice
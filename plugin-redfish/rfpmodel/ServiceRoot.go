@@ -21,6 +21,9 @@ type ServiceRoot struct {
 	UUID           string
 	ID             string
 	RedfishVersion string
+	Vendor         string
+	Product        string
+	Oem            map[string]interface{}
 	Context        string `json:"@odata.context"`
 	Etag           string `json:"@odata.etag,omitempty"`
 	Oid            string `json:"@odata.id"`
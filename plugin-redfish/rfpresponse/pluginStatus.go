@@ -15,20 +15,38 @@
 //Package rfpresponse ...
 package rfpresponse
 
+import "github.com/ODIM-Project/ODIM/lib-utilities/pluginsdk"
+
 //PluginStatusResponse holds the information of response of PluginStatus
 type PluginStatusResponse struct {
-	Comment         string          `json:"_comment"`
-	Name            string          `json:"Name"`
-	Version         string          `json:"Version"`
-	Status          Status          `json:"Status"`
-	EventMessageBus EventMessageBus `json:"EventMessageBus"`
+	Comment                    string                     `json:"_comment"`
+	Name                       string                     `json:"Name"`
+	Version                    string                     `json:"Version"`
+	Status                     Status                     `json:"Status"`
+	EventMessageBus            EventMessageBus            `json:"EventMessageBus"`
+	EventListener              EventListener              `json:"EventListener"`
+	SubscriptionReconciliation SubscriptionReconciliation `json:"SubscriptionReconciliation"`
 }
 
 //Status holds information of Plugin Status
-type Status struct {
-	Available string `json:"Available"`
-	Uptime    string `json:"Uptime"`
-	TimeStamp string `json:"TimeStamp"`
+type Status = pluginsdk.Status
+
+//EventListener holds the count of the events received from the devices, and of the rejected ones by reason
+type EventListener struct {
+	ReceivedEvents         uint64            `json:"ReceivedEvents"`
+	RejectedEvents         uint64            `json:"RejectedEvents"`
+	RejectedEventsByReason map[string]uint64 `json:"RejectedEventsByReason"`
+}
+
+//SubscriptionReconciliation holds the count of the drifts found on the event subscriptions of the devices
+//by the reconciliations run on the plugin startup calls
+type SubscriptionReconciliation struct {
+	LastReconciliation      string `json:"LastReconciliation,omitempty"`
+	ReconciledDevices       uint64 `json:"ReconciledDevices"`
+	FailedDevices           uint64 `json:"FailedDevices"`
+	MissingSubscriptions    uint64 `json:"MissingSubscriptions"`
+	MismatchedSubscriptions uint64 `json:"MismatchedSubscriptions"`
+	DuplicateSubscriptions  uint64 `json:"DuplicateSubscriptions"`
 }
 
 //EventMessageBus holds the  information of  EMB Broker type and EMBQueue information
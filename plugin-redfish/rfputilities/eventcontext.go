@@ -0,0 +1,94 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+)
+
+// eventContextPrefix starts the Context of the event subscriptions created by the plugin on the devices
+const eventContextPrefix = "ODIMRA_Event."
+
+// eventContextKey is the key signing the event subscription contexts, it is derived from the
+// plugin private key so that every instance of the plugin accepts the events of the others
+func eventContextKey() []byte {
+	key := sha256.Sum256(append([]byte(eventContextPrefix), config.Data.KeyCertConf.PrivateKey...))
+	return key[:]
+}
+
+func signEventSource(host, issued string) []byte {
+	mac := hmac.New(sha256.New, eventContextKey())
+	mac.Write([]byte(host + "." + issued))
+	return mac.Sum(nil)
+}
+
+// EventContext returns the Context of a new event subscription of the device, the device
+// sends it back in its events, which identifies the device whatever the address they come from.
+// The Context carries the time it was issued at, so that the Contexts of the subscriptions
+// deleted before can be told from the one of a later subscription of the device.
+func EventContext(host string) string {
+	issued := strconv.FormatInt(time.Now().UnixNano(), 10)
+	return eventContextPrefix + base64.RawURLEncoding.EncodeToString([]byte(host)) + "." + issued + "." +
+		base64.RawURLEncoding.EncodeToString(signEventSource(host, issued))
+}
+
+// EventSecretHeader is the HTTP header carrying the shared secret of the device in its events
+const EventSecretHeader = "X-ODIMRA-Event-Secret"
+
+// EventSecret returns the shared secret the device sends in the HTTP headers of its events,
+// it is set in the HttpHeaders of its event subscription
+func EventSecret(host string) string {
+	mac := hmac.New(sha256.New, eventContextKey())
+	mac.Write([]byte("EventSecret." + host))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidEventSecret checks the shared secret sent in an event of the device
+func ValidEventSecret(host, secret string) bool {
+	return hmac.Equal([]byte(secret), []byte(EventSecret(host)))
+}
+
+// EventSource returns the host of the device which sent an event with the given Context and the time
+// the Context was issued at, an error is returned when the Context was not created by the plugin
+func EventSource(context string) (string, time.Time, error) {
+	parts := strings.Split(strings.TrimPrefix(context, eventContextPrefix), ".")
+	if !strings.HasPrefix(context, eventContextPrefix) || len(parts) != 3 {
+		return "", time.Time{}, fmt.Errorf("event context %v is not one of the plugin", context)
+	}
+	host, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("event context %v is not one of the plugin: %v", context, err)
+	}
+	issued, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("event context %v is not one of the plugin: %v", context, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("event context %v is not one of the plugin: %v", context, err)
+	}
+	if !hmac.Equal(signature, signEventSource(string(host), parts[1])) {
+		return "", time.Time{}, fmt.Errorf("event context %v has an invalid signature", context)
+	}
+	return string(host), time.Unix(0, issued), nil
+}
@@ -0,0 +1,64 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+)
+
+func TestEventSource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	context := EventContext("10.24.0.12")
+	otherContext := EventContext("10.24.0.13")
+	parts, otherParts := strings.Split(context, "."), strings.Split(otherContext, ".")
+	tests := []struct {
+		name    string
+		context string
+		want    string
+		wantErr bool
+	}{
+		{name: "context of the plugin", context: context, want: "10.24.0.12"},
+		{name: "context of another device", context: otherContext, want: "10.24.0.13"},
+		{name: "context not created by the plugin", context: "ODIMRA_Event", wantErr: true},
+		{name: "empty context", context: "", wantErr: true},
+		{name: "forged host", context: strings.Join([]string{parts[0], otherParts[1], parts[2], parts[3]}, "."), wantErr: true},
+		{name: "forged issue time", context: strings.Join([]string{parts[0], parts[1], otherParts[2], parts[3]}, "."), wantErr: true},
+		{name: "invalid signature", context: context + "A", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, issued, err := EventSource(tt.context)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EventSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("EventSource() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && (issued.After(time.Now()) || time.Since(issued) > time.Minute) {
+				t.Errorf("EventSource() issued at %v, want the time the context was created at", issued)
+			}
+		})
+	}
+	if !ValidEventSecret("10.24.0.12", EventSecret("10.24.0.12")) {
+		t.Errorf("ValidEventSecret() of the device secret = false, want true")
+	}
+	if ValidEventSecret("10.24.0.12", EventSecret("10.24.0.13")) {
+		t.Errorf("ValidEventSecret() of the secret of another device = true, want false")
+	}
+}
@@ -0,0 +1,328 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
+)
+
+// deviceProfile is the quirk profile applying to a device, it is detected on the first request to the device.
+// The manager the firmware version was read from and the version are kept for the firmware updates to be noticed.
+type deviceProfile struct {
+	lock            sync.Mutex
+	detected        bool
+	profile         *config.QuirkProfile
+	managerURI      string
+	firmwareVersion string
+	// retryAt is set when the identification failed, the device has no profile until then,
+	// the delay grows with backoff on every failure
+	retryAt time.Time
+	backoff time.Duration
+}
+
+var (
+	// identificationRetryBackoff and maxIdentificationRetryBackoff bound the delay before a failed identification is retried
+	identificationRetryBackoff    = time.Minute
+	maxIdentificationRetryBackoff = 30 * time.Minute
+
+	deviceProfilesLock sync.Mutex
+	// deviceProfiles holds the quirk profile of every device the plugin sent a request to, by host
+	deviceProfiles = make(map[string]*deviceProfile)
+)
+
+// getQuirkProfile returns the quirk profile applying to the device, nil when none applies.
+// The device is identified once. When the identification fails, the device has no profile until it is
+// tried again after the backoff, so that the requests to the device do not all repeat the identification.
+func (client *RedfishClient) getQuirkProfile(device *RedfishDevice) *config.QuirkProfile {
+	if len(config.Data.QuirkProfiles) == 0 {
+		return nil
+	}
+	deviceProfilesLock.Lock()
+	profile, ok := deviceProfiles[device.Host]
+	if !ok {
+		profile = &deviceProfile{}
+		deviceProfiles[device.Host] = profile
+	}
+	deviceProfilesLock.Unlock()
+
+	profile.lock.Lock()
+	defer profile.lock.Unlock()
+	if profile.detected || time.Now().Before(profile.retryAt) {
+		return profile.profile
+	}
+	identity, err := client.identifyDevice(device)
+	if err != nil {
+		profile.backoff *= 2
+		if profile.backoff < identificationRetryBackoff {
+			profile.backoff = identificationRetryBackoff
+		}
+		if profile.backoff > maxIdentificationRetryBackoff {
+			profile.backoff = maxIdentificationRetryBackoff
+		}
+		profile.retryAt = time.Now().Add(profile.backoff)
+		log.Printf("error while identifying the device %v for its quirk profile, it is identified again in %v: %v", device.Host, profile.backoff, err)
+		return nil
+	}
+	profile.detected = true
+	profile.backoff = 0
+	profile.managerURI = identity.managerURI
+	profile.firmwareVersion = identity.firmwareVersion
+	for _, quirkProfile := range config.Data.QuirkProfiles {
+		if quirkProfile.Matches(identity.vendor, identity.product, identity.firmwareVersion) {
+			log.Printf("info: quirk profile %v applies to the device %v", quirkProfile.Name, device.Host)
+			profile.profile = quirkProfile
+			break
+		}
+	}
+	return profile.profile
+}
+
+// ForgetQuirkProfile removes the quirk profile of the device, it is identified again on its next request
+func ForgetQuirkProfile(host string) {
+	deviceProfilesLock.Lock()
+	delete(deviceProfiles, host)
+	deviceProfilesLock.Unlock()
+}
+
+// CheckFirmwareVersion forgets the quirk profile of the device when the resource got from it is the manager
+// the device was identified by and its firmware version changed since, the profile may not apply anymore
+func CheckFirmwareVersion(host string, resource []byte) {
+	var manager struct {
+		Oid             string `json:"@odata.id"`
+		FirmwareVersion string `json:"FirmwareVersion"`
+	}
+	if err := json.Unmarshal(resource, &manager); err != nil || manager.Oid == "" {
+		return
+	}
+	deviceProfilesLock.Lock()
+	profile, ok := deviceProfiles[host]
+	deviceProfilesLock.Unlock()
+	if !ok {
+		return
+	}
+	profile.lock.Lock()
+	changed := profile.detected && profile.managerURI == manager.Oid && profile.firmwareVersion != manager.FirmwareVersion
+	profile.lock.Unlock()
+	if changed {
+		log.Printf("info: firmware version of the device %v changed to %v, its quirk profile is detected again", host, manager.FirmwareVersion)
+		deviceProfilesLock.Lock()
+		// the profile may have been forgotten and detected again meanwhile
+		if deviceProfiles[host] == profile {
+			delete(deviceProfiles, host)
+		}
+		deviceProfilesLock.Unlock()
+	}
+}
+
+// deviceIdentity is what the quirk profile of a device is chosen by
+type deviceIdentity struct {
+	vendor          string
+	product         string
+	managerURI      string
+	firmwareVersion string
+}
+
+// identifyDevice returns the vendor and the product of the device, read from its service root, and the
+// firmware version of its first manager. The vendor is the Oem property of the service root when it has no Vendor.
+func (client *RedfishClient) identifyDevice(device *RedfishDevice) (deviceIdentity, error) {
+	var serviceRoot rfpmodel.ServiceRoot
+	if err := client.identificationGet(device, redfishServiceRootURI, &serviceRoot); err != nil {
+		return deviceIdentity{}, err
+	}
+	identity := deviceIdentity{
+		vendor:  serviceRoot.Vendor,
+		product: serviceRoot.Product,
+	}
+	if identity.vendor == "" && len(serviceRoot.Oem) == 1 {
+		for oem := range serviceRoot.Oem {
+			identity.vendor = oem
+		}
+	}
+	if serviceRoot.Managers.Oid == "" {
+		return identity, nil
+	}
+	var managers struct {
+		Members []struct {
+			Oid string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	// the firmware version is optional, the devices which do not accept basic auth are identified without it
+	if err := client.identificationGet(device, serviceRoot.Managers.Oid, &managers); err != nil || len(managers.Members) == 0 {
+		return identity, nil
+	}
+	var manager struct {
+		FirmwareVersion string `json:"FirmwareVersion"`
+	}
+	if err := client.identificationGet(device, managers.Members[0].Oid, &manager); err != nil {
+		return identity, nil
+	}
+	identity.managerURI = managers.Members[0].Oid
+	identity.firmwareVersion = manager.FirmwareVersion
+	return identity, nil
+}
+
+// identificationGet gets a resource of the device with basic auth, without applying any quirk profile
+func (client *RedfishClient) identificationGet(device *RedfishDevice, uri string, resource interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s%s", device.Host, uri), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	auth := device.Username + ":" + string(device.Password)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(auth)))
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("while getting %v got %v", uri, resp.StatusCode)
+	}
+	return json.Unmarshal(body, resource)
+}
+
+// do sends the request to the device, after applying the quirk profile of the device to it:
+// the headers of the profile are set, the URI is translated and the bad properties are removed
+// from the request body and from the response body
+func (client *RedfishClient) do(device *RedfishDevice, req *http.Request) (*http.Response, error) {
+	profile := client.getQuirkProfile(device)
+	if profile == nil {
+		return client.httpClient.Do(req)
+	}
+	for header, value := range profile.Headers {
+		req.Header.Set(header, value)
+	}
+	for key, value := range profile.URITranslation {
+		req.URL.Path = strings.Replace(req.URL.Path, key, value, -1)
+	}
+	req.URL.RawPath = ""
+	if len(profile.BadProperties) > 0 && req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = removeProperties(body, profile.BadProperties)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.ContentLength = int64(len(body))
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil || len(profile.BadProperties) == 0 {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	body = removeProperties(body, profile.BadProperties)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+	return resp, nil
+}
+
+// removeProperties removes the properties from the JSON object, the paths are property names joined
+// by dots and the arrays on the way are walked through. The bodies which are not JSON objects are kept as is.
+func removeProperties(body []byte, paths []string) []byte {
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return body
+	}
+	for _, path := range paths {
+		removeProperty(data, strings.Split(path, "."))
+	}
+	result, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+func removeProperty(data interface{}, path []string) {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(value, path[0])
+			return
+		}
+		removeProperty(value[path[0]], path[1:])
+	case []interface{}:
+		for _, item := range value {
+			removeProperty(item, path)
+		}
+	}
+}
+
+// AuthMethod returns the authentication method of the quirk profile of the device, empty when none is set
+func (client *RedfishClient) AuthMethod(device *RedfishDevice) string {
+	if profile := client.getQuirkProfile(device); profile != nil {
+		return profile.AuthMethod
+	}
+	return ""
+}
+
+// SettingsURI returns the URI the settings of the resource at the URI are patched on, according to the
+// quirk profile of the device. The URIs of the settings objects end with /Settings, the resource is at the URI without it.
+func (client *RedfishClient) SettingsURI(device *RedfishDevice, uri string) string {
+	profile := client.getQuirkProfile(device)
+	if profile == nil || profile.SettingsObject == "" {
+		return uri
+	}
+	resourceURI := uri
+	if strings.HasSuffix(strings.ToLower(uri), "/settings") {
+		resourceURI = uri[:len(uri)-len("/settings")]
+	}
+	if profile.SettingsObject == config.SettingsObjectDirect {
+		return resourceURI
+	}
+	var resource struct {
+		Settings struct {
+			SettingsObject struct {
+				Oid string `json:"@odata.id"`
+			} `json:"SettingsObject"`
+		} `json:"@Redfish.Settings"`
+	}
+	resp, err := client.GetWithSession(device, resourceURI)
+	if err != nil {
+		log.Printf("error while getting the settings object of %v: %v", resourceURI, err)
+		return uri
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK || json.Unmarshal(body, &resource) != nil {
+		return uri
+	}
+	if resource.Settings.SettingsObject.Oid == "" {
+		return uri
+	}
+	return resource.Settings.SettingsObject.Oid
+}
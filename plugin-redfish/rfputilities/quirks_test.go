@@ -0,0 +1,223 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+)
+
+// quirkyDevice is a device deviating from the Redfish specification, it records the last PATCH request it got
+// and counts the requests to its service root, which fail while it is unavailable
+type quirkyDevice struct {
+	unavailable         int32
+	serviceRootRequests int32
+	firmwareVersion     string
+	patchURI            string
+	patchBody           map[string]interface{}
+	patchHeader         http.Header
+}
+
+func (d *quirkyDevice) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/redfish/v1":
+		atomic.AddInt32(&d.serviceRootRequests, 1)
+		if atomic.LoadInt32(&d.unavailable) != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"Oem":{"Hp":{}},"Product":"ProLiant","Managers":{"@odata.id":"/redfish/v1/Managers"}}`))
+	case "/redfish/v1/Managers":
+		w.Write([]byte(`{"Members":[{"@odata.id":"/redfish/v1/Managers/1"}]}`))
+	case "/redfish/v1/Managers/1":
+		firmwareVersion := d.firmwareVersion
+		if firmwareVersion == "" {
+			firmwareVersion = "iLO 4 v2.70"
+		}
+		w.Write([]byte(`{"FirmwareVersion":"` + firmwareVersion + `"}`))
+	case "/redfish/v1/Systems/1":
+		w.Write([]byte(`{"@Redfish.Settings":{"SettingsObject":{"@odata.id":"/redfish/v1/Systems/1/Settings"}}}`))
+	case "/redfish/v1/Systems/1/Bios":
+		w.Write([]byte(`{"@Redfish.Settings":{"SettingsObject":{"@odata.id":"/redfish/v1/Systems/1/Bios/Pending"}},"Attributes":{"BootMode":"Uefi"},"Oem":{"Hp":{"Links":{}}}}`))
+	default:
+		if r.Method != http.MethodPatch {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		d.patchURI = r.URL.Path
+		d.patchHeader = r.Header
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &d.patchBody)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func TestQuirkProfiles(t *testing.T) {
+	profiles := `[
+		{"Name": "other", "Vendor": "^Dell$"},
+		{
+			"Name": "HPE iLO 4",
+			"Vendor": "^Hp$",
+			"FirmwareVersion": "^iLO 4",
+			"AuthMethod": "BasicAuth",
+			"URITranslation": {"/redfish/v1/Systems/1/Bios/Pending": "/redfish/v1/Systems/1/Bios/Settings"},
+			"SettingsObject": "Follow",
+			"Headers": {"X-Vendor": "quirk"},
+			"BadProperties": ["Attributes.Unsupported", "Oem.Hp.Links"]
+		}
+	]`
+	dir, err := ioutil.TempDir("", "quirks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "quirks.json")
+	if err := ioutil.WriteFile(path, []byte(profiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.LoadQuirkProfiles(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadQuirkProfiles() of a missing file succeeded")
+	}
+	config.Data.QuirkProfiles, err = config.LoadQuirkProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadQuirkProfiles() error = %v", err)
+	}
+	defer func() { config.Data.QuirkProfiles = nil }()
+
+	bmc := &quirkyDevice{}
+	ts := httptest.NewTLSServer(bmc)
+	defer ts.Close()
+	client := &RedfishClient{httpClient: ts.Client()}
+	device := &RedfishDevice{
+		Host:     strings.TrimPrefix(ts.URL, "https://"),
+		Username: "admin",
+		Password: "password",
+	}
+	defer ForgetQuirkProfile(device.Host)
+
+	if authMethod := client.AuthMethod(device); authMethod != config.AuthMethodBasicAuth {
+		t.Errorf("AuthMethod() = %v, want %v", authMethod, config.AuthMethodBasicAuth)
+	}
+	uri := client.SettingsURI(device, "/redfish/v1/Systems/1/Bios/Settings")
+	if uri != "/redfish/v1/Systems/1/Bios/Pending" {
+		t.Errorf("SettingsURI() = %v, want the settings object of the resource", uri)
+	}
+
+	device.PostBody = []byte(`{"Attributes":{"BootMode":"Legacy","Unsupported":"value"}}`)
+	resp, err := client.DeviceCall(device, uri, http.MethodPatch)
+	if err != nil {
+		t.Fatalf("DeviceCall() error = %v", err)
+	}
+	resp.Body.Close()
+	if bmc.patchURI != "/redfish/v1/Systems/1/Bios/Settings" {
+		t.Errorf("PATCH URI = %v, want the translated URI", bmc.patchURI)
+	}
+	if bmc.patchHeader.Get("X-Vendor") != "quirk" {
+		t.Errorf("PATCH headers = %v, want the headers of the profile", bmc.patchHeader)
+	}
+	attributes, _ := bmc.patchBody["Attributes"].(map[string]interface{})
+	if _, ok := attributes["Unsupported"]; ok || attributes["BootMode"] != "Legacy" {
+		t.Errorf("PATCH body = %v, want the bad properties removed", bmc.patchBody)
+	}
+
+	resp, err = client.GetWithSession(device, "/redfish/v1/Systems/1/Bios")
+	if err != nil {
+		t.Fatalf("GetWithSession() error = %v", err)
+	}
+	defer resp.Body.Close()
+	var bios map[string]interface{}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if err := json.Unmarshal(body, &bios); err != nil {
+		t.Fatalf("response body %s: %v", body, err)
+	}
+	if hp, _ := bios["Oem"].(map[string]interface{})["Hp"].(map[string]interface{}); hp == nil || hp["Links"] != nil {
+		t.Errorf("response body = %s, want Oem.Hp.Links removed", body)
+	}
+
+	resp, err = client.SetDefaultBootOrder(device, "/redfish/v1/Systems/1/Actions/ComputerSystem.SetDefaultBootOrder")
+	if err != nil {
+		t.Fatalf("SetDefaultBootOrder() error = %v", err)
+	}
+	resp.Body.Close()
+	if bmc.patchURI != "/redfish/v1/Systems/1/Settings/Actions/ComputerSystem.SetDefaultBootOrder" {
+		t.Errorf("SetDefaultBootOrder() URI = %v, want the action on the settings object of the system", bmc.patchURI)
+	}
+
+	// only a new firmware version of the manager the device was identified by makes it identified again
+	bmc.firmwareVersion = "iLO 5 v1.40"
+	CheckFirmwareVersion(device.Host, []byte(`{"@odata.id":"/redfish/v1/Managers/2","FirmwareVersion":"iLO 5 v1.40"}`))
+	CheckFirmwareVersion(device.Host, []byte(`{"@odata.id":"/redfish/v1/Managers/1","FirmwareVersion":"iLO 4 v2.70"}`))
+	if authMethod := client.AuthMethod(device); authMethod != config.AuthMethodBasicAuth {
+		t.Errorf("AuthMethod() = %v, want the profile kept while the firmware version is unchanged", authMethod)
+	}
+	CheckFirmwareVersion(device.Host, []byte(`{"@odata.id":"/redfish/v1/Managers/1","FirmwareVersion":"iLO 5 v1.40"}`))
+	if authMethod := client.AuthMethod(device); authMethod != "" {
+		t.Errorf("AuthMethod() = %v, want no profile after the firmware update", authMethod)
+	}
+}
+
+func TestQuirkProfileIdentificationBackoff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "quirks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "quirks.json")
+	if err := ioutil.WriteFile(path, []byte(`[{"Name": "HPE", "Vendor": "^Hp$", "AuthMethod": "BasicAuth"}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config.Data.QuirkProfiles, err = config.LoadQuirkProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadQuirkProfiles() error = %v", err)
+	}
+	defer func(backoff, maxBackoff time.Duration) {
+		config.Data.QuirkProfiles = nil
+		identificationRetryBackoff, maxIdentificationRetryBackoff = backoff, maxBackoff
+	}(identificationRetryBackoff, maxIdentificationRetryBackoff)
+	identificationRetryBackoff, maxIdentificationRetryBackoff = 50*time.Millisecond, 50*time.Millisecond
+
+	bmc := &quirkyDevice{unavailable: 1}
+	ts := httptest.NewTLSServer(bmc)
+	defer ts.Close()
+	client := &RedfishClient{httpClient: ts.Client()}
+	device := &RedfishDevice{Host: strings.TrimPrefix(ts.URL, "https://")}
+	defer ForgetQuirkProfile(device.Host)
+
+	// the failed identification is not repeated by the requests sent before the backoff elapsed
+	for i := 0; i < 3; i++ {
+		if authMethod := client.AuthMethod(device); authMethod != "" {
+			t.Errorf("AuthMethod() = %v, want no profile while the device is not identified", authMethod)
+		}
+	}
+	if requests := atomic.LoadInt32(&bmc.serviceRootRequests); requests != 1 {
+		t.Errorf("identification requests = %v, want 1 before the backoff elapsed", requests)
+	}
+
+	atomic.StoreInt32(&bmc.unavailable, 0)
+	time.Sleep(100 * time.Millisecond)
+	if authMethod := client.AuthMethod(device); authMethod != config.AuthMethodBasicAuth {
+		t.Errorf("AuthMethod() = %v, want the profile once the device is identified after the backoff", authMethod)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 
 	lutilconf "github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
@@ -46,6 +47,7 @@ type RedfishDevice struct {
 	ComputerSystems []*Identifier
 	PostBody        []byte `json:"PostBody,omitempty"`
 	Location        string `json:"Location"`
+	SessionLocation string `json:"-"`
 }
 
 //Identifier struct definition
@@ -106,7 +108,7 @@ func (client *RedfishClient) Get(device *RedfishDevice, requestURI string) (*htt
 	}
 	req.Close = true
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(device, req)
 	if err != nil {
 		return resp, err
 	}
@@ -140,29 +142,34 @@ func (client *RedfishClient) GetRootService(device *RedfishDevice) error {
 
 // AuthWithDevice : Performs authentication with the given device and saves the token
 func (client *RedfishClient) AuthWithDevice(device *RedfishDevice) error {
-	if device.RootNode == nil {
-		return fmt.Errorf("no ServiceRoot found for device")
-	}
-
-	// TODO auth (Issue #22)
 	endpoint := fmt.Sprintf("https://%s%s", device.Host, "/redfish/v1/SessionService/Sessions")
 
-	var jsonStr = []byte(`{"UserName":"` + device.Username + `","Password":"` + string(device.Password) + `"}`)
-	req, _ := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonStr))
-	req.Close = true
+	jsonStr, err := json.Marshal(map[string]string{
+		"UserName": device.Username,
+		"Password": device.Password,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonStr))
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("OData-Version", "4.0")
 
-	req.Close = true
-
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(device, req)
 	if err != nil {
 		return err
 	}
 
 	defer resp.Body.Close()
-	device.Token = resp.Header["X-Auth-Token"][0]
-	fmt.Println(device.Token)
+	ioutil.ReadAll(resp.Body)
+	device.Token = resp.Header.Get("X-Auth-Token")
+	if resp.StatusCode >= 300 || device.Token == "" {
+		return &SessionError{Host: device.Host, StatusCode: resp.StatusCode}
+	}
+	device.SessionLocation = resp.Header.Get("Location")
 
 	return nil
 }
@@ -186,7 +193,7 @@ func (client *RedfishClient) BasicAuthWithDevice(device *RedfishDevice, requestU
 	req.Header.Add("Content-Type", "application/json")
 	req.Close = true
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(device, req)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +201,8 @@ func (client *RedfishClient) BasicAuthWithDevice(device *RedfishDevice, requestU
 	return resp, nil
 }
 
-// GetWithBasicAuth : Performs authentication with the given device and saves the token
+// GetWithBasicAuth : Performs authentication with the given device and saves the token,
+// the connection is kept alive for the next requests to the device
 func (client *RedfishClient) GetWithBasicAuth(device *RedfishDevice, requestURI string) (*http.Response, error) {
 
 	endpoint := fmt.Sprintf("https://%s%s", device.Host, requestURI)
@@ -203,15 +211,13 @@ func (client *RedfishClient) GetWithBasicAuth(device *RedfishDevice, requestURI
 		return nil, err
 	}
 
-	req.Close = true
 	req.Header.Set("Accept", "application/json")
 	auth := device.Username + ":" + string(device.Password)
 	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
 	req.Header.Add("Authorization", Basicauth)
 	req.Header.Add("Content-Type", "application/json")
-	req.Close = true
 
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.do(device, req)
 	if err != nil {
 		return nil, err
 	}
@@ -219,147 +225,40 @@ func (client *RedfishClient) GetWithBasicAuth(device *RedfishDevice, requestURI
 	return resp, nil
 }
 
-// SubscribeForEvents :Subscribes for events with Basic Auth
+// SubscribeForEvents :Subscribes for events with the session of the device
 func (client *RedfishClient) SubscribeForEvents(device *RedfishDevice) (*http.Response, error) {
 	endpoint := fmt.Sprintf("https://%s%s", device.Host, "/redfish/v1/EventService/Subscriptions")
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(device.PostBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Close = true
-	req.Header.Set("Accept", "application/json")
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Set("Content-Type", "application/json")
-	req.Close = true
-	var resp *http.Response
-	resp, err = client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, http.MethodPost, endpoint, device.PostBody)
 }
 
 // ResetComputerSystem :Reset the computer system with given ResetType
 func (client *RedfishClient) ResetComputerSystem(device *RedfishDevice, uri string) (*http.Response, error) {
-
-	endpoint := "https://" + device.Host + uri
-
-	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(device.PostBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Close = true
-	req.Header.Set("Accept", "application/json")
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Set("Content-Type", "application/json")
-	req.Close = true
-	var resp *http.Response
-	resp, err = client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, http.MethodPost, "https://"+device.Host+uri, device.PostBody)
 }
 
 // SetDefaultBootOrder : sets default boot order
 func (client *RedfishClient) SetDefaultBootOrder(device *RedfishDevice, uri string) (*http.Response, error) {
-
-	endpoint := "https://" + device.Host + uri
-
-	req, err := http.NewRequest(http.MethodPatch, endpoint, nil)
-	if err != nil {
-		return nil, err
+	// the action applies to the settings object of the system on the devices whose quirk profile says so
+	if index := strings.Index(uri, "/Actions/"); index > 0 {
+		systemURI := uri[:index]
+		uri = client.SettingsURI(device, systemURI) + uri[index:]
 	}
-	req.Close = true
-	req.Header.Set("Accept", "application/json")
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Set("Content-Type", "application/json")
-	req.Close = true
-	var resp *http.Response
-	resp, err = client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, http.MethodPatch, "https://"+device.Host+uri, nil)
 }
 
 // DeleteSubscriptionDetail will accepts device struct
 // and it will delete the subscription detail
 func (client *RedfishClient) DeleteSubscriptionDetail(device *RedfishDevice) (*http.Response, error) {
-	req, err := http.NewRequest("DELETE", device.Location, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Close = true
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-	req.Close = true
-
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, http.MethodDelete, device.Location, nil)
 }
 
 // DeviceCall will call device with the given device details on the url given
-// TODO: use same method to all other calls in this file
 func (client *RedfishClient) DeviceCall(device *RedfishDevice, url, method string) (*http.Response, error) {
-	endpoint := fmt.Sprintf("https://%s%s", device.Host, url)
-	req, err := http.NewRequest(method, endpoint, bytes.NewBuffer(device.PostBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Close = true
-	req.Header.Set("Accept", "application/json")
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Set("Content-Type", "application/json")
-	req.Close = true
-	var resp *http.Response
-	resp, err = client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, method, fmt.Sprintf("https://%s%s", device.Host, url), device.PostBody)
 }
 
 // GetSubscriptionDetail will accepts device struct
 // and it will get the subscription detail
 func (client *RedfishClient) GetSubscriptionDetail(device *RedfishDevice) (*http.Response, error) {
-	req, err := http.NewRequest("GET", device.Location, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Close = true
-	req.Header.Set("Accept", "application/json")
-	auth := device.Username + ":" + string(device.Password)
-	Basicauth := "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", Basicauth)
-	req.Header.Add("Content-Type", "application/json")
-	req.Close = true
-
-	resp, err := client.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-
-	return resp, nil
+	return client.CallWithSession(device, http.MethodGet, device.Location, nil)
 }
@@ -0,0 +1,207 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/plugin-redfish/config"
+)
+
+// deviceSession is the Redfish session opened on a device, shared by all the requests sent to the device
+type deviceSession struct {
+	lock     sync.Mutex
+	token    string
+	location string
+	// basicAuth is set when the device does not support sessions, its requests then use basic auth
+	basicAuth bool
+	// retryAt is set when the session creation failed on the device for another reason,
+	// its requests use basic auth until then, the delay grows with backoff on every failure
+	retryAt time.Time
+	backoff time.Duration
+}
+
+// SessionError is returned when a device refuses to open a session
+type SessionError struct {
+	Host       string
+	StatusCode int
+}
+
+func (e *SessionError) Error() string {
+	return fmt.Sprintf("session creation on %v failed with status %v", e.Host, e.StatusCode)
+}
+
+var (
+	// sessionRetryBackoff and maxSessionRetryBackoff bound the delay before a failed session creation is retried
+	sessionRetryBackoff    = time.Minute
+	maxSessionRetryBackoff = 30 * time.Minute
+
+	deviceSessionsLock sync.Mutex
+	// deviceSessions holds the session of every device and user the plugin sent a request to
+	deviceSessions = make(map[string]*deviceSession)
+)
+
+func deviceSessionKey(device *RedfishDevice) string {
+	return device.Host + "|" + device.Username
+}
+
+// getDeviceSession returns the session of the device, it is created empty on the first request to the device
+func getDeviceSession(device *RedfishDevice) *deviceSession {
+	deviceSessionsLock.Lock()
+	defer deviceSessionsLock.Unlock()
+	key := deviceSessionKey(device)
+	session, ok := deviceSessions[key]
+	if !ok {
+		session = &deviceSession{}
+		deviceSessions[key] = session
+	}
+	return session
+}
+
+// GetWithSession gets the resource from the device with the session of the device
+func (client *RedfishClient) GetWithSession(device *RedfishDevice, requestURI string) (*http.Response, error) {
+	return client.CallWithSession(device, http.MethodGet, fmt.Sprintf("https://%s%s", device.Host, requestURI), nil)
+}
+
+// CallWithSession sends the request to the device with the session of the device, the session is opened
+// on the first request and opened again when the device rejects its token. The connections to the device
+// are kept alive between the requests. Devices which do not support sessions are sent basic auth requests.
+func (client *RedfishClient) CallWithSession(device *RedfishDevice, method, endpoint string, body []byte) (*http.Response, error) {
+	session := getDeviceSession(device)
+	token, err := session.getToken(client, device, "")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.callWithToken(device, method, endpoint, body, token)
+	if err != nil || token == "" || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	// the session expired or was deleted on the device, it is opened again once
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+	if token, err = session.getToken(client, device, token); err != nil {
+		return nil, err
+	}
+	return client.callWithToken(device, method, endpoint, body, token)
+}
+
+// getToken returns the token of the session, a new session is opened when there is none or when
+// the token is the rejected one. An empty token is returned when basic auth is to be used instead.
+func (session *deviceSession) getToken(client *RedfishClient, device *RedfishDevice, rejectedToken string) (string, error) {
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	authMethod := client.AuthMethod(device)
+	if session.basicAuth || authMethod == config.AuthMethodBasicAuth || time.Now().Before(session.retryAt) {
+		return "", nil
+	}
+	if session.token != "" && session.token != rejectedToken {
+		return session.token, nil
+	}
+	sessionDevice := *device
+	err := client.AuthWithDevice(&sessionDevice)
+	if err != nil {
+		var sessionErr *SessionError
+		// the devices of a profile requiring sessions are not sent basic auth requests
+		if !errors.As(err, &sessionErr) || authMethod == config.AuthMethodSession {
+			return "", err
+		}
+		session.token = ""
+		switch {
+		case sessionErr.StatusCode == http.StatusUnauthorized || sessionErr.StatusCode == http.StatusForbidden:
+			// the request is sent with basic auth for the device to reject the credentials itself
+		case sessionErr.StatusCode == http.StatusNotFound || sessionErr.StatusCode == http.StatusMethodNotAllowed ||
+			sessionErr.StatusCode == http.StatusNotImplemented || sessionErr.StatusCode < http.StatusMultipleChoices:
+			// the device has no session service, or opens sessions without a token
+			log.Printf("info: %v, basic auth is used for the device", err.Error())
+			session.basicAuth = true
+		default:
+			// the session service of the device failed, the session is opened again after the backoff
+			session.backoff *= 2
+			if session.backoff < sessionRetryBackoff {
+				session.backoff = sessionRetryBackoff
+			}
+			if session.backoff > maxSessionRetryBackoff {
+				session.backoff = maxSessionRetryBackoff
+			}
+			session.retryAt = time.Now().Add(session.backoff)
+			log.Printf("warn: %v, basic auth is used for the device for %v", err.Error(), session.backoff)
+		}
+		return "", nil
+	}
+	session.token = sessionDevice.Token
+	session.location = sessionDevice.SessionLocation
+	session.backoff = 0
+	return session.token, nil
+}
+
+// callWithToken sends the request with the token of the session, or with basic auth when the token is empty
+func (client *RedfishClient) callWithToken(device *RedfishDevice, method, endpoint string, body []byte, token string) (*http.Response, error) {
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Auth-Token", token)
+	} else {
+		req.SetBasicAuth(device.Username, device.Password)
+	}
+	return client.do(device, req)
+}
+
+// DeleteSession deletes the session opened on the device, it is used when the device is removed
+func (client *RedfishClient) DeleteSession(device *RedfishDevice) error {
+	deviceSessionsLock.Lock()
+	key := deviceSessionKey(device)
+	session, ok := deviceSessions[key]
+	delete(deviceSessions, key)
+	deviceSessionsLock.Unlock()
+	if !ok {
+		return nil
+	}
+	session.lock.Lock()
+	defer session.lock.Unlock()
+	if session.token == "" || session.location == "" {
+		return nil
+	}
+	endpoint := session.location
+	if endpoint[0] == '/' {
+		endpoint = fmt.Sprintf("https://%s%s", device.Host, session.location)
+	}
+	req, err := http.NewRequest(http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Auth-Token", session.token)
+	session.token = ""
+	resp, err := client.do(device, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("session deletion on %v failed with status %v", device.Host, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package rfputilities
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockDevice is a device handing out session tokens, the tokens can be revoked to mimic their expiry
+type mockDevice struct {
+	lock           sync.Mutex
+	sessions       map[string]bool
+	logins         int
+	basicAuths     int
+	deletions      int
+	supportSession bool
+	// sessionStatus is the status of the failed session creations, when it is set
+	sessionStatus int
+}
+
+func (d *mockDevice) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	switch {
+	case r.URL.Path == "/redfish/v1/SessionService/Sessions" && r.Method == http.MethodPost:
+		if !d.supportSession {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if d.sessionStatus != 0 {
+			w.WriteHeader(d.sessionStatus)
+			return
+		}
+		d.logins++
+		token := "token" + strings.Repeat("1", d.logins)
+		d.sessions[token] = true
+		w.Header().Set("X-Auth-Token", token)
+		w.Header().Set("Location", "/redfish/v1/SessionService/Sessions/"+token)
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasPrefix(r.URL.Path, "/redfish/v1/SessionService/Sessions/") && r.Method == http.MethodDelete:
+		d.deletions++
+		delete(d.sessions, strings.TrimPrefix(r.URL.Path, "/redfish/v1/SessionService/Sessions/"))
+		w.WriteHeader(http.StatusNoContent)
+	case d.sessions[r.Header.Get("X-Auth-Token")]:
+		w.Write([]byte(`{"@odata.id":"` + r.URL.Path + `"}`))
+	case r.Header.Get("Authorization") != "":
+		d.basicAuths++
+		w.Write([]byte(`{"@odata.id":"` + r.URL.Path + `"}`))
+	default:
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func TestGetWithSession(t *testing.T) {
+	bmc := &mockDevice{sessions: make(map[string]bool), supportSession: true}
+	ts := httptest.NewTLSServer(bmc)
+	defer ts.Close()
+	client := &RedfishClient{httpClient: ts.Client()}
+	device := &RedfishDevice{
+		Host:     strings.TrimPrefix(ts.URL, "https://"),
+		Username: "admin",
+		Password: "password",
+	}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.GetWithSession(device, "/redfish/v1/Systems")
+		if err != nil {
+			t.Fatalf("GetWithSession() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GetWithSession() status = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	}
+	if bmc.logins != 1 || bmc.basicAuths != 0 {
+		t.Errorf("got %v logins and %v basic auth requests, want 1 login and no basic auth request", bmc.logins, bmc.basicAuths)
+	}
+
+	// the session expired on the device
+	bmc.lock.Lock()
+	bmc.sessions = make(map[string]bool)
+	bmc.lock.Unlock()
+	resp, err := client.GetWithSession(device, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("GetWithSession() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bmc.logins != 2 {
+		t.Errorf("GetWithSession() status = %v after %v logins, want %v after 2 logins", resp.StatusCode, bmc.logins, http.StatusOK)
+	}
+
+	if err := client.DeleteSession(device); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if bmc.deletions != 1 || len(bmc.sessions) != 0 {
+		t.Errorf("got %v session deletions and %v sessions left, want the session deleted", bmc.deletions, len(bmc.sessions))
+	}
+
+	// a device without sessions is sent basic auth requests
+	bmc.supportSession = false
+	resp, err = client.GetWithSession(device, "/redfish/v1/Systems")
+	if err != nil {
+		t.Fatalf("GetWithSession() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bmc.basicAuths != 1 {
+		t.Errorf("GetWithSession() status = %v with %v basic auth requests, want %v with 1", resp.StatusCode, bmc.basicAuths, http.StatusOK)
+	}
+	client.DeleteSession(device)
+}
+
+func TestCallWithSessionRetry(t *testing.T) {
+	bmc := &mockDevice{sessions: make(map[string]bool), supportSession: true, sessionStatus: http.StatusServiceUnavailable}
+	ts := httptest.NewTLSServer(bmc)
+	defer ts.Close()
+	client := &RedfishClient{httpClient: ts.Client()}
+	device := &RedfishDevice{
+		Host:     strings.TrimPrefix(ts.URL, "https://"),
+		Username: "admin",
+		Password: "password",
+		PostBody: []byte(`{"ResetType":"On"}`),
+	}
+	defer client.DeleteSession(device)
+
+	// the session service failed, the requests are sent with basic auth until the backoff elapsed
+	for i := 0; i < 2; i++ {
+		resp, err := client.DeviceCall(device, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", http.MethodPost)
+		if err != nil {
+			t.Fatalf("DeviceCall() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("DeviceCall() status = %v, want %v", resp.StatusCode, http.StatusOK)
+		}
+	}
+	if bmc.basicAuths != 2 || bmc.logins != 0 {
+		t.Errorf("got %v logins and %v basic auth requests, want no login and 2 basic auth requests", bmc.logins, bmc.basicAuths)
+	}
+
+	// the session is opened once the backoff elapsed
+	bmc.lock.Lock()
+	bmc.sessionStatus = 0
+	bmc.lock.Unlock()
+	session := getDeviceSession(device)
+	session.lock.Lock()
+	session.retryAt = time.Now()
+	session.lock.Unlock()
+	resp, err := client.DeviceCall(device, "/redfish/v1/Systems/1/Actions/ComputerSystem.Reset", http.MethodPost)
+	if err != nil {
+		t.Fatalf("DeviceCall() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || bmc.logins != 1 || bmc.basicAuths != 2 {
+		t.Errorf("DeviceCall() status = %v after %v logins and %v basic auth requests, want %v after 1 login",
+			resp.StatusCode, bmc.logins, bmc.basicAuths, http.StatusOK)
+	}
+}
@@ -38,7 +38,6 @@ import (
 	"log"
 
 	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpmodel"
-	"github.com/ODIM-Project/ODIM/plugin-redfish/rfpresponse"
 )
 
 // GetPlainText ...
@@ -72,6 +71,3 @@ func GetPlainText(password []byte) ([]byte, error) {
 		nil,
 	)
 }
-
-//Status holds the Status of plugin it will be intizaied during startup time
-var Status rfpresponse.Status
@@ -15,8 +15,11 @@
 package account
 
 import (
+	"time"
+
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 const (
@@ -25,18 +28,31 @@ const (
 
 // ExternalInterface holds all the external connections account package functions uses
 type ExternalInterface struct {
-	CreateUser         func(asmodel.User) *errors.Error
-	GetUserDetails     func(string) (asmodel.User, *errors.Error)
-	GetRoleDetailsByID func(string) (asmodel.Role, *errors.Error)
-	UpdateUserDetails  func(asmodel.User, asmodel.User) *errors.Error
+	CreateUser          func(asmodel.User) *errors.Error
+	GetUserDetails      func(string) (asmodel.User, *errors.Error)
+	GetRoleDetailsByID  func(string) (asmodel.Role, *errors.Error)
+	UpdateUserDetails   func(asmodel.User, asmodel.User) *errors.Error
+	RefreshSessions     func(string, string) *errors.Error
+	UpdatePasswordState func(string, bool, time.Time) *errors.Error
 }
 
 // GetExternalInterface retrieves all the external connections account package functions uses
 func GetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         asmodel.CreateUser,
-		GetUserDetails:     asmodel.GetUserDetails,
-		GetRoleDetailsByID: asmodel.GetRoleDetailsByID,
-		UpdateUserDetails:  asmodel.UpdateUserDetails,
+		CreateUser:          asmodel.CreateUser,
+		GetUserDetails:      asmodel.GetUserDetails,
+		GetRoleDetailsByID:  asmodel.GetRoleDetailsByID,
+		UpdateUserDetails:   asmodel.UpdateUserDetails,
+		RefreshSessions:     auth.RefreshUserSessions,
+		UpdatePasswordState: auth.UpdatePasswordState,
+	}
+}
+
+// formatPasswordExpiration will format the password expiration of an account for display,
+// an empty string is returned when the password does not expire
+func formatPasswordExpiration(expiration time.Time) string {
+	if expiration.IsZero() {
+		return ""
 	}
+	return expiration.Format(time.RFC3339)
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 	"golang.org/x/crypto/sha3"
 	"testing"
+	"time"
 )
 
 func TestGetExternalInterface(t *testing.T) {
@@ -43,10 +44,12 @@ func TestGetExternalInterface(t *testing.T) {
 
 func getMockExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
-		CreateUser:         mockCreateUser,
-		GetUserDetails:     mockGetUserDetails,
-		GetRoleDetailsByID: mockGetRoleDetailsByID,
-		UpdateUserDetails:  mockUpdateUserDetails,
+		CreateUser:          mockCreateUser,
+		GetUserDetails:      mockGetUserDetails,
+		GetRoleDetailsByID:  mockGetRoleDetailsByID,
+		UpdateUserDetails:   mockUpdateUserDetails,
+		RefreshSessions:     mockRefreshSessions,
+		UpdatePasswordState: mockUpdatePasswordState,
 	}
 }
 
@@ -84,6 +87,14 @@ func mockUpdateUserDetails(user, newData asmodel.User) *errors.Error {
 	return nil
 }
 
+func mockRefreshSessions(userName, roleID string) *errors.Error {
+	return nil
+}
+
+func mockUpdatePasswordState(userName string, required bool, expiration time.Time) *errors.Error {
+	return nil
+}
+
 func mockGetRoleDetailsByID(roleID string) (asmodel.Role, *errors.Error) {
 	if roleID == "xyz" {
 		return asmodel.Role{}, errors.PackError(errors.DBKeyNotFound, "error while trying to get role details: ", fmt.Sprintf("error: Invalid RoleID %v present", roleID))
@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
@@ -57,7 +58,7 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 	}
 
 	commonResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/" + createAccount.UserName,
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           createAccount.UserName,
@@ -83,6 +84,9 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 		Password: createAccount.Password,
 		RoleID:   createAccount.RoleID,
 	}
+	if createAccount.PasswordChangeRequired != nil {
+		user.PasswordChangeRequired = *createAccount.PasswordChangeRequired
+	}
 
 	if !(session.Privileges[common.PrivilegeConfigureUsers]) {
 		errorMessage := "error: user does not have the privilege to create a new user"
@@ -162,6 +166,7 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 	hashSum := hash.Sum(nil)
 	hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
 	user.Password = hashedPassword
+	user.PasswordExpiration = passwordExpiration()
 	user.AccountTypes = []string{"Redfish"}
 	if cerr := e.CreateUser(user); cerr != nil {
 		errorMessage := "error while trying to add new user: " + cerr.Error()
@@ -206,10 +211,12 @@ func (e *ExternalInterface) Create(req *accountproto.CreateAccountRequest, sessi
 
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     formatPasswordExpiration(user.PasswordExpiration),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID + "/",
@@ -264,3 +271,37 @@ func validatePassword(userName, password string) error {
 	}
 	return nil
 }
+
+// passwordExpiration will compute the expiration time of a password which is set now,
+// the zero time is returned when passwords are configured not to expire
+func passwordExpiration() time.Time {
+	days := config.Data.AuthConf.PasswordRules.PasswordExpirationDays
+	if days <= 0 {
+		return time.Time{}
+	}
+	return time.Now().AddDate(0, 0, days)
+}
+
+// passwordHistory will check the new hashed password against the current and the previous
+// passwords of the user as per the configured history depth, and will return the history
+// to be stored along with the new password
+func passwordHistory(user asmodel.User, hashedPassword string) ([]string, error) {
+	depth := config.Data.AuthConf.PasswordRules.PasswordHistoryDepth
+	if depth <= 0 {
+		return nil, nil
+	}
+	recent := append([]string{user.Password}, user.PasswordHistory...)
+	if len(recent) > depth {
+		recent = recent[:depth]
+	}
+	for _, password := range recent {
+		if password == hashedPassword {
+			return nil, fmt.Errorf("error: invalid password, password is same as one of the last %v passwords", depth)
+		}
+	}
+	// the new password takes the place of the oldest one
+	if len(recent) == depth {
+		recent = recent[:depth-1]
+	}
+	return recent, nil
+}
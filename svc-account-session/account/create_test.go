@@ -229,7 +229,7 @@ func TestCreate(t *testing.T) {
 	})
 
 	successResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/testUser",
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           "testUser",
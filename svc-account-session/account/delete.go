@@ -26,6 +26,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 // Delete defines deletion of an existing account.
@@ -112,6 +113,16 @@ func Delete(session *asmodel.Session, accountID string) response.RPC {
 		return resp
 	}
 
+	if derr := auth.DeleteUserSessions(accountID); derr != nil {
+		errorMessage := "error while deleting sessions of user: " + derr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		resp.Header = map[string]string{
+			"Content-type": "application/json; charset=utf-8", // TODO: add all error headers
+		}
+		log.Printf(errorMessage)
+		return resp
+	}
+
 	resp.StatusCode = http.StatusNoContent
 	resp.StatusMessage = response.AccountRemoved
 
@@ -126,7 +126,7 @@ func GetAllAccounts(session *asmodel.Session) response.RPC {
 // error will be passed back.
 func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 	commonResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/" + accountID,
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           accountID,
@@ -206,10 +206,12 @@ func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 	commonResponse.MessageID = ""
 	commonResponse.Severity = ""
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     formatPasswordExpiration(user.PasswordExpiration),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID + "/",
@@ -228,7 +230,7 @@ func GetAccount(session *asmodel.Session, accountID string) response.RPC {
 // error will be passed back.
 func GetAccountService() response.RPC {
 	commonResponse := response.Response{
-		OdataType:    "#AccountService.v1_6_0.AccountService",
+		OdataType:    "#AccountService.v1_7_0.AccountService",
 		OdataID:      "/redfish/v1/AccountService",
 		OdataContext: "/redfish/v1/$metadata#AccountService.AccountService",
 		ID:           "AccountService",
@@ -278,6 +280,13 @@ func GetAccountService() response.RPC {
 		Roles: asresponse.Accounts{
 			OdataID: "/redfish/v1/AccountService/Roles",
 		},
+		MultiFactorAuth: asresponse.MultiFactorAuth{
+			ClientCertificate: asresponse.ClientCertificate{
+				Enabled:                         config.Data.AuthConf.ClientCertificate.Enabled,
+				RespondToUnauthenticatedClients: *config.Data.AuthConf.ClientCertificate.RespondToUnauthenticatedClients,
+				CertificateMappingAttribute:     config.Data.AuthConf.ClientCertificate.CertificateMappingAttribute,
+			},
+		},
 	}
 
 	return resp
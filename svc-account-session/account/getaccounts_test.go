@@ -138,7 +138,7 @@ func TestGetAllAccounts(t *testing.T) {
 
 func TestGetAccount(t *testing.T) {
 	successResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/testUser1",
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           "testUser1",
@@ -330,7 +330,7 @@ func TestGetAccount(t *testing.T) {
 
 func TestGetAccountService(t *testing.T) {
 	successResponse := response.Response{
-		OdataType:    "#AccountService.v1_6_0.AccountService",
+		OdataType:    "#AccountService.v1_7_0.AccountService",
 		OdataID:      "/redfish/v1/AccountService",
 		OdataContext: "/redfish/v1/$metadata#AccountService.AccountService",
 		ID:           "AccountService",
@@ -373,6 +373,12 @@ func TestGetAccountService(t *testing.T) {
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					MultiFactorAuth: asresponse.MultiFactorAuth{
+						ClientCertificate: asresponse.ClientCertificate{
+							RespondToUnauthenticatedClients: true,
+							CertificateMappingAttribute:     "CommonName",
+						},
+					},
 				},
 			},
 		},
@@ -404,6 +410,12 @@ func TestGetAccountService(t *testing.T) {
 					Roles: asresponse.Accounts{
 						OdataID: "/redfish/v1/AccountService/Roles",
 					},
+					MultiFactorAuth: asresponse.MultiFactorAuth{
+						ClientCertificate: asresponse.ClientCertificate{
+							RespondToUnauthenticatedClients: true,
+							CertificateMappingAttribute:     "CommonName",
+						},
+					},
 				},
 			},
 		},
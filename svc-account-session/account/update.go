@@ -42,7 +42,7 @@ import (
 // Output is the RPC response, which contains the status code, status message, headers and body.
 func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, session *asmodel.Session) response.RPC {
 	commonResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/" + req.AccountID,
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           req.AccountID,
@@ -107,6 +107,13 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		return resp
 	}
 
+	// a session of a user who must change the password is only allowed to change its own password
+	if session.PasswordChangeRequired && (id != session.UserName || requestUser.Password == "" || requestUser.RoleID != "" || updateAccount.PasswordChangeRequired != nil) {
+		errorMessage := "error: password of user " + session.UserName + " must be changed before any other operation"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusForbidden, response.PasswordChangeRequired, errorMessage, nil, nil)
+	}
+
 	if requestUser.RoleID != "" {
 		if requestUser.RoleID != common.RoleAdmin {
 			if requestUser.RoleID != common.RoleMonitor {
@@ -220,6 +227,14 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		}
 	}
 
+	// Only the users with PrivilegeConfigureUsers can force or waive a password change of any account
+	if updateAccount.PasswordChangeRequired != nil && !session.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := "error: user does not have the privilege to update PasswordChangeRequired of any account, including his own account"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+	}
+
+	requestUser.PasswordChangeRequired = user.PasswordChangeRequired
 	if requestUser.Password != "" {
 		// Password modification not allowed, if user doesn't have ConfigureSelf or ConfigureUsers privilege
 		if !session.Privileges[common.PrivilegeConfigureSelf] && !session.Privileges[common.PrivilegeConfigureUsers] {
@@ -272,7 +287,22 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		hash.Write([]byte(requestUser.Password))
 		hashSum := hash.Sum(nil)
 		hashedPassword := base64.URLEncoding.EncodeToString(hashSum)
+		history, err := passwordHistory(user, hashedPassword)
+		if err != nil {
+			errorMessage := err.Error()
+			log.Println(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errorMessage, []interface{}{updateAccount.Password, "Password"}, nil)
+		}
 		requestUser.Password = hashedPassword
+		requestUser.PasswordHistory = history
+		requestUser.PasswordExpiration = passwordExpiration()
+		// changing the own password fulfills the pending password change
+		if user.UserName == session.UserName {
+			requestUser.PasswordChangeRequired = false
+		}
+	}
+	if updateAccount.PasswordChangeRequired != nil {
+		requestUser.PasswordChangeRequired = *updateAccount.PasswordChangeRequired
 	}
 
 	if uerr := e.UpdateUserDetails(user, requestUser); uerr != nil {
@@ -285,6 +315,36 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 		return resp
 	}
 
+	// the active sessions of the user must not retain the privileges of the previous role
+	if requestUser.RoleID != "" && requestUser.RoleID != user.RoleID {
+		if rerr := e.RefreshSessions(user.UserName, requestUser.RoleID); rerr != nil {
+			errorMessage := "error while trying to refresh sessions of user: " + rerr.Error()
+			resp.CreateInternalErrorResponse(errorMessage)
+			resp.Header = map[string]string{
+				"Content-type": "application/json; charset=utf-8", // TODO: add all error headers
+			}
+			log.Printf(errorMessage)
+			return resp
+		}
+	}
+
+	// the active sessions of the user must be restricted or released as per the new password state
+	if requestUser.Password != "" || requestUser.PasswordChangeRequired != user.PasswordChangeRequired {
+		user.PasswordChangeRequired = requestUser.PasswordChangeRequired
+		if requestUser.Password != "" {
+			user.PasswordExpiration = requestUser.PasswordExpiration
+		}
+		if serr := e.UpdatePasswordState(user.UserName, user.PasswordChangeRequired, user.PasswordExpiration); serr != nil {
+			errorMessage := "error while trying to update password state of sessions of user: " + serr.Error()
+			resp.CreateInternalErrorResponse(errorMessage)
+			resp.Header = map[string]string{
+				"Content-type": "application/json; charset=utf-8", // TODO: add all error headers
+			}
+			log.Printf(errorMessage)
+			return resp
+		}
+	}
+
 	resp.StatusCode = http.StatusOK
 	resp.StatusMessage = response.AccountModified
 
@@ -302,10 +362,12 @@ func (e *ExternalInterface) Update(req *accountproto.UpdateAccountRequest, sessi
 	}
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Account{
-		Response:     commonResponse,
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		AccountTypes: user.AccountTypes,
+		Response:               commonResponse,
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		AccountTypes:           user.AccountTypes,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     formatPasswordExpiration(user.PasswordExpiration),
 		Links: asresponse.Links{
 			Role: asresponse.Role{
 				OdataID: "/redfish/v1/AccountService/Roles/" + user.RoleID + "/",
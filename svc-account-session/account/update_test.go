@@ -18,8 +18,11 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	accountproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/account"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
@@ -30,7 +33,7 @@ func TestUpdate(t *testing.T) {
 	acc := getMockExternalInterface()
 
 	successResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/testUser1",
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           "testUser1",
@@ -38,7 +41,7 @@ func TestUpdate(t *testing.T) {
 	}
 
 	operatorSuccessResponse := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/operatorUser",
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           "operatorUser",
@@ -46,7 +49,7 @@ func TestUpdate(t *testing.T) {
 	}
 
 	successResponse2 := response.Response{
-		OdataType:    "#ManagerAccount.v1_4_0.ManagerAccount",
+		OdataType:    "#ManagerAccount.v1_6_0.ManagerAccount",
 		OdataID:      "/redfish/v1/AccountService/Accounts/testUser2",
 		OdataContext: "/redfish/v1/$metadata#ManagerAccount.ManagerAccount",
 		ID:           "testUser2",
@@ -630,3 +633,126 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdatePasswordPolicy(t *testing.T) {
+	common.SetUpMockConfig()
+	config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 90
+	config.Data.AuthConf.PasswordRules.PasswordHistoryDepth = 2
+	defer func() {
+		config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 0
+		config.Data.AuthConf.PasswordRules.PasswordHistoryDepth = 0
+	}()
+
+	var updated asmodel.User
+	var sessionsFlagged *bool
+	acc := getMockExternalInterface()
+	acc.UpdateUserDetails = func(user, newData asmodel.User) *errors.Error {
+		updated = newData
+		return nil
+	}
+	var sessionsExpiration time.Time
+	acc.UpdatePasswordState = func(userName string, required bool, expiration time.Time) *errors.Error {
+		sessionsFlagged = &required
+		sessionsExpiration = expiration
+		return nil
+	}
+	currentUser, _ := mockGetUserDetails("testUser1")
+
+	reqBodyCurrentPwd, _ := json.Marshal(asmodel.Account{
+		Password: "P@$$w0rd",
+	})
+	reqBodyNewPwd, _ := json.Marshal(asmodel.Account{
+		Password: "P@$$w0rd@123",
+	})
+	reqBodyRoleID, _ := json.Marshal(asmodel.Account{
+		RoleID: common.RoleMonitor,
+	})
+	required := true
+	reqBodyChangeRequired, _ := json.Marshal(asmodel.Account{
+		PasswordChangeRequired: &required,
+	})
+
+	flaggedSession := &asmodel.Session{
+		UserName: "testUser1",
+		Privileges: map[string]bool{
+			common.PrivilegeConfigureSelf:  true,
+			common.PrivilegeConfigureUsers: true,
+		},
+		PasswordChangeRequired: true,
+	}
+	tests := []struct {
+		name           string
+		req            *accountproto.UpdateAccountRequest
+		session        *asmodel.Session
+		wantStatusCode int32
+		wantMessage    string
+	}{
+		{
+			name:           "reuse of the current password",
+			req:            &accountproto.UpdateAccountRequest{RequestBody: reqBodyCurrentPwd, AccountID: "testUser1"},
+			session:        flaggedSession,
+			wantStatusCode: http.StatusBadRequest,
+			wantMessage:    response.PropertyValueFormatError,
+		},
+		{
+			name:           "pending password change with update of other account",
+			req:            &accountproto.UpdateAccountRequest{RequestBody: reqBodyNewPwd, AccountID: "testUser2"},
+			session:        flaggedSession,
+			wantStatusCode: http.StatusForbidden,
+			wantMessage:    response.PasswordChangeRequired,
+		},
+		{
+			name:           "pending password change with update of role",
+			req:            &accountproto.UpdateAccountRequest{RequestBody: reqBodyRoleID, AccountID: "testUser1"},
+			session:        flaggedSession,
+			wantStatusCode: http.StatusForbidden,
+			wantMessage:    response.PasswordChangeRequired,
+		},
+		{
+			name:           "forcing password change without ConfigureUsers privilege",
+			req:            &accountproto.UpdateAccountRequest{RequestBody: reqBodyChangeRequired, AccountID: "testUser1"},
+			session:        &asmodel.Session{UserName: "testUser1", Privileges: map[string]bool{common.PrivilegeConfigureSelf: true}},
+			wantStatusCode: http.StatusForbidden,
+			wantMessage:    response.InsufficientPrivilege,
+		},
+		{
+			name:           "pending password change with own password change",
+			req:            &accountproto.UpdateAccountRequest{RequestBody: reqBodyNewPwd, AccountID: "testUser1"},
+			session:        flaggedSession,
+			wantStatusCode: http.StatusOK,
+			wantMessage:    response.AccountModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := acc.Update(tt.req, tt.session)
+			if got.StatusCode != tt.wantStatusCode || got.StatusMessage != tt.wantMessage {
+				t.Errorf("Update() = %v %v, want %v %v", got.StatusCode, got.StatusMessage, tt.wantStatusCode, tt.wantMessage)
+			}
+		})
+	}
+
+	if updated.PasswordChangeRequired {
+		t.Errorf("Update() should clear PasswordChangeRequired when the own password is changed")
+	}
+	if !reflect.DeepEqual(updated.PasswordHistory, []string{currentUser.Password}) {
+		t.Errorf("Update() password history = %v, want the previous password", updated.PasswordHistory)
+	}
+	if updated.PasswordExpiration.Before(time.Now().AddDate(0, 0, 89)) {
+		t.Errorf("Update() password expiration = %v, want 90 days from now", updated.PasswordExpiration)
+	}
+	if sessionsFlagged == nil || *sessionsFlagged || !sessionsExpiration.Equal(updated.PasswordExpiration) {
+		t.Errorf("Update() should release the sessions of the user after the password change")
+	}
+
+	// the previous password is still in the history
+	currentUser.PasswordHistory = []string{currentUser.Password}
+	currentUser.Password = updated.Password
+	if _, err := passwordHistory(currentUser, currentUser.PasswordHistory[0]); err == nil {
+		t.Errorf("passwordHistory() should reject the reuse of the previous password")
+	}
+	config.Data.AuthConf.PasswordRules.PasswordHistoryDepth = 1
+	if _, err := passwordHistory(currentUser, currentUser.PasswordHistory[0]); err != nil {
+		t.Errorf("passwordHistory() error = %v, previous password is out of the history depth", err)
+	}
+}
@@ -17,6 +17,7 @@ package asmodel
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -24,17 +25,21 @@ import (
 
 // Account is the model for creating/updating an Account
 type Account struct {
-	UserName string `json:"UserName"`
-	Password string `json:"Password"`
-	RoleID   string `json:"RoleId"`
+	UserName               string `json:"UserName"`
+	Password               string `json:"Password"`
+	RoleID                 string `json:"RoleId"`
+	PasswordChangeRequired *bool  `json:"PasswordChangeRequired"`
 }
 
 // User is the model for User Account
 type User struct {
-	UserName     string   `json:"UserName"`
-	Password     string   `json:"Password"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
+	UserName               string    `json:"UserName"`
+	Password               string    `json:"Password"`
+	RoleID                 string    `json:"RoleId"`
+	AccountTypes           []string  `json:"AccountTypes"`
+	PasswordChangeRequired bool      `json:"PasswordChangeRequired"`
+	PasswordExpiration     time.Time `json:"PasswordExpiration"`
+	PasswordHistory        []string  `json:"PasswordHistory,omitempty"` // hashes of the previous passwords, the most recent first
 }
 
 // CreateUser connects to the persistencemgr and creates a user in db
@@ -105,10 +110,36 @@ func DeleteUser(key string) *errors.Error {
 	if err = conn.Delete("User", key); err != nil {
 		return err
 	}
-	return nil
+	// the API gateways must not accept the credentials of the deleted user from their caches
+	return common.InvalidateAuthCache()
 }
 
-// UpdateUserDetails will modify the current details to given changes
+// InitPasswordExpiration will set the password expiration of the user when the password has none,
+// and will return the stored user. The passwords set before the expiration was introduced have none.
+func InitPasswordExpiration(userName string, expiration time.Time) (User, *errors.Error) {
+	var user User
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return user, err
+	}
+	err = conn.Modify("User", userName, func(data string) (interface{}, error) {
+		if data == "" {
+			return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key ", userName, " found")
+		}
+		if jerr := json.Unmarshal([]byte(data), &user); jerr != nil {
+			return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal user data: ", jerr)
+		}
+		if user.PasswordExpiration.IsZero() {
+			user.PasswordExpiration = expiration
+		}
+		return user, nil
+	})
+	return user, err
+}
+
+// UpdateUserDetails will modify the current details to given changes,
+// the password expiration and history are modified along with the password
+// and PasswordChangeRequired is always taken from the given changes
 func UpdateUserDetails(user, newData User) *errors.Error {
 
 	conn, err := common.GetDBConnection(common.OnDisk)
@@ -121,12 +152,16 @@ func UpdateUserDetails(user, newData User) *errors.Error {
 
 	if newData.Password != "" {
 		user.Password = newData.Password
+		user.PasswordExpiration = newData.PasswordExpiration
+		user.PasswordHistory = newData.PasswordHistory
 	}
+	user.PasswordChangeRequired = newData.PasswordChangeRequired
 	if newData.RoleID != "" {
 		user.RoleID = newData.RoleID
 	}
 	if _, err = conn.Update(table, user.UserName, user); err != nil {
 		return err
 	}
-	return nil
+	// the API gateways must not accept the previous password of the user from their caches
+	return common.InvalidateAuthCache()
 }
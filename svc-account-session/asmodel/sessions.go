@@ -19,22 +19,44 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-persistence-manager/persistencemgr"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 )
 
 var sessionStore = common.InMemory
 
+const (
+	userSessionIndex = "UserSessionIndex"
+	roleSessionIndex = "RoleSessionIndex"
+)
+
+// kinds of the implicit sessions, which are created by the service
+// for the requests that doesn't carry a session token
+const (
+	// BasicAuthSession is the session reused by the basic auth requests of a user
+	BasicAuthSession = "BasicAuthSession"
+	// ClientCertificateSession is the session reused by the requests authenticated with a client certificate
+	ClientCertificateSession = "ClientCertificateSession"
+)
+
+// sessionIndex holds the tokens of all the sessions of a user or a role
+type sessionIndex struct {
+	Tokens []string
+}
+
 // Session will hold the data assosiated with the session
 type Session struct {
-	ID           string
-	Token        string
-	UserName     string
-	RoleID       string
-	Privileges   map[string]bool
-	Origin       string
-	CreatedTime  time.Time
-	LastUsedTime time.Time
+	ID                     string
+	Token                  string
+	UserName               string
+	RoleID                 string
+	Privileges             map[string]bool
+	Origin                 string
+	CreatedTime            time.Time
+	LastUsedTime           time.Time
+	PasswordChangeRequired bool
+	PasswordExpiration     time.Time
 }
 
 //CreateSession will hold input request for creating a session
@@ -52,7 +74,75 @@ func (s *Session) Persist() *errors.Error {
 	if err = connPool.Create("session", s.Token, s); err != nil {
 		return errors.PackError(err.ErrNo(), "error while trying to create new session: ", err.Error())
 	}
-	return nil
+	return s.index(connPool)
+}
+
+// PersistWithinLimit will create a session in the DB once admit made room for it among the active sessions
+// of the user. The sessions of the user are counted and the session is added to the user session index in a
+// single transaction, so that the concurrent creations by all the instances of the service can't exceed the limit.
+// admit returns the sessions to delete for the new one, or the error rejecting it. It is called again when the
+// sessions of the user change meanwhile. The deleted sessions are returned.
+func (s *Session) PersistWithinLimit(admit func(sessions []Session) ([]Session, *errors.Error)) ([]Session, *errors.Error) {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	// the session is stored before it is indexed, so that the concurrent creations always find the indexed sessions
+	if err = connPool.Create("session", s.Token, s); err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to create new session: ", err.Error())
+	}
+	var evicted []Session
+	err = connPool.Modify(userSessionIndex, s.UserName, func(data string) (interface{}, error) {
+		var index sessionIndex
+		if data != "" {
+			if jerr := json.Unmarshal([]byte(data), &index); jerr != nil {
+				return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal session index: ", jerr)
+			}
+		}
+		var sessions []Session
+		for _, token := range index.Tokens {
+			session, err := GetSession(token)
+			if err != nil {
+				if err.ErrNo() == errors.DBKeyNotFound {
+					continue
+				}
+				return nil, err
+			}
+			sessions = append(sessions, session)
+		}
+		sessionsToEvict, err := admit(sessions)
+		if err != nil {
+			return nil, err
+		}
+		evicted = sessionsToEvict
+		tokensToEvict := make(map[string]bool, len(evicted))
+		for _, session := range evicted {
+			tokensToEvict[session.Token] = true
+		}
+		index.Tokens = []string{s.Token}
+		for _, session := range sessions {
+			if !tokensToEvict[session.Token] {
+				index.Tokens = append(index.Tokens, session.Token)
+			}
+		}
+		return index, nil
+	})
+	if err != nil {
+		if derr := connPool.Delete("session", s.Token); derr != nil {
+			return nil, errors.PackError(derr.ErrNo(), "error while trying to delete the session which is not admitted: ", derr.Error())
+		}
+		return nil, err
+	}
+	if err = addToSessionIndex(connPool, roleSessionIndex, s.RoleID, s.Token); err != nil {
+		return nil, err
+	}
+	for _, session := range evicted {
+		// the session may have been deleted meanwhile by another request
+		if err = session.Delete(); err != nil && err.ErrNo() != errors.DBKeyNotFound {
+			return nil, errors.PackError(err.ErrNo(), "error while trying to evict session ", session.ID, ": ", err.Error())
+		}
+	}
+	return evicted, nil
 }
 
 // Update will update a session in the DB
@@ -67,6 +157,46 @@ func (s *Session) Update() *errors.Error {
 	return nil
 }
 
+// UpdateLastUsedTime will update only the last used time of the session in the DB,
+// so that the use of a session never restores the other fields which are changed meanwhile
+func (s *Session) UpdateLastUsedTime() *errors.Error {
+	return modifySession(s.Token, func(session *Session) {
+		session.LastUsedTime = s.LastUsedTime
+	})
+}
+
+// UpdatePasswordState will update only the password state of the user kept in the session,
+// so that the concurrent updates of the other fields of the session are not lost
+func (s *Session) UpdatePasswordState(passwordChangeRequired bool, passwordExpiration time.Time) *errors.Error {
+	return modifySession(s.Token, func(session *Session) {
+		session.PasswordChangeRequired = passwordChangeRequired
+		session.PasswordExpiration = passwordExpiration
+	})
+}
+
+// modifySession will atomically update the fields of the stored session which are changed by modify
+func modifySession(token string, modify func(*Session)) *errors.Error {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	err = connPool.Modify("session", token, func(data string) (interface{}, error) {
+		if data == "" {
+			return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key ", token, " found")
+		}
+		var session Session
+		if jerr := json.Unmarshal([]byte(data), &session); jerr != nil {
+			return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal session data: ", jerr)
+		}
+		modify(&session)
+		return session, nil
+	})
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to update session: ", err.Error())
+	}
+	return nil
+}
+
 // GetSession will get the session details from db if available
 func GetSession(token string) (Session, *errors.Error) {
 	var session Session
@@ -93,9 +223,175 @@ func (s *Session) Delete() *errors.Error {
 	if err = connPool.Delete("session", s.Token); err != nil {
 		return errors.PackError(err.ErrNo(), "error while trying to delete session: ", err.Error())
 	}
+	if err = s.unindex(connPool); err != nil {
+		return err
+	}
+	// the API gateways must not serve the requests of the cached credentials with the deleted session
+	return common.InvalidateAuthCache()
+}
+
+// UpdateRole will move the session to the given role with the given privileges
+// and keep the role index of the session in sync
+func (s *Session) UpdateRole(roleID string, privileges map[string]bool) *errors.Error {
+	var oldRoleID string
+	err := modifySession(s.Token, func(session *Session) {
+		oldRoleID = session.RoleID
+		session.RoleID = roleID
+		session.Privileges = privileges
+	})
+	if err != nil {
+		return err
+	}
+	s.RoleID = roleID
+	s.Privileges = privileges
+	// the API gateways authenticate the cached credentials again, with the privileges of the new role
+	if err = common.InvalidateAuthCache(); err != nil {
+		return err
+	}
+	if oldRoleID == roleID {
+		return nil
+	}
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = removeFromSessionIndex(connPool, roleSessionIndex, oldRoleID, s.Token); err != nil {
+		return err
+	}
+	return addToSessionIndex(connPool, roleSessionIndex, roleID, s.Token)
+}
+
+// index will add the session token to the user and role session indexes
+func (s *Session) index(connPool *persistencemgr.ConnPool) *errors.Error {
+	if err := addToSessionIndex(connPool, userSessionIndex, s.UserName, s.Token); err != nil {
+		return err
+	}
+	return addToSessionIndex(connPool, roleSessionIndex, s.RoleID, s.Token)
+}
+
+// unindex will remove the session token from the user and role session indexes
+func (s *Session) unindex(connPool *persistencemgr.ConnPool) *errors.Error {
+	if err := removeFromSessionIndex(connPool, userSessionIndex, s.UserName, s.Token); err != nil {
+		return err
+	}
+	return removeFromSessionIndex(connPool, roleSessionIndex, s.RoleID, s.Token)
+}
+
+func readSessionIndex(connPool *persistencemgr.ConnPool, table, key string) (sessionIndex, *errors.Error) {
+	var index sessionIndex
+	data, err := connPool.Read(table, key)
+	if err != nil {
+		if err.ErrNo() == errors.DBKeyNotFound {
+			return index, nil
+		}
+		return index, errors.PackError(err.ErrNo(), "error while trying to read session index: ", err.Error())
+	}
+	if jerr := json.Unmarshal([]byte(data), &index); jerr != nil {
+		return index, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal session index: ", jerr)
+	}
+	return index, nil
+}
+
+// addToSessionIndex will add the token to the session index, the index is updated atomically
+// as the sessions of a user or a role are created and deleted concurrently by all the instances of the service
+func addToSessionIndex(connPool *persistencemgr.ConnPool, table, key, token string) *errors.Error {
+	if key == "" {
+		return nil
+	}
+	return modifySessionIndex(connPool, table, key, func(tokens []string) []string {
+		for _, t := range tokens {
+			if t == token {
+				return tokens
+			}
+		}
+		return append(tokens, token)
+	})
+}
+
+// removeFromSessionIndex will remove the token from the session index, the index is deleted when it is empty
+func removeFromSessionIndex(connPool *persistencemgr.ConnPool, table, key, token string) *errors.Error {
+	if key == "" {
+		return nil
+	}
+	return modifySessionIndex(connPool, table, key, func(tokens []string) []string {
+		var remaining []string
+		for _, t := range tokens {
+			if t != token {
+				remaining = append(remaining, t)
+			}
+		}
+		return remaining
+	})
+}
+
+func modifySessionIndex(connPool *persistencemgr.ConnPool, table, key string, modify func([]string) []string) *errors.Error {
+	err := connPool.Modify(table, key, func(data string) (interface{}, error) {
+		var index sessionIndex
+		if data != "" {
+			if jerr := json.Unmarshal([]byte(data), &index); jerr != nil {
+				return nil, errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal session index: ", jerr)
+			}
+		}
+		index.Tokens = modify(index.Tokens)
+		if len(index.Tokens) == 0 {
+			return nil, nil
+		}
+		return index, nil
+	})
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to update session index: ", err.Error())
+	}
 	return nil
 }
 
+// IndexAllSessions will add all the sessions in the DB to the user and role session indexes,
+// so that the sessions created before the indexes existed are found by their user and role
+func IndexAllSessions() *errors.Error {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	tokens, err := connPool.GetAllDetails("session")
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get all session tokens: ", err.Error())
+	}
+	for _, token := range tokens {
+		session, err := GetSession(token)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return err
+		}
+		if err = session.index(connPool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSessionTokensByUserName will collect the tokens of all the sessions of the user
+func GetSessionTokensByUserName(userName string) ([]string, *errors.Error) {
+	return getIndexedSessionTokens(userSessionIndex, userName)
+}
+
+// GetSessionTokensByRoleID will collect the tokens of all the sessions which are bound to the role
+func GetSessionTokensByRoleID(roleID string) ([]string, *errors.Error) {
+	return getIndexedSessionTokens(roleSessionIndex, roleID)
+}
+
+func getIndexedSessionTokens(table, key string) ([]string, *errors.Error) {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return nil, errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	index, err := readSessionIndex(connPool, table, key)
+	if err != nil {
+		return nil, err
+	}
+	return index.Tokens, nil
+}
+
 // GetAllSessionKeys will collect all session keys available in the DB
 func GetAllSessionKeys() ([]string, *errors.Error) {
 	connPool, err := common.GetDBConnection(sessionStore)
@@ -108,3 +404,39 @@ func GetAllSessionKeys() ([]string, *errors.Error) {
 	}
 	return sessionIDs, nil
 }
+
+// GetImplicitSessionToken will get the token of the session which is reused by all the
+// requests of the user authenticated by the given kind of implicit session
+func GetImplicitSessionToken(kind, userName string) (string, *errors.Error) {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return "", errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	token, err := connPool.Read(kind, userName)
+	if err != nil {
+		return "", errors.PackError(err.ErrNo(), "error while trying to get the implicit session from DB: ", err.Error())
+	}
+	var sessionToken string
+	if jerr := json.Unmarshal([]byte(token), &sessionToken); jerr != nil {
+		return "", errors.PackError(errors.UndefinedErrorType, "error while trying to unmarshal implicit session: ", jerr)
+	}
+	return sessionToken, nil
+}
+
+// SetImplicitSessionToken will save the token of the session which is reused by all the
+// requests of the user authenticated by the given kind of implicit session
+func SetImplicitSessionToken(kind, userName, token string) *errors.Error {
+	connPool, err := common.GetDBConnection(sessionStore)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if _, err = connPool.Update(kind, userName, token); err != nil {
+		if err.ErrNo() != errors.DBKeyNotFound {
+			return errors.PackError(err.ErrNo(), "error while trying to update implicit session: ", err.Error())
+		}
+		if err = connPool.Create(kind, userName, token); err != nil {
+			return errors.PackError(err.ErrNo(), "error while trying to create implicit session: ", err.Error())
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -156,3 +157,121 @@ func TestUpdateNegativeTestCase(t *testing.T) {
 	err := invalidSession.Update()
 	assert.NotNil(t, err, "There should be an error")
 }
+
+func TestSessionIndex(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	sess := session
+	err := sess.Persist()
+	assert.Nil(t, err, "There should be no error")
+
+	tokens, err := GetSessionTokensByUserName(sess.UserName)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, []string{sess.Token}, tokens, "session should be indexed by username")
+	tokens, err = GetSessionTokensByRoleID(sess.RoleID)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, []string{sess.Token}, tokens, "session should be indexed by role")
+
+	err = sess.UpdateRole("newRole", map[string]bool{common.PrivilegeLogin: true})
+	assert.Nil(t, err, "There should be no error")
+	tokens, _ = GetSessionTokensByRoleID("someRole")
+	assert.Empty(t, tokens, "session should be removed from the old role index")
+	tokens, _ = GetSessionTokensByRoleID("newRole")
+	assert.Equal(t, []string{sess.Token}, tokens, "session should be indexed by the new role")
+
+	err = sess.Delete()
+	assert.Nil(t, err, "There should be no error")
+	tokens, _ = GetSessionTokensByUserName(sess.UserName)
+	assert.Empty(t, tokens, "session should be removed from the username index")
+	tokens, _ = GetSessionTokensByRoleID("newRole")
+	assert.Empty(t, tokens, "session should be removed from the role index")
+}
+
+func TestPersistWithinLimit(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	oldest := session
+	err := oldest.Persist()
+	assert.Nil(t, err, "There should be no error")
+
+	rejected := Session{ID: "rejectedID", Token: "rejectedToken", UserName: session.UserName, RoleID: session.RoleID}
+	_, err = rejected.PersistWithinLimit(func(sessions []Session) ([]Session, *errors.Error) {
+		return nil, errors.PackError(errors.SessionLimitExceeded, "error: limit reached")
+	})
+	assert.Equal(t, errors.SessionLimitExceeded, err.ErrNo(), "the session should be rejected")
+	_, err = GetSession(rejected.Token)
+	assert.NotNil(t, err, "rejected session should not be stored")
+
+	sess := Session{ID: "newID", Token: "newToken", UserName: session.UserName, RoleID: session.RoleID}
+	evicted, err := sess.PersistWithinLimit(func(sessions []Session) ([]Session, *errors.Error) {
+		return sessions, nil
+	})
+	assert.Nil(t, err, "There should be no error")
+	if assert.Len(t, evicted, 1, "the admitted evictions should be returned") {
+		assert.Equal(t, oldest.Token, evicted[0].Token, "the admitted evictions should be returned")
+	}
+	_, err = GetSession(oldest.Token)
+	assert.NotNil(t, err, "evicted session should be deleted")
+	tokens, _ := GetSessionTokensByUserName(session.UserName)
+	assert.Equal(t, []string{sess.Token}, tokens, "only the new session should be indexed by username")
+	tokens, _ = GetSessionTokensByRoleID(session.RoleID)
+	assert.Equal(t, []string{sess.Token}, tokens, "only the new session should be indexed by role")
+}
+
+func TestUpdateLastUsedTime(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	sess := session
+	err := sess.Persist()
+	assert.Nil(t, err, "There should be no error")
+
+	// the session read before its role is updated only writes back its last used time
+	stale, _ := GetSession(sess.Token)
+	err = sess.UpdateRole("newRole", map[string]bool{common.PrivilegeLogin: true})
+	assert.Nil(t, err, "There should be no error")
+	stale.LastUsedTime = time.Now()
+	err = stale.UpdateLastUsedTime()
+	assert.Nil(t, err, "There should be no error")
+	got, _ := GetSession(sess.Token)
+	assert.Equal(t, "newRole", got.RoleID, "the updated role of the session should be kept")
+	assert.True(t, got.LastUsedTime.Equal(stale.LastUsedTime), "the last used time should be updated")
+
+	// a deleted session is not restored by its use
+	err = sess.Delete()
+	assert.Nil(t, err, "There should be no error")
+	err = stale.UpdateLastUsedTime()
+	assert.NotNil(t, err, "There should be an error")
+	_, err = GetSession(sess.Token)
+	assert.NotNil(t, err, "the deleted session should not be restored")
+}
+
+func TestIndexAllSessions(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	// sessions created before the indexes existed
+	mockData(common.InMemory, "session", session.Token, session)
+	err := IndexAllSessions()
+	assert.Nil(t, err, "There should be no error")
+	tokens, _ := GetSessionTokensByUserName(session.UserName)
+	assert.Equal(t, []string{session.Token}, tokens, "session should be indexed by username")
+	tokens, _ = GetSessionTokensByRoleID(session.RoleID)
+	assert.Equal(t, []string{session.Token}, tokens, "session should be indexed by role")
+
+	// indexing again does not duplicate the tokens
+	err = IndexAllSessions()
+	assert.Nil(t, err, "There should be no error")
+	tokens, _ = GetSessionTokensByUserName(session.UserName)
+	assert.Equal(t, []string{session.Token}, tokens, "session should be indexed once")
+}
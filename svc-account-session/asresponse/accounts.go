@@ -22,12 +22,14 @@ import (
 // Account struct is used to ommit password for display purposes
 type Account struct {
 	response.Response
-	UserName     string   `json:"UserName"`
-	RoleID       string   `json:"RoleId"`
-	AccountTypes []string `json:"AccountTypes"`
-	Password     *string  `json:"Password"`
-	Links        Links    `json:"Links"`
-	OEM          *OEM     `json:"Oem,omitempty"`
+	UserName               string   `json:"UserName"`
+	RoleID                 string   `json:"RoleId"`
+	AccountTypes           []string `json:"AccountTypes"`
+	Password               *string  `json:"Password"`
+	PasswordChangeRequired bool     `json:"PasswordChangeRequired"`
+	PasswordExpiration     string   `json:"PasswordExpiration,omitempty"`
+	Links                  Links    `json:"Links"`
+	OEM                    *OEM     `json:"Oem,omitempty"`
 }
 
 //OEM struct definition
@@ -47,15 +49,28 @@ type Role struct {
 //AccountService struct definition
 type AccountService struct {
 	response.Response
-	Status                          Status   `json:"Status"`
-	ServiceEnabled                  bool     `json:"ServiceEnabled"`
-	AuthFailureLoggingThreshold     int      `json:"AuthFailureLoggingThreshold"`
-	MinPasswordLength               int      `json:"MinPasswordLength"`
-	AccountLockoutThreshold         int      `json:"AccountLockoutThreshold"`
-	AccountLockoutDuration          int      `json:"AccountLockoutDuration"`
-	AccountLockoutCounterResetAfter int      `json:"AccountLockoutCounterResetAfter"`
-	Accounts                        Accounts `json:"Accounts"`
-	Roles                           Accounts `json:"Roles"`
+	Status                          Status          `json:"Status"`
+	ServiceEnabled                  bool            `json:"ServiceEnabled"`
+	AuthFailureLoggingThreshold     int             `json:"AuthFailureLoggingThreshold"`
+	MinPasswordLength               int             `json:"MinPasswordLength"`
+	AccountLockoutThreshold         int             `json:"AccountLockoutThreshold"`
+	AccountLockoutDuration          int             `json:"AccountLockoutDuration"`
+	AccountLockoutCounterResetAfter int             `json:"AccountLockoutCounterResetAfter"`
+	Accounts                        Accounts        `json:"Accounts"`
+	Roles                           Accounts        `json:"Roles"`
+	MultiFactorAuth                 MultiFactorAuth `json:"MultiFactorAuth"`
+}
+
+//MultiFactorAuth struct definition
+type MultiFactorAuth struct {
+	ClientCertificate ClientCertificate `json:"ClientCertificate"`
+}
+
+//ClientCertificate struct definition
+type ClientCertificate struct {
+	Enabled                         bool   `json:"Enabled"`
+	RespondToUnauthenticatedClients bool   `json:"RespondToUnauthenticatedClients"`
+	CertificateMappingAttribute     string `json:"CertificateMappingAttribute"`
 }
 
 //Accounts struct definition
@@ -44,12 +44,18 @@ func Auth(req *authproto.AuthRequest) (int32, string) {
 		return err.GetAuthStatusCodeAndMessage()
 	}
 	session.LastUsedTime = time.Now()
-	// Update Session
-	if err = session.Update(); err != nil {
+	// Update only the last used time, so that the privileges refreshed meanwhile are kept
+	if err = session.UpdateLastUsedTime(); err != nil {
 		log.Printf("error while trying to update session: %v", err.Error())
 		return err.GetAuthStatusCodeAndMessage()
 	}
 
+	// a session of a user who must change the password is not allowed to access any other resource
+	if err = CheckPasswordChangeRequired(session); err != nil {
+		log.Println(err.Error())
+		return err.GetAuthStatusCodeAndMessage()
+	}
+
 	// if the service has all the privileges then return success
 	// if any of the privilege isn't assigned to service then return failure
 	for _, privilege := range req.Privileges {
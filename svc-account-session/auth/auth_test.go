@@ -62,6 +62,17 @@ func TestAuth(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error: %v", err)
 	}
+	restrictedSession := asmodel.Session{
+		Token:                  "restrictedToken",
+		ID:                     "restrictedID",
+		Privileges:             privilegesMap,
+		CreatedTime:            currentTime,
+		LastUsedTime:           currentTime,
+		PasswordChangeRequired: true,
+	}
+	if err := restrictedSession.Persist(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
 	// positive test case privilege
 	privileges := []string{common.PrivilegeConfigureUsers}
 
@@ -128,6 +139,18 @@ func TestAuth(t *testing.T) {
 			want:  http.StatusForbidden,
 			want1: response.InsufficientPrivilege,
 		},
+		{
+			name: "Password change required",
+			args: args{
+				req: &authproto.AuthRequest{
+					SessionToken:  "restrictedToken",
+					Privileges:    privileges,
+					Oemprivileges: oemPrivileges,
+				},
+			},
+			want:  http.StatusForbidden,
+			want1: response.PasswordChangeRequired,
+		},
 		{
 			name: "without privileges",
 			args: args{
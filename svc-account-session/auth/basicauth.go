@@ -0,0 +1,42 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+// BasicAuth will verify the basic auth credentials and respond with the token of a session
+// of the user. The same session is reused by all the basic auth requests of the user, so
+// the requests doesn't create and delete a session each time.
+func BasicAuth(req *authproto.BasicAuthRequest) (int32, string, string) {
+	user, err := CheckSessionCreationCredentials(req.UserName, req.Password)
+	if err != nil {
+		log.Printf("error while authorizing basic auth credentials: %v", err.Error())
+		if err.ErrNo() == errors.DBConnFailed {
+			return http.StatusServiceUnavailable, response.CouldNotEstablishConnection, ""
+		}
+		return http.StatusUnauthorized, response.NoValidSession, ""
+	}
+
+	return implicitSession(user, asmodel.BasicAuthSession)
+}
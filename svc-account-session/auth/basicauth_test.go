@@ -0,0 +1,75 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+func TestBasicAuth(t *testing.T) {
+	Lock.Lock()
+	common.SetUpMockConfig()
+	Lock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	role := asmodel.Role{
+		ID:                 common.RoleMonitor,
+		AssignedPrivileges: []string{common.PrivilegeLogin},
+	}
+	if err := role.Create(); err != nil {
+		t.Fatalf("error while creating mock role: %v", err)
+	}
+	if err := createMockUser("testUser1", common.RoleMonitor); err != nil {
+		t.Fatalf("error while creating mock user: %v", err)
+	}
+
+	statusCode, statusMessage, token := BasicAuth(&authproto.BasicAuthRequest{UserName: "testUser1", Password: "P@$$w0rd"})
+	if statusCode != http.StatusOK || statusMessage != response.Success || token == "" {
+		t.Fatalf("BasicAuth() = %v, %v, %v, want a session token", statusCode, statusMessage, token)
+	}
+	_, _, reusedToken := BasicAuth(&authproto.BasicAuthRequest{UserName: "testUser1", Password: "P@$$w0rd"})
+	if reusedToken != token {
+		t.Errorf("BasicAuth() = %v, want the session %v to be reused", reusedToken, token)
+	}
+	tokens, _ := asmodel.GetSessionTokensByUserName("testUser1")
+	if len(tokens) != 1 {
+		t.Errorf("BasicAuth() created %v sessions, want 1", len(tokens))
+	}
+
+	statusCode, statusMessage, token = BasicAuth(&authproto.BasicAuthRequest{UserName: "testUser1", Password: "wrongPassword"})
+	if statusCode != http.StatusUnauthorized || statusMessage != response.NoValidSession || token != "" {
+		t.Errorf("BasicAuth() = %v, %v, %v, want unauthorized", statusCode, statusMessage, token)
+	}
+
+	// the basic auth requests of a user whose password expired are only allowed to change the password
+	user, _ := asmodel.GetUserDetails("testUser1")
+	user.PasswordExpiration = time.Now().Add(-time.Minute)
+	if err := asmodel.UpdateUserDetails(user, user); err != nil {
+		t.Fatalf("error while updating mock user: %v", err)
+	}
+	_, _, token = BasicAuth(&authproto.BasicAuthRequest{UserName: "testUser1", Password: "P@$$w0rd"})
+	statusCode, statusMessage = Auth(&authproto.AuthRequest{SessionToken: token, Privileges: []string{common.PrivilegeLogin}})
+	if statusCode != http.StatusForbidden || statusMessage != response.PasswordChangeRequired {
+		t.Errorf("Auth() = %v, %v, want the expired password to be changed", statusCode, statusMessage)
+	}
+}
@@ -0,0 +1,196 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+var (
+	// oidSubjectAltName is the object identifier of the subject alternative name extension
+	oidSubjectAltName = asn1.ObjectIdentifier{2, 5, 29, 17}
+	// oidUserPrincipalName is the object identifier of the user principal name otherName
+	oidUserPrincipalName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+)
+
+// ClientCertificateAuth will verify the client certificate presented to the API gateway against the
+// client CA and its CRL, and map it to an account as per the configured CertificateMappingAttribute. It responds with
+// the token of a session of the account, which is reused by all the requests made with a certificate
+// mapped to the same account.
+func ClientCertificateAuth(req *authproto.ClientCertificateRequest) (int32, string, string) {
+	if !config.Data.AuthConf.ClientCertificate.Enabled {
+		log.Println("error: client certificate authentication is not enabled")
+		return http.StatusUnauthorized, response.NoValidSession, ""
+	}
+	cert, err := verifyClientCertificate(req.Certificate, req.Intermediates)
+	if err != nil {
+		log.Printf("error while verifying client certificate: %v", err)
+		return http.StatusUnauthorized, response.NoValidSession, ""
+	}
+	userName, err := mapClientCertificate(cert, config.Data.AuthConf.ClientCertificate.CertificateMappingAttribute)
+	if err != nil {
+		log.Printf("error while mapping client certificate to an account: %v", err)
+		return http.StatusUnauthorized, response.NoValidSession, ""
+	}
+	user, gerr := asmodel.GetUserDetails(userName)
+	if gerr != nil {
+		log.Printf("error while trying to get the account mapped to the client certificate: %v", gerr.Error())
+		if gerr.ErrNo() == errors.DBConnFailed {
+			return http.StatusServiceUnavailable, response.CouldNotEstablishConnection, ""
+		}
+		return http.StatusUnauthorized, response.NoValidSession, ""
+	}
+
+	return implicitSession(&user, asmodel.ClientCertificateSession)
+}
+
+// verifyClientCertificate will parse the DER encoded client certificate and verify
+// it is issued by the client CA for client authentication and it is not revoked
+func verifyClientCertificate(certificate []byte, intermediates [][]byte) (*x509.Certificate, error) {
+	cert, err := x509.ParseCertificate(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(config.Data.AuthConf.ClientCertificate.CACertificate) {
+		return nil, fmt.Errorf("failed to load client CA certificate")
+	}
+	intermediatePool := x509.NewCertPool()
+	for _, der := range intermediates {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse intermediate certificate: %v", err)
+		}
+		intermediatePool.AddCert(intermediate)
+	}
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediatePool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the client CA presented as the client certificate is trusted as it is, no CRL revokes a root
+	if path := config.Data.AuthConf.ClientCertificate.CertificateRevocationListPath; path != "" && len(chains[0]) > 1 {
+		if err = checkRevocation(cert, chains[0][1], path); err != nil {
+			return nil, err
+		}
+	}
+	return cert, nil
+}
+
+// checkRevocation will check the client certificate against the CRL of its issuer, read from the CRL file
+// which holds the PEM or DER encoded CRLs of the client CAs. The file is read each time, so that the
+// renewed CRLs apply without a restart. The certificate is rejected when its issuer has no valid CRL.
+func checkRevocation(cert, issuer *x509.Certificate, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate revocation list: %v", err)
+	}
+	var ders [][]byte
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "X509 CRL" {
+			ders = append(ders, block.Bytes)
+		}
+	}
+	if len(ders) == 0 {
+		ders = [][]byte{data}
+	}
+	for _, der := range ders {
+		crl, err := x509.ParseRevocationList(der)
+		if err != nil || crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+			return fmt.Errorf("certificate revocation list of %v expired at %v", issuer.Subject, crl.NextUpdate)
+		}
+		for _, revoked := range crl.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("client certificate %v of %v is revoked", cert.SerialNumber, cert.Subject)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("no certificate revocation list of %v found", issuer.Subject)
+}
+
+// mapClientCertificate will get the user name of the account mapped to the client certificate.
+// CommonName maps the common name of the subject as it is, UserPrincipalName maps the
+// part of the user principal name before the @ as it carries the domain of the user.
+func mapClientCertificate(cert *x509.Certificate, mappingAttribute string) (string, error) {
+	switch mappingAttribute {
+	case config.CertificateMappingCommonName:
+		if cert.Subject.CommonName == "" {
+			return "", fmt.Errorf("no common name found in the client certificate")
+		}
+		return cert.Subject.CommonName, nil
+	case config.CertificateMappingUserPrincipalName:
+		upn, err := userPrincipalName(cert)
+		if err != nil {
+			return "", err
+		}
+		return strings.SplitN(upn, "@", 2)[0], nil
+	}
+	return "", fmt.Errorf("certificate mapping attribute %v is not supported", mappingAttribute)
+}
+
+// userPrincipalName will extract the user principal name from the otherName entries
+// of the subject alternative names of the certificate
+func userPrincipalName(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		var names []asn1.RawValue
+		if _, err := asn1.Unmarshal(ext.Value, &names); err != nil {
+			return "", fmt.Errorf("failed to parse subject alternative names: %v", err)
+		}
+		for _, name := range names {
+			// otherName is the context specific tag 0 of GeneralName
+			if name.Class != asn1.ClassContextSpecific || name.Tag != 0 {
+				continue
+			}
+			// the value of otherName is explicitly tagged with 0, which wraps the UTF8String of the UPN
+			var otherName struct {
+				TypeID asn1.ObjectIdentifier
+				Value  asn1.RawValue
+			}
+			if _, err := asn1.UnmarshalWithParams(name.FullBytes, &otherName, "tag:0"); err != nil || !otherName.TypeID.Equal(oidUserPrincipalName) {
+				continue
+			}
+			var upn string
+			if _, err := asn1.UnmarshalWithParams(otherName.Value.Bytes, &upn, "utf8"); err == nil {
+				return upn, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no user principal name found in the client certificate")
+}
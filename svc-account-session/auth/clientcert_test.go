@@ -0,0 +1,226 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+)
+
+type mockCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func createMockCA(t *testing.T) mockCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mock CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error while creating CA certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return mockCA{cert: cert, key: key}
+}
+
+func (ca mockCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca mockCA) issue(t *testing.T, commonName, upn string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error while generating client key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if upn != "" {
+		value, _ := asn1.MarshalWithParams(upn, "utf8")
+		otherName, _ := asn1.MarshalWithParams(struct {
+			TypeID asn1.ObjectIdentifier
+			Value  asn1.RawValue
+		}{oidUserPrincipalName, asn1.RawValue{Class: asn1.ClassContextSpecific, IsCompound: true, Bytes: value}}, "tag:0")
+		san, _ := asn1.Marshal([]asn1.RawValue{{FullBytes: otherName}})
+		template.ExtraExtensions = []pkix.Extension{{Id: oidSubjectAltName, Value: san}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("error while creating client certificate: %v", err)
+	}
+	return der
+}
+
+func TestClientCertificateAuth(t *testing.T) {
+	Lock.Lock()
+	common.SetUpMockConfig()
+	Lock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+		config.Data.AuthConf.ClientCertificate.Enabled = false
+		config.Data.AuthConf.ClientCertificate.CertificateMappingAttribute = config.CertificateMappingCommonName
+		config.Data.AuthConf.ClientCertificate.CACertificate = nil
+	}()
+	role := asmodel.Role{
+		ID:                 common.RoleMonitor,
+		AssignedPrivileges: []string{common.PrivilegeLogin},
+	}
+	if err := role.Create(); err != nil {
+		t.Fatalf("error while creating mock role: %v", err)
+	}
+	if err := createMockUser("testUser1", common.RoleMonitor); err != nil {
+		t.Fatalf("error while creating mock user: %v", err)
+	}
+	ca := createMockCA(t)
+	config.Data.AuthConf.ClientCertificate.CACertificate = ca.pem()
+	// the client certificates are not verified against the CA of the services
+	config.Data.KeyCertConf = &config.KeyCertConf{RootCACertificate: createMockCA(t).pem()}
+	defer func() { config.Data.KeyCertConf = nil }()
+	userCert := ca.issue(t, "testUser1", "")
+	upnCert := ca.issue(t, "someone", "testUser1@odim.local")
+	unknownCert := ca.issue(t, "testUser2", "")
+	untrustedCert := createMockCA(t).issue(t, "testUser1", "")
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		mapping     string
+		certificate []byte
+		wantStatus  int32
+		wantMessage string
+	}{
+		{"disabled", false, config.CertificateMappingCommonName, userCert, http.StatusUnauthorized, response.NoValidSession},
+		{"common name mapped", true, config.CertificateMappingCommonName, userCert, http.StatusOK, response.Success},
+		{"user principal name mapped", true, config.CertificateMappingUserPrincipalName, upnCert, http.StatusOK, response.Success},
+		{"no user principal name", true, config.CertificateMappingUserPrincipalName, userCert, http.StatusUnauthorized, response.NoValidSession},
+		{"unknown account", true, config.CertificateMappingCommonName, unknownCert, http.StatusUnauthorized, response.NoValidSession},
+		{"untrusted issuer", true, config.CertificateMappingCommonName, untrustedCert, http.StatusUnauthorized, response.NoValidSession},
+	}
+	var sessionToken string
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.AuthConf.ClientCertificate.Enabled = tt.enabled
+			config.Data.AuthConf.ClientCertificate.CertificateMappingAttribute = tt.mapping
+			statusCode, statusMessage, token := ClientCertificateAuth(&authproto.ClientCertificateRequest{Certificate: tt.certificate})
+			if statusCode != tt.wantStatus || statusMessage != tt.wantMessage {
+				t.Fatalf("ClientCertificateAuth() = %v, %v, want %v, %v", statusCode, statusMessage, tt.wantStatus, tt.wantMessage)
+			}
+			if statusCode != http.StatusOK {
+				return
+			}
+			if sessionToken != "" && token != sessionToken {
+				t.Errorf("ClientCertificateAuth() = %v, want the session %v to be reused", token, sessionToken)
+			}
+			sessionToken = token
+		})
+	}
+}
+
+func (ca mockCA) crl(t *testing.T, nextUpdate time.Time, revoked ...[]byte) []byte {
+	var entries []x509.RevocationListEntry
+	for _, der := range revoked {
+		cert, _ := x509.ParseCertificate(der)
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: cert.SerialNumber, RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("error while creating CRL: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func TestVerifyClientCertificateRevocation(t *testing.T) {
+	common.SetUpMockConfig()
+	dir, err := ioutil.TempDir("", "crl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		os.RemoveAll(dir)
+		config.Data.AuthConf.ClientCertificate.CACertificate = nil
+		config.Data.AuthConf.ClientCertificate.CertificateRevocationListPath = ""
+	}()
+	ca := createMockCA(t)
+	otherCA := createMockCA(t)
+	config.Data.AuthConf.ClientCertificate.CACertificate = ca.pem()
+	validCert := ca.issue(t, "testUser1", "")
+	revokedCert := ca.issue(t, "testUser2", "")
+	path := filepath.Join(dir, "crl.pem")
+	config.Data.AuthConf.ClientCertificate.CertificateRevocationListPath = path
+
+	tests := []struct {
+		name        string
+		crl         []byte
+		certificate []byte
+		wantErr     bool
+	}{
+		{"no CRL file", nil, validCert, true},
+		{"certificate not revoked", append(otherCA.crl(t, time.Now().Add(time.Hour)), ca.crl(t, time.Now().Add(time.Hour), revokedCert)...), validCert, false},
+		{"certificate revoked", ca.crl(t, time.Now().Add(time.Hour), revokedCert), revokedCert, true},
+		{"expired CRL", ca.crl(t, time.Now().Add(-time.Minute)), validCert, true},
+		{"CRL of another CA", otherCA.crl(t, time.Now().Add(time.Hour)), validCert, true},
+		{"CA certificate as client certificate", ca.crl(t, time.Now().Add(time.Hour)), ca.cert.Raw, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.crl != nil {
+				if err := ioutil.WriteFile(path, tt.crl, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := verifyClientCertificate(tt.certificate, nil); (err != nil) != tt.wantErr {
+				t.Errorf("verifyClientCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package auth ...
+package auth
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	uuid "github.com/satori/go.uuid"
+)
+
+// implicitSession will respond with the token of the session reused by all the requests of the
+// authenticated user of the given kind, a new session is created when there is no active one.
+func implicitSession(user *asmodel.User, kind string) (int32, string, string) {
+	Lock.Lock()
+	defer Lock.Unlock()
+	if token, err := asmodel.GetImplicitSessionToken(kind, user.UserName); err == nil {
+		session, err := asmodel.GetSession(token)
+		if err == nil && time.Since(session.LastUsedTime).Minutes() <= config.Data.AuthConf.SessionTimeOutInMins {
+			// the reused session follows the password state of the user just authenticated
+			if session.PasswordChangeRequired != user.PasswordChangeRequired || !session.PasswordExpiration.Equal(user.PasswordExpiration) {
+				if err = session.UpdatePasswordState(user.PasswordChangeRequired, user.PasswordExpiration); err != nil {
+					log.Printf("error while trying to update %v: %v", kind, err.Error())
+					return http.StatusInternalServerError, response.InternalError, ""
+				}
+			}
+			return http.StatusOK, response.Success, token
+		}
+	}
+
+	role, err := asmodel.GetRoleDetailsByID(user.RoleID)
+	if err != nil {
+		log.Printf("error while trying to get role privileges for %v: %v", kind, err.Error())
+		return http.StatusInternalServerError, response.InternalError, ""
+	}
+	privileges := GetPrivileges(role)
+	if !privileges[common.PrivilegeLogin] {
+		log.Printf("error: user doesn't have required privilege to create %v", kind)
+		return http.StatusForbidden, response.InsufficientPrivilege, ""
+	}
+
+	currentTime := time.Now()
+	session := asmodel.Session{
+		ID:                     uuid.NewV4().String(),
+		Token:                  uuid.NewV4().String(),
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		Privileges:             privileges,
+		CreatedTime:            currentTime,
+		LastUsedTime:           currentTime,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     user.PasswordExpiration,
+	}
+	if err = PersistSession(&session); err != nil {
+		log.Printf("error while trying to insert %v details: %v", kind, err.Error())
+		if err.ErrNo() == errors.SessionLimitExceeded {
+			return http.StatusServiceUnavailable, response.SessionLimitExceeded, ""
+		}
+		statusCode, statusMessage := err.GetAuthStatusCodeAndMessage()
+		return statusCode, statusMessage, ""
+	}
+	if err = asmodel.SetImplicitSessionToken(kind, user.UserName, session.Token); err != nil {
+		log.Printf("error while trying to save %v: %v", kind, err.Error())
+	}
+	return http.StatusOK, response.Success, session.Token
+}
@@ -18,9 +18,11 @@ package auth
 import (
 	"encoding/base64"
 	"log"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
@@ -100,3 +102,205 @@ func expiredSessionCleanUp() {
 		sessionTokens = nil
 	}
 }
+
+// GetPrivileges will build the session privileges from the privileges assigned to the role
+func GetPrivileges(role asmodel.Role) map[string]bool {
+	privileges := make(map[string]bool)
+	for _, privilege := range role.AssignedPrivileges {
+		privileges[privilege] = true
+	}
+	return privileges
+}
+
+// RefreshRoleSessions will recompute the privileges of all the active sessions bound to the role,
+// sessions which no longer have the Login privilege are deleted
+func RefreshRoleSessions(role asmodel.Role) *errors.Error {
+	tokens, err := asmodel.GetSessionTokensByRoleID(role.ID)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get sessions of role ", role.ID, ": ", err.Error())
+	}
+	return rebindSessions(tokens, role)
+}
+
+// RefreshUserSessions will bind all the active sessions of the user to the given role
+// and recompute their privileges, sessions which no longer have the Login privilege are deleted
+func RefreshUserSessions(userName, roleID string) *errors.Error {
+	role, err := asmodel.GetRoleDetailsByID(roleID)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get role privileges of ", roleID, ": ", err.Error())
+	}
+	tokens, err := asmodel.GetSessionTokensByUserName(userName)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get sessions of user ", userName, ": ", err.Error())
+	}
+	return rebindSessions(tokens, role)
+}
+
+// DeleteUserSessions will delete all the active sessions of the user
+func DeleteUserSessions(userName string) *errors.Error {
+	tokens, err := asmodel.GetSessionTokensByUserName(userName)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get sessions of user ", userName, ": ", err.Error())
+	}
+	return deleteSessions(tokens)
+}
+
+// DeleteRoleSessions will delete all the active sessions bound to the role
+func DeleteRoleSessions(roleID string) *errors.Error {
+	tokens, err := asmodel.GetSessionTokensByRoleID(roleID)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get sessions of role ", roleID, ": ", err.Error())
+	}
+	return deleteSessions(tokens)
+}
+
+// PasswordChangePending will check whether the user must change the password before being allowed
+// any other operation, either because the account is flagged or because the password has expired
+func PasswordChangePending(user *asmodel.User) bool {
+	if user.PasswordChangeRequired {
+		return true
+	}
+	return !user.PasswordExpiration.IsZero() && time.Now().After(user.PasswordExpiration)
+}
+
+// CheckPasswordChangeRequired will return an error of type PasswordChangeRequired
+// when the session is only allowed to change the password of its user, the password
+// expiration is checked on every use so that it expires in the active sessions too
+func CheckPasswordChangeRequired(session *asmodel.Session) *errors.Error {
+	if PasswordChangePending(&asmodel.User{PasswordChangeRequired: session.PasswordChangeRequired, PasswordExpiration: session.PasswordExpiration}) {
+		return errors.PackError(errors.PasswordChangeRequired, "error: password of user ", session.UserName, " must be changed before access is granted")
+	}
+	return nil
+}
+
+// UpdatePasswordState will copy the password state of the user to all the active sessions of the user,
+// a session of a user who must change the password is only allowed to change the password of its user
+func UpdatePasswordState(userName string, passwordChangeRequired bool, passwordExpiration time.Time) *errors.Error {
+	tokens, err := asmodel.GetSessionTokensByUserName(userName)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get sessions of user ", userName, ": ", err.Error())
+	}
+	for _, token := range tokens {
+		session := asmodel.Session{Token: token}
+		if err = session.UpdatePasswordState(passwordChangeRequired, passwordExpiration); err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return errors.PackError(err.ErrNo(), "error while trying to update session of user ", userName, ": ", err.Error())
+		}
+	}
+	return nil
+}
+
+// InitPasswordExpiration will set the password expiration of the accounts created before the passwords
+// expired, and of their active sessions, as if their passwords were set now
+func InitPasswordExpiration() *errors.Error {
+	days := config.Data.AuthConf.PasswordRules.PasswordExpirationDays
+	if days <= 0 {
+		return nil
+	}
+	users, err := asmodel.GetAllUsers()
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to get users: ", err.Error())
+	}
+	expiration := time.Now().AddDate(0, 0, days)
+	for _, user := range users {
+		if !user.PasswordExpiration.IsZero() {
+			continue
+		}
+		// the user may have been updated meanwhile, the stored user is kept in the sessions
+		stored, err := asmodel.InitPasswordExpiration(user.UserName, expiration)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return errors.PackError(err.ErrNo(), "error while trying to set password expiration of user ", user.UserName, ": ", err.Error())
+		}
+		if err = UpdatePasswordState(stored.UserName, stored.PasswordChangeRequired, stored.PasswordExpiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PersistSession will create the session, making room for it as per the configured session limit.
+// When the user already has MaxSessionsPerUser active sessions, the oldest sessions are deleted if the
+// SessionLimitAction is EvictOldest, else an error of type SessionLimitExceeded is returned.
+// The sessions are counted and the session is created atomically, so that the limit holds across
+// the concurrent session creations of all the instances of the service.
+func PersistSession(session *asmodel.Session) *errors.Error {
+	limit := config.Data.AuthConf.MaxSessionsPerUser
+	if limit <= 0 {
+		return session.Persist()
+	}
+	evicted, err := session.PersistWithinLimit(func(sessions []asmodel.Session) ([]asmodel.Session, *errors.Error) {
+		var expired, active []asmodel.Session
+		for _, s := range sessions {
+			// timed out sessions are yet to be cleaned up, they are not counted against the limit
+			if sessionExpired(s) {
+				expired = append(expired, s)
+				continue
+			}
+			active = append(active, s)
+		}
+		if len(active) < limit {
+			return expired, nil
+		}
+		if config.Data.AuthConf.SessionLimitAction == config.SessionLimitActionReject {
+			return nil, errors.PackError(errors.SessionLimitExceeded, "error: user ", session.UserName, " already has ", len(active), " active sessions")
+		}
+		sort.Slice(active, func(i, j int) bool {
+			return active[i].CreatedTime.Before(active[j].CreatedTime)
+		})
+		return append(expired, active[:len(active)-limit+1]...), nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, s := range evicted {
+		if !sessionExpired(s) {
+			log.Printf("session %v of user %v is evicted as the session limit is reached", s.ID, session.UserName)
+		}
+	}
+	return nil
+}
+
+func sessionExpired(session asmodel.Session) bool {
+	return time.Since(session.LastUsedTime).Minutes() > config.Data.AuthConf.SessionTimeOutInMins
+}
+
+func rebindSessions(tokens []string, role asmodel.Role) *errors.Error {
+	privileges := GetPrivileges(role)
+	if !privileges[common.PrivilegeLogin] {
+		return deleteSessions(tokens)
+	}
+	for _, token := range tokens {
+		session, err := asmodel.GetSession(token)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return err
+		}
+		if err = session.UpdateRole(role.ID, privileges); err != nil {
+			return errors.PackError(err.ErrNo(), "error while trying to update privileges of session ", session.ID, ": ", err.Error())
+		}
+	}
+	return nil
+}
+
+func deleteSessions(tokens []string) *errors.Error {
+	for _, token := range tokens {
+		session, err := asmodel.GetSession(token)
+		if err != nil {
+			if err.ErrNo() == errors.DBKeyNotFound {
+				continue
+			}
+			return err
+		}
+		if err = session.Delete(); err != nil {
+			return errors.PackError(err.ErrNo(), "error while trying to delete session ", session.ID, ": ", err.Error())
+		}
+	}
+	return nil
+}
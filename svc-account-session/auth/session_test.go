@@ -22,6 +22,7 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
 )
 
@@ -175,3 +176,252 @@ func TestCheckSessionTimeOut(t *testing.T) {
 		time.Sleep(4 * time.Second)
 	}
 }
+
+func createMockSession(t *testing.T, userName, roleID string) asmodel.Session {
+	sess := asmodel.Session{
+		ID:           userName + roleID,
+		Token:        "token" + userName + roleID,
+		UserName:     userName,
+		RoleID:       roleID,
+		Privileges:   map[string]bool{common.PrivilegeLogin: true, common.PrivilegeConfigureUsers: true},
+		CreatedTime:  time.Now(),
+		LastUsedTime: time.Now(),
+	}
+	if err := sess.Persist(); err != nil {
+		t.Fatalf("error while creating mock session: %v", err)
+	}
+	return sess
+}
+
+func TestRefreshRoleSessions(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	sess := createMockSession(t, "testUser1", "someRole")
+
+	role := asmodel.Role{
+		ID:                 "someRole",
+		AssignedPrivileges: []string{common.PrivilegeLogin},
+	}
+	if err := RefreshRoleSessions(role); err != nil {
+		t.Fatalf("RefreshRoleSessions() error = %v", err)
+	}
+	got, err := asmodel.GetSession(sess.Token)
+	if err != nil {
+		t.Fatalf("error while reading session: %v", err)
+	}
+	if !reflect.DeepEqual(got.Privileges, map[string]bool{common.PrivilegeLogin: true}) {
+		t.Errorf("RefreshRoleSessions() privileges = %v, want only Login", got.Privileges)
+	}
+
+	role.AssignedPrivileges = []string{common.PrivilegeConfigureSelf}
+	if err := RefreshRoleSessions(role); err != nil {
+		t.Fatalf("RefreshRoleSessions() error = %v", err)
+	}
+	if _, err := asmodel.GetSession(sess.Token); err == nil {
+		t.Errorf("RefreshRoleSessions() session without Login privilege should be deleted")
+	}
+}
+
+func TestRefreshUserSessions(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+		common.TruncateDB(common.OnDisk)
+	}()
+	role := asmodel.Role{
+		ID:                 "newRole",
+		AssignedPrivileges: []string{common.PrivilegeLogin, common.PrivilegeConfigureSelf},
+	}
+	if err := role.Create(); err != nil {
+		t.Fatalf("error while creating mock role: %v", err)
+	}
+	sess := createMockSession(t, "testUser1", common.RoleAdmin)
+
+	if err := RefreshUserSessions("testUser1", "newRole"); err != nil {
+		t.Fatalf("RefreshUserSessions() error = %v", err)
+	}
+	got, err := asmodel.GetSession(sess.Token)
+	if err != nil {
+		t.Fatalf("error while reading session: %v", err)
+	}
+	if got.RoleID != "newRole" || got.Privileges[common.PrivilegeConfigureUsers] {
+		t.Errorf("RefreshUserSessions() = %v, want session bound to newRole", got)
+	}
+	tokens, _ := asmodel.GetSessionTokensByRoleID(common.RoleAdmin)
+	if len(tokens) != 0 {
+		t.Errorf("RefreshUserSessions() session should be removed from the old role index")
+	}
+
+	if err := DeleteUserSessions("testUser1"); err != nil {
+		t.Fatalf("DeleteUserSessions() error = %v", err)
+	}
+	if _, err := asmodel.GetSession(sess.Token); err == nil {
+		t.Errorf("DeleteUserSessions() session should be deleted")
+	}
+}
+
+func TestUpdatePasswordState(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	sess := createMockSession(t, "testUser1", common.RoleAdmin)
+
+	if err := UpdatePasswordState("testUser1", true, time.Time{}); err != nil {
+		t.Fatalf("UpdatePasswordState() error = %v", err)
+	}
+	got, err := asmodel.GetSession(sess.Token)
+	if err != nil {
+		t.Fatalf("error while reading session: %v", err)
+	}
+	if err := CheckPasswordChangeRequired(&got); err == nil || err.ErrNo() != errors.PasswordChangeRequired {
+		t.Errorf("CheckPasswordChangeRequired() error = %v, want PasswordChangeRequired", err)
+	}
+
+	// the password expires while the session is active
+	if err := UpdatePasswordState("testUser1", false, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("UpdatePasswordState() error = %v", err)
+	}
+	got, _ = asmodel.GetSession(sess.Token)
+	if err := CheckPasswordChangeRequired(&got); err == nil || err.ErrNo() != errors.PasswordChangeRequired {
+		t.Errorf("CheckPasswordChangeRequired() error = %v, want PasswordChangeRequired for the expired password", err)
+	}
+
+	if err := UpdatePasswordState("testUser1", false, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("UpdatePasswordState() error = %v", err)
+	}
+	got, _ = asmodel.GetSession(sess.Token)
+	if err := CheckPasswordChangeRequired(&got); err != nil {
+		t.Errorf("CheckPasswordChangeRequired() error = %v, want session to be released", err)
+	}
+	if got.Privileges[common.PrivilegeConfigureUsers] != sess.Privileges[common.PrivilegeConfigureUsers] || got.UserName != sess.UserName {
+		t.Errorf("UpdatePasswordState() changed the other fields of the session: %v", got)
+	}
+}
+
+func TestInitPasswordExpiration(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 0
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	if err := createMockUser("testUser1", common.RoleAdmin); err != nil {
+		t.Fatalf("error while creating mock user: %v", err)
+	}
+	expiration := time.Now().Add(time.Hour).Round(time.Second)
+	if err := asmodel.CreateUser(asmodel.User{UserName: "testUser2", RoleID: common.RoleAdmin, PasswordExpiration: expiration}); err != nil {
+		t.Fatalf("error while creating mock user: %v", err)
+	}
+	sess := createMockSession(t, "testUser1", common.RoleAdmin)
+
+	// the passwords do not expire
+	if err := InitPasswordExpiration(); err != nil {
+		t.Fatalf("InitPasswordExpiration() error = %v", err)
+	}
+	if user, _ := asmodel.GetUserDetails("testUser1"); !user.PasswordExpiration.IsZero() {
+		t.Errorf("InitPasswordExpiration() set password expiration %v, want none", user.PasswordExpiration)
+	}
+
+	config.Data.AuthConf.PasswordRules.PasswordExpirationDays = 90
+	if err := InitPasswordExpiration(); err != nil {
+		t.Fatalf("InitPasswordExpiration() error = %v", err)
+	}
+	user, _ := asmodel.GetUserDetails("testUser1")
+	if user.PasswordExpiration.Before(time.Now().AddDate(0, 0, 89)) {
+		t.Errorf("InitPasswordExpiration() password expiration = %v, want 90 days from now", user.PasswordExpiration)
+	}
+	got, _ := asmodel.GetSession(sess.Token)
+	if !got.PasswordExpiration.Equal(user.PasswordExpiration) {
+		t.Errorf("InitPasswordExpiration() session password expiration = %v, want %v", got.PasswordExpiration, user.PasswordExpiration)
+	}
+	if user, _ := asmodel.GetUserDetails("testUser2"); !user.PasswordExpiration.Equal(expiration) {
+		t.Errorf("InitPasswordExpiration() password expiration = %v, want %v to be kept", user.PasswordExpiration, expiration)
+	}
+}
+
+func TestPasswordChangePending(t *testing.T) {
+	tests := []struct {
+		name string
+		user asmodel.User
+		want bool
+	}{
+		{name: "password without expiration", user: asmodel.User{}, want: false},
+		{name: "password not yet expired", user: asmodel.User{PasswordExpiration: time.Now().Add(time.Hour)}, want: false},
+		{name: "expired password", user: asmodel.User{PasswordExpiration: time.Now().Add(-time.Hour)}, want: true},
+		{name: "password change required", user: asmodel.User{PasswordChangeRequired: true}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PasswordChangePending(&tt.user); got != tt.want {
+				t.Errorf("PasswordChangePending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPersistSession(t *testing.T) {
+	common.SetUpMockConfig()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+		config.Data.AuthConf.MaxSessionsPerUser = 0
+		config.Data.AuthConf.SessionLimitAction = config.SessionLimitActionEvictOldest
+	}()
+	oldest := createMockSession(t, "testUser1", "role1")
+	latest := createMockSession(t, "testUser1", "role2")
+	newSession := func(roleID string) asmodel.Session {
+		sess := createMockSession(t, "testUser1", roleID)
+		if err := sess.Delete(); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return sess
+	}
+
+	withoutLimit := newSession("role3")
+	if err := PersistSession(&withoutLimit); err != nil {
+		t.Fatalf("PersistSession() without limit error = %v", err)
+	}
+	if err := withoutLimit.Delete(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	config.Data.AuthConf.MaxSessionsPerUser = 2
+	version, _ := common.AuthCacheVersion()
+	sess := newSession("role4")
+	if err := PersistSession(&sess); err != nil {
+		t.Fatalf("PersistSession() error = %v", err)
+	}
+	if _, err := asmodel.GetSession(oldest.Token); err == nil {
+		t.Errorf("PersistSession() didn't evict the oldest session")
+	}
+	for _, s := range []asmodel.Session{latest, sess} {
+		if _, err := asmodel.GetSession(s.Token); err != nil {
+			t.Errorf("PersistSession() evicted the session %v: %v", s.ID, err)
+		}
+	}
+	if got, _ := common.AuthCacheVersion(); got == version {
+		t.Errorf("PersistSession() didn't invalidate the auth cache of the API gateways for the evicted session")
+	}
+
+	config.Data.AuthConf.SessionLimitAction = config.SessionLimitActionReject
+	rejected := newSession("role5")
+	err := PersistSession(&rejected)
+	if err == nil || err.ErrNo() != errors.SessionLimitExceeded {
+		t.Errorf("PersistSession() error = %v, want SessionLimitExceeded", err)
+	}
+	if _, err := asmodel.GetSession(rejected.Token); err == nil {
+		t.Errorf("PersistSession() kept the rejected session")
+	}
+	if tokens, _ := asmodel.GetSessionTokensByUserName("testUser1"); len(tokens) != 2 {
+		t.Errorf("PersistSession() left %v sessions of the user, want 2", tokens)
+	}
+	other := createMockSession(t, "testUser2", "role1")
+	if err := other.Delete(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err = PersistSession(&other); err != nil {
+		t.Errorf("PersistSession() for user without sessions error = %v", err)
+	}
+}
@@ -3,7 +3,7 @@ module github.com/ODIM-Project/ODIM/svc-account-session
 go 1.13
 
 require (
-	github.com/ODIM-Project/ODIM/lib-persistence-manager v0.0.0-20201012075046-3c059402892a // indirect
+	github.com/ODIM-Project/ODIM/lib-persistence-manager v0.0.0-20201012075046-3c059402892a
 	github.com/ODIM-Project/ODIM/lib-utilities v0.0.0-20201012075046-3c059402892a
 	github.com/satori/go.uuid v1.2.0
 	github.com/stretchr/testify v1.5.1
@@ -24,6 +24,8 @@ import (
 	roleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/role"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 	"github.com/ODIM-Project/ODIM/svc-account-session/rpc"
 )
 
@@ -41,6 +43,15 @@ func main() {
 		log.Fatalf("error while trying to check DB connection health: %v", err)
 	}
 
+	// index the sessions created before the session indexes existed, so that they follow the updates of their user and role
+	if err := asmodel.IndexAllSessions(); err != nil {
+		log.Printf("error while trying to index the active sessions: %v", err)
+	}
+	// the accounts created before the passwords expired get the configured expiration from now
+	if err := auth.InitPasswordExpiration(); err != nil {
+		log.Printf("error while trying to set the password expiration of the accounts: %v", err)
+	}
+
 	if err := services.InitializeService(services.AccountSession); err != nil {
 		log.Fatalf("fatal: error while trying to initialize the service: %v", err)
 	}
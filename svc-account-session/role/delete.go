@@ -31,6 +31,9 @@ import (
 
 func doSessionAuthAndUpdate(resp *response.RPC, sessionToken string) (*asmodel.Session, error) {
 	sess, err := auth.CheckSessionTimeOut(sessionToken)
+	if err == nil {
+		err = auth.CheckPasswordChangeRequired(sess)
+	}
 	if err != nil {
 		errorMessage := "error while authorizing session token: " + err.Error()
 		resp.StatusCode, resp.StatusMessage = err.GetAuthStatusCodeAndMessage()
@@ -176,6 +179,12 @@ func Delete(req *roleproto.DeleteRoleRequest) *response.RPC {
 		log.Printf(errorMessage)
 		return &resp
 	}
+	if derr := auth.DeleteRoleSessions(role.ID); derr != nil {
+		errorMessage := "error while trying to delete sessions of role: " + derr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		log.Printf(errorMessage)
+		return &resp
+	}
 
 	resp.StatusCode = http.StatusNoContent
 	resp.StatusMessage = response.ResourceRemoved
@@ -25,6 +25,7 @@ import (
 	roleproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/role"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
+	"github.com/ODIM-Project/ODIM/svc-account-session/auth"
 )
 
 // Update defines the updation of the role details. Every role details can be
@@ -250,6 +251,13 @@ func Update(req *roleproto.UpdateRoleRequest, session *asmodel.Session) response
 		resp.CreateInternalErrorResponse(errorMessage)
 		return resp
 	}
+	// active sessions bound to the role must immediately reflect the updated privileges
+	if uerr := auth.RefreshRoleSessions(role); uerr != nil {
+		errorMessage := "error while trying to refresh sessions of role:" + uerr.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		log.Printf(errorMessage)
+		return resp
+	}
 
 	resp.Body = role
 	resp.StatusCode = http.StatusOK
@@ -54,6 +54,9 @@ func (a *Account) Create(ctx context.Context, req *accountproto.CreateAccountReq
 		ErrorArgs: errorArgs,
 	}
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -121,6 +124,9 @@ func (a *Account) GetAllAccounts(ctx context.Context, req *accountproto.AccountR
 		ErrorArgs: errorArgs,
 	}
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -186,6 +192,9 @@ func (a *Account) GetAccount(ctx context.Context, req *accountproto.GetAccountRe
 		ErrorArgs: errorArgs,
 	}
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -383,6 +392,9 @@ func (a *Account) Delete(ctx context.Context, req *accountproto.DeleteAccountReq
 		ErrorArgs: errorArgs,
 	}
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
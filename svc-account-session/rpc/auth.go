@@ -32,3 +32,18 @@ func (a *Auth) IsAuthorized(ctx context.Context, req *authproto.AuthRequest, res
 	resp.StatusMessage = errorMessage
 	return nil
 }
+
+// AuthenticateBasicAuth will accepts the basic auth credentials and send them to BasicAuth method
+// from auth package, if the credentials are valid then respond with the token of the session of the user.
+func (a *Auth) AuthenticateBasicAuth(ctx context.Context, req *authproto.BasicAuthRequest, resp *authproto.BasicAuthResponse) error {
+	resp.StatusCode, resp.StatusMessage, resp.SessionToken = auth.BasicAuth(req)
+	return nil
+}
+
+// AuthenticateClientCertificate will accepts the client certificate presented to the API gateway and send it to
+// ClientCertificateAuth method from auth package, if the certificate is valid and mapped to an account then respond
+// with the token of the session of the account.
+func (a *Auth) AuthenticateClientCertificate(ctx context.Context, req *authproto.ClientCertificateRequest, resp *authproto.ClientCertificateResponse) error {
+	resp.StatusCode, resp.StatusMessage, resp.SessionToken = auth.ClientCertificateAuth(req)
+	return nil
+}
@@ -57,6 +57,9 @@ func (r *Role) CreateRole(ctx context.Context, req *roleproto.RoleRequest, resp
 
 	// Validating the session
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -127,6 +130,9 @@ func (r *Role) GetRole(ctx context.Context, req *roleproto.GetRoleRequest, resp
 
 	// Validating the session
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -197,6 +203,9 @@ func (r *Role) GetAllRoles(ctx context.Context, req *roleproto.GetRoleRequest, r
 	}
 
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
@@ -268,6 +277,9 @@ func (r *Role) UpdateRole(ctx context.Context, req *roleproto.UpdateRoleRequest,
 
 	// Validating the session
 	sess, errs := auth.CheckSessionTimeOut(req.SessionToken)
+	if errs == nil {
+		errs = auth.CheckPasswordChangeRequired(sess)
+	}
 	if errs != nil {
 		errorMessage := "error while authorizing session token: " + errs.Error()
 		resp.StatusCode, resp.StatusMessage = errs.GetAuthStatusCodeAndMessage()
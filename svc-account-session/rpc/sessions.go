@@ -71,6 +71,23 @@ func (s *Session) DeleteSession(ctx context.Context, req *sessionproto.SessionRe
 	return nil
 }
 
+// DeleteUserSessions is a rpc call to terminate all the active sessions of an account
+func (s *Session) DeleteUserSessions(ctx context.Context, req *sessionproto.UserSessionsRequest, resp *sessionproto.SessionResponse) error {
+	response := session.DeleteUserSessions(req)
+	body, err := json.Marshal(response.Body)
+	if err != nil {
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = "error while trying marshal the response body for delete user sessions: " + err.Error()
+		log.Printf(response.StatusMessage)
+		return nil
+	}
+	resp.StatusCode = response.StatusCode
+	resp.StatusMessage = response.StatusMessage
+	resp.Header = response.Header
+	resp.Body = body
+	return nil
+}
+
 func getHeader() map[string]string {
 	return map[string]string{
 		"Cache-Control":     "no-cache",
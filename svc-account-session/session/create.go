@@ -29,6 +29,7 @@ import (
 	uuid "github.com/satori/go.uuid"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -104,10 +105,7 @@ func CreateNewSession(req *sessionproto.SessionCreateRequest) (response.RPC, str
 		log.Printf(errorMessage)
 		return resp, ""
 	}
-	rolePrivilege := make(map[string]bool)
-	for _, privilege := range role.AssignedPrivileges {
-		rolePrivilege[privilege] = true
-	}
+	rolePrivilege := auth.GetPrivileges(role)
 	//User requires Login privelege to create a session
 	if _, exist := rolePrivilege[common.PrivilegeLogin]; !exist {
 		errorMessage := "user doesn't have required privilege to create a session"
@@ -117,18 +115,27 @@ func CreateNewSession(req *sessionproto.SessionCreateRequest) (response.RPC, str
 
 	currentTime := time.Now()
 	sess := asmodel.Session{
-		ID:           uuid.NewV4().String(),
-		Token:        uuid.NewV4().String(),
-		UserName:     user.UserName,
-		RoleID:       user.RoleID,
-		Privileges:   rolePrivilege,
-		CreatedTime:  currentTime,
-		LastUsedTime: currentTime,
+		ID:                     uuid.NewV4().String(),
+		Token:                  uuid.NewV4().String(),
+		UserName:               user.UserName,
+		RoleID:                 user.RoleID,
+		Privileges:             rolePrivilege,
+		Origin:                 formatOrigin(req.ClientIP, req.UserAgent),
+		CreatedTime:            currentTime,
+		LastUsedTime:           currentTime,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		PasswordExpiration:     user.PasswordExpiration,
+	}
+	if auth.PasswordChangePending(user) {
+		// the session is created, but it can only be used to change the password
+		log.Printf("password of user %v must be changed before access is granted", user.UserName)
 	}
-	auth.Lock.Lock()
-	defer auth.Lock.Unlock()
-	if err = sess.Persist(); err != nil {
+	if err = auth.PersistSession(&sess); err != nil {
 		errorMessage := "error while trying to insert session details: " + err.Error()
+		if err.ErrNo() == errors.SessionLimitExceeded {
+			log.Printf(errorMessage)
+			return common.GeneralError(http.StatusServiceUnavailable, response.SessionLimitExceeded, errorMessage, nil, nil), ""
+		}
 		resp.CreateInternalErrorResponse(errorMessage)
 		resp.Header = map[string]string{
 			"Content-type": "application/json; charset=utf-8", // TODO: add all error headers
@@ -154,9 +161,24 @@ func CreateNewSession(req *sessionproto.SessionCreateRequest) (response.RPC, str
 	commonResponse.OdataID = "/redfish/v1/SessionService/Sessions/" + commonResponse.ID
 	commonResponse.CreateGenericResponse(resp.StatusMessage)
 	resp.Body = asresponse.Session{
-		Response: commonResponse,
-		UserName: createSession.UserName,
+		Response:              commonResponse,
+		UserName:              createSession.UserName,
+		ClientOriginIPAddress: req.ClientIP,
 	}
 
 	return resp, commonResponse.ID
 }
+
+// formatOrigin will build the origin of the session from the address and the user agent of the client,
+// the address comes first so that it can be read back with originIPAddress
+func formatOrigin(clientIP, userAgent string) string {
+	if userAgent == "" {
+		return clientIP
+	}
+	return clientIP + " (" + userAgent + ")"
+}
+
+// originIPAddress will extract the client address from the origin of the session
+func originIPAddress(origin string) string {
+	return strings.SplitN(origin, " ", 2)[0]
+}
@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
@@ -231,3 +232,40 @@ func TestCreateSession(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateSessionWithExpiredPassword(t *testing.T) {
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	auth.Lock.Lock()
+	common.SetUpMockConfig()
+	auth.Lock.Unlock()
+	if err := createMockRole(common.RoleAdmin, []string{common.PrivilegeConfigureManager, common.PrivilegeLogin}, []string{}); err != nil {
+		t.Fatalf("Error while creating role: %v", err)
+	}
+	if err := createMockUser("admin", common.RoleAdmin); err != nil {
+		t.Fatalf("Error while creating account: %v", err)
+	}
+	user, _ := asmodel.GetUserDetails("admin")
+	user.PasswordExpiration = time.Now().Add(-time.Hour)
+	if err := asmodel.UpdateUserDetails(user, user); err != nil {
+		t.Fatalf("Error while updating account: %v", err)
+	}
+
+	reqBody, _ := json.Marshal(asmodel.CreateSession{
+		UserName: "admin",
+		Password: "P@$$w0rd",
+	})
+	got, _ := CreateNewSession(&sessionproto.SessionCreateRequest{RequestBody: reqBody})
+	if got.StatusCode != http.StatusCreated {
+		t.Fatalf("CreateNewSession() = %v, want session to be created", got.StatusCode)
+	}
+	sess, err := asmodel.GetSession(got.Header["X-Auth-Token"])
+	if err != nil {
+		t.Fatalf("error while reading session: %v", err)
+	}
+	if err := auth.CheckPasswordChangeRequired(&sess); err == nil || !sess.PasswordExpiration.Equal(user.PasswordExpiration) {
+		t.Errorf("CreateNewSession() session should be restricted to the password change")
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"net/http"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
@@ -123,6 +125,71 @@ func DeleteSession(req *sessionproto.SessionRequest) response.RPC {
 	return resp
 }
 
+// DeleteUserSessions is a method to terminate all the active sessions of an account
+// it will accepts the UserSessionsRequest which will have the sessiontoken and the username of the account
+// and it will check the session has ConfigureUsers privilege and then delete all the sessions of the account
+// respond RPC response and error if there is.
+func DeleteUserSessions(req *sessionproto.UserSessionsRequest) response.RPC {
+	var resp response.RPC
+	currentSession, err := auth.CheckSessionTimeOut(req.SessionToken)
+	if err == nil {
+		err = auth.CheckPasswordChangeRequired(currentSession)
+	}
+	if err != nil {
+		errorMessage := "error while authorizing session token: " + err.Error()
+		resp.StatusCode, resp.StatusMessage = err.GetAuthStatusCodeAndMessage()
+		if resp.StatusCode == http.StatusServiceUnavailable {
+			resp.Body = common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, []interface{}{config.Data.DBConf.InMemoryHost + ":" + config.Data.DBConf.InMemoryPort}, nil).Body
+		} else {
+			resp.Body = common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, nil, nil).Body
+		}
+		resp.Header = getHeader()
+		log.Printf(errorMessage)
+		return resp
+	}
+
+	if errs := UpdateLastUsedTime(req.SessionToken); errs != nil {
+		errorMessage := "error while updating last used time of session with token " + req.SessionToken + ": " + errs.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		resp.Header = getHeader()
+		log.Printf(errorMessage)
+		return resp
+	}
+
+	if !currentSession.Privileges[common.PrivilegeConfigureUsers] {
+		errorMessage := "error: user does not have the privilege to terminate the sessions of other accounts"
+		resp = common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, errorMessage, nil, nil)
+		resp.Header = getHeader()
+		log.Printf(errorMessage)
+		return resp
+	}
+
+	if _, err = asmodel.GetUserDetails(req.UserName); err != nil {
+		errorMessage := "error while trying to get account: " + err.Error()
+		if err.ErrNo() == errors.DBKeyNotFound {
+			resp = common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Account", req.UserName}, nil)
+		} else {
+			resp.CreateInternalErrorResponse(errorMessage)
+		}
+		resp.Header = getHeader()
+		log.Printf(errorMessage)
+		return resp
+	}
+
+	if err = auth.DeleteUserSessions(req.UserName); err != nil {
+		errorMessage := "error while trying to delete sessions of account " + req.UserName + ": " + err.Error()
+		resp.CreateInternalErrorResponse(errorMessage)
+		resp.Header = getHeader()
+		log.Printf(errorMessage)
+		return resp
+	}
+	log.Printf("all the sessions of account %v are terminated by %v", req.UserName, currentSession.UserName)
+	resp.StatusCode = http.StatusNoContent
+	resp.StatusMessage = response.ResourceRemoved
+	resp.Header = getHeader()
+	return resp
+}
+
 func checkPrivilege(sessionToken string, session, currentSession *asmodel.Session) bool {
 	if (session.UserName == currentSession.UserName && currentSession.Privileges[common.PrivilegeConfigureSelf]) ||
 		currentSession.Privileges[common.PrivilegeConfigureUsers] {
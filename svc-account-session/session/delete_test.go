@@ -183,3 +183,82 @@ func TestDeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteUserSessions(t *testing.T) {
+	_, sessionToken := createSession(t, common.RoleAdmin, "admin", []string{common.PrivilegeConfigureUsers, common.PrivilegeLogin})
+	_, clientToken := createSession(t, common.RoleClient, "client", []string{common.PrivilegeLogin})
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	type args struct {
+		req *sessionproto.UserSessionsRequest
+	}
+	tests := []struct {
+		name string
+		args args
+		want response.RPC
+	}{
+		{
+			name: "terminate sessions with insufficient privileges",
+			args: args{
+				req: &sessionproto.UserSessionsRequest{
+					SessionToken: clientToken,
+					UserName:     "admin",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusForbidden,
+				StatusMessage: response.InsufficientPrivilege,
+				Header:        getHeader(),
+				Body:          common.GeneralError(http.StatusForbidden, response.InsufficientPrivilege, "error: user does not have the privilege to terminate the sessions of other accounts", nil, nil).Body,
+			},
+		},
+		{
+			name: "terminate sessions of non-existing account",
+			args: args{
+				req: &sessionproto.UserSessionsRequest{
+					SessionToken: sessionToken,
+					UserName:     "operator",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusNotFound,
+				StatusMessage: response.ResourceNotFound,
+				Header:        getHeader(),
+				Body:          common.GeneralError(http.StatusNotFound, response.ResourceNotFound, "error while trying to get account: error while trying to get user: no data with the with key operator found", []interface{}{"Account", "operator"}, nil).Body,
+			},
+		},
+		{
+			name: "successful termination of sessions",
+			args: args{
+				req: &sessionproto.UserSessionsRequest{
+					SessionToken: sessionToken,
+					UserName:     "client",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusNoContent,
+				StatusMessage: response.ResourceRemoved,
+				Header:        getHeader(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DeleteUserSessions(tt.args.req)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DeleteUserSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := asmodel.GetSession(clientToken); err == nil {
+		t.Errorf("DeleteUserSessions() didn't delete the session of the account")
+	}
+}
@@ -16,11 +16,14 @@
 package session
 
 import (
+	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-account-session/asmodel"
@@ -125,8 +128,9 @@ func GetSession(req *sessionproto.SessionRequest) response.RPC {
 				}
 
 				respBody := asresponse.Session{
-					Response: commonResponse,
-					UserName: session.UserName,
+					Response:              commonResponse,
+					UserName:              session.UserName,
+					ClientOriginIPAddress: originIPAddress(session.Origin),
 				}
 
 				resp.Body = respBody
@@ -185,6 +189,9 @@ func GetAllActiveSessions(req *sessionproto.SessionRequest) response.RPC {
 
 	// Validating the session
 	currentSession, gerr := auth.CheckSessionTimeOut(req.SessionToken)
+	if gerr == nil {
+		gerr = auth.CheckPasswordChangeRequired(currentSession)
+	}
 	if gerr != nil {
 		errorMessage := "error while authorizing session token: " + gerr.Error()
 		resp.StatusCode, resp.StatusMessage = gerr.GetAuthStatusCodeAndMessage()
@@ -219,9 +226,23 @@ func GetAllActiveSessions(req *sessionproto.SessionRequest) response.RPC {
 		return resp
 	}
 
-	sessionTokens, errs := asmodel.GetAllSessionKeys()
+	var sessionTokens []string
+	var errs *errors.Error
+	if req.Filter == "" {
+		sessionTokens, errs = asmodel.GetAllSessionKeys()
+	} else {
+		userName, ferr := parseUserNameFilter(req.Filter)
+		if ferr != nil {
+			errorMessage := "error while parsing the filter query: " + ferr.Error()
+			resp = common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, errorMessage, nil, nil)
+			resp.Header = getHeader()
+			log.Printf(errorMessage)
+			return resp
+		}
+		sessionTokens, errs = asmodel.GetSessionTokensByUserName(userName)
+	}
 	if errs != nil {
-		errorMessage := "error:  while trying to get all session keys in delete session: " + errs.Error()
+		errorMessage := "error:  while trying to get all session keys in get all active sessions: " + errs.Error()
 		resp.CreateInternalErrorResponse(errorMessage)
 		resp.Header = getHeader()
 		log.Printf(errorMessage)
@@ -257,6 +278,18 @@ func GetAllActiveSessions(req *sessionproto.SessionRequest) response.RPC {
 	return resp
 }
 
+// userNameFilter matches the only filter expression supported on the sessions collection
+var userNameFilter = regexp.MustCompile(`^\s*UserName\s+eq\s+'([^']+)'\s*$`)
+
+// parseUserNameFilter will extract the user name from the $filter query of the sessions collection
+func parseUserNameFilter(filter string) (string, error) {
+	match := userNameFilter.FindStringSubmatch(filter)
+	if match == nil {
+		return "", fmt.Errorf("filter %v is not supported, only UserName eq '<name>' is supported", filter)
+	}
+	return match[1], nil
+}
+
 // GetSessionService is a method to get session
 // it will accepts the SessionCreateRequest which will have sessionid and sessiontoken
 // and it will check the session service is enabled or not from the config file.
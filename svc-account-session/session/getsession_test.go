@@ -217,6 +217,57 @@ func TestGetAllActiveSessions(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "get all active sessions of a user",
+			args: args{
+				req: &sessionproto.SessionRequest{
+					SessionToken: sessionToken,
+					Filter:       "UserName eq 'admin'",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Header:        getHeader(),
+				Body: asresponse.List{
+					Response:     commonResponse,
+					MembersCount: len(listMembers),
+					Members:      listMembers,
+				},
+			},
+		},
+		{
+			name: "get all active sessions of a user without sessions",
+			args: args{
+				req: &sessionproto.SessionRequest{
+					SessionToken: sessionToken,
+					Filter:       "UserName eq 'operator'",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusOK,
+				StatusMessage: response.Success,
+				Header:        getHeader(),
+				Body: asresponse.List{
+					Response: commonResponse,
+				},
+			},
+		},
+		{
+			name: "get all sessions with unsupported filter",
+			args: args{
+				req: &sessionproto.SessionRequest{
+					SessionToken: sessionToken,
+					Filter:       "RoleId eq 'Administrator'",
+				},
+			},
+			want: response.RPC{
+				StatusCode:    http.StatusBadRequest,
+				StatusMessage: response.QueryNotSupported,
+				Header:        getHeader(),
+				Body:          common.GeneralError(http.StatusBadRequest, response.QueryNotSupported, "error while parsing the filter query: filter RoleId eq 'Administrator' is not supported, only UserName eq '<name>' is supported", nil, nil).Body,
+			},
+		},
 		{
 			name: "get all sessions with no session token",
 			args: args{
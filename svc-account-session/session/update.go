@@ -31,8 +31,8 @@ func UpdateLastUsedTime(token string) error {
 		return fmt.Errorf("error while trying to get the session details with the token %v: %v", token, err)
 	}
 	session.LastUsedTime = time.Now()
-	// Update Session
-	err = session.Update()
+	// Update only the last used time, so that the privileges refreshed meanwhile are kept
+	err = session.UpdateLastUsedTime()
 	if err != nil {
 		return fmt.Errorf("error while trying to update session details: %v", err)
 	}
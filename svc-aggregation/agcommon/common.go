@@ -19,6 +19,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
@@ -52,6 +53,12 @@ func GetPluginStatus(plugin agmodel.Plugin) bool {
 		PluginPrefferedAuthType: plugin.PreferredAuthType,
 		CACertificate:           &config.Data.KeyCertConf.RootCACertificate,
 	}
+	if len(plugin.Instances) > 0 {
+		// the plugin is available as long as one of its instances is
+		status := pmbhandle.CheckInstancesStatus(pluginStatus, pmbhandle.PluginAddresses(plugin.IP, plugin.Port, plugin.Instances))
+		log.Println("Status of plugin", plugin.ID, status)
+		return status
+	}
 	status, _, _, err := pluginStatus.CheckStatus()
 	if err != nil && !status {
 		log.Println("Error While getting the status for plugin ", plugin.ID, err)
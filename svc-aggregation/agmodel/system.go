@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"strings"
+	"time"
 
 	dmtfmodel "github.com/ODIM-Project/ODIM/lib-dmtf/model"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
@@ -53,6 +55,8 @@ type Plugin struct {
 	PluginType        string
 	PreferredAuthType string
 	ManagerUUID       string
+	Instances         []string
+	Draining          bool
 }
 
 //Target is for sending the requst to south bound/plugin
@@ -80,7 +84,8 @@ type AggregationSource struct {
 
 // Aggregate payload is used for perform the operations on Aggregate
 type Aggregate struct {
-	Elements []string `json:"Elements"`
+	Elements       []string `json:"Elements"`
+	MembershipRule string   `json:"MembershipRule,omitempty"`
 }
 
 // ConnectionMethod payload is used for perform the operations on connection method
@@ -216,6 +221,40 @@ func GenericSave(body []byte, table string, key string) error {
 	return nil
 }
 
+// SetManagerStatus replaces atomically the Status of the stored manager with the given one, leaving
+// its other properties as they are, and returns the Status it replaced, nil when the manager had none
+func SetManagerStatus(managerURI string, status map[string]interface{}) (map[string]interface{}, *errors.Error) {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return nil, err
+	}
+	var previousStatus map[string]interface{}
+	err = conn.Modify("Managers", managerURI, func(data string) (interface{}, error) {
+		if data == "" {
+			return nil, errors.PackError(errors.DBKeyNotFound, "no data with the with key ", managerURI, " found")
+		}
+		var resource string
+		if err := json.Unmarshal([]byte(data), &resource); err != nil {
+			return nil, err
+		}
+		var manager map[string]interface{}
+		if err := json.Unmarshal([]byte(resource), &manager); err != nil {
+			return nil, err
+		}
+		previousStatus, _ = manager["Status"].(map[string]interface{})
+		manager["Status"] = status
+		managerBytes, err := json.Marshal(manager)
+		if err != nil {
+			return nil, err
+		}
+		return string(managerBytes), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return previousStatus, nil
+}
+
 //SaveRegistryFile will save any Registry file in database OnDisk DB
 func SaveRegistryFile(body []byte, table string, key string) error {
 
@@ -274,15 +313,40 @@ func DeleteComputeSystem(index int, key string) *errors.Error {
 	return nil
 }
 
-func deletefilteredkeys(key string) error {
+// DeleteComputeSystemData deletes the compute system from the in-memory DB along with its search indexes,
+// unlike DeleteComputeSystem it leaves the other resources of the server
+func DeleteComputeSystemData(key string) *errors.Error {
+	connPool, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to connecting to DB: ", err.Error())
+	}
+	if err = connPool.Delete("ComputerSystem", key); err != nil {
+		return errors.PackError(err.ErrNo(), "error while trying to delete compute system: ", err.Error())
+	}
+	if errs := deletefilteredkeys(key); errs != nil {
+		return errors.PackError(errors.UndefinedErrorType, errs)
+	}
+	return nil
+}
+
+// GetSearchSchema reads the search/filter schema, which lists the indexed properties of the systems
+func GetSearchSchema() (Schema, error) {
 	var sf Schema
 	schemaFile, ioErr := ioutil.ReadFile(config.Data.SearchAndFilterSchemaPath)
 	if ioErr != nil {
-		return fmt.Errorf("fatal: error while trying to read search/filter schema json: %v", ioErr)
+		return sf, fmt.Errorf("fatal: error while trying to read search/filter schema json: %v", ioErr)
 	}
 	jsonErr := json.Unmarshal(schemaFile, &sf)
 	if jsonErr != nil {
-		return fmt.Errorf("fatal: error while trying to fetch search/filter schema json: %v", jsonErr)
+		return sf, fmt.Errorf("fatal: error while trying to fetch search/filter schema json: %v", jsonErr)
+	}
+	return sf, nil
+}
+
+func deletefilteredkeys(key string) error {
+	sf, sfErr := GetSearchSchema()
+	if sfErr != nil {
+		return sfErr
 	}
 	conn, err := common.GetDBConnection(common.InMemory)
 	if err != nil {
@@ -448,6 +512,18 @@ func UpdateIndex(searchForm map[string]interface{}, table, uuid string) error {
 	return nil
 }
 
+// UpdateIndexValues replaces the given index values of the resource, the other index values are kept
+func UpdateIndexValues(searchForm map[string]interface{}, table string) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connecting to DB: %v", err)
+	}
+	if err := conn.UpdateResourceIndex(searchForm, table); err != nil {
+		return fmt.Errorf("error while trying to update index: %v", err)
+	}
+	return nil
+}
+
 //UpdateComputeSystem is used for updating ComputerSystem table
 func UpdateComputeSystem(key string, computeData interface{}) error {
 	conn, err := common.GetDBConnection(common.InMemory)
@@ -499,6 +575,29 @@ func GetString(index, match string) ([]string, error) {
 	return list, nil
 }
 
+// GetIndexedValues returns the values saved in the given index, keyed by the URI of the indexed resource.
+// String values are saved in lower case, and lists as their space separated elements between brackets.
+func GetIndexedValues(index string) (map[string]string, error) {
+	conn, dberr := common.GetDBConnection(common.InMemory)
+	if dberr != nil {
+		return nil, fmt.Errorf("error while trying to connecting to DB: %v", dberr.Error())
+	}
+	list, err := conn.GetString(index, 0, "*", true)
+	if err != nil && err.Error() != "no data with ID found" {
+		return nil, fmt.Errorf("error while trying to get the index %v: %v", index, err)
+	}
+	values := make(map[string]string)
+	for _, entry := range list {
+		// the scores of the numeric values are listed along with the entries
+		separator := strings.LastIndex(entry, "::")
+		if separator == -1 {
+			continue
+		}
+		values[entry[separator+2:]] = entry[:separator]
+	}
+	return values, nil
+}
+
 // AddSystemOperationInfo connects to the persistencemgr and Add the system operation info to db
 /* Inputs:
 1.systemURI: computer system uri for which system operation is maintained
@@ -685,6 +784,30 @@ func UpdateAggregtionSource(aggregationSource AggregationSource, key string) *er
 	return nil
 }
 
+// UpdateSystemAndAggregationSource updates the BMC details and its aggregation source in a single transaction
+func UpdateSystemAndAggregationSource(system SaveSystem, aggregationSource AggregationSource, aggregationSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	return conn.UpdateAll(map[string]map[string]interface{}{
+		"System":            {system.DeviceUUID: system},
+		"AggregationSource": {aggregationSourceURI: aggregationSource},
+	})
+}
+
+// UpdatePluginAndAggregationSource updates the plugin details and its aggregation source in a single transaction
+func UpdatePluginAndAggregationSource(plugin Plugin, pluginID string, aggregationSource AggregationSource, aggregationSourceURI string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	return conn.UpdateAll(map[string]map[string]interface{}{
+		"Plugin":            {pluginID: plugin},
+		"AggregationSource": {aggregationSourceURI: aggregationSource},
+	})
+}
+
 //GetAllMatchingDetails accepts the table name ,pattern and DB type and return all the keys which mathces the pattern
 func GetAllMatchingDetails(table, pattern string, dbtype common.DbType) ([]string, *errors.Error) {
 	conn, err := common.GetDBConnection(dbtype)
@@ -785,6 +908,33 @@ func GetAllKeysFromTable(table string) ([]string, error) {
 	return keysArray, nil
 }
 
+// AcquireLease takes or renews the lease with the given key for the instance of the service, it returns whether
+// the instance holds the lease. The leases are kept in the OnDisk DB shared by all the instances of the service.
+func AcquireLease(key, owner string, lease time.Duration) (bool, error) {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return false, err
+	}
+	held, err := conn.AcquireLease(key, owner, lease)
+	if err != nil {
+		return false, fmt.Errorf("error while trying to acquire the lease %v: %v", key, err.Error())
+	}
+	return held, nil
+}
+
+// UpdateAggregate saves the given aggregate in place of the existing one
+func UpdateAggregate(aggregate Aggregate, aggregateURL string) *errors.Error {
+	conn, err := common.GetDBConnection(common.OnDisk)
+	if err != nil {
+		return err
+	}
+	const table string = "Aggregate"
+	if _, err := conn.Update(table, aggregateURL, aggregate); err != nil {
+		return err
+	}
+	return nil
+}
+
 //AddElementsToAggregate add elements to the aggregate
 func AddElementsToAggregate(aggregate Aggregate, aggregateURL string) *errors.Error {
 	conn, err := common.GetDBConnection(common.OnDisk)
@@ -387,6 +387,38 @@ func TestGenericSave(t *testing.T) {
 	}
 }
 
+func TestSetManagerStatus(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	managerURI := "/redfish/v1/Managers/1234877451-1234"
+	if err := GenericSave([]byte(`{"Name":"GRF","Status":{"State":"Enabled"}}`), "Managers", managerURI); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	previousStatus, err := SetManagerStatus(managerURI, map[string]interface{}{"State": "UnavailableOffline"})
+	if err != nil {
+		t.Fatalf("SetManagerStatus() error = %v", err)
+	}
+	if !reflect.DeepEqual(previousStatus, map[string]interface{}{"State": "Enabled"}) {
+		t.Errorf("SetManagerStatus() = %v, want the previous status", previousStatus)
+	}
+	data, err := GetResource("Managers", managerURI)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	assert.JSONEq(t, `{"Name":"GRF","Status":{"State":"UnavailableOffline"}}`, data, "SetManagerStatus() should replace only the Status")
+
+	// a deleted manager is not created again
+	if _, err := SetManagerStatus("/redfish/v1/Managers/deleted", map[string]interface{}{"State": "Enabled"}); err == nil || err.ErrNo() != errors.DBKeyNotFound {
+		t.Errorf("SetManagerStatus() error = %v, want DBKeyNotFound", err)
+	}
+	if _, err := GetResource("Managers", "/redfish/v1/Managers/deleted"); err == nil {
+		t.Errorf("SetManagerStatus() created the deleted manager")
+	}
+}
+
 func TestSaveRegistryFile(t *testing.T) {
 	config.SetUpMockConfig(t)
 	defer func() {
@@ -502,6 +534,38 @@ func TestDeleteComputeSystem(t *testing.T) {
 
 }
 
+func TestDeleteComputeSystemData(t *testing.T) {
+	config.SetUpMockConfig(t)
+
+	sampleFile := filepath.Join(cwdDir, "sample.json")
+	createFile(t, sampleFile, sampleData)
+	config.Data.SearchAndFilterSchemaPath = sampleFile
+	defer func() {
+		os.Remove(sampleFile)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockData(t, common.InMemory, "ComputerSystem", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1", `{"Id":"1"}`)
+	mockData(t, common.InMemory, "ComputerSystem", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:10", `{"Id":"10"}`)
+	mockData(t, common.InMemory, "Memory", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1/Memory/1", "some data")
+
+	if err := DeleteComputeSystemData("/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1"); err != nil {
+		t.Fatalf("DeleteComputeSystemData() = %v, want nil", err)
+	}
+	if _, err := GetResource("ComputerSystem", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1"); err == nil {
+		t.Errorf("the deleted compute system is still present")
+	}
+	// the other systems and resources of the server are left
+	if _, err := GetResource("ComputerSystem", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:10"); err != nil {
+		t.Errorf("the other compute system is deleted: %v", err)
+	}
+	if _, err := GetResource("Memory", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1/Memory/1"); err != nil {
+		t.Errorf("the resource under the compute system is deleted: %v", err)
+	}
+	if err := DeleteComputeSystemData("/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:2"); err == nil {
+		t.Errorf("DeleteComputeSystemData() of a missing compute system = nil, want an error")
+	}
+}
+
 func TestDeleteSystem(t *testing.T) {
 	config.SetUpMockConfig(t)
 	defer func() {
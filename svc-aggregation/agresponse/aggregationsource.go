@@ -29,5 +29,25 @@ type AggregationSourceResponse struct {
 // AggregateResponse defines the response for aggregate
 type AggregateResponse struct {
 	response.Response
-	Elements []string `json:"Elements"`
+	Elements       []string `json:"Elements"`
+	MembershipRule string   `json:"MembershipRule,omitempty"`
+}
+
+// AggregationSourceResult defines the outcome of adding one aggregation source of a bulk add request
+type AggregationSourceResult struct {
+	HostName          string `json:"HostName"`
+	PluginID          string `json:"PluginID"`
+	StatusCode        int32  `json:"StatusCode"`
+	Message           string `json:"Message,omitempty"`
+	SubTask           string `json:"SubTask,omitempty"`
+	AggregationSource string `json:"AggregationSource,omitempty"`
+}
+
+// AddAggregationSourcesResponse defines the report of a bulk add of aggregation sources
+type AddAggregationSourcesResponse struct {
+	Code      string                    `json:"code"`
+	Message   string                    `json:"message"`
+	Succeeded int                       `json:"Succeeded"`
+	Failed    int                       `json:"Failed"`
+	Results   []AggregationSourceResult `json:"Results"`
 }
@@ -80,6 +80,12 @@ func main() {
 		UpdateTask:      system.UpdateTaskData,
 	}
 	go p.RediscoverResources()
+	// Index the model, manufacturer and rack of the servers added before they were indexed
+	go system.BackfillGroupingIndex()
+	// Periodically retrieve the inventory again and publish events for the changed resources
+	go p.ScheduleInventoryRefresh()
+	// Periodically check the status of the plugins and record it in their managers
+	go p.MonitorPluginHealth()
 
 	if err = services.Service.Run(); err != nil {
 		log.Fatalf("failed to run a service: %v", err)
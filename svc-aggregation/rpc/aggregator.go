@@ -386,6 +386,90 @@ func (a *Aggregator) AddAggregationSource(ctx context.Context, req *aggregatorpr
 	return nil
 }
 
+// AddAggregationSources function is for handling the RPC communication for adding aggregation sources in bulk
+func (a *Aggregator) AddAggregationSources(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+
+	var taskID string
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authStatusCode, authStatusMessage := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	if authStatusCode != http.StatusOK {
+		errMsg := "error while trying to authenticate session"
+		generateResponse(common.GeneralError(authStatusCode, authStatusMessage, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return nil
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "error while trying to get the session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return nil
+	}
+
+	// parsing the AddAggregationSourcesRequest
+	var addRequest system.AddAggregationSourcesRequest
+	err = json.Unmarshal(req.RequestBody, &addRequest)
+	if err != nil {
+		errMsg := "unable to parse the add request: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return nil
+	}
+	if len(addRequest.AggregationSources) == 0 {
+		errMsg := "error: Mandatory field AggregationSources Missing"
+		generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AggregationSources"}, nil), resp)
+		log.Printf(errMsg)
+		return nil
+	}
+	// validating every entry before the task is started, so that the request
+	// can be corrected as a whole instead of failing entry by entry
+	for index, aggregationSource := range addRequest.AggregationSources {
+		invalidParam := validateAggregationSourceRequest(aggregationSource)
+		if invalidParam != "" {
+			errMsg := fmt.Sprintf("error: Mandatory field %v Missing in AggregationSources[%v]", invalidParam, index)
+			generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{fmt.Sprintf("AggregationSources[%v].%v", index, strings.TrimSpace(invalidParam))}, nil), resp)
+			log.Printf(errMsg)
+			return nil
+		}
+		if err := validateManagerAddress(aggregationSource.HostName); err != nil {
+			generateResponse(common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, err.Error(), []interface{}{aggregationSource.HostName, fmt.Sprintf("AggregationSources[%v].HostName", index)}, nil), resp)
+			log.Printf(err.Error())
+			return nil
+		}
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(sessionUserName)
+	if err != nil {
+		errMsg := "error while trying to create the task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return nil
+	}
+	strArray := strings.Split(taskURI, "/")
+	if strings.HasSuffix(taskURI, "/") {
+		taskID = strArray[len(strArray)-2]
+	} else {
+		taskID = strArray[len(strArray)-1]
+	}
+	// spawn the thread here to process the action asynchronously
+	go a.connector.AddAggregationSources(taskID, sessionUserName, req)
+
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+			"Location":     "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+	return nil
+}
+
 func validateAggregationSourceRequest(req system.AggregationSource) string {
 	param := ""
 	if req.HostName == "" {
@@ -860,6 +944,146 @@ func (a *Aggregator) SetDefaultBootOrderElementsOfAggregate(ctx context.Context,
 	return nil
 }
 
+// RediscoverAggregationSource defines the operations which handles the RPC request response
+// for the RediscoverAggregationSource service of aggregation micro service.
+// The inventory of the server is retrieved again under a task.
+func (a *Aggregator) RediscoverAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+	a.startActionTask(req, resp, validateRediscoverRequest, a.connector.RediscoverAggregationSource)
+	return nil
+}
+
+// RediscoverElementsOfAggregate defines the operations which handles the RPC request response
+// for the RediscoverElementsOfAggregate service of aggregation micro service.
+// The inventory of the servers of the aggregate is retrieved again under a task.
+func (a *Aggregator) RediscoverElementsOfAggregate(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+	a.startActionTask(req, resp, validateRediscoverRequest, a.connector.RediscoverElementsOfAggregate)
+	return nil
+}
+
+// InsertMediaElementsOfAggregate defines the operations which handles the RPC request response
+// for the InsertMediaElementsOfAggregate service of aggregation micro service.
+// The media is inserted in the virtual media of the systems of the aggregate under a task.
+func (a *Aggregator) InsertMediaElementsOfAggregate(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+	a.startActionTask(req, resp, validateInsertMediaRequest, a.connector.InsertMediaElementsOfAggregate)
+	return nil
+}
+
+// EjectMediaElementsOfAggregate defines the operations which handles the RPC request response
+// for the EjectMediaElementsOfAggregate service of aggregation micro service.
+// The media is ejected from the virtual media of the systems of the aggregate under a task.
+func (a *Aggregator) EjectMediaElementsOfAggregate(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+	a.startActionTask(req, resp, validateEjectMediaRequest, a.connector.EjectMediaElementsOfAggregate)
+	return nil
+}
+
+// DrainAggregationSource defines the operations which handles the RPC request response
+// for the DrainAggregationSource service of aggregation micro service.
+// The plugin is drained, and its BMCs are moved to another plugin, under a task.
+func (a *Aggregator) DrainAggregationSource(ctx context.Context, req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse) error {
+	a.startActionTask(req, resp, validateDrainRequest, a.connector.DrainAggregationSource)
+	return nil
+}
+
+// validateRediscoverRequest returns the error response for an invalid rediscover request
+func validateRediscoverRequest(requestBody []byte) *response.RPC {
+	rediscoverRequest, invalidProperty, err := system.ParseRediscoverRequest(requestBody)
+	if err == nil {
+		return nil
+	}
+	errMsg := "error while trying to validate request fields: " + err.Error()
+	log.Printf(errMsg)
+	if invalidProperty != "" {
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{rediscoverRequest.Scope, invalidProperty}, nil)
+		return &resp
+	}
+	resp := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	return &resp
+}
+
+// validateInsertMediaRequest returns the error response for an invalid insert media request
+func validateInsertMediaRequest(requestBody []byte) *response.RPC {
+	_, invalidProperty, statusMessage, err := system.ParseInsertMediaRequest(requestBody)
+	if err == nil {
+		return nil
+	}
+	resp := system.MediaRequestError(invalidProperty, statusMessage, err, nil)
+	return &resp
+}
+
+// validateEjectMediaRequest returns the error response for an invalid eject media request
+func validateEjectMediaRequest(requestBody []byte) *response.RPC {
+	_, invalidProperty, statusMessage, err := system.ParseEjectMediaRequest(requestBody)
+	if err == nil {
+		return nil
+	}
+	resp := system.MediaRequestError(invalidProperty, statusMessage, err, nil)
+	return &resp
+}
+
+// validateDrainRequest returns the error response for an invalid drain request
+func validateDrainRequest(requestBody []byte) *response.RPC {
+	_, conflictingProperties, err := system.ParseDrainRequest(requestBody)
+	if err == nil {
+		return nil
+	}
+	errMsg := "error while trying to validate request fields: " + err.Error()
+	log.Printf(errMsg)
+	if conflictingProperties != nil {
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, conflictingProperties, nil)
+		return &resp
+	}
+	resp := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, nil)
+	return &resp
+}
+
+// startActionTask validates the action request and runs the action asynchronously under a new task
+func (a *Aggregator) startActionTask(req *aggregatorproto.AggregatorRequest, resp *aggregatorproto.AggregatorResponse,
+	validate func(requestBody []byte) *response.RPC,
+	run func(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC) {
+	var oemprivileges []string
+	privileges := []string{common.PrivilegeConfigureComponents}
+	authStatusCode, authStatusMessage := a.connector.Auth(req.SessionToken, privileges, oemprivileges)
+	if authStatusCode != http.StatusOK {
+		errMsg := "error while trying to authenticate session"
+		generateResponse(common.GeneralError(authStatusCode, authStatusMessage, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return
+	}
+	sessionUserName, err := a.connector.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errMsg := "error while trying to get the session username: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return
+	}
+	if errResp := validate(req.RequestBody); errResp != nil {
+		generateResponse(*errResp, resp)
+		return
+	}
+
+	// Task Service using RPC and get the taskID
+	taskURI, err := a.connector.CreateTask(sessionUserName)
+	if err != nil {
+		errMsg := "error while trying to create task: " + err.Error()
+		generateResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil), resp)
+		log.Printf(errMsg)
+		return
+	}
+	taskID := strings.TrimPrefix(taskURI, "/redfish/v1/TaskService/Tasks/")
+	go run(taskID, sessionUserName, req)
+	// return 202 Accepted
+	var rpcResp = response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+			"Location":     "/taskmon/" + taskID,
+		},
+	}
+	generateTaskRespone(taskID, taskURI, &rpcResp)
+	generateResponse(rpcResp, resp)
+}
+
 // GetAllConnectionMethods defines the operations which handles the RPC request response
 // for the GetAllConnectionMethods service of systems micro service.
 // The functionality retrives the request and return backs the response to
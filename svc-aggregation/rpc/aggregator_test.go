@@ -481,6 +481,101 @@ func TestAggregator_AddAggreagationSource(t *testing.T) {
 	}
 }
 
+func TestAggregator_AddAggregationSources(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mockPluginData(t, "ILO")
+	system.ActiveReqSet.UpdateMu.Lock()
+	system.ActiveReqSet.ReqRecord = make(map[string]interface{})
+	system.ActiveReqSet.UpdateMu.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	aggregationSource := system.AggregationSource{
+		HostName: "100.0.0.1:50000",
+		UserName: "admin",
+		Password: "password",
+		Links: &system.Links{
+			Oem: &system.AddOEM{
+				PluginID:          "ILO",
+				PreferredAuthType: "BasicAuth",
+				PluginType:        "Compute",
+			},
+		},
+	}
+	successReq, _ := json.Marshal(system.AddAggregationSourcesRequest{
+		AggregationSources: []system.AggregationSource{aggregationSource},
+		MaxConcurrency:     2,
+	})
+	invalidAddress := aggregationSource
+	invalidAddress.HostName = ":50000"
+	invalidReqBody, _ := json.Marshal(system.AddAggregationSourcesRequest{
+		AggregationSources: []system.AggregationSource{aggregationSource, invalidAddress},
+	})
+	missingparamReq, _ := json.Marshal(system.AddAggregationSourcesRequest{
+		AggregationSources: []system.AggregationSource{aggregationSource, system.AggregationSource{}},
+	})
+	emptyReq, _ := json.Marshal(system.AddAggregationSourcesRequest{})
+	tests := []struct {
+		name           string
+		req            *aggregatorproto.AggregatorRequest
+		wantStatusCode int32
+	}{
+		{
+			name:           "positive case",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: successReq},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name:           "auth fail",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "invalidToken", RequestBody: successReq},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "get session username fails",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "noDetailsToken", RequestBody: successReq},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "unable to create task",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "noTaskToken", RequestBody: successReq},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "with invalid request",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: []byte("someData")},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "without aggregation sources",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: emptyReq},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid manager address in one entry",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: invalidReqBody},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "missing parameters in one entry",
+			req:            &aggregatorproto.AggregatorRequest{SessionToken: "validToken", RequestBody: missingparamReq},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	a := &Aggregator{connector: connector}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &aggregatorproto.AggregatorResponse{}
+			if err := a.AddAggregationSources(context.TODO(), tt.req, resp); err != nil {
+				t.Errorf("Aggregator.AddAggregationSources() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Aggregator.AddAggregationSources() status code = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
 func TestAggregator_GetAllAggregationSource(t *testing.T) {
 	defer func() {
 		common.TruncateDB(common.OnDisk)
@@ -1691,6 +1786,105 @@ func TestAggregator_SetDefaultBootOrderElementsOfAggregate(t *testing.T) {
 	}
 }
 
+func TestAggregator_RediscoverAggregationSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            *aggregatorproto.AggregatorRequest
+		wantStatusCode int32
+	}{
+		{
+			name: "Positive case",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "Invalid Token",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "invalidToken",
+				URL:          "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Invalid scope",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+				RequestBody:  []byte(`{"Scope":"Fans"}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid request body",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+				RequestBody:  []byte(`Scope`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Aggregator{connector: connector}
+			resp := &aggregatorproto.AggregatorResponse{}
+			a.RediscoverAggregationSource(context.TODO(), tt.req, resp)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Aggregator.RediscoverAggregationSource() got = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestAggregator_InsertMediaElementsOfAggregate(t *testing.T) {
+	tests := []struct {
+		name           string
+		req            *aggregatorproto.AggregatorRequest
+		wantStatusCode int32
+	}{
+		{
+			name: "Positive case",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia/",
+				RequestBody:  []byte(`{"Image":"http://10.0.0.1/os.iso"}`),
+			},
+			wantStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "Invalid Token",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "invalidToken",
+				URL:          "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia/",
+				RequestBody:  []byte(`{"Image":"http://10.0.0.1/os.iso"}`),
+			},
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "Missing image",
+			req: &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia/",
+				RequestBody:  []byte(`{"MediaType":"CD"}`),
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Aggregator{connector: connector}
+			resp := &aggregatorproto.AggregatorResponse{}
+			a.InsertMediaElementsOfAggregate(context.TODO(), tt.req, resp)
+			if resp.StatusCode != tt.wantStatusCode {
+				t.Errorf("Aggregator.InsertMediaElementsOfAggregate() got = %v, want %v", resp.StatusCode, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
 func TestAggregator_GetAllConnectionMethods(t *testing.T) {
 	config.Data.EnabledServices = append(config.Data.EnabledServices, "AggregationService")
 	type args struct {
@@ -52,6 +52,7 @@ func GetAggregator() *Aggregator {
 			DeleteComputeSystem:     agmodel.DeleteComputeSystem,
 			DeleteSystem:            agmodel.DeleteSystem,
 			DeleteEventSubscription: services.DeleteSubscription,
+			MoveEventSubscriptions:  services.MoveEventSubscriptions,
 			EventNotification:       agmessagebus.Publish,
 			GetAllKeysFromTable:     agmodel.GetAllKeysFromTable,
 			GetConnectionMethod:     agmodel.GetConnectionMethod,
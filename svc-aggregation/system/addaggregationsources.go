@@ -0,0 +1,264 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+// DefaultAddAggregationSourcesConcurrency is the number of aggregation sources
+// added in parallel when the bulk request does not specify MaxConcurrency
+const DefaultAddAggregationSourcesConcurrency = 8
+
+// AddAggregationSourcesRequest holds the list of aggregation sources to be added in bulk
+type AddAggregationSourcesRequest struct {
+	AggregationSources []AggregationSource `json:"AggregationSources"`
+	MaxConcurrency     int                 `json:"MaxConcurrency,omitempty"`
+}
+
+// concurrency returns the number of aggregation sources which can be added in parallel
+func (req AddAggregationSourcesRequest) concurrency() int {
+	limit := req.MaxConcurrency
+	if limit <= 0 {
+		limit = DefaultAddAggregationSourcesConcurrency
+	}
+	if limit > len(req.AggregationSources) {
+		limit = len(req.AggregationSources)
+	}
+	return limit
+}
+
+// AddAggregationSources is the handler for adding a list of bmc or managers in one request.
+// Each aggregation source is added through its own sub task, at most MaxConcurrency at a time,
+// and the parent task response holds the result of every entry so that the failed ones
+// can be retried individually.
+func (e *ExternalInterface) AddAggregationSources(taskID string, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := "/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources/"
+	var resp response.RPC
+	var percentComplete int32
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	var addRequest AddAggregationSourcesRequest
+	if err := json.Unmarshal(req.RequestBody, &addRequest); err != nil {
+		errMsg := "unable to parse the add request: " + err.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+	if len(addRequest.AggregationSources) == 0 {
+		errMsg := "error: mandatory field AggregationSources missing in the request"
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"AggregationSources"}, taskInfo)
+	}
+	var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+	if err := e.UpdateTask(task); err != nil {
+		errMsg := "error while starting the task: " + err.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+
+	total := len(addRequest.AggregationSources)
+	results := make([]agresponse.AggregationSourceResult, total)
+	// resultChan is a buffered channel with buffer size equal to total number of aggregation sources,
+	// so that sub tasks which are still running when the task is cancelled can exit gracefully.
+	resultChan := make(chan int, total)
+	var cancelled bool
+	var cancelMu sync.Mutex
+	isCancelled := func() bool {
+		cancelMu.Lock()
+		defer cancelMu.Unlock()
+		return cancelled
+	}
+	var wg, collectWG sync.WaitGroup
+	collectWG.Add(1)
+	go func() {
+		var collected bool
+		defer func() {
+			// UpdateTask exits the goroutine once the task is cancelled,
+			// which must stop the remaining aggregation sources from being added
+			if !collected {
+				cancelMu.Lock()
+				cancelled = true
+				cancelMu.Unlock()
+			}
+			collectWG.Done()
+		}()
+		for i := 0; i < total; i++ {
+			<-resultChan
+			if i == total-1 || isCancelled() {
+				continue
+			}
+			percentComplete = int32((i + 1) * 100 / total)
+			var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+			if err := e.UpdateTask(task); err != nil && err.Error() == common.Cancelling {
+				cancelMu.Lock()
+				cancelled = true
+				cancelMu.Unlock()
+			}
+		}
+		collected = true
+	}()
+
+	// slots bounds the number of aggregation sources being added at the same time
+	slots := make(chan struct{}, addRequest.concurrency())
+	for index, aggregationSource := range addRequest.AggregationSources {
+		results[index] = newAggregationSourceResult(aggregationSource)
+		slots <- struct{}{}
+		if isCancelled() {
+			<-slots
+			results[index].Message = "not attempted as the task was cancelled"
+			resultChan <- index
+			continue
+		}
+		wg.Add(1)
+		go func(index int, aggregationSource AggregationSource) {
+			defer func() {
+				<-slots
+				resultChan <- index
+				wg.Done()
+			}()
+			e.addAggregationSourceEntry(taskID, sessionUserName, req.SessionToken, aggregationSource, &results[index])
+		}(index, aggregationSource)
+	}
+	wg.Wait()
+	collectWG.Wait()
+
+	report := agresponse.AddAggregationSourcesResponse{
+		Code:    response.Success,
+		Message: "Request completed successfully",
+		Results: results,
+	}
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	for _, result := range results {
+		if result.StatusCode == http.StatusCreated {
+			report.Succeeded++
+			continue
+		}
+		report.Failed++
+		if result.StatusCode > resp.StatusCode {
+			resp.StatusCode = result.StatusCode
+		}
+	}
+	taskState, taskStatus := common.Completed, common.OK
+	if report.Failed > 0 {
+		report.Code = response.GeneralError
+		report.Message = "one or more of the aggregation sources could not be added, the failed entries can be retried individually"
+		taskState, taskStatus = common.Exception, common.Warning
+		if report.Succeeded == 0 {
+			taskStatus = common.Critical
+		}
+		if resp.StatusCode == http.StatusOK {
+			// entries which were never attempted because of a cancel have no status code
+			resp.StatusCode = http.StatusInternalServerError
+		}
+		resp.StatusMessage = response.GeneralError
+		log.Printf("%v of %v aggregation sources could not be added. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/%v", report.Failed, total, taskID)
+	} else {
+		log.Println("all aggregation sources are successfully added. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	}
+	if isCancelled() {
+		taskState = common.Cancelled
+	}
+	resp.Header = map[string]string{
+		"Cache-Control":     "no-cache",
+		"Connection":        "keep-alive",
+		"Content-type":      "application/json; charset=utf-8",
+		"Transfer-Encoding": "chunked",
+		"OData-Version":     "4.0",
+	}
+	resp.Body = report
+	percentComplete = 100
+	task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, taskState, taskStatus, percentComplete, http.MethodPost)
+	e.UpdateTask(task)
+	return resp
+}
+
+// addAggregationSourceEntry adds one aggregation source of the bulk request under a sub task of taskID
+// and records the outcome in result
+func (e *ExternalInterface) addAggregationSourceEntry(taskID, sessionUserName, sessionToken string, aggregationSource AggregationSource, result *agresponse.AggregationSourceResult) {
+	subTaskURI, err := e.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		result.StatusCode = http.StatusInternalServerError
+		result.Message = "error while trying to create sub task: " + err.Error()
+		log.Println(result.Message)
+		return
+	}
+	subTaskURI = strings.TrimSuffix(subTaskURI, "/")
+	result.SubTask = subTaskURI
+	subTaskID := subTaskURI[strings.LastIndex(subTaskURI, "/")+1:]
+	reqBody, err := json.Marshal(aggregationSource)
+	if err != nil {
+		result.StatusCode = http.StatusInternalServerError
+		result.Message = "error while trying to marshal the aggregation source: " + err.Error()
+		log.Println(result.Message)
+		return
+	}
+	// the status is marked failed until the add completes, since the sub task
+	// goroutine exits without returning when the sub task gets cancelled
+	result.StatusCode = http.StatusInternalServerError
+	result.Message = "sub task was cancelled"
+	resp := e.AddAggregationSource(subTaskID, sessionUserName, &aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  reqBody,
+	})
+	result.StatusCode = resp.StatusCode
+	result.Message = ""
+	if resp.StatusCode == http.StatusCreated {
+		result.AggregationSource = resp.Header["Location"]
+		return
+	}
+	result.Message = rpcErrorMessage(resp)
+}
+
+// newAggregationSourceResult creates the result entry for an aggregation source of the bulk request
+func newAggregationSourceResult(aggregationSource AggregationSource) agresponse.AggregationSourceResult {
+	result := agresponse.AggregationSourceResult{
+		HostName: aggregationSource.HostName,
+	}
+	if aggregationSource.Links != nil && aggregationSource.Links.Oem != nil {
+		result.PluginID = aggregationSource.Links.Oem.PluginID
+	}
+	return result
+}
+
+// rpcErrorMessage extracts the most specific error message from an error response
+func rpcErrorMessage(resp response.RPC) string {
+	data, err := json.Marshal(resp.Body)
+	if err != nil {
+		return resp.StatusMessage
+	}
+	var errResp response.CommonError
+	if err := json.Unmarshal(data, &errResp); err != nil {
+		return resp.StatusMessage
+	}
+	for _, info := range errResp.Error.MessageExtendedInfo {
+		if info.Message != "" {
+			return info.Message
+		}
+	}
+	if errResp.Error.Message != "" {
+		return errResp.Error.Message
+	}
+	return resp.StatusMessage
+}
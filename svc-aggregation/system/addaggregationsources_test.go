@@ -0,0 +1,196 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
+)
+
+func TestAddAggregationSourcesRequestConcurrency(t *testing.T) {
+	sources := make([]AggregationSource, 20)
+	tests := []struct {
+		name string
+		req  AddAggregationSourcesRequest
+		want int
+	}{
+		{
+			name: "default concurrency",
+			req:  AddAggregationSourcesRequest{AggregationSources: sources},
+			want: DefaultAddAggregationSourcesConcurrency,
+		},
+		{
+			name: "requested concurrency",
+			req:  AddAggregationSourcesRequest{AggregationSources: sources, MaxConcurrency: 3},
+			want: 3,
+		},
+		{
+			name: "concurrency limited to the number of entries",
+			req:  AddAggregationSourcesRequest{AggregationSources: sources[:2], MaxConcurrency: 10},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.concurrency(); got != tt.want {
+				t.Errorf("concurrency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRPCErrorMessage(t *testing.T) {
+	errResp := common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, "error: BMC already added", []interface{}{"HostName", "HostName", "100.0.0.1"}, nil)
+	if got := rpcErrorMessage(errResp); got == "" || got == errResp.StatusMessage {
+		t.Errorf("rpcErrorMessage() = %v, want the message of the error response", got)
+	}
+	plainResp := response.RPC{StatusMessage: response.InternalError}
+	if got := rpcErrorMessage(plainResp); got != response.InternalError {
+		t.Errorf("rpcErrorMessage() = %v, want %v", got, response.InternalError)
+	}
+}
+
+func TestExternalInterface_AddAggregationSources(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	addComputeRetrieval := config.AddComputeSkipResources{
+		SystemCollection: []string{"Chassis", "LogServices"},
+	}
+	config.Data.AddComputeSkipResources = &addComputeRetrieval
+	defer func() {
+		err := common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	mockPluginData(t, "GRF")
+
+	reqSuccess, _ := json.Marshal(AddAggregationSourcesRequest{
+		AggregationSources: []AggregationSource{
+			{
+				HostName: "100.0.0.1",
+				UserName: "admin",
+				Password: "password",
+				Links:    &Links{Oem: &AddOEM{PluginID: "GRF"}},
+			},
+		},
+	})
+	reqPartial, _ := json.Marshal(AddAggregationSourcesRequest{
+		AggregationSources: []AggregationSource{
+			{
+				HostName: "100.0.0.3",
+				UserName: "admin",
+				Password: "password",
+				Links:    &Links{Oem: &AddOEM{PluginID: "GRF"}},
+			},
+			{
+				HostName: "100.0.0.4",
+				UserName: "admin",
+				Password: "password",
+				Links:    &Links{Oem: &AddOEM{PluginID: "invalidpluginid"}},
+			},
+		},
+		MaxConcurrency: 1,
+	})
+	p := &ExternalInterface{
+		ContactClient:       mockContactClient,
+		Auth:                mockIsAuthorized,
+		CreateChildTask:     mockCreateChildTask,
+		UpdateTask:          mockUpdateTask,
+		CreateSubcription:   EventFunctionsForTesting,
+		PublishEvent:        PostEventFunctionForTesting,
+		GetPluginStatus:     GetPluginStatusForTesting,
+		EncryptPassword:     stubDevicePassword,
+		DecryptPassword:     stubDevicePassword,
+		DeleteComputeSystem: deleteComputeforTest,
+	}
+	tests := []struct {
+		name          string
+		taskID        string
+		reqBody       []byte
+		wantCode      int32
+		wantSucceeded int
+		wantFailed    int
+	}{
+		{
+			name:          "all aggregation sources added",
+			taskID:        "123",
+			reqBody:       reqSuccess,
+			wantCode:      http.StatusOK,
+			wantSucceeded: 1,
+		},
+		{
+			name:          "one of the aggregation sources failed",
+			taskID:        "123",
+			reqBody:       reqPartial,
+			wantCode:      http.StatusNotFound,
+			wantSucceeded: 1,
+			wantFailed:    1,
+		},
+		{
+			name:       "unable to create sub tasks",
+			taskID:     "taskWithoutChild",
+			reqBody:    reqSuccess,
+			wantCode:   http.StatusInternalServerError,
+			wantFailed: 1,
+		},
+		{
+			name:     "invalid request body",
+			taskID:   "123",
+			reqBody:  []byte("someData"),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "without aggregation sources",
+			taskID:   "123",
+			reqBody:  []byte(`{"AggregationSources":[]}`),
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ActiveReqSet.ReqRecord = make(map[string]interface{})
+			got := p.AddAggregationSources(tt.taskID, "admin", &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				RequestBody:  tt.reqBody,
+			})
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("AddAggregationSources() status code = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+			report, ok := got.Body.(agresponse.AddAggregationSourcesResponse)
+			if !ok {
+				return
+			}
+			if report.Succeeded != tt.wantSucceeded || report.Failed != tt.wantFailed {
+				t.Errorf("AddAggregationSources() succeeded = %v, failed = %v, want %v and %v", report.Succeeded, report.Failed, tt.wantSucceeded, tt.wantFailed)
+			}
+			if len(report.Results) != tt.wantSucceeded+tt.wantFailed {
+				t.Errorf("AddAggregationSources() reported %v results, want %v", len(report.Results), tt.wantSucceeded+tt.wantFailed)
+			}
+		})
+	}
+}
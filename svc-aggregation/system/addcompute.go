@@ -17,6 +17,7 @@ package system
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
@@ -26,7 +27,6 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
-	uuid "github.com/satori/go.uuid"
 )
 
 // AddCompute is the handler for adding system
@@ -46,6 +46,11 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 		log.Println(errMsg)
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", addResourceRequest.Oem.PluginID}, taskInfo), "", nil
 	}
+	if plugin.Draining {
+		errMsg := fmt.Sprintf("error: plugin %v is draining, no BMC can be added to it", addResourceRequest.Oem.PluginID)
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo), "", nil
+	}
 
 	var saveSystem agmodel.SaveSystem
 	saveSystem.ManagerAddress = addResourceRequest.ManagerAddress
@@ -104,15 +109,23 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 	resp.StatusMessage = getResponse.StatusMessage
 	resp.Header = map[string]string{"Content-type": "application/json; charset=utf-8"}
 
-	saveSystem.DeviceUUID = uuid.NewV4().String()
 	getSystemBody := map[string]interface{}{
 		"ManagerAddress": saveSystem.ManagerAddress,
 		"UserName":       saveSystem.UserName,
 		"Password":       saveSystem.Password,
 	}
 
-	//Discover Systems collection this will be moved to a function later if needed
+	// The identifier is derived from the BMC itself, so that removing and adding
+	// the server again, under the same or any other address, gives the same identifier
 	pluginContactRequest.DeviceInfo = getSystemBody
+	identity := getDeviceIdentity(body, addResourceRequest.ManagerAddress, pluginContactRequest)
+	saveSystem.DeviceUUID = identity.deviceUUID()
+	if errResp := reserveDevice(saveSystem.DeviceUUID, identity, addResourceRequest, taskInfo); errResp != nil {
+		return *errResp, "", nil
+	}
+	defer releaseDevice(saveSystem.DeviceUUID)
+
+	//Discover Systems collection this will be moved to a function later if needed
 	pluginContactRequest.OID = "/redfish/v1/Systems"
 	pluginContactRequest.DeviceUUID = saveSystem.DeviceUUID
 	pluginContactRequest.HTTPMethodType = http.MethodGet
@@ -125,6 +138,10 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 	systemsEstimatedWork := int32(65)
 	var resourceURI string
 	if resourceURI, progress, err = h.getAllSystemInfo(taskID, progress, systemsEstimatedWork, pluginContactRequest); err != nil {
+		if err == errTaskCancelled {
+			go e.rollbackInMemory(resourceURI)
+			return resp, "", nil
+		}
 		errMsg := "error while trying to add compute: " + err.Error()
 		log.Println(errMsg)
 		var msgArg = make([]interface{}, 0)
@@ -156,7 +173,10 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 
 	progress = percentComplete
 	firmwareEstimatedWork := int32(15)
-	progress = h.getAllRootInfo(taskID, progress, firmwareEstimatedWork, pluginContactRequest)
+	if progress, err = h.getAllRootInfo(taskID, progress, firmwareEstimatedWork, pluginContactRequest); err != nil {
+		go e.rollbackInMemory(resourceURI)
+		return resp, "", nil
+	}
 	percentComplete = progress
 	task = fillTaskData(taskID, targetURI, pluginContactRequest.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
 	e.UpdateTask(task)
@@ -169,7 +189,10 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 
 	progress = percentComplete
 	softwareEstimatedWork := int32(15)
-	progress = h.getAllRootInfo(taskID, progress, softwareEstimatedWork, pluginContactRequest)
+	if progress, err = h.getAllRootInfo(taskID, progress, softwareEstimatedWork, pluginContactRequest); err != nil {
+		go e.rollbackInMemory(resourceURI)
+		return resp, "", nil
+	}
 	percentComplete = progress
 	task = fillTaskData(taskID, targetURI, pluginContactRequest.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
 	e.UpdateTask(task)
@@ -208,7 +231,10 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 
 	progress = percentComplete
 	chassisEstimatedWork := int32(15)
-	progress = h.getAllRootInfo(taskID, progress, chassisEstimatedWork, pluginContactRequest)
+	if progress, err = h.getAllRootInfo(taskID, progress, chassisEstimatedWork, pluginContactRequest); err != nil {
+		go e.rollbackInMemory(resourceURI)
+		return resp, "", nil
+	}
 	percentComplete = progress
 	task = fillTaskData(taskID, targetURI, pluginContactRequest.TaskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
 	err = e.UpdateTask(task)
@@ -256,5 +282,6 @@ func (e *ExternalInterface) addCompute(taskID, targetURI string, percentComplete
 		"Location":     resourceURI,
 	}
 	log.Printf("sucessfully added system with manager address %v using plugin id %v.", addResourceRequest.ManagerAddress, addResourceRequest.Oem.PluginID)
+	UpdateDynamicAggregates()
 	return resp, saveSystem.DeviceUUID, ciphertext
 }
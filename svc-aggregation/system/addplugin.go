@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"strings"
 
@@ -47,6 +48,13 @@ func (e *ExternalInterface) addPluginData(req AddResourceRequest, taskID, target
 		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{"PluginType", fmt.Sprintf("%v", config.Data.SupportedPluginTypes)}, taskInfo), "", nil
 	}
 
+	// checking the addresses of the other instances of the plugin
+	if invalidInstance := validatePluginInstances(req.ManagerAddress, req.Oem.Instances); invalidInstance != "" {
+		errMsg := "error: incorrect request property value for Instances: " + invalidInstance
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{invalidInstance, "Instances"}, taskInfo), "", nil
+	}
+
 	// checking whether the Plugin already exists
 	// If GetPluginData was successful, it indicates plugin already exists,
 	// but it could also return errors, for below reasons, and has to be considered
@@ -91,6 +99,7 @@ func (e *ExternalInterface) addPluginData(req AddResourceRequest, taskID, target
 		ID:                req.Oem.PluginID,
 		PluginType:        req.Oem.PluginType,
 		PreferredAuthType: req.Oem.PreferredAuthType,
+		Instances:         req.Oem.Instances,
 	}
 	pluginContactRequest.Plugin = plugin
 	pluginContactRequest.StatusPoll = true
@@ -180,6 +189,15 @@ func (e *ExternalInterface) addPluginData(req AddResourceRequest, taskID, target
 
 		managersData[pluginContactRequest.OID] = body
 	}
+
+	// Verifying the other instances of the plugin
+	for _, address := range plugin.Instances {
+		if getResponse, err := checkPluginInstance(pluginContactRequest, address, managerUUID); err != nil {
+			errMsg := err.Error()
+			log.Println(errMsg)
+			return common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, errMsg, getResponse.MsgArgs, taskInfo), "", nil
+		}
+	}
 	e.SubscribeToEMB(plugin.ID, queueList)
 	// saving all plugin manager data
 	var listMembers = make([]agresponse.ListMember, 0)
@@ -218,3 +236,68 @@ func (e *ExternalInterface) addPluginData(req AddResourceRequest, taskID, target
 	log.Println("sucessfully added  plugin with the id ", req.Oem.PluginID)
 	return resp, uuid.NewV4().String(), ciphertext
 }
+
+// validatePluginInstances checks the addresses of the other instances of a plugin being added,
+// and returns the first invalid one. Every address has to be given as IP:Port, only once
+// and must not be the address the plugin is added with.
+func validatePluginInstances(managerAddress string, instances []string) string {
+	addresses := map[string]bool{managerAddress: true}
+	for _, address := range instances {
+		if _, port, err := net.SplitHostPort(address); err != nil || port == "" || addresses[address] {
+			return address
+		}
+		addresses[address] = true
+	}
+	return ""
+}
+
+// checkPluginInstance verifies that another instance of the plugin being added answers,
+// and that it serves the same plugin manager as the instance the plugin is added with
+func checkPluginInstance(pluginContactRequest getResourceRequest, address, managerUUID string) (responseStatus, error) {
+	pluginContactRequest.Plugin.IP, pluginContactRequest.Plugin.Port, _ = net.SplitHostPort(address)
+	pluginContactRequest.StatusPoll = false
+	if strings.EqualFold(pluginContactRequest.Plugin.PreferredAuthType, "XAuthToken") {
+		// sessions are not shared between the instances of a plugin
+		pluginContactRequest.HTTPMethodType = http.MethodPost
+		pluginContactRequest.OID = "/ODIM/v1/Sessions"
+		_, token, getResponse, err := contactPlugin(pluginContactRequest, "error while creating the session with the plugin instance "+address+": ")
+		if err != nil {
+			return getResponse, err
+		}
+		pluginContactRequest.Token = token
+	}
+	pluginContactRequest.HTTPMethodType = http.MethodGet
+	pluginContactRequest.OID = "/ODIM/v1/Managers"
+	body, _, getResponse, err := contactPlugin(pluginContactRequest, "error while getting the details "+pluginContactRequest.OID+" from the plugin instance "+address+": ")
+	if err != nil {
+		return getResponse, err
+	}
+	var managers struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		}
+	}
+	if err := json.Unmarshal(body, &managers); err != nil {
+		return responseStatus{StatusCode: http.StatusInternalServerError, StatusMessage: response.InternalError},
+			fmt.Errorf("unable to parse the managers response of the plugin instance %v: %v", address, err)
+	}
+	for _, member := range managers.Members {
+		pluginContactRequest.OID = member.OdataID
+		body, _, getResponse, err := contactPlugin(pluginContactRequest, "error while getting the details "+pluginContactRequest.OID+" from the plugin instance "+address+": ")
+		if err != nil {
+			return getResponse, err
+		}
+		var manager struct {
+			UUID string
+		}
+		if err := json.Unmarshal(body, &manager); err == nil && manager.UUID == managerUUID {
+			return getResponse, nil
+		}
+	}
+	errMsg := fmt.Sprintf("error: %v is not an instance of the plugin at the given HostName, its manager UUID differs", address)
+	return responseStatus{
+		StatusCode:    http.StatusBadRequest,
+		StatusMessage: response.PropertyValueConflict,
+		MsgArgs:       []interface{}{"Instances", "HostName"},
+	}, fmt.Errorf(errMsg)
+}
@@ -130,6 +130,42 @@ func TestExternalInterface_Plugin(t *testing.T) {
 			PluginType:        "Compute",
 		},
 	}
+	reqWithInstances := AddResourceRequest{
+		ManagerAddress: "localhost:9091",
+		UserName:       "admin",
+		Password:       "password",
+
+		Oem: &AddOEM{
+			PluginID:          "GRFHA",
+			PreferredAuthType: "BasicAuth",
+			PluginType:        "Compute",
+			Instances:         []string{"localhost:9092"},
+		},
+	}
+	reqInvalidInstance := AddResourceRequest{
+		ManagerAddress: "localhost:9091",
+		UserName:       "admin",
+		Password:       "password",
+
+		Oem: &AddOEM{
+			PluginID:          "GRFHA2",
+			PreferredAuthType: "BasicAuth",
+			PluginType:        "Compute",
+			Instances:         []string{"localhost:9092", "localhost:9091"},
+		},
+	}
+	reqInstanceOfOtherPlugin := AddResourceRequest{
+		ManagerAddress: "localhost:9091",
+		UserName:       "admin",
+		Password:       "password",
+
+		Oem: &AddOEM{
+			PluginID:          "GRFHA3",
+			PreferredAuthType: "BasicAuth",
+			PluginType:        "Compute",
+			Instances:         []string{"100.0.0.9:9091"},
+		},
+	}
 
 	p := &ExternalInterface{
 		ContactClient:     mockContactClient,
@@ -226,6 +262,39 @@ func TestExternalInterface_Plugin(t *testing.T) {
 				StatusCode: http.StatusConflict,
 			},
 		},
+		{
+			name: "Plugin with instances",
+			p:    p,
+			args: args{
+				taskID: "123",
+				req:    reqWithInstances,
+			},
+			want: response.RPC{
+				StatusCode: http.StatusCreated,
+			},
+		},
+		{
+			name: "Instance with the address of the plugin",
+			p:    p,
+			args: args{
+				taskID: "123",
+				req:    reqInvalidInstance,
+			},
+			want: response.RPC{
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "Instance of another plugin",
+			p:    p,
+			args: args{
+				taskID: "123",
+				req:    reqInstanceOfOtherPlugin,
+			},
+			want: response.RPC{
+				StatusCode: http.StatusBadRequest,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,12 +63,34 @@ func (e *ExternalInterface) CreateAggregate(req *aggregatorproto.AggregatorReque
 		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Elements"}, nil)
 	}
 
-	statuscode, err := validateElements(createRequest.Elements)
-	if err != nil {
-		errMsg := "invalid elements for create an aggregate" + err.Error()
-		log.Println(errMsg)
-		errArgs := []interface{}{"Elements", string(req.RequestBody)}
-		return common.GeneralError(statuscode, response.ResourceNotFound, errMsg, errArgs, nil)
+	if createRequest.MembershipRule != "" {
+		// the elements of an aggregate with a membership rule are the systems matching the rule
+		if createRequest.Elements != nil {
+			errMsg := "Elements cannot be given along with a MembershipRule"
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"MembershipRule", "Elements"}, nil)
+		}
+		if err := validateMembershipRule(createRequest.MembershipRule); err != nil {
+			errMsg := "invalid membership rule for create an aggregate: " + err.Error()
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueFormatError, errMsg, []interface{}{createRequest.MembershipRule, "MembershipRule"}, nil)
+		}
+		dynamicAggregatesLock.Lock()
+		defer dynamicAggregatesLock.Unlock()
+		createRequest.Elements, err = evaluateMembershipRule(createRequest.MembershipRule)
+		if err != nil {
+			errMsg := "error while trying to evaluate the membership rule: " + err.Error()
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		}
+	} else {
+		statuscode, err := validateElements(createRequest.Elements)
+		if err != nil {
+			errMsg := "invalid elements for create an aggregate" + err.Error()
+			log.Println(errMsg)
+			errArgs := []interface{}{"Elements", string(req.RequestBody)}
+			return common.GeneralError(statuscode, response.ResourceNotFound, errMsg, errArgs, nil)
+		}
 	}
 	targetURI := "/redfish/v1/AggregationService/Aggregates"
 	aggregateUUID := uuid.NewV4().String()
@@ -98,8 +120,9 @@ func (e *ExternalInterface) CreateAggregate(req *aggregatorproto.AggregatorReque
 	}
 	commonResponse.CreateGenericResponse(response.Created)
 	resp.Body = agresponse.AggregateResponse{
-		Response: commonResponse,
-		Elements: createRequest.Elements,
+		Response:       commonResponse,
+		Elements:       createRequest.Elements,
+		MembershipRule: createRequest.MembershipRule,
 	}
 	resp.StatusCode = http.StatusCreated
 	return resp
@@ -207,8 +230,9 @@ func (e *ExternalInterface) GetAggregate(req *aggregatorproto.AggregatorRequest)
 	}
 	commonResponse.CreateGenericResponse(response.Success)
 	resp.Body = agresponse.AggregateResponse{
-		Response: commonResponse,
-		Elements: aggregate.Elements,
+		Response:       commonResponse,
+		Elements:       aggregate.Elements,
+		MembershipRule: aggregate.MembershipRule,
 	}
 	return resp
 }
@@ -289,6 +313,9 @@ func (e *ExternalInterface) AddElementsToAggregate(req *aggregatorproto.Aggregat
 		}
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
 	}
+	if errResp := validateStaticAggregate(aggregate, addRequest, "AddElements"); errResp != nil {
+		return *errResp
+	}
 	if checkElementsPresent(addRequest.Elements, aggregate.Elements) {
 		errMsg := "Elements present in aggregate"
 		log.Println(errMsg)
@@ -369,6 +396,9 @@ func (e *ExternalInterface) RemoveElementsFromAggregate(req *aggregatorproto.Agg
 		}
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
 	}
+	if errResp := validateStaticAggregate(aggregate, removeRequest, "RemoveElements"); errResp != nil {
+		return *errResp
+	}
 	if !checkRemovingElementsPresent(removeRequest.Elements, aggregate.Elements) {
 		errMsg := "Elements not present in aggregate"
 		log.Println(errMsg)
@@ -407,6 +437,24 @@ func (e *ExternalInterface) RemoveElementsFromAggregate(req *aggregatorproto.Agg
 	return resp
 }
 
+// validateStaticAggregate checks that the elements of the aggregate can be added or removed,
+// the elements of an aggregate with a membership rule only follow the rule
+func validateStaticAggregate(aggregate, request agmodel.Aggregate, action string) *response.RPC {
+	if aggregate.MembershipRule != "" {
+		errMsg := "the elements of an aggregate with a membership rule cannot be changed"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errMsg, []interface{}{action}, nil)
+		return &resp
+	}
+	if request.MembershipRule != "" {
+		errMsg := "MembershipRule can only be given when creating an aggregate"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.ActionParameterNotSupported, errMsg, []interface{}{"MembershipRule", action}, nil)
+		return &resp
+	}
+	return nil
+}
+
 func checkElementsPresent(requestElements, presentElements []string) bool {
 	for _, element := range requestElements {
 		front := 0
@@ -76,6 +76,10 @@ func TestExternalInterface_CreateAggregate(t *testing.T) {
 		},
 	})
 	missingparamReq, _ := json.Marshal(agmodel.Aggregate{})
+	ruleWithElementsReq, _ := json.Marshal(agmodel.Aggregate{
+		Elements:       []string{"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"},
+		MembershipRule: "Model eq 'ProLiant DL360 Gen10'",
+	})
 
 	p := &ExternalInterface{
 		Auth: mockIsAuthorized,
@@ -149,6 +153,18 @@ func TestExternalInterface_CreateAggregate(t *testing.T) {
 				StatusCode: http.StatusBadRequest,
 			},
 		},
+		{
+			name: "membership rule with elements",
+			e:    p,
+			args: args{
+				req: &aggregatorproto.AggregatorRequest{
+					RequestBody: ruleWithElementsReq,
+				},
+			},
+			want: response.RPC{
+				StatusCode: http.StatusBadRequest,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
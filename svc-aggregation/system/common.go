@@ -39,6 +39,7 @@ import (
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agcommon"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmessagebus"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	uuid "github.com/satori/go.uuid"
 )
 
 //Device struct to define the response from plugin for UUID
@@ -66,6 +67,7 @@ type ExternalInterface struct {
 	DeleteComputeSystem     func(int, string) *errors.Error
 	DeleteSystem            func(string) *errors.Error
 	DeleteEventSubscription func(string) (*eventsproto.EventSubResponse, error)
+	MoveEventSubscriptions  func(string, string) (*eventsproto.EventSubResponse, error)
 	EventNotification       func(string, string, string)
 	GetAllKeysFromTable     func(string) ([]string, error)
 	GetConnectionMethod     func(string) (agmodel.ConnectionMethod, *errors.Error)
@@ -107,10 +109,24 @@ type respHolder struct {
 	StatusCode     int32
 	StatusMessage  string
 	MsgArgs        []interface{}
-	lock           sync.Mutex
+	lock           sync.Mutex // lock protects the response details, TraversedLinks and the staged inventory
 	SystemURL      []string
 	PluginResponse string
 	TraversedLinks map[string]bool
+	// Staged holds the resources retrieved by a rediscovery, by table and key, until the whole retrieval
+	// succeeds. The resources are saved at once when the holder stages them, with the indexing and the
+	// deletions done after the retrieval.
+	Staged    map[string]stagedResource
+	onCommits []func() error
+	// failedURIs are the resources which failed to be retrieved for another reason than being missing
+	failedURIs []string
+}
+
+// stagedResource is a resource retrieved by a rediscovery, to save once the retrieval succeeds
+type stagedResource struct {
+	table string
+	key   string
+	data  string
 }
 
 //AddResourceRequest is payload of adding a  resource
@@ -123,9 +139,10 @@ type AddResourceRequest struct {
 
 // AddOEM is struct to have the add request parameters
 type AddOEM struct {
-	PluginID          string `json:"PluginID"`
-	PreferredAuthType string `json:"PreferredAuthType,omitempty"`
-	PluginType        string `json:"PluginType,omitempty"`
+	PluginID          string   `json:"PluginID"`
+	PreferredAuthType string   `json:"PreferredAuthType,omitempty"`
+	PluginType        string   `json:"PluginType,omitempty"`
+	Instances         []string `json:"Instances,omitempty"`
 }
 
 // TaskData holds the data of the Task
@@ -150,6 +167,21 @@ type ActiveRequestsSet struct {
 // ActiveReqSet is the global instance for tracking ongoing requests
 var ActiveReqSet ActiveRequestsSet
 
+// instanceID identifies this instance of the service to the leases of the work done by a single instance
+var instanceID = uuid.NewV4().String()
+
+// holdsLease takes or renews the lease of the periodic work, it returns whether this instance of the service
+// does the work. The lease lasts two periods of the work, so that it is renewed before it expires and is taken
+// over by another instance only when this one stops.
+func holdsLease(name string, period time.Duration) bool {
+	held, err := agmodel.AcquireLease(name, instanceID, 2*period)
+	if err != nil {
+		log.Printf("error while trying to acquire the lease of %v: %v", name, err)
+		return false
+	}
+	return held
+}
+
 var southBoundURL = "southboundurl"
 var northBoundURL = "northboundurl"
 
@@ -327,6 +359,9 @@ func (h *respHolder) getAllSystemInfo(taskID string, progress int32, alottedWork
 		oDataID := object.(map[string]interface{})["@odata.id"].(string)
 		req.OID = oDataID
 		if resourceURI, progress, err = h.getSystemInfo(taskID, progress, estimatedWork, req); err != nil {
+			if err == errTaskCancelled {
+				return resourceURI, progress, err
+			}
 			errorMessage += oDataID + ":err-" + err.Error() + "; "
 			foundErr = true
 		}
@@ -513,7 +548,7 @@ func isFileExist(existingFiles []string, substr string) bool {
 	return fileExist
 }
 
-func (h *respHolder) getAllRootInfo(taskID string, progress int32, alottedWork int32, req getResourceRequest) int32 {
+func (h *respHolder) getAllRootInfo(taskID string, progress int32, alottedWork int32, req getResourceRequest) (int32, error) {
 	resourceName := req.OID
 	body, _, getResponse, err := contactPlugin(req, "error while trying to get the"+resourceName+"collection details: ")
 	if err != nil {
@@ -524,7 +559,7 @@ func (h *respHolder) getAllRootInfo(taskID string, progress int32, alottedWork i
 		h.MsgArgs = getResponse.MsgArgs
 		h.lock.Unlock()
 		log.Println(err)
-		return progress
+		return progress, nil
 	}
 
 	resourceMap := make(map[string]interface{})
@@ -536,7 +571,7 @@ func (h *respHolder) getAllRootInfo(taskID string, progress int32, alottedWork i
 		h.StatusCode = http.StatusInternalServerError
 		h.lock.Unlock()
 		log.Println("error while trying to unmarshal"+resourceName+": ", err)
-		return progress
+		return progress, nil
 
 	}
 
@@ -546,9 +581,11 @@ func (h *respHolder) getAllRootInfo(taskID string, progress int32, alottedWork i
 		estimatedWork := alottedWork / int32(len(resourceMembers.([]interface{})))
 		oDataID := object.(map[string]interface{})["@odata.id"].(string)
 		req.OID = oDataID
-		progress = h.getIndivdualInfo(taskID, progress, estimatedWork, req)
+		if progress, err = h.getIndivdualInfo(taskID, progress, estimatedWork, req); err != nil {
+			return progress, err
+		}
 	}
-	return progress
+	return progress, nil
 }
 
 func (h *respHolder) getSystemInfo(taskID string, progress int32, alottedWork int32, req getResourceRequest) (string, int32, error) {
@@ -604,7 +641,7 @@ func (h *respHolder) getSystemInfo(taskID string, progress int32, alottedWork in
 	}
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
 	// persist the response with table ComputerSystem  and key as system UUID + Oid Needs relook TODO
-	err = agmodel.GenericSave([]byte(updatedResourceData), "ComputerSystem", oidKey)
+	err = h.saveResource(updatedResourceData, "ComputerSystem", oidKey)
 	if err != nil {
 		h.lock.Lock()
 		h.ErrorMessage = "error while trying to save data: " + err.Error()
@@ -613,29 +650,29 @@ func (h *respHolder) getSystemInfo(taskID string, progress int32, alottedWork in
 		h.lock.Unlock()
 		return oidKey, progress, err
 	}
-	h.TraversedLinks[req.OID] = true
 	h.SystemURL = append(h.SystemURL, oidKey)
 	var retrievalLinks = make(map[string]bool)
 	getLinks(computeSystem, retrievalLinks, false)
 
+	h.lock.Lock()
+	h.TraversedLinks[req.OID] = true
 	removeRetrievalLinks(retrievalLinks, oid, config.Data.AddComputeSkipResources.SystemCollection, h.TraversedLinks)
+	h.lock.Unlock()
 
 	req.SystemID = computeSystemID
 	req.ParentOID = oid
-	for resourceOID, oemFlag := range retrievalLinks {
-		estimatedWork := alottedWork / int32(len(retrievalLinks))
-		req.OID = resourceOID
-		req.OemFlag = oemFlag
-		progress = h.getResourceDetails(taskID, progress, estimatedWork, req)
-	}
-	json.Unmarshal([]byte(updatedResourceData), &computeSystem)
-	searchForm := createServerSearchIndex(computeSystem, oidKey, req.DeviceUUID)
-	//save the final search form here
-	if req.UpdateFlag {
-		err = agmodel.UpdateIndex(searchForm, oidKey, computeSystemUUID)
-	} else {
-		err = agmodel.SaveIndex(searchForm, oidKey, computeSystemUUID)
+	if progress, err = h.crawlLinks(taskID, progress, alottedWork, req, retrievalLinks); err != nil {
+		return oidKey, progress, err
 	}
+	err = h.onCommit(func() error {
+		json.Unmarshal([]byte(updatedResourceData), &computeSystem)
+		searchForm := createServerSearchIndex(computeSystem, oidKey, req.DeviceUUID)
+		//save the final search form here
+		if req.UpdateFlag {
+			return agmodel.UpdateIndex(searchForm, oidKey, computeSystemUUID)
+		}
+		return agmodel.SaveIndex(searchForm, oidKey, computeSystemUUID)
+	})
 	if err != nil {
 		h.ErrorMessage = "error while trying save index values: " + err.Error()
 		h.StatusMessage = response.InternalError
@@ -678,7 +715,7 @@ func (h *respHolder) getStorageInfo(progress int32, alottedWork int32, req getRe
 	data, dbErr := agmodel.GetResource("ComputerSystem", systemURI)
 	if dbErr != nil {
 		log.Println("error while getting the systems data", dbErr.Error())
-		return "", progress, err
+		return "", progress, dbErr
 	}
 	// unmarshall the systems data
 	var systemData map[string]interface{}
@@ -696,7 +733,7 @@ func (h *respHolder) getStorageInfo(progress int32, alottedWork int32, req getRe
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
 	// persist the response with table Storage
 	resourceName := getResourceName(req.OID, true)
-	err = agmodel.GenericSave([]byte(updatedResourceData), resourceName, oidKey)
+	err = h.saveResource(updatedResourceData, resourceName, oidKey)
 	if err != nil {
 		h.lock.Lock()
 		h.ErrorMessage = "error while trying to save data: " + err.Error()
@@ -705,30 +742,28 @@ func (h *respHolder) getStorageInfo(progress int32, alottedWork int32, req getRe
 		h.lock.Unlock()
 		return oidKey, progress, err
 	}
-	h.TraversedLinks[req.OID] = true
 	h.SystemURL = append(h.SystemURL, oidKey)
 	var retrievalLinks = make(map[string]bool)
 	getLinks(computeSystem, retrievalLinks, false)
 
+	h.lock.Lock()
+	h.TraversedLinks[req.OID] = true
 	removeRetrievalLinks(retrievalLinks, oid, config.Data.AddComputeSkipResources.SystemCollection, h.TraversedLinks)
+	h.lock.Unlock()
 
 	req.SystemID = computeSystemID
 	req.ParentOID = oid
-	for resourceOID, oemFlag := range retrievalLinks {
-		estimatedWork := alottedWork / int32(len(retrievalLinks))
-		req.OID = resourceOID
-		req.OemFlag = oemFlag
-		// Passing taskid as empty string
-		progress = h.getResourceDetails("", progress, estimatedWork, req)
-	}
-	json.Unmarshal([]byte(updatedResourceData), &computeSystem)
-	searchForm := createServerSearchIndex(computeSystem, oidKey, req.DeviceUUID)
-	//save the final search form here
-	if req.UpdateFlag {
-		err = agmodel.UpdateIndex(searchForm, oidKey, computeSystemUUID)
-	} else {
-		err = agmodel.SaveIndex(searchForm, oidKey, computeSystemUUID)
-	}
+	// Passing taskid as empty string, the crawl is then never cancelled
+	progress, _ = h.crawlLinks("", progress, alottedWork, req, retrievalLinks)
+	err = h.onCommit(func() error {
+		json.Unmarshal([]byte(updatedResourceData), &computeSystem)
+		searchForm := createServerSearchIndex(computeSystem, oidKey, req.DeviceUUID)
+		//save the final search form here
+		if req.UpdateFlag {
+			return agmodel.UpdateIndex(searchForm, oidKey, computeSystemUUID)
+		}
+		return agmodel.SaveIndex(searchForm, oidKey, computeSystemUUID)
+	})
 	if err != nil {
 		h.ErrorMessage = "error while trying save index values: " + err.Error()
 		h.StatusMessage = response.InternalError
@@ -760,6 +795,10 @@ func createServerSearchIndex(computeSystem map[string]interface{}, oidKey, devic
 	if _, ok := computeSystem["PowerState"]; ok {
 		searchForm["PowerState"] = computeSystem["PowerState"].(string)
 	}
+	// saving the model, manufacturer and rack of the system, to group the servers by them
+	for key, value := range createGroupingIndex(computeSystem) {
+		searchForm[key] = value
+	}
 
 	// saving the firmware version
 	if !strings.Contains(oidKey, "/Storage") {
@@ -813,7 +852,101 @@ func createServerSearchIndex(computeSystem map[string]interface{}, oidKey, devic
 	}
 	return searchForm
 }
-func (h *respHolder) getIndivdualInfo(taskID string, progress int32, alottedWork int32, req getResourceRequest) int32 {
+// createGroupingIndex returns the index values the servers are grouped by: the model and manufacturer of the system,
+// and the rack of the chassis containing it. The rack is indexed once the chassis is saved, when it is saved after the system.
+func createGroupingIndex(computeSystem map[string]interface{}) map[string]interface{} {
+	var searchForm = make(map[string]interface{})
+	if model, ok := computeSystem["Model"].(string); ok {
+		searchForm["Model"] = model
+	}
+	if manufacturer, ok := computeSystem["Manufacturer"].(string); ok {
+		searchForm["Manufacturer"] = manufacturer
+	}
+	links, _ := computeSystem["Links"].(map[string]interface{})
+	chassisLinks, _ := links["Chassis"].([]interface{})
+	for _, link := range chassisLinks {
+		linkData, _ := link.(map[string]interface{})
+		chassisURI, _ := linkData["@odata.id"].(string)
+		data, dbErr := agmodel.GetResource("Chassis", chassisURI)
+		if dbErr != nil {
+			continue
+		}
+		var chassis map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &chassis); err != nil {
+			log.Println("Error while unmarshaling chassis data", err)
+			continue
+		}
+		if rack := getRack(chassis); rack != "" {
+			searchForm["Location/Placement/Rack"] = rack
+			break
+		}
+	}
+	return searchForm
+}
+
+// getRack returns the rack of the Location of the chassis
+func getRack(chassis map[string]interface{}) string {
+	location, _ := chassis["Location"].(map[string]interface{})
+	placement, _ := location["Placement"].(map[string]interface{})
+	rack, _ := placement["Rack"].(string)
+	return rack
+}
+
+// indexChassisRack indexes the rack of the chassis for the systems it contains
+func indexChassisRack(chassisData string) {
+	var chassis map[string]interface{}
+	if err := json.Unmarshal([]byte(chassisData), &chassis); err != nil {
+		log.Println("Error while unmarshaling chassis data", err)
+		return
+	}
+	rack := getRack(chassis)
+	if rack == "" {
+		return
+	}
+	links, _ := chassis["Links"].(map[string]interface{})
+	systemLinks, _ := links["ComputerSystems"].([]interface{})
+	for _, link := range systemLinks {
+		linkData, _ := link.(map[string]interface{})
+		systemURI, _ := linkData["@odata.id"].(string)
+		if systemURI == "" {
+			continue
+		}
+		if err := agmodel.UpdateIndexValues(map[string]interface{}{"Location/Placement/Rack": rack}, systemURI); err != nil {
+			log.Printf("error while trying to index the rack of %v: %v", systemURI, err)
+		}
+	}
+}
+
+// BackfillGroupingIndex indexes the model, manufacturer and rack of the servers added before they were indexed,
+// it is run when the service starts
+func BackfillGroupingIndex() {
+	systemURIs, dbErr := agmodel.GetAllMatchingDetails("ComputerSystem", "", common.InMemory)
+	if dbErr != nil {
+		log.Println("error while trying to get the systems to index: " + dbErr.Error())
+		return
+	}
+	for _, systemURI := range systemURIs {
+		data, dbErr := agmodel.GetResource("ComputerSystem", systemURI)
+		if dbErr != nil {
+			log.Println("error while trying to get the system to index: " + dbErr.Error())
+			continue
+		}
+		var computeSystem map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &computeSystem); err != nil {
+			log.Println("Error while unmarshaling system's data", err)
+			continue
+		}
+		searchForm := createGroupingIndex(computeSystem)
+		if len(searchForm) == 0 {
+			continue
+		}
+		if err := agmodel.UpdateIndexValues(searchForm, systemURI); err != nil {
+			log.Printf("error while trying to index %v: %v", systemURI, err)
+		}
+	}
+}
+
+func (h *respHolder) getIndivdualInfo(taskID string, progress int32, alottedWork int32, req getResourceRequest) (int32, error) {
 	resourceName := getResourceName(req.OID, false)
 	body, _, getResponse, err := contactPlugin(req, "error while trying to get "+resourceName+" details: ")
 	if err != nil {
@@ -821,8 +954,9 @@ func (h *respHolder) getIndivdualInfo(taskID string, progress int32, alottedWork
 		h.ErrorMessage = err.Error()
 		h.StatusMessage = getResponse.StatusMessage
 		h.StatusCode = getResponse.StatusCode
+		h.keepUnder(req.OID, h.StatusCode)
 		h.lock.Unlock()
-		return progress
+		return progress, nil
 	}
 	var resource map[string]interface{}
 	err = json.Unmarshal(body, &resource)
@@ -831,8 +965,9 @@ func (h *respHolder) getIndivdualInfo(taskID string, progress int32, alottedWork
 		h.ErrorMessage = "error while trying unmarshal response body: " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
+		h.keepUnder(req.OID, h.StatusCode)
 		h.lock.Unlock()
-		return progress
+		return progress, nil
 	}
 	oid := resource["@odata.id"].(string)
 	resourceID := resource["Id"].(string)
@@ -842,43 +977,119 @@ func (h *respHolder) getIndivdualInfo(taskID string, progress int32, alottedWork
 	//replacing the uuid while saving the data
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
 	// persist the response with table resource and key as system UUID + Oid Needs relook TODO
-	err = agmodel.GenericSave([]byte(updatedResourceData), resourceName, oidKey)
+	err = h.saveResource(updatedResourceData, resourceName, oidKey)
 	if err != nil {
 		h.lock.Lock()
 		h.ErrorMessage = "error while trying to save data: " + err.Error()
 		h.StatusMessage = response.InternalError
 		h.StatusCode = http.StatusInternalServerError
+		h.keepUnder(req.OID, h.StatusCode)
 		h.lock.Unlock()
-		return progress
+		return progress, nil
 	}
-	h.TraversedLinks[req.OID] = true
 	var retrievalLinks = make(map[string]bool)
 	getLinks(resource, retrievalLinks, false)
 
+	h.lock.Lock()
+	h.TraversedLinks[req.OID] = true
 	removeRetrievalLinks(retrievalLinks, oid, config.Data.AddComputeSkipResources.ChassisCollection, h.TraversedLinks)
+	h.lock.Unlock()
 
 	req.SystemID = resourceID
 	req.ParentOID = oid
-	for resourceOID, oemFlag := range retrievalLinks {
-		estimatedWork := alottedWork / int32(len(retrievalLinks))
-		req.OID = resourceOID
-		req.OemFlag = oemFlag
-		progress = h.getResourceDetails(taskID, progress, estimatedWork, req)
+	return h.crawlLinks(taskID, progress, alottedWork, req, retrievalLinks)
+}
+
+// saveResource saves the resource retrieved, or stages it when the holder stages the retrieval.
+// The systems in the chassis are indexed by the rack of the chassis once it is saved.
+func (h *respHolder) saveResource(data, table, key string) error {
+	if h.Staged == nil {
+		if err := agmodel.GenericSave([]byte(data), table, key); err != nil {
+			return err
+		}
+	} else {
+		h.lock.Lock()
+		h.Staged[table+":"+key] = stagedResource{table: table, key: key, data: data}
+		h.lock.Unlock()
 	}
-	return progress
+	if table == "Chassis" {
+		h.onCommit(func() error {
+			indexChassisRack(data)
+			return nil
+		})
+	}
+	return nil
 }
 
-func (h *respHolder) getResourceDetails(taskID string, progress int32, alottedWork int32, req getResourceRequest) int32 {
-	h.TraversedLinks[req.OID] = true
+// onCommit runs the work depending on the resources saved, the work is deferred until the staged
+// resources are saved when the holder stages the retrieval
+func (h *respHolder) onCommit(work func() error) error {
+	if h.Staged == nil {
+		return work()
+	}
+	h.lock.Lock()
+	h.onCommits = append(h.onCommits, work)
+	h.lock.Unlock()
+	return nil
+}
+
+// keepUnder records a resource which failed to be retrieved by a staged rediscovery, the resources stored
+// under it are then kept as they are, unless the server does not have it any more.
+// The lock of the holder must be held.
+func (h *respHolder) keepUnder(oid string, statusCode int32) {
+	if h.Staged != nil && statusCode != http.StatusNotFound {
+		h.failedURIs = append(h.failedURIs, strings.TrimSuffix(oid, "/"))
+	}
+}
+
+// retrieved reports whether the resource of the server stored with the given key is retrieved again by the
+// staged rediscovery, or is under a resource which failed to be retrieved
+func (h *respHolder) retrieved(deviceUUID, key, uri string) bool {
+	if _, ok := h.Staged[key]; ok {
+		return true
+	}
+	for _, failedURI := range h.failedURIs {
+		failedURI = updateResourceDataWithUUID(failedURI, deviceUUID)
+		if uri == failedURI || strings.HasPrefix(uri, failedURI+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// commit saves the staged resources, prunes the resources no longer retrieved, then does the work
+// deferred until they are saved
+func (h *respHolder) commit(prune func()) error {
+	for _, resource := range h.Staged {
+		if err := agmodel.GenericSave([]byte(resource.data), resource.table, resource.key); err != nil {
+			return err
+		}
+	}
+	prune()
+	for _, work := range h.onCommits {
+		if err := work(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getResourceDetails will retrieve and save the resource, it returns the requests for the linked
+// resources which are yet to be retrieved, they are marked as traversed so that no other worker
+// of the inventory crawl retrieves them again
+func (h *respHolder) getResourceDetails(req getResourceRequest) []getResourceRequest {
+	acquireCrawlRequestSlot()
 	body, _, getResponse, err := contactPlugin(req, "error while trying to get the "+req.OID+" details: ")
+	releaseCrawlRequestSlot()
 	if err != nil {
 		h.lock.Lock()
 		h.ErrorMessage = err.Error()
 		h.StatusMessage = getResponse.StatusMessage
 		h.MsgArgs = getResponse.MsgArgs
 		h.StatusCode = getResponse.StatusCode
+		h.keepUnder(req.OID, h.StatusCode)
 		h.lock.Unlock()
-		return progress
+		return nil
 	}
 	var resourceData map[string]interface{}
 	err = json.Unmarshal(body, &resourceData)
@@ -886,10 +1097,11 @@ func (h *respHolder) getResourceDetails(taskID string, progress int32, alottedWo
 		h.lock.Lock()
 		h.ErrorMessage = "error while trying unmarshal : " + err.Error()
 		h.StatusCode = http.StatusInternalServerError
+		h.keepUnder(req.OID, h.StatusCode)
 		h.StatusMessage = response.InternalError
 		log.Println(h.ErrorMessage)
 		h.lock.Unlock()
-		return progress
+		return nil
 	}
 	oidKey := keyFormation(req.OID, req.SystemID, req.DeviceUUID)
 	var memberFlag bool
@@ -901,44 +1113,38 @@ func (h *respHolder) getResourceDetails(taskID string, progress int32, alottedWo
 	//replacing the uuid while saving the data
 	updatedResourceData := updateResourceDataWithUUID(string(body), req.DeviceUUID)
 	// persist the response with table resourceName and key as system UUID + Oid Needs relook TODO
-	err = agmodel.GenericSave([]byte(updatedResourceData), resourceName, oidKey)
+	err = h.saveResource(updatedResourceData, resourceName, oidKey)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate") {
-			return progress
+			return nil
 		}
 		h.lock.Lock()
 		h.ErrorMessage = "error while trying to save data: " + err.Error()
 		h.StatusCode = http.StatusInternalServerError
+		h.keepUnder(req.OID, h.StatusCode)
 		h.StatusMessage = response.InternalError
 		log.Println(h.ErrorMessage)
 		h.lock.Unlock()
-		return progress
+		return nil
 	}
 	var retrievalLinks = make(map[string]bool)
 	getLinks(resourceData, retrievalLinks, req.OemFlag)
 	/* Loop through  Collection members and discover all of them*/
+	var childReqs []getResourceRequest
+	h.lock.Lock()
+	defer h.lock.Unlock()
 	for oid, oemFlag := range retrievalLinks {
 		// skipping the Retrieval if oid mathches the parent oid
 		if checkRetrieval(oid, req.OID, h.TraversedLinks) {
-			estimatedWork := alottedWork / int32(len(retrievalLinks))
+			h.TraversedLinks[oid] = true
 			childReq := req
 			childReq.OID = oid
 			childReq.ParentOID = req.OID
 			childReq.OemFlag = oemFlag
-			progress = h.getResourceDetails(taskID, progress, estimatedWork, childReq)
+			childReqs = append(childReqs, childReq)
 		}
 	}
-
-	progress = progress + alottedWork
-	var task = fillTaskData(taskID, req.TargetURI, req.TaskRequest, response.RPC{}, common.Running, common.OK, progress, http.MethodPost)
-	err = req.UpdateTask(task)
-
-	if err != nil && (err.Error() == common.Cancelling) {
-		var task = fillTaskData(taskID, req.TargetURI, req.TaskRequest, response.RPC{}, common.Cancelled, common.OK, progress, http.MethodPost)
-		req.UpdateTask(task)
-
-	}
-	return progress
+	return childReqs
 }
 
 func getResourceName(oDataID string, memberFlag bool) string {
@@ -0,0 +1,222 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+var (
+	// errTaskCancelled is returned by the inventory crawl when its task is being cancelled
+	errTaskCancelled = fmt.Errorf("the task is cancelled")
+	// crawlRequestSlots bounds the plugin requests made by all the inventory crawls in progress
+	crawlRequestSlots     chan struct{}
+	crawlRequestSlotsOnce sync.Once
+)
+
+// crawlJob is a resource to be retrieved by the inventory crawl,
+// along with the share of the task progress the resource accounts for
+type crawlJob struct {
+	req         getResourceRequest
+	alottedWork int32
+}
+
+// resourceCrawl retrieves a set of resources and all the resources linked from them,
+// with a pool of workers bounded by the per BMC concurrency limit
+type resourceCrawl struct {
+	h         *respHolder
+	taskID    string
+	lock      sync.Mutex
+	available *sync.Cond
+	queue     []crawlJob
+	pending   int // number of jobs queued or in progress
+	progress  int32
+	reported  int32
+	cancelled bool
+}
+
+// getCrawlConcurrency will return the per BMC and the global concurrency limits of the inventory crawl
+func getCrawlConcurrency() (int, int) {
+	perBMC, global := config.DefaultMaxConcurrentRequestsPerBMC, config.DefaultMaxConcurrentRequests
+	if conf := config.Data.InventoryCrawlConf; conf != nil {
+		if conf.MaxConcurrentRequestsPerBMC > 0 {
+			perBMC = conf.MaxConcurrentRequestsPerBMC
+		}
+		if conf.MaxConcurrentRequests > 0 {
+			global = conf.MaxConcurrentRequests
+		}
+	}
+	return perBMC, global
+}
+
+// acquireCrawlRequestSlot will block until the number of plugin requests made by
+// all the inventory crawls in progress is below the global concurrency limit
+func acquireCrawlRequestSlot() {
+	crawlRequestSlotsOnce.Do(func() {
+		_, global := getCrawlConcurrency()
+		crawlRequestSlots = make(chan struct{}, global)
+	})
+	crawlRequestSlots <- struct{}{}
+}
+
+// releaseCrawlRequestSlot will release the slot taken with acquireCrawlRequestSlot
+func releaseCrawlRequestSlot() {
+	<-crawlRequestSlots
+}
+
+// crawlLinks will retrieve the given links and all the resources linked from them in parallel.
+// The alotted work is shared among the links, each resource passes its share on to the resources
+// linked from it, and the task progress is updated as the resources are retrieved.
+// req holds the details common to all the links, such as the parent and the target of the links.
+// It returns errTaskCancelled when the task is being cancelled, the remaining resources are then skipped.
+func (h *respHolder) crawlLinks(taskID string, progress int32, alottedWork int32, req getResourceRequest, links map[string]bool) (int32, error) {
+	if len(links) == 0 {
+		return progress + alottedWork, nil
+	}
+	c := &resourceCrawl{
+		h:        h,
+		taskID:   taskID,
+		progress: progress,
+		reported: progress,
+	}
+	c.available = sync.NewCond(&c.lock)
+
+	var reqs []getResourceRequest
+	h.lock.Lock()
+	for oid, oemFlag := range links {
+		h.TraversedLinks[oid] = true
+		linkReq := req
+		linkReq.OID = oid
+		linkReq.OemFlag = oemFlag
+		reqs = append(reqs, linkReq)
+	}
+	h.lock.Unlock()
+	c.progress += c.enqueue(reqs, alottedWork)
+	c.pending = len(c.queue)
+
+	workers, _ := getCrawlConcurrency()
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.work()
+		}()
+	}
+	// the task is updated from the goroutine of the caller, as UpdateTaskData ends the goroutine
+	// when the task is being cancelled, the workers are stopped before the goroutine ends
+	defer func() {
+		c.cancel()
+		wg.Wait()
+	}()
+	return c.reportProgress(req)
+}
+
+// enqueue will add the jobs for the given requests sharing the alotted work among them,
+// it returns the part of the alotted work which could not be shared. The caller must hold
+// the lock of the crawl, if the crawl is already started.
+func (c *resourceCrawl) enqueue(reqs []getResourceRequest, alottedWork int32) int32 {
+	if len(reqs) == 0 {
+		return alottedWork
+	}
+	share := alottedWork / int32(len(reqs))
+	for _, req := range reqs {
+		c.queue = append(c.queue, crawlJob{req: req, alottedWork: share})
+	}
+	return alottedWork - share*int32(len(reqs))
+}
+
+// work will retrieve the queued resources until all the resources of the crawl are retrieved
+func (c *resourceCrawl) work() {
+	for {
+		c.lock.Lock()
+		for len(c.queue) == 0 && c.pending > 0 {
+			c.available.Wait()
+		}
+		if c.pending == 0 {
+			c.lock.Unlock()
+			return
+		}
+		// the most recently found resources are retrieved first, this keeps the queue short
+		job := c.queue[len(c.queue)-1]
+		c.queue = c.queue[:len(c.queue)-1]
+		cancelled := c.cancelled
+		c.lock.Unlock()
+
+		var childReqs []getResourceRequest
+		if !cancelled {
+			childReqs = c.h.getResourceDetails(job.req)
+		}
+
+		c.lock.Lock()
+		c.progress += c.enqueue(childReqs, job.alottedWork)
+		c.pending += len(childReqs) - 1
+		c.lock.Unlock()
+		c.available.Broadcast()
+	}
+}
+
+// cancel will make the workers skip the remaining resources of the crawl
+func (c *resourceCrawl) cancel() {
+	c.lock.Lock()
+	c.cancelled = true
+	c.lock.Unlock()
+	c.available.Broadcast()
+}
+
+// reportProgress will report the progress of the crawl on its task until all the resources are retrieved,
+// it returns the progress of the crawl, and errTaskCancelled when the task is being cancelled
+func (c *resourceCrawl) reportProgress(req getResourceRequest) (int32, error) {
+	c.lock.Lock()
+	for c.pending > 0 && !c.cancelled {
+		if c.taskID == "" || c.progress <= c.reported {
+			c.available.Wait()
+			continue
+		}
+		c.reported = c.progress
+		progress := c.progress
+		c.lock.Unlock()
+		err := c.updateTask(req, progress)
+		c.lock.Lock()
+		if err != nil {
+			c.cancelled = true
+		}
+	}
+	progress, cancelled := c.progress, c.cancelled
+	c.lock.Unlock()
+	if cancelled {
+		return progress, errTaskCancelled
+	}
+	return progress, nil
+}
+
+// updateTask will report the progress of the crawl on the task, it returns errTaskCancelled
+// when the task is being cancelled
+func (c *resourceCrawl) updateTask(req getResourceRequest, progress int32) error {
+	var task = fillTaskData(c.taskID, req.TargetURI, req.TaskRequest, response.RPC{}, common.Running, common.OK, progress, http.MethodPost)
+	err := req.UpdateTask(task)
+	if err != nil && (err.Error() == common.Cancelling) {
+		var task = fillTaskData(c.taskID, req.TargetURI, req.TaskRequest, response.RPC{}, common.Cancelled, common.OK, progress, http.MethodPost)
+		req.UpdateTask(task)
+		return errTaskCancelled
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"fmt"
+	"net/http"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestGetCrawlConcurrency(t *testing.T) {
+	config.SetUpMockConfig(t)
+	perBMC, global := getCrawlConcurrency()
+	if perBMC != config.Data.InventoryCrawlConf.MaxConcurrentRequestsPerBMC || global != config.Data.InventoryCrawlConf.MaxConcurrentRequests {
+		t.Errorf("getCrawlConcurrency() = %v, %v, want the configured limits", perBMC, global)
+	}
+
+	conf := config.Data.InventoryCrawlConf
+	config.Data.InventoryCrawlConf = nil
+	defer func() {
+		config.Data.InventoryCrawlConf = conf
+	}()
+	perBMC, global = getCrawlConcurrency()
+	if perBMC != config.DefaultMaxConcurrentRequestsPerBMC || global != config.DefaultMaxConcurrentRequests {
+		t.Errorf("getCrawlConcurrency() = %v, %v, want the default limits", perBMC, global)
+	}
+}
+
+func TestResourceCrawlEnqueue(t *testing.T) {
+	var c resourceCrawl
+	reqs := []getResourceRequest{
+		getResourceRequest{OID: "/redfish/v1/Systems/1/Memory"},
+		getResourceRequest{OID: "/redfish/v1/Systems/1/Processors"},
+		getResourceRequest{OID: "/redfish/v1/Systems/1/Storage"},
+	}
+	if remainder := c.enqueue(reqs, 10); remainder != 1 {
+		t.Errorf("enqueue() = %v, want 1", remainder)
+	}
+	if len(c.queue) != len(reqs) {
+		t.Fatalf("enqueue() queued %v jobs, want %v", len(c.queue), len(reqs))
+	}
+	for _, job := range c.queue {
+		if job.alottedWork != 3 {
+			t.Errorf("enqueue() alotted work = %v, want 3", job.alottedWork)
+		}
+	}
+	if remainder := c.enqueue(nil, 10); remainder != 10 {
+		t.Errorf("enqueue() = %v, want the whole work when there is nothing to retrieve", remainder)
+	}
+}
+
+// mockCrawlBMC serves a system with many memory modules, counting the requests
+// made to it and taking some time for each of them
+func mockCrawlBMC(memoryCount int) (func() int, func(string, string, string, string, interface{}, map[string]string) (*http.Response, error)) {
+	bmc := &mockBMC{resources: make(map[string]string)}
+	bmc.set("/ODIM/v1/Systems/1", `{"@odata.id":"/ODIM/v1/Systems/1","Id":"1","UUID":"1-uuid","Memory":{"@odata.id":"/ODIM/v1/Systems/1/Memory"}}`)
+	members := ""
+	for i := 1; i <= memoryCount; i++ {
+		bmc.set(fmt.Sprintf("/ODIM/v1/Systems/1/Memory/%v", i), fmt.Sprintf(`{"@odata.id":"/ODIM/v1/Systems/1/Memory/%v","Id":"%v"}`, i, i))
+		if members != "" {
+			members += ","
+		}
+		members += fmt.Sprintf(`{"@odata.id":"/ODIM/v1/Systems/1/Memory/%v"}`, i)
+	}
+	bmc.set("/ODIM/v1/Systems/1/Memory", `{"@odata.id":"/ODIM/v1/Systems/1/Memory","Members":[`+members+`]}`)
+	var lock sync.Mutex
+	var requests int
+	count := func() int {
+		lock.Lock()
+		defer lock.Unlock()
+		return requests
+	}
+	contactClient := func(url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+		lock.Lock()
+		requests++
+		lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return bmc.contactClient(url, method, token, odataID, body, credentials)
+	}
+	return count, contactClient
+}
+
+func TestCrawlCancelled(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	memoryCount := 40
+	tests := []struct {
+		name string
+		// goexit ends the goroutine on the cancellation, as UpdateTaskData does
+		goexit bool
+	}{
+		{name: "cancellation returned"},
+		{name: "goroutine ended on the cancellation", goexit: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests, contactClient := mockCrawlBMC(memoryCount)
+			var lock sync.Mutex
+			var updates int
+			var cancelled bool
+			req := getResourceRequest{
+				ContactClient:    contactClient,
+				GetPluginStatus:  GetPluginStatusForTesting,
+				Plugin:           agmodel.Plugin{IP: "localhost", Port: "9091", PreferredAuthType: "BasicAuth"},
+				LoginCredentials: map[string]string{"UserName": "admin", "Password": "password"},
+				HTTPMethodType:   http.MethodGet,
+				OID:              "/redfish/v1/Systems/1",
+				DeviceUUID:       "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+				UpdateFlag:       true,
+				UpdateTask: func(task common.TaskData) error {
+					lock.Lock()
+					defer lock.Unlock()
+					if task.TaskState == common.Cancelled {
+						cancelled = true
+						return nil
+					}
+					// the task gets cancelled after a few resources are retrieved
+					if updates++; updates < 3 {
+						return nil
+					}
+					if tt.goexit {
+						cancelled = true
+						runtime.Goexit()
+					}
+					return fmt.Errorf(common.Cancelling)
+				},
+			}
+			var h respHolder
+			h.TraversedLinks = make(map[string]bool)
+			var err error
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				_, _, err = h.getSystemInfo("123", 0, 100, req)
+			}()
+			<-done
+			if !tt.goexit && err != errTaskCancelled {
+				t.Errorf("getSystemInfo() error = %v, want %v", err, errTaskCancelled)
+			}
+			if !cancelled {
+				t.Errorf("the task is not marked as cancelled")
+			}
+			// the remaining resources are not retrieved after the cancellation, even after a while
+			retrieved := requests()
+			time.Sleep(100 * time.Millisecond)
+			if requests() != retrieved || retrieved >= memoryCount+2 {
+				t.Errorf("%v resources are retrieved after the cancellation, then %v, want fewer than %v", retrieved, requests(), memoryCount+2)
+			}
+		})
+	}
+}
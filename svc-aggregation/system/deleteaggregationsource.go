@@ -143,23 +143,24 @@ func (e *ExternalInterface) deletePlugin(oid string) response.RPC {
 		return common.GeneralError(http.StatusNotAcceptable, response.ResourceCannotBeDeleted, errMsg, nil, nil)
 	}
 
-	// verifying if plugin is up
-	var pluginContactRequest getResourceRequest
-
-	pluginContactRequest.ContactClient = e.ContactClient
-	pluginContactRequest.Plugin = plugin
-	pluginContactRequest.StatusPoll = false
-	pluginContactRequest.HTTPMethodType = http.MethodGet
-	pluginContactRequest.LoginCredentials = map[string]string{
-		"UserName": plugin.Username,
-		"Password": string(plugin.Password),
-	}
-	pluginContactRequest.OID = "/ODIM/v1/Status"
-	_, _, _, err := contactPlugin(pluginContactRequest, "error while getting the details "+pluginContactRequest.OID+": ")
-	if err == nil { // no err means plugin is still up, so we can't remove it
-		errMsg := "error: plugin is still up, so it cannot be removed."
-		log.Println(errMsg)
-		return common.GeneralError(http.StatusNotAcceptable, response.ResourceCannotBeDeleted, errMsg, nil, nil)
+	// verifying if plugin is up, a drained plugin is removed while it is running
+	if !plugin.Draining {
+		var pluginContactRequest getResourceRequest
+		pluginContactRequest.ContactClient = e.ContactClient
+		pluginContactRequest.Plugin = plugin
+		pluginContactRequest.StatusPoll = false
+		pluginContactRequest.HTTPMethodType = http.MethodGet
+		pluginContactRequest.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		pluginContactRequest.OID = "/ODIM/v1/Status"
+		_, _, _, err := contactPlugin(pluginContactRequest, "error while getting the details "+pluginContactRequest.OID+": ")
+		if err == nil { // no err means plugin is still up, so we can't remove it
+			errMsg := "error: plugin is still up, so it cannot be removed."
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusNotAcceptable, response.ResourceCannotBeDeleted, errMsg, nil, nil)
+		}
 	}
 
 	// deleting the manager info
@@ -263,6 +264,7 @@ func (e *ExternalInterface) deleteCompute(key string, index int) response.RPC {
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
 	e.EventNotification(key, "ResourceRemoved", "SystemsCollection")
+	UpdateDynamicAggregates()
 	resp.Header = map[string]string{
 		"Cache-Control":     "no-cache",
 		"Transfer-Encoding": "chunked",
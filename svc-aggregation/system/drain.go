@@ -0,0 +1,240 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// DrainRequest is the payload of the drain action of the aggregation source of a plugin
+type DrainRequest struct {
+	// Draining is false to put the plugin back in service, the plugin is drained when it is not given
+	Draining *bool `json:"Draining,omitempty"`
+	// TargetPluginID is the plugin to move the BMCs of the drained plugin to, they are left where they are when empty
+	TargetPluginID string `json:"TargetPluginID,omitempty"`
+}
+
+// ParseDrainRequest parses the request body of a drain action, an empty body drains the plugin without moving its BMCs.
+// It returns the names of the conflicting properties along with the error.
+func ParseDrainRequest(requestBody []byte) (DrainRequest, []interface{}, error) {
+	var drainRequest DrainRequest
+	if len(strings.TrimSpace(string(requestBody))) != 0 {
+		if err := json.Unmarshal(requestBody, &drainRequest); err != nil {
+			return drainRequest, nil, err
+		}
+	}
+	if drainRequest.Draining == nil {
+		draining := true
+		drainRequest.Draining = &draining
+	}
+	if !*drainRequest.Draining && drainRequest.TargetPluginID != "" {
+		return drainRequest, []interface{}{"TargetPluginID", "Draining"}, fmt.Errorf("TargetPluginID cannot be given when Draining is false")
+	}
+	return drainRequest, nil, nil
+}
+
+// DrainAggregationSource marks the plugin of the aggregation source as draining, so that no BMC
+// is added to it anymore and it can be deleted while it is still running. When a target plugin is
+// given, the BMCs managed by the drained plugin are moved to it one after the other.
+func (e *ExternalInterface) DrainAggregationSource(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	drainRequest, conflictingProperties, err := ParseDrainRequest(req.RequestBody)
+	if err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		log.Println(errMsg)
+		if conflictingProperties != nil {
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, conflictingProperties, taskInfo)
+		}
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+
+	aggregationSourceURI := strings.TrimSuffix(strings.Split(req.URL, "/Actions/")[0], "/")
+	aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(aggregationSourceURI)
+	if dbErr != nil {
+		log.Printf("error getting AggregationSource : %v", dbErr)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, dbErr.Error(), []interface{}{"AggregationSource", aggregationSourceURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, dbErr.Error(), nil, taskInfo)
+	}
+	links := aggregationSource.Links.(map[string]interface{})
+	oem := links["Oem"].(map[string]interface{})
+	if _, ok := oem["PluginType"]; !ok {
+		errMsg := "error: only the aggregation source of a plugin can be drained"
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errMsg, []interface{}{"AggregationSource.Drain"}, taskInfo)
+	}
+	pluginID := oem["PluginID"].(string)
+	plugin, errs := agmodel.GetPluginData(pluginID)
+	if errs != nil {
+		errMsg := "error while getting plugin data: " + errs.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", pluginID}, taskInfo)
+	}
+	if drainRequest.TargetPluginID != "" {
+		if errResp := validateDrainTarget(plugin, drainRequest.TargetPluginID, taskInfo); errResp != nil {
+			return *errResp
+		}
+	}
+
+	if resp := e.setPluginDraining(plugin, *drainRequest.Draining, aggregationSource, aggregationSourceURI); resp.StatusCode != http.StatusOK {
+		return common.GeneralError(resp.StatusCode, resp.StatusMessage, "error while trying to drain the plugin "+pluginID, nil, taskInfo)
+	}
+	log.Printf("info: plugin %v draining is set to %v", pluginID, *drainRequest.Draining)
+
+	if drainRequest.TargetPluginID != "" {
+		if resp := e.moveBMCsOfPlugin(taskID, targetURI, string(req.RequestBody), pluginID, drainRequest.TargetPluginID); resp.StatusCode != http.StatusOK {
+			return common.GeneralError(resp.StatusCode, resp.StatusMessage, "error while trying to move the BMCs of the plugin "+pluginID, nil, taskInfo)
+		}
+	}
+
+	resp := rediscoverSuccessResponse()
+	var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, common.OK, 100, http.MethodPost)
+	err = e.UpdateTask(task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.UpdateTask(task)
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// validateDrainTarget checks that the BMCs of the drained plugin can be moved to the target plugin
+func validateDrainTarget(plugin agmodel.Plugin, targetPluginID string, taskInfo *common.TaskUpdateInfo) *response.RPC {
+	if targetPluginID == plugin.ID {
+		errMsg := "error: the BMCs of a drained plugin cannot be moved to the plugin itself"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"TargetPluginID", "PluginID"}, taskInfo)
+		return &resp
+	}
+	targetPlugin, errs := agmodel.GetPluginData(targetPluginID)
+	if errs != nil {
+		errMsg := "error while getting plugin data: " + errs.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", targetPluginID}, taskInfo)
+		return &resp
+	}
+	if targetPlugin.Draining {
+		errMsg := fmt.Sprintf("error: plugin %v is draining, the BMCs cannot be moved to it", targetPluginID)
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+		return &resp
+	}
+	if targetPlugin.PluginType != plugin.PluginType {
+		errMsg := fmt.Sprintf("error: plugin %v is of type %v, the drained plugin is of type %v", targetPluginID, targetPlugin.PluginType, plugin.PluginType)
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"TargetPluginID", "PluginType"}, taskInfo)
+		return &resp
+	}
+	return nil
+}
+
+// setPluginDraining records whether the plugin is draining, in the plugin data and in the links of its aggregation source
+func (e *ExternalInterface) setPluginDraining(plugin agmodel.Plugin, draining bool, aggregationSource agmodel.AggregationSource, aggregationSourceURI string) response.RPC {
+	// the plugin password is saved encrypted
+	ciphertext, err := e.EncryptPassword(plugin.Password)
+	if err != nil {
+		errMsg := "error while trying to encrypt: " + err.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	plugin.Password = ciphertext
+	plugin.Draining = draining
+	if dbErr := agmodel.UpdatePluginData(plugin, plugin.ID); dbErr != nil {
+		errMsg := "error while trying to update plugin info: " + dbErr.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	oem := aggregationSource.Links.(map[string]interface{})["Oem"].(map[string]interface{})
+	if draining {
+		oem["Draining"] = true
+	} else {
+		delete(oem, "Draining")
+	}
+	if dbErr := agmodel.UpdateAggregtionSource(aggregationSource, aggregationSourceURI); dbErr != nil {
+		errMsg := "error while trying to update aggregation source info: " + dbErr.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	return response.RPC{
+		StatusCode: http.StatusOK,
+	}
+}
+
+// moveBMCsOfPlugin moves every BMC managed by the plugin to the target plugin, the same way as
+// a PATCH of Links.Oem.PluginID of their aggregation source does. All the BMCs are tried, the
+// response of the first failed move is returned.
+func (e *ExternalInterface) moveBMCsOfPlugin(taskID, targetURI, taskRequest, pluginID, targetPluginID string) response.RPC {
+	targets, dbErr := agmodel.GetAllSystems()
+	if dbErr != nil {
+		errMsg := "error while trying to get the BMCs of the plugin: " + dbErr.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	var deviceUUIDs []string
+	for _, target := range targets {
+		if target.PluginID == pluginID {
+			deviceUUIDs = append(deviceUUIDs, target.DeviceUUID)
+		}
+	}
+	moveRequest, _ := json.Marshal(map[string]interface{}{
+		"Links": map[string]interface{}{
+			"Oem": map[string]interface{}{
+				"PluginID": targetPluginID,
+			},
+		},
+	})
+	resp := response.RPC{
+		StatusCode: http.StatusOK,
+	}
+	for i, deviceUUID := range deviceUUIDs {
+		moveResp := e.UpdateAggregationSource(&aggregatorproto.AggregatorRequest{
+			URL:         "/redfish/v1/AggregationService/AggregationSources/" + deviceUUID,
+			RequestBody: moveRequest,
+		})
+		if moveResp.StatusCode != http.StatusOK {
+			log.Printf("error: BMC with ID %v could not be moved from the plugin %v to the plugin %v", deviceUUID, pluginID, targetPluginID)
+			if resp.StatusCode == http.StatusOK {
+				resp = moveResp
+			}
+		}
+		percentComplete := int32((i + 1) * 100 / len(deviceUUIDs))
+		if percentComplete == 100 {
+			// the task is completed by the caller
+			continue
+		}
+		var task = fillTaskData(taskID, targetURI, taskRequest, response.RPC{}, common.Running, common.OK, percentComplete, http.MethodPost)
+		err := e.UpdateTask(task)
+		if err != nil && err.Error() == common.Cancelling {
+			task = fillTaskData(taskID, targetURI, taskRequest, response.RPC{}, common.Cancelled, common.Critical, percentComplete, http.MethodPost)
+			e.UpdateTask(task)
+			runtime.Goexit()
+		}
+	}
+	return resp
+}
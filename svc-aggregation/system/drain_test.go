@@ -0,0 +1,265 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestParseDrainRequest(t *testing.T) {
+	tests := []struct {
+		name                  string
+		body                  []byte
+		wantDraining          bool
+		wantTargetPluginID    string
+		conflictingProperties []interface{}
+		wantErr               bool
+	}{
+		{name: "without request body", body: nil, wantDraining: true},
+		{name: "with target plugin", body: []byte(`{"TargetPluginID":"GRF"}`), wantDraining: true, wantTargetPluginID: "GRF"},
+		{name: "put back in service", body: []byte(`{"Draining":false}`), wantDraining: false},
+		{
+			name:                  "target plugin without draining",
+			body:                  []byte(`{"Draining":false,"TargetPluginID":"GRF"}`),
+			wantTargetPluginID:    "GRF",
+			conflictingProperties: []interface{}{"TargetPluginID", "Draining"},
+			wantErr:               true,
+		},
+		{name: "invalid request body", body: []byte(`Draining`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflictingProperties, err := ParseDrainRequest(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDrainRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(conflictingProperties, tt.conflictingProperties) {
+				t.Errorf("ParseDrainRequest() conflicting properties = %v, want %v", conflictingProperties, tt.conflictingProperties)
+			}
+			if err == nil && (*got.Draining != tt.wantDraining || got.TargetPluginID != tt.wantTargetPluginID) {
+				t.Errorf("ParseDrainRequest() = %v, %v, want %v, %v", *got.Draining, got.TargetPluginID, tt.wantDraining, tt.wantTargetPluginID)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_DrainAggregationSource(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockPluginData(t, "GRF")
+	mockPluginData(t, "ILO")
+	mockData(t, common.OnDisk, "Plugin", "DrainedPlugin", agmodel.Plugin{
+		IP:                "localhost",
+		Port:              "9091",
+		Username:          "admin",
+		Password:          getEncryptedKey(t, []byte("password")),
+		ID:                "DrainedPlugin",
+		PreferredAuthType: "BasicAuth",
+		ManagerUUID:       "1234877451-1236",
+		Draining:          true,
+	})
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "localhost:9091",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "GRF", "PluginType": "Compute"}},
+	}, "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11")
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "100.0.0.1",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "GRF"}},
+	}, "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e")
+
+	p := &ExternalInterface{
+		ContactClient:   mockContactClient,
+		Auth:            mockIsAuthorized,
+		UpdateTask:      mockUpdateTask,
+		EncryptPassword: common.EncryptWithPublicKey,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+	}
+	tests := []struct {
+		name         string
+		url          string
+		reqBody      []byte
+		wantCode     int32
+		wantDraining bool
+	}{
+		{
+			name:     "aggregation source of a BMC",
+			url:      "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Drain/",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "BMCs moved to the drained plugin itself",
+			url:      "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Drain/",
+			reqBody:  []byte(`{"TargetPluginID":"GRF"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "BMCs moved to a draining plugin",
+			url:      "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Drain/",
+			reqBody:  []byte(`{"TargetPluginID":"DrainedPlugin"}`),
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:     "BMCs moved to an unknown plugin",
+			url:      "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Drain/",
+			reqBody:  []byte(`{"TargetPluginID":"NotAPlugin"}`),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:         "plugin drained",
+			url:          "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Drain/",
+			wantCode:     http.StatusOK,
+			wantDraining: true,
+		},
+		{
+			name:         "plugin put back in service",
+			url:          "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Drain/",
+			reqBody:      []byte(`{"Draining":false}`),
+			wantCode:     http.StatusOK,
+			wantDraining: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          tt.url,
+				RequestBody:  tt.reqBody,
+			}
+			got := p.DrainAggregationSource("someTaskID", "someUser", req)
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("DrainAggregationSource() = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			plugin, errs := agmodel.GetPluginData("GRF")
+			if errs != nil {
+				t.Fatalf("error: %v", errs)
+			}
+			if plugin.Draining != tt.wantDraining {
+				t.Errorf("DrainAggregationSource() left the plugin draining %v, want %v", plugin.Draining, tt.wantDraining)
+			}
+			aggregationSource, _ := agmodel.GetAggregationSourceInfo("/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11")
+			data, _ := json.Marshal(aggregationSource.Links)
+			var links struct {
+				Oem struct {
+					Draining bool
+				}
+			}
+			json.Unmarshal(data, &links)
+			if links.Oem.Draining != tt.wantDraining {
+				t.Errorf("DrainAggregationSource() recorded draining %v in the aggregation source, want %v", links.Oem.Draining, tt.wantDraining)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_DeleteDrainedPlugin(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	// the plugin is still up, as the status of the plugins of noStatusUser is found
+	mockData(t, common.OnDisk, "Plugin", "DrainedPlugin", agmodel.Plugin{
+		IP:                "localhost",
+		Port:              "9091",
+		Username:          "noStatusUser",
+		Password:          getEncryptedKey(t, []byte("password")),
+		ID:                "DrainedPlugin",
+		PreferredAuthType: "BasicAuth",
+		ManagerUUID:       "1234877451-1236",
+		Draining:          true,
+	})
+	mockManagersData("/redfish/v1/Managers/1234877451-1236", map[string]interface{}{
+		"Name": "DrainedPlugin",
+		"UUID": "1234877451-1236",
+	})
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "localhost:9091",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "DrainedPlugin", "PluginType": "Compute", "Draining": true}},
+	}, "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11")
+	// a BMC left to the drained plugin
+	mockDeviceData("ef83e569-7336-492a-aaee-31c02d9db831", agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		Password:       []byte("imKp3Q6Cx989b6JSPHnRhritEcXWtaB3zqVBkSwhCenJYfgAYBf9FlAocE"),
+		UserName:       "admin",
+		DeviceUUID:     "ef83e569-7336-492a-aaee-31c02d9db831",
+		PluginID:       "DrainedPlugin",
+	})
+	reqData, _ := json.Marshal(map[string]interface{}{"@odata.id": "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1"})
+	mockSystemResourceData(reqData, "ComputerSystem", "/redfish/v1/Systems/ef83e569-7336-492a-aaee-31c02d9db831:1")
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "100.0.0.1",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "DrainedPlugin"}},
+	}, "/redfish/v1/AggregationService/AggregationSources/ef83e569-7336-492a-aaee-31c02d9db831")
+
+	d := &ExternalInterface{
+		ContactClient:           mockContactClientForDelete,
+		DeleteComputeSystem:     deleteComputeforTest,
+		DeleteSystem:            deleteSystemforTest,
+		DeleteEventSubscription: mockDeleteSubscription,
+		EventNotification:       mockEventNotification,
+		DecryptPassword:         stubDevicePassword,
+	}
+	req := &aggregatorproto.AggregatorRequest{
+		SessionToken: "SessionToken",
+		URL:          "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11",
+	}
+	// the drained plugin is not deleted while it still manages a BMC
+	got := d.DeleteAggregationSource(req)
+	if got.StatusCode != http.StatusNotAcceptable {
+		t.Errorf("DeleteAggregationSource() = %v, want %v", got.StatusCode, http.StatusNotAcceptable)
+	}
+	if _, errs := agmodel.GetAggregationSourceInfo("/redfish/v1/AggregationService/AggregationSources/ef83e569-7336-492a-aaee-31c02d9db831"); errs != nil {
+		t.Errorf("DeleteAggregationSource() deleted the aggregation source of the BMC of the drained plugin")
+	}
+	if _, errs := agmodel.GetPluginData("DrainedPlugin"); errs != nil {
+		t.Errorf("DeleteAggregationSource() deleted the drained plugin which still manages a BMC")
+	}
+
+	// once its last BMC is moved or deleted, the drained plugin is deleted while it is still up
+	if errs := agmodel.DeleteSystem("ef83e569-7336-492a-aaee-31c02d9db831"); errs != nil {
+		t.Fatalf("error while deleting the BMC: %v", errs)
+	}
+	got = d.DeleteAggregationSource(req)
+	if got.StatusCode != http.StatusNoContent {
+		t.Errorf("DeleteAggregationSource() = %v, want %v", got.StatusCode, http.StatusNoContent)
+	}
+	if _, errs := agmodel.GetPluginData("DrainedPlugin"); errs == nil {
+		t.Errorf("DeleteAggregationSource() left the drained plugin")
+	}
+}
@@ -0,0 +1,133 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+	uuid "github.com/satori/go.uuid"
+)
+
+// deviceUUIDNamespace is the name space of the identifiers which ODIM derives for the added servers.
+// It must never change, as the identifiers of the servers already added would change with it.
+var deviceUUIDNamespace = uuid.Must(uuid.FromString("8c3c0e5f-2d1e-4f67-9c4a-6d1f3f0b7a21"))
+
+// deviceIdentity is what identifies the physical BMC behind an aggregation source
+type deviceIdentity struct {
+	// Source tells where the identity comes from: ServiceRoot, ComputerSystem or ManagerAddress
+	Source string
+	Value  string
+}
+
+// deviceUUID derives the ODIM identifier of the server from its identity,
+// so that the server gets the same identifier every time it is added
+func (identity deviceIdentity) deviceUUID() string {
+	return uuid.NewV5(deviceUUIDNamespace, identity.Source+":"+identity.Value).String()
+}
+
+// normalizeUUID returns the UUID in its canonical form, or an empty string when
+// the value is not a usable UUID, for example the nil UUID some BMCs report
+func normalizeUUID(value string) string {
+	id, err := uuid.FromString(strings.TrimSpace(value))
+	if err != nil || uuid.Equal(id, uuid.Nil) {
+		return ""
+	}
+	return id.String()
+}
+
+// getDeviceIdentity finds the identity of the BMC, in the order of preference
+// the UUID of its service root as returned by the plugin in the validate response,
+// the UUID of its first computer system and the manager address
+func getDeviceIdentity(validateResponse []byte, managerAddress string, req getResourceRequest) deviceIdentity {
+	var device Device
+	if err := json.Unmarshal(validateResponse, &device); err == nil {
+		if id := normalizeUUID(device.DeviceUUID); id != "" {
+			return deviceIdentity{Source: "ServiceRoot", Value: id}
+		}
+	}
+	if id := getComputerSystemUUID(req); id != "" {
+		return deviceIdentity{Source: "ComputerSystem", Value: id}
+	}
+	log.Printf("no UUID found for the BMC with manager address %v, its identifier is derived from the address", managerAddress)
+	return deviceIdentity{Source: "ManagerAddress", Value: strings.ToLower(strings.TrimSpace(managerAddress))}
+}
+
+// getComputerSystemUUID returns the UUID of the first computer system of the BMC
+func getComputerSystemUUID(req getResourceRequest) string {
+	req.OID = "/redfish/v1/Systems"
+	req.HTTPMethodType = http.MethodGet
+	body, _, _, err := contactPlugin(req, "error while trying to get system collection details: ")
+	if err != nil {
+		log.Println(err.Error())
+		return ""
+	}
+	var collection struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &collection); err != nil || len(collection.Members) == 0 {
+		return ""
+	}
+	req.OID = collection.Members[0].OdataID
+	body, _, _, err = contactPlugin(req, "error while trying to get system details: ")
+	if err != nil {
+		log.Println(err.Error())
+		return ""
+	}
+	var computerSystem struct {
+		UUID string `json:"UUID"`
+	}
+	if err := json.Unmarshal(body, &computerSystem); err != nil {
+		return ""
+	}
+	return normalizeUUID(computerSystem.UUID)
+}
+
+// reserveDevice makes sure the BMC is neither added already nor being added through
+// another request, possibly under another address, and records the ongoing request for it
+func reserveDevice(deviceUUID string, identity deviceIdentity, addResourceRequest AddResourceRequest, taskInfo *common.TaskUpdateInfo) *response.RPC {
+	ActiveReqSet.UpdateMu.Lock()
+	defer ActiveReqSet.UpdateMu.Unlock()
+	if _, exist := ActiveReqSet.ReqRecord[deviceUUID]; exist {
+		errMsg := fmt.Sprintf("error: An active request already exists for adding the BMC with %v UUID %v", identity.Source, identity.Value)
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"AggregationSource", "HostName", addResourceRequest.ManagerAddress}, taskInfo)
+		return &resp
+	}
+	if target, err := agmodel.GetTarget(deviceUUID); err == nil {
+		errMsg := fmt.Sprintf("error: the BMC with manager address %v is already added as aggregation source %v with manager address %v",
+			addResourceRequest.ManagerAddress, deviceUUID, target.ManagerAddress)
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusConflict, response.ResourceAlreadyExists, errMsg, []interface{}{"AggregationSource", "HostName", target.ManagerAddress}, taskInfo)
+		return &resp
+	}
+	ActiveReqSet.ReqRecord[deviceUUID] = addResourceRequest.Oem.PluginID
+	return nil
+}
+
+// releaseDevice removes the record of the ongoing request for the BMC
+func releaseDevice(deviceUUID string) {
+	ActiveReqSet.UpdateMu.Lock()
+	delete(ActiveReqSet.ReqRecord, deviceUUID)
+	ActiveReqSet.UpdateMu.Unlock()
+}
@@ -0,0 +1,99 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestNormalizeUUID(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "2B6A2F0E-4C5D-4A6B-9F3E-1A2B3C4D5E6F", want: "2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f"},
+		{value: " 2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f ", want: "2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f"},
+		{value: "00000000-0000-0000-0000-000000000000", want: ""},
+		{value: "1s7sda8asd-asdas8as0", want: ""},
+		{value: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeUUID(tt.value); got != tt.want {
+			t.Errorf("normalizeUUID(%v) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestDeviceIdentityDeviceUUID(t *testing.T) {
+	serviceRoot := deviceIdentity{Source: "ServiceRoot", Value: "2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f"}
+	if serviceRoot.deviceUUID() != serviceRoot.deviceUUID() {
+		t.Errorf("deviceUUID() is expected to be the same for the same identity")
+	}
+	computerSystem := deviceIdentity{Source: "ComputerSystem", Value: serviceRoot.Value}
+	if serviceRoot.deviceUUID() == computerSystem.deviceUUID() {
+		t.Errorf("deviceUUID() is expected to differ for identities from different sources")
+	}
+}
+
+func TestGetDeviceIdentity(t *testing.T) {
+	validateResponse := []byte(`{"ServerIP":"100.0.0.1","Username":"admin","device_UUID":"2B6A2F0E-4C5D-4A6B-9F3E-1A2B3C4D5E6F"}`)
+	want := deviceIdentity{Source: "ServiceRoot", Value: "2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f"}
+	if got := getDeviceIdentity(validateResponse, "100.0.0.1", getResourceRequest{}); got != want {
+		t.Errorf("getDeviceIdentity() = %v, want %v", got, want)
+	}
+}
+
+func TestReserveDevice(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	ActiveReqSet.ReqRecord = make(map[string]interface{})
+	added := deviceIdentity{Source: "ServiceRoot", Value: "2b6a2f0e-4c5d-4a6b-9f3e-1a2b3c4d5e6f"}
+	err := mockDeviceData(added.deviceUUID(), agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		UserName:       "admin",
+		DeviceUUID:     added.deviceUUID(),
+		PluginID:       "GRF",
+	})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	req := AddResourceRequest{ManagerAddress: "100.0.0.2", Oem: &AddOEM{PluginID: "GRF"}}
+
+	// the same BMC under another address
+	if resp := reserveDevice(added.deviceUUID(), added, req, nil); resp == nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("reserveDevice() expected a conflict for a BMC which is already added")
+	}
+
+	newDevice := deviceIdentity{Source: "ManagerAddress", Value: "100.0.0.2"}
+	if resp := reserveDevice(newDevice.deviceUUID(), newDevice, req, nil); resp != nil {
+		t.Errorf("reserveDevice() = %v, want nil", resp.StatusCode)
+	}
+	// the same BMC while the first request is still ongoing
+	if resp := reserveDevice(newDevice.deviceUUID(), newDevice, req, nil); resp == nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("reserveDevice() expected a conflict for a BMC which is being added")
+	}
+	releaseDevice(newDevice.deviceUUID())
+	if resp := reserveDevice(newDevice.deviceUUID(), newDevice, req, nil); resp != nil {
+		t.Errorf("reserveDevice() = %v after the release, want nil", resp.StatusCode)
+	}
+}
@@ -0,0 +1,177 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"crypto/sha256"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// inventoryChange is a difference found between two inventory snapshots of a server
+type inventoryChange struct {
+	EventType string
+	URI       string
+}
+
+// inventorySnapshot holds a digest of every resource of a server stored in the in-memory DB, by resource URI
+type inventorySnapshot map[string][sha256.Size]byte
+
+// getInventoryRefreshConf returns the inventory refresh schedule, falling back to
+// the defaults for the values not set
+func getInventoryRefreshConf() (interval, maxJitter time.Duration, maxConcurrentBMCs int) {
+	maxConcurrentBMCs = config.DefaultInventoryRefreshMaxConcurrentBMCs
+	if conf := config.Data.InventoryRefreshConf; conf != nil {
+		interval = time.Duration(conf.IntervalInMinutes) * time.Minute
+		maxJitter = time.Duration(conf.MaxJitterInSeconds) * time.Second
+		if conf.MaxConcurrentBMCs > 0 {
+			maxConcurrentBMCs = conf.MaxConcurrentBMCs
+		}
+	}
+	return interval, maxJitter, maxConcurrentBMCs
+}
+
+// ScheduleInventoryRefresh periodically refreshes the inventory of all the added servers,
+// so that the servers which do not send events are not left with a stale inventory.
+// The inventory is refreshed by the single instance of the service holding the lease of the refresh.
+// It returns only when the periodic refresh is disabled in the configuration.
+func (e *ExternalInterface) ScheduleInventoryRefresh() {
+	interval, _, _ := getInventoryRefreshConf()
+	if interval <= 0 {
+		log.Println("info: periodic inventory refresh is disabled")
+		return
+	}
+	for {
+		time.Sleep(interval)
+		if holdsLease("InventoryRefresh", interval) {
+			e.RefreshInventory()
+			// the lease is renewed once the refresh is done, as it may take longer than the interval
+			holdsLease("InventoryRefresh", interval)
+		}
+	}
+}
+
+// RefreshInventory retrieves again the inventory of all the added servers, at most
+// MaxConcurrentBMCs at a time and each after a random delay of up to MaxJitterInSeconds,
+// and publishes an event for every resource added, changed or removed since the last retrieval
+func (e *ExternalInterface) RefreshInventory() {
+	targets, err := agmodel.GetAllSystems()
+	if err != nil || len(targets) == 0 {
+		return
+	}
+	_, maxJitter, maxConcurrentBMCs := getInventoryRefreshConf()
+	log.Printf("info: periodic inventory refresh of %v servers is started.", len(targets))
+	slots := make(chan struct{}, maxConcurrentBMCs)
+	var wg sync.WaitGroup
+	for index := range targets {
+		wg.Add(1)
+		go func(target agmodel.Target) {
+			defer wg.Done()
+			if maxJitter > 0 {
+				time.Sleep(time.Duration(rand.Int63n(int64(maxJitter))))
+			}
+			slots <- struct{}{}
+			defer func() {
+				<-slots
+			}()
+			e.refreshTargetInventory(target)
+		}(targets[index])
+	}
+	wg.Wait()
+	UpdateDynamicAggregates()
+	log.Printf("info: periodic inventory refresh of %v servers is complete.", len(targets))
+}
+
+// refreshTargetInventory retrieves again the inventory of a server and publishes its changes
+func (e *ExternalInterface) refreshTargetInventory(target agmodel.Target) {
+	before := getInventorySnapshot(target.DeviceUUID)
+	if _, errResp := e.retrieveServerInventory(target.DeviceUUID, RediscoverScopeFull, nil); errResp != nil {
+		log.Printf("error: periodic inventory refresh of the BMC with ID %v failed with the status %v", target.DeviceUUID, errResp.StatusCode)
+		return
+	}
+	// no events when the server got deleted while its inventory was being retrieved
+	if _, err := agmodel.GetTarget(target.DeviceUUID); err != nil {
+		return
+	}
+	after := getInventorySnapshot(target.DeviceUUID)
+	for _, change := range diffInventory(before, after) {
+		e.PublishEventMB(change.URI, change.EventType, collectionOfResource(change.URI))
+	}
+}
+
+// getInventorySnapshot takes the digest of every resource of the server stored in the in-memory DB
+func getInventorySnapshot(deviceUUID string) inventorySnapshot {
+	snapshot := make(inventorySnapshot)
+	keys, err := agmodel.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
+	if err != nil {
+		log.Printf("error while trying to fetch the resources of the BMC with ID %v: %v", deviceUUID, err)
+		return snapshot
+	}
+	for _, key := range keys {
+		resourceDetails := strings.SplitN(key, ":", 2)
+		if len(resourceDetails) != 2 || !strings.HasPrefix(resourceDetails[1], "/redfish/v1/") {
+			continue
+		}
+		data, err := agmodel.GetResource(resourceDetails[0], resourceDetails[1])
+		if err != nil {
+			continue
+		}
+		snapshot[resourceDetails[1]] = sha256.Sum256([]byte(data))
+	}
+	return snapshot
+}
+
+// diffInventory lists the resources added, changed and removed between two snapshots, ordered by URI
+func diffInventory(before, after inventorySnapshot) []inventoryChange {
+	var changes []inventoryChange
+	for uri, digest := range after {
+		previous, exist := before[uri]
+		switch {
+		case !exist:
+			changes = append(changes, inventoryChange{EventType: "ResourceAdded", URI: uri})
+		case previous != digest:
+			changes = append(changes, inventoryChange{EventType: "ResourceChanged", URI: uri})
+		}
+	}
+	for uri := range before {
+		if _, exist := after[uri]; !exist {
+			changes = append(changes, inventoryChange{EventType: "ResourceRemoved", URI: uri})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].URI < changes[j].URI
+	})
+	return changes
+}
+
+// collectionOfResource returns the collection under which the resource events are published
+func collectionOfResource(uri string) string {
+	switch {
+	case strings.HasPrefix(uri, "/redfish/v1/Chassis/"):
+		return "ChassisCollection"
+	case strings.HasPrefix(uri, "/redfish/v1/Managers/"):
+		return "ManagerCollection"
+	default:
+		return "SystemsCollection"
+	}
+}
@@ -0,0 +1,253 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// mockBMC serves the inventory of a BMC through its plugin, by resource URI
+type mockBMC struct {
+	lock      sync.Mutex
+	resources map[string]string
+	// failing are the resources the plugin fails to get from the BMC
+	failing map[string]bool
+}
+
+func (bmc *mockBMC) fail(uri string, failing bool) {
+	bmc.lock.Lock()
+	defer bmc.lock.Unlock()
+	if bmc.failing == nil {
+		bmc.failing = make(map[string]bool)
+	}
+	bmc.failing[uri] = failing
+}
+
+func (bmc *mockBMC) set(uri, body string) {
+	bmc.lock.Lock()
+	defer bmc.lock.Unlock()
+	if body == "" {
+		delete(bmc.resources, uri)
+		return
+	}
+	bmc.resources[uri] = body
+}
+
+func (bmc *mockBMC) contactClient(url, method, token string, odataID string, body interface{}, credentials map[string]string) (*http.Response, error) {
+	bmc.lock.Lock()
+	defer bmc.lock.Unlock()
+	uri := strings.TrimPrefix(url, "https://localhost:9091")
+	if bmc.failing[uri] {
+		return &http.Response{
+			StatusCode: http.StatusServiceUnavailable,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"BMC unreachable"}`)),
+		}, nil
+	}
+	resource, ok := bmc.resources[uri]
+	if !ok {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"not found"}`)),
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(resource)),
+	}, nil
+}
+
+func TestDiffInventory(t *testing.T) {
+	before := inventorySnapshot{
+		"/redfish/v1/Systems/uuid:1/Memory/1":      sha256.Sum256([]byte(`{"CapacityMiB":8192}`)),
+		"/redfish/v1/Systems/uuid:1/Memory/2":      sha256.Sum256([]byte(`{"CapacityMiB":8192}`)),
+		"/redfish/v1/Chassis/uuid:1/Power":         sha256.Sum256([]byte(`{"PowerControl":[]}`)),
+		"/redfish/v1/Managers/uuid:1/VirtualMedia": sha256.Sum256([]byte(`{"Members":[]}`)),
+	}
+	after := inventorySnapshot{
+		"/redfish/v1/Systems/uuid:1/Memory/1":      sha256.Sum256([]byte(`{"CapacityMiB":8192}`)),
+		"/redfish/v1/Systems/uuid:1/Memory/3":      sha256.Sum256([]byte(`{"CapacityMiB":16384}`)),
+		"/redfish/v1/Chassis/uuid:1/Power":         sha256.Sum256([]byte(`{"PowerControl":[{"PowerConsumedWatts":120}]}`)),
+		"/redfish/v1/Managers/uuid:1/VirtualMedia": sha256.Sum256([]byte(`{"Members":[]}`)),
+	}
+	want := []inventoryChange{
+		{EventType: "ResourceChanged", URI: "/redfish/v1/Chassis/uuid:1/Power"},
+		{EventType: "ResourceRemoved", URI: "/redfish/v1/Systems/uuid:1/Memory/2"},
+		{EventType: "ResourceAdded", URI: "/redfish/v1/Systems/uuid:1/Memory/3"},
+	}
+	if got := diffInventory(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffInventory() = %v, want %v", got, want)
+	}
+	if got := diffInventory(after, after); len(got) != 0 {
+		t.Errorf("diffInventory() = %v for the same inventory, want no changes", got)
+	}
+}
+
+func TestCollectionOfResource(t *testing.T) {
+	tests := []struct {
+		uri  string
+		want string
+	}{
+		{uri: "/redfish/v1/Systems/uuid:1/Memory/1", want: "SystemsCollection"},
+		{uri: "/redfish/v1/Chassis/uuid:1/Power", want: "ChassisCollection"},
+		{uri: "/redfish/v1/Managers/uuid:1/VirtualMedia", want: "ManagerCollection"},
+	}
+	for _, tt := range tests {
+		if got := collectionOfResource(tt.uri); got != tt.want {
+			t.Errorf("collectionOfResource(%v) = %v, want %v", tt.uri, got, tt.want)
+		}
+	}
+}
+
+func TestGetInventoryRefreshConf(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		config.Data.InventoryRefreshConf = nil
+	}()
+	config.Data.InventoryRefreshConf = &config.InventoryRefreshConf{IntervalInMinutes: 60, MaxJitterInSeconds: 30}
+	interval, maxJitter, maxConcurrentBMCs := getInventoryRefreshConf()
+	if interval != time.Hour || maxJitter != 30*time.Second || maxConcurrentBMCs != config.DefaultInventoryRefreshMaxConcurrentBMCs {
+		t.Errorf("getInventoryRefreshConf() = %v, %v, %v", interval, maxJitter, maxConcurrentBMCs)
+	}
+	config.Data.InventoryRefreshConf = nil
+	if interval, _, _ = getInventoryRefreshConf(); interval != 0 {
+		t.Errorf("getInventoryRefreshConf() interval = %v without configuration, want 0", interval)
+	}
+}
+
+func TestExternalInterface_RefreshTargetInventory(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	if err := ioutil.WriteFile(schemaFile, []byte(`{"searchKeys":[{"SystemType":{"type":"string"}}]}`), 0600); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	config.Data.SearchAndFilterSchemaPath = schemaFile
+	defer func() {
+		os.Remove(schemaFile)
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	deviceUUID := "6d4a0a66-7efa-578e-83cf-44dc68d2874e"
+	target := agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		Password:       []byte("password"),
+		UserName:       "admin",
+		DeviceUUID:     deviceUUID,
+		PluginID:       "GRF",
+	}
+	mockDeviceData(deviceUUID, target)
+	mockPluginData(t, "GRF")
+
+	bmc := &mockBMC{resources: make(map[string]string)}
+	system := func(id string) string {
+		return `{"@odata.id":"/ODIM/v1/Systems/` + id + `","Id":"` + id + `","UUID":"` + id + `-uuid","SystemType":"Physical",
+			"Memory":{"@odata.id":"/ODIM/v1/Systems/` + id + `/Memory"}}`
+	}
+	memory := func(id, memoryMiB string) {
+		bmc.set("/ODIM/v1/Systems/"+id+"/Memory", `{"@odata.id":"/ODIM/v1/Systems/`+id+`/Memory","Members":[{"@odata.id":"/ODIM/v1/Systems/`+id+`/Memory/1"}]}`)
+		bmc.set("/ODIM/v1/Systems/"+id+"/Memory/1", `{"@odata.id":"/ODIM/v1/Systems/`+id+`/Memory/1","Id":"1","CapacityMiB":`+memoryMiB+`}`)
+	}
+	bmc.set("/ODIM/v1/Systems", `{"Members":[{"@odata.id":"/ODIM/v1/Systems/1"},{"@odata.id":"/ODIM/v1/Systems/2"}]}`)
+	bmc.set("/ODIM/v1/Systems/1", system("1"))
+	bmc.set("/ODIM/v1/Systems/2", system("2"))
+	memory("1", "8192")
+	memory("2", "8192")
+	bmc.set("/ODIM/v1/Chassis", `{"Members":[{"@odata.id":"/ODIM/v1/Chassis/1"}]}`)
+	bmc.set("/ODIM/v1/Chassis/1", `{"@odata.id":"/ODIM/v1/Chassis/1","Id":"1"}`)
+	bmc.set("/ODIM/v1/Managers", `{"Members":[{"@odata.id":"/ODIM/v1/Managers/1"},{"@odata.id":"/ODIM/v1/Managers/2"}]}`)
+	bmc.set("/ODIM/v1/Managers/1", `{"@odata.id":"/ODIM/v1/Managers/1","Id":"1","FirmwareVersion":"1.0"}`)
+	bmc.set("/ODIM/v1/Managers/2", `{"@odata.id":"/ODIM/v1/Managers/2","Id":"2"}`)
+
+	var events []string
+	var eventsLock sync.Mutex
+	p := &ExternalInterface{
+		ContactClient:   bmc.contactClient,
+		UpdateTask:      mockUpdateTask,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+		PublishEventMB: func(systemID, eventType, collectionType string) {
+			eventsLock.Lock()
+			events = append(events, fmt.Sprintf("%v %v %v", eventType, systemID, collectionType))
+			eventsLock.Unlock()
+		},
+	}
+	p.refreshTargetInventory(target)
+	if len(events) != 9 {
+		t.Fatalf("refreshTargetInventory() of a new server published %v, want the 9 resources added", events)
+	}
+
+	// the second system, its memory and the second manager are removed, the memory of the first system
+	// and the firmware of the first manager change
+	events = nil
+	bmc.set("/ODIM/v1/Systems", `{"Members":[{"@odata.id":"/ODIM/v1/Systems/1"}]}`)
+	bmc.set("/ODIM/v1/Systems/2", "")
+	memory("1", "16384")
+	bmc.set("/ODIM/v1/Managers", `{"Members":[{"@odata.id":"/ODIM/v1/Managers/1"}]}`)
+	bmc.set("/ODIM/v1/Managers/1", `{"@odata.id":"/ODIM/v1/Managers/1","Id":"1","FirmwareVersion":"1.1"}`)
+	p.refreshTargetInventory(target)
+	want := []string{
+		"ResourceChanged /redfish/v1/Managers/" + deviceUUID + ":1 ManagerCollection",
+		"ResourceRemoved /redfish/v1/Managers/" + deviceUUID + ":2 ManagerCollection",
+		"ResourceChanged /redfish/v1/Systems/" + deviceUUID + ":1/Memory/1 SystemsCollection",
+		"ResourceRemoved /redfish/v1/Systems/" + deviceUUID + ":2 SystemsCollection",
+		"ResourceRemoved /redfish/v1/Systems/" + deviceUUID + ":2/Memory SystemsCollection",
+		"ResourceRemoved /redfish/v1/Systems/" + deviceUUID + ":2/Memory/1 SystemsCollection",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("refreshTargetInventory() published %v, want %v", events, want)
+	}
+	if _, err := agmodel.GetResource("ComputerSystem", "/redfish/v1/Systems/"+deviceUUID+":1"); err != nil {
+		t.Errorf("the system still listed by the server is deleted: %v", err)
+	}
+
+	// nothing is published when the inventory did not change
+	events = nil
+	p.refreshTargetInventory(target)
+	if len(events) != 0 {
+		t.Errorf("refreshTargetInventory() of an unchanged server published %v, want none", events)
+	}
+
+	// the inventory is left as it is when the BMC is unreachable, or under the resources failing to be retrieved
+	before := getInventorySnapshot(deviceUUID)
+	bmc.fail("/ODIM/v1/Systems", true)
+	p.refreshTargetInventory(target)
+	bmc.fail("/ODIM/v1/Systems", false)
+	bmc.fail("/ODIM/v1/Systems/1/Memory", true)
+	p.refreshTargetInventory(target)
+	if after := getInventorySnapshot(deviceUUID); len(events) != 0 || !reflect.DeepEqual(before, after) {
+		t.Errorf("refreshTargetInventory() of an unreachable BMC published %v and changed the inventory %v into %v", events, before, after)
+	}
+	bmc.fail("/ODIM/v1/Systems/1/Memory", false)
+	p.refreshTargetInventory(target)
+	if len(events) != 0 {
+		t.Errorf("refreshTargetInventory() of a BMC reachable again published %v, want none", events)
+	}
+}
@@ -0,0 +1,390 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// membershipRule is a node of a parsed membership rule of an aggregate. It is either
+// a logical operation on its operands, or the comparison of an indexed property with a value.
+type membershipRule struct {
+	operator  string
+	operands  []*membershipRule
+	key       string
+	keyType   string
+	condition string
+	value     string
+}
+
+// dynamicAggregatesLock serializes the evaluations of the membership rules,
+// so that the elements of an aggregate are not overwritten by an older evaluation
+var dynamicAggregatesLock sync.Mutex
+
+// indexedSystemKeys are the properties indexed for every system without being in the search/filter schema
+var indexedSystemKeys = map[string]string{
+	"PowerState": "string",
+}
+
+// parseMembershipRule parses a membership rule written like a $filter expression, such as
+// "ProcessorSummary/Model eq 'Intel Xeon' and not (FirmwareVersion eq 'U30 v2.10')"
+func parseMembershipRule(rule string, schema agmodel.Schema) (*membershipRule, error) {
+	tokens, err := tokenizeMembershipRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("the membership rule is empty")
+	}
+	keyTypes := make(map[string]string)
+	for key, keyType := range indexedSystemKeys {
+		keyTypes[key] = keyType
+	}
+	for _, searchKey := range schema.SearchKeys {
+		for key, value := range searchKey {
+			keyTypes[key] = value["type"]
+		}
+	}
+	p := ruleParser{tokens: tokens, keyTypes: keyTypes, conditions: schema.ConditionKeys}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.position != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %v in the membership rule", p.tokens[p.position])
+	}
+	return node, nil
+}
+
+// tokenizeMembershipRule splits a membership rule into parentheses, quoted values and words
+func tokenizeMembershipRule(rule string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(rule); {
+		switch c := rule[i]; {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '\'':
+			end := strings.IndexByte(rule[i+1:], '\'')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated value %v in the membership rule", rule[i:])
+			}
+			tokens = append(tokens, rule[i:i+end+2])
+			i += end + 2
+		default:
+			end := strings.IndexAny(rule[i:], " \t()")
+			if end == -1 {
+				end = len(rule) - i
+			}
+			tokens = append(tokens, rule[i:i+end])
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+// ruleParser is a recursive descent parser of the membership rules, "not" binds tighter than "and", which binds tighter than "or"
+type ruleParser struct {
+	tokens     []string
+	position   int
+	keyTypes   map[string]string
+	conditions []string
+}
+
+func (p *ruleParser) next() string {
+	if p.position == len(p.tokens) {
+		return ""
+	}
+	token := p.tokens[p.position]
+	p.position++
+	return token
+}
+
+func (p *ruleParser) peek() string {
+	if p.position == len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.position]
+}
+
+func (p *ruleParser) parseOr() (*membershipRule, error) {
+	return p.parseLogical("or", p.parseAnd)
+}
+
+func (p *ruleParser) parseAnd() (*membershipRule, error) {
+	return p.parseLogical("and", p.parseNot)
+}
+
+func (p *ruleParser) parseLogical(operator string, parseOperand func() (*membershipRule, error)) (*membershipRule, error) {
+	operand, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	node := &membershipRule{operator: operator, operands: []*membershipRule{operand}}
+	for p.peek() == operator {
+		p.next()
+		if operand, err = parseOperand(); err != nil {
+			return nil, err
+		}
+		node.operands = append(node.operands, operand)
+	}
+	if len(node.operands) == 1 {
+		return node.operands[0], nil
+	}
+	return node, nil
+}
+
+func (p *ruleParser) parseNot() (*membershipRule, error) {
+	switch p.peek() {
+	case "not":
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &membershipRule{operator: "not", operands: []*membershipRule{operand}}, nil
+	case "(":
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis in the membership rule")
+		}
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *ruleParser) parseComparison() (*membershipRule, error) {
+	key, condition, value := p.next(), p.next(), p.next()
+	keyType, ok := p.keyTypes[key]
+	if !ok {
+		return nil, fmt.Errorf("%v is not an indexed property of the systems", key)
+	}
+	var validCondition bool
+	for _, c := range p.conditions {
+		validCondition = validCondition || c == condition
+	}
+	if !validCondition {
+		return nil, fmt.Errorf("%v is not one of the conditions %v", condition, p.conditions)
+	}
+	if value == "" || value == "(" || value == ")" {
+		return nil, fmt.Errorf("missing value for %v in the membership rule", key)
+	}
+	value = strings.Trim(value, "'")
+	if keyType == "string" || keyType == "[]string" {
+		if condition != "eq" && condition != "ne" {
+			return nil, fmt.Errorf("%v is a string, it can only be compared with eq or ne", key)
+		}
+		value = strings.ToLower(value)
+	} else if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return nil, fmt.Errorf("%v is a number, %v is not", key, value)
+	}
+	return &membershipRule{key: key, keyType: keyType, condition: condition, value: value}, nil
+}
+
+// keys returns the indexed properties compared in the membership rule
+func (r *membershipRule) keys(keys map[string]bool) {
+	if r.operator == "" {
+		keys[r.key] = true
+	}
+	for _, operand := range r.operands {
+		operand.keys(keys)
+	}
+}
+
+// matches evaluates the membership rule for a system, indexedValues holds the values of every indexed property of the rule
+func (r *membershipRule) matches(systemURI string, indexedValues map[string]map[string]string) bool {
+	switch r.operator {
+	case "and":
+		for _, operand := range r.operands {
+			if !operand.matches(systemURI, indexedValues) {
+				return false
+			}
+		}
+		return true
+	case "or":
+		for _, operand := range r.operands {
+			if operand.matches(systemURI, indexedValues) {
+				return true
+			}
+		}
+		return false
+	case "not":
+		return !r.operands[0].matches(systemURI, indexedValues)
+	}
+	indexedValue, ok := indexedValues[r.key][systemURI]
+	if !ok {
+		// a system without the property matches none of the comparisons
+		return false
+	}
+	if r.keyType == "[]string" {
+		return hasElement(indexedValue, r.value) == (r.condition == "eq")
+	}
+	values := []string{indexedValue}
+	if strings.HasPrefix(r.keyType, "[]") {
+		values = strings.Fields(strings.Trim(indexedValue, "[]"))
+	}
+	for _, value := range values {
+		if compareIndexedValue(value, r.condition, r.value, r.keyType) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasElement checks whether the value is an element of the indexed list, which holds its space separated elements between brackets.
+// The value is searched between the element separators, so that the elements with spaces are found as well.
+func hasElement(indexedList, value string) bool {
+	if !strings.HasPrefix(indexedList, "[") || !strings.HasSuffix(indexedList, "]") {
+		return false
+	}
+	return indexedList == "["+value+"]" || strings.HasPrefix(indexedList, "["+value+" ") ||
+		strings.HasSuffix(indexedList, " "+value+"]") || strings.Contains(indexedList, " "+value+" ")
+}
+
+// compareIndexedValue compares an indexed value with the value of a membership rule
+func compareIndexedValue(indexedValue, condition, value, keyType string) bool {
+	if keyType == "string" || keyType == "[]string" {
+		return (indexedValue == value) == (condition == "eq")
+	}
+	a, err := strconv.ParseFloat(indexedValue, 64)
+	if err != nil {
+		return false
+	}
+	b, _ := strconv.ParseFloat(value, 64)
+	switch condition {
+	case "eq":
+		return a == b
+	case "ne":
+		return a != b
+	case "gt":
+		return a > b
+	case "ge":
+		return a >= b
+	case "lt":
+		return a < b
+	case "le":
+		return a <= b
+	}
+	return false
+}
+
+// validateMembershipRule checks that the membership rule of an aggregate can be evaluated
+func validateMembershipRule(rule string) error {
+	schema, err := agmodel.GetSearchSchema()
+	if err != nil {
+		return err
+	}
+	_, err = parseMembershipRule(rule, schema)
+	return err
+}
+
+// evaluateMembershipRule returns the URIs of the systems matching the membership rule, in the search index
+func evaluateMembershipRule(rule string) ([]string, error) {
+	schema, err := agmodel.GetSearchSchema()
+	if err != nil {
+		return nil, err
+	}
+	node, err := parseMembershipRule(rule, schema)
+	if err != nil {
+		return nil, err
+	}
+	// every indexed system has its UUID indexed
+	systems, err := getIndexedSystemValues("UUID")
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	node.keys(keys)
+	indexedValues := make(map[string]map[string]string)
+	for key := range keys {
+		if indexedValues[key], err = getIndexedSystemValues(key); err != nil {
+			return nil, err
+		}
+	}
+	elements := make([]string, 0)
+	for systemURI := range systems {
+		if node.matches(systemURI, indexedValues) {
+			elements = append(elements, systemURI)
+		}
+	}
+	sort.Strings(elements)
+	return elements, nil
+}
+
+// getIndexedSystemValues returns the values of an index keyed by the URI of the system,
+// the storage properties being indexed under the storage collection of the system
+func getIndexedSystemValues(index string) (map[string]string, error) {
+	values, err := agmodel.GetIndexedValues(index)
+	if err != nil {
+		return nil, err
+	}
+	systemValues := make(map[string]string, len(values))
+	for uri, value := range values {
+		if strings.HasSuffix(uri, "/Storage") {
+			if strings.HasPrefix(index, "Storage/") {
+				systemValues[strings.TrimSuffix(uri, "/Storage")] = value
+			}
+			continue
+		}
+		systemValues[uri] = value
+	}
+	return systemValues, nil
+}
+
+// UpdateDynamicAggregates evaluates again the membership rules of the aggregates and
+// saves their elements when they changed. It is done whenever systems are added, deleted or rediscovered.
+func UpdateDynamicAggregates() {
+	dynamicAggregatesLock.Lock()
+	defer dynamicAggregatesLock.Unlock()
+	aggregateURIs, err := agmodel.GetAllKeysFromTable("Aggregate")
+	if err != nil {
+		log.Println("error while trying to get the aggregates: " + err.Error())
+		return
+	}
+	for _, aggregateURI := range aggregateURIs {
+		aggregate, dbErr := agmodel.GetAggregate(aggregateURI)
+		if dbErr != nil || aggregate.MembershipRule == "" {
+			continue
+		}
+		elements, err := evaluateMembershipRule(aggregate.MembershipRule)
+		if err != nil {
+			log.Printf("error while trying to evaluate the membership rule of the aggregate %v: %v", aggregateURI, err)
+			continue
+		}
+		if reflect.DeepEqual(elements, aggregate.Elements) {
+			continue
+		}
+		aggregate.Elements = elements
+		if dbErr := agmodel.UpdateAggregate(aggregate, aggregateURI); dbErr != nil {
+			log.Printf("error while trying to update the elements of the aggregate %v: %v", aggregateURI, dbErr.Error())
+			continue
+		}
+		log.Printf("info: aggregate %v now has %v elements matching its membership rule", aggregateURI, len(elements))
+	}
+}
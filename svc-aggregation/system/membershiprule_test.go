@@ -0,0 +1,151 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestMembershipRule(t *testing.T) {
+	schema := agmodel.Schema{
+		SearchKeys: []map[string]map[string]string{
+			{"Model": {"type": "string"}},
+			{"FirmwareVersion": {"type": "string"}},
+			{"ProcessorSummary/Count": {"type": "float64"}},
+			{"Storage/Drives/Type": {"type": "[]string"}},
+		},
+		ConditionKeys: []string{"eq", "ne", "gt", "ge", "lt", "le"},
+	}
+	system1 := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"
+	system2 := "/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48:1"
+	indexedValues := map[string]map[string]string{
+		"Model":                  {system1: "proliant dl360 gen10", system2: "proliant dl380 gen10"},
+		"FirmwareVersion":        {system1: "u32", system2: "u30"},
+		"PowerState":             {system1: "on"},
+		"ProcessorSummary/Count": {system1: "2", system2: "1"},
+		"Storage/Drives/Type":    {system1: "[hdd ssd]", system2: "[hdd solid state]"},
+	}
+	tests := []struct {
+		name    string
+		rule    string
+		want    []bool
+		wantErr bool
+	}{
+		{name: "string comparison", rule: "Model eq 'ProLiant DL360 Gen10'", want: []bool{true, false}},
+		{name: "number comparison", rule: "ProcessorSummary/Count ge 2", want: []bool{true, false}},
+		{name: "array comparison", rule: "Storage/Drives/Type eq SSD", want: []bool{true, false}},
+		{name: "array exclusion", rule: "Storage/Drives/Type ne SSD", want: []bool{false, true}},
+		{name: "array element with spaces", rule: "Storage/Drives/Type eq 'Solid State'", want: []bool{false, true}},
+		{name: "missing property", rule: "PowerState ne On", want: []bool{false, false}},
+		{name: "or", rule: "FirmwareVersion eq U30 or PowerState eq On", want: []bool{true, true}},
+		{name: "not and parentheses", rule: "not (Model eq 'ProLiant DL360 Gen10' and FirmwareVersion eq U32)", want: []bool{false, true}},
+		{name: "unknown property", rule: "BiosVersion eq U30", wantErr: true},
+		{name: "invalid condition", rule: "Model gt 'ProLiant'", wantErr: true},
+		{name: "invalid number", rule: "ProcessorSummary/Count eq two", wantErr: true},
+		{name: "missing value", rule: "Model eq", wantErr: true},
+		{name: "unterminated value", rule: "Model eq 'ProLiant", wantErr: true},
+		{name: "missing parenthesis", rule: "(Model eq ProLiant", wantErr: true},
+		{name: "empty rule", rule: " ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseMembershipRule(tt.rule, schema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMembershipRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for i, systemURI := range []string{system1, system2} {
+				if got := rule.matches(systemURI, indexedValues); got != tt.want[i] {
+					t.Errorf("membershipRule.matches(%v) = %v, want %v", systemURI, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGroupingIndex(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer func() {
+		if err := common.TruncateDB(common.InMemory); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+	system1 := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"
+	system2 := "/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48:1"
+	chassis1 := "/redfish/v1/Chassis/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"
+	connPool, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	resources := []struct {
+		table    string
+		uri      string
+		resource map[string]interface{}
+	}{
+		{table: "ComputerSystem", uri: system1, resource: map[string]interface{}{
+			"Model":        "ProLiant DL360 Gen10",
+			"Manufacturer": "HPE",
+			"Links":        map[string]interface{}{"Chassis": []interface{}{map[string]string{"@odata.id": chassis1}}},
+		}},
+		{table: "ComputerSystem", uri: system2, resource: map[string]interface{}{"Model": "ProLiant DL380 Gen10"}},
+		{table: "Chassis", uri: chassis1, resource: map[string]interface{}{
+			"Location": map[string]interface{}{"Placement": map[string]string{"Rack": "Rack 1"}},
+			"Links":    map[string]interface{}{"ComputerSystems": []interface{}{map[string]string{"@odata.id": system1}}},
+		}},
+	}
+	for _, resource := range resources {
+		data, _ := json.Marshal(resource.resource)
+		if err := connPool.Create(resource.table, resource.uri, string(data)); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	// the servers indexed before the model, manufacturer and rack are indexed at startup,
+	// the rack is the one of the chassis containing the system
+	BackfillGroupingIndex()
+	want := map[string]map[string]string{
+		"Model":                   {system1: "proliant dl360 gen10", system2: "proliant dl380 gen10"},
+		"Manufacturer":            {system1: "hpe"},
+		"Location/Placement/Rack": {system1: "rack 1"},
+	}
+	for index, values := range want {
+		got, err := agmodel.GetIndexedValues(index)
+		if err != nil {
+			t.Fatalf("GetIndexedValues(%v) error = %v", index, err)
+		}
+		for systemURI, value := range values {
+			if got[systemURI] != value {
+				t.Errorf("index %v of %v = %q, want %q", index, systemURI, got[systemURI], value)
+			}
+		}
+		if len(got) != len(values) {
+			t.Errorf("index %v = %v, want %v", index, got, values)
+		}
+	}
+
+	// the rack of a chassis saved after its system is indexed for the system
+	indexChassisRack(`{"Location":{"Placement":{"Rack":"Rack 2"}},"Links":{"ComputerSystems":[{"@odata.id":"` + system1 + `"}]}}`)
+	got, indexErr := agmodel.GetIndexedValues("Location/Placement/Rack")
+	if indexErr != nil || got[system1] != "rack 2" || len(got) != 1 {
+		t.Errorf("index Location/Placement/Rack = %v, %v, want rack 2 for %v", got, indexErr, system1)
+	}
+}
@@ -0,0 +1,178 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// pluginHealth is the availability of a plugin found by the plugin health monitor
+type pluginHealth struct {
+	Available bool
+	Uptime    string
+	QueueList []string
+}
+
+// MonitorPluginHealth checks the status of every added plugin every PollingFrequencyInMins,
+// records it in the Status of the plugin manager and publishes an event whenever a plugin
+// becomes unavailable or available again. Only the instance of the service holding the lease
+// checks the plugins, so that the events are published once. It never returns.
+func (e *ExternalInterface) MonitorPluginHealth() {
+	interval := time.Duration(config.Data.PluginStatusPolling.PollingFrequencyInMins) * time.Minute
+	for {
+		if holdsLease("PluginHealth", interval) {
+			e.CheckPluginsHealth()
+			// the lease is renewed once the checks are done, as the plugins may take long to answer
+			holdsLease("PluginHealth", interval)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// CheckPluginsHealth checks once the status of every added plugin
+func (e *ExternalInterface) CheckPluginsHealth() {
+	pluginIDs, err := agmodel.GetAllKeysFromTable("Plugin")
+	if err != nil {
+		log.Println("error while trying to get the plugins: " + err.Error())
+		return
+	}
+	var wg sync.WaitGroup
+	for _, pluginID := range pluginIDs {
+		plugin, errs := agmodel.GetPluginData(pluginID)
+		if errs != nil {
+			log.Printf("error while trying to get the plugin %v: %v", pluginID, errs.Error())
+			continue
+		}
+		wg.Add(1)
+		go func(plugin agmodel.Plugin) {
+			defer wg.Done()
+			e.checkPluginHealth(plugin)
+		}(plugin)
+	}
+	wg.Wait()
+}
+
+// checkPluginHealth checks the status of a plugin and records it in the Status of the plugin manager.
+// When the plugin becomes available again, it is subscribed again to the event message bus queues.
+func (e *ExternalInterface) checkPluginHealth(plugin agmodel.Plugin) {
+	if plugin.ManagerUUID == "" {
+		return
+	}
+	managerURI := "/redfish/v1/Managers/" + plugin.ManagerUUID
+	health := e.getPluginHealth(plugin)
+	// only the Status is replaced, so that the changes made to the manager meanwhile are kept
+	previousStatus, errs := agmodel.SetManagerStatus(managerURI, pluginManagerStatus(health, time.Now().UTC()))
+	if errs != nil {
+		log.Printf("error while trying to save the status of the plugin %v: %v", plugin.ID, errs.Error())
+		return
+	}
+	// a plugin is available when it is added, so no event is published for its first check
+	wasAvailable := true
+	if state, ok := previousStatus["State"].(string); ok && state != "" {
+		wasAvailable = state == "Enabled"
+	}
+
+	switch {
+	case health.Available && !wasAvailable:
+		log.Printf("info: plugin %v is available again", plugin.ID)
+		e.SubscribeToEMB(plugin.ID, health.QueueList)
+		e.PublishEventMB(managerURI, "ResourceStatusChangedOK", "ManagerCollection")
+	case !health.Available && wasAvailable:
+		log.Printf("warn: plugin %v is not available", plugin.ID)
+		e.PublishEventMB(managerURI, "ResourceStatusChangedCritical", "ManagerCollection")
+	}
+}
+
+// getPluginHealth requests the status of a plugin
+func (e *ExternalInterface) getPluginHealth(plugin agmodel.Plugin) pluginHealth {
+	var req getResourceRequest
+	req.ContactClient = e.ContactClient
+	req.Plugin = plugin
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		req.HTTPMethodType = http.MethodPost
+		req.DeviceInfo = map[string]interface{}{
+			"Username": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		req.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(req, "error while creating the session: ")
+		if err != nil {
+			log.Printf("error while checking the status of the plugin %v: %v", plugin.ID, err)
+			return pluginHealth{}
+		}
+		req.Token = token
+	} else {
+		req.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	req.HTTPMethodType = http.MethodGet
+	req.OID = "/ODIM/v1/Status"
+	body, _, _, err := contactPlugin(req, "error while getting the details "+req.OID+": ")
+	if err != nil {
+		log.Printf("error while checking the status of the plugin %v: %v", plugin.ID, err)
+		return pluginHealth{}
+	}
+	var statusResponse common.StatusResponse
+	if err := json.Unmarshal(body, &statusResponse); err != nil {
+		log.Printf("error while checking the status of the plugin %v: %v", plugin.ID, err)
+		return pluginHealth{}
+	}
+	health := pluginHealth{
+		Available: true,
+		QueueList: make([]string, 0),
+	}
+	if statusResponse.Status != nil {
+		health.Uptime = statusResponse.Status.Uptime
+	}
+	if statusResponse.EventMessageBus != nil {
+		for _, queue := range statusResponse.EventMessageBus.EmbQueue {
+			health.QueueList = append(health.QueueList, queue.QueueName)
+		}
+	}
+	return health
+}
+
+// pluginManagerStatus frames the Status of a plugin manager from the health of the plugin
+func pluginManagerStatus(health pluginHealth, checkedAt time.Time) map[string]interface{} {
+	oem := map[string]interface{}{
+		"LastChecked": checkedAt.Format(time.RFC3339),
+	}
+	if !health.Available {
+		return map[string]interface{}{
+			"State":  "UnavailableOffline",
+			"Health": "Critical",
+			"Oem":    oem,
+		}
+	}
+	if health.Uptime != "" {
+		oem["Uptime"] = health.Uptime
+	}
+	return map[string]interface{}{
+		"State":  "Enabled",
+		"Health": "OK",
+		"Oem":    oem,
+	}
+}
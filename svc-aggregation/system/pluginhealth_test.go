@@ -0,0 +1,138 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestPluginManagerStatus(t *testing.T) {
+	checkedAt := time.Date(2020, 10, 12, 7, 50, 46, 0, time.UTC)
+	want := map[string]interface{}{
+		"State":  "Enabled",
+		"Health": "OK",
+		"Oem":    map[string]interface{}{"LastChecked": "2020-10-12T07:50:46Z", "Uptime": "1h2m"},
+	}
+	if got := pluginManagerStatus(pluginHealth{Available: true, Uptime: "1h2m"}, checkedAt); !reflect.DeepEqual(got, want) {
+		t.Errorf("pluginManagerStatus() = %v, want %v", got, want)
+	}
+	want = map[string]interface{}{
+		"State":  "UnavailableOffline",
+		"Health": "Critical",
+		"Oem":    map[string]interface{}{"LastChecked": "2020-10-12T07:50:46Z"},
+	}
+	if got := pluginManagerStatus(pluginHealth{}, checkedAt); !reflect.DeepEqual(got, want) {
+		t.Errorf("pluginManagerStatus() = %v, want %v", got, want)
+	}
+}
+
+func TestExternalInterface_CheckPluginsHealth(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	if err := mockPluginData(t, "GRF"); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	mockData(t, common.OnDisk, "Plugin", "ILO", agmodel.Plugin{
+		IP:                "100.0.0.3",
+		Port:              "9091",
+		Username:          "admin",
+		Password:          getEncryptedKey(t, []byte("password")),
+		ID:                "ILO",
+		PreferredAuthType: "BasicAuth",
+		ManagerUUID:       "1234877451-1233",
+	})
+	// GRF was found unavailable by the previous check
+	managers := map[string]string{
+		"/redfish/v1/Managers/1234877451-1234": `{"Name":"GRF","Status":{"State":"UnavailableOffline","Health":"Critical"}}`,
+		"/redfish/v1/Managers/1234877451-1233": `{"Name":"ILO","Status":{"State":"Enabled","Health":"OK"}}`,
+	}
+	for managerURI, data := range managers {
+		if err := agmodel.GenericSave([]byte(data), "Managers", managerURI); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	var mutex sync.Mutex
+	events := make(map[string]string)
+	var subscribedPlugins []string
+	p := &ExternalInterface{
+		ContactClient: mockContactClient,
+		PublishEventMB: func(managerURI, eventType, collectionType string) {
+			mutex.Lock()
+			events[managerURI] = eventType
+			mutex.Unlock()
+		},
+		SubscribeToEMB: func(pluginID string, queueList []string) {
+			mutex.Lock()
+			subscribedPlugins = append(subscribedPlugins, pluginID)
+			mutex.Unlock()
+		},
+	}
+	p.CheckPluginsHealth()
+
+	wantEvents := map[string]string{
+		"/redfish/v1/Managers/1234877451-1234": "ResourceStatusChangedOK",
+		"/redfish/v1/Managers/1234877451-1233": "ResourceStatusChangedCritical",
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("CheckPluginsHealth() published %v, want %v", events, wantEvents)
+	}
+	if !reflect.DeepEqual(subscribedPlugins, []string{"GRF"}) {
+		t.Errorf("CheckPluginsHealth() subscribed again %v to the event message bus, want [GRF]", subscribedPlugins)
+	}
+	wantStates := map[string]string{
+		"/redfish/v1/Managers/1234877451-1234": "Enabled",
+		"/redfish/v1/Managers/1234877451-1233": "UnavailableOffline",
+	}
+	for managerURI, wantState := range wantStates {
+		data, err := agmodel.GetResource("Managers", managerURI)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		var manager struct {
+			Name   string
+			Status struct {
+				State string
+			}
+		}
+		json.Unmarshal([]byte(data), &manager)
+		if manager.Status.State != wantState {
+			t.Errorf("CheckPluginsHealth() recorded the state %v for %v, want %v", manager.Status.State, managerURI, wantState)
+		}
+		if manager.Name == "" {
+			t.Errorf("CheckPluginsHealth() did not keep the other properties of %v", managerURI)
+		}
+	}
+
+	// no event once the state is recorded
+	events = make(map[string]string)
+	p.CheckPluginsHealth()
+	if len(events) != 0 {
+		t.Errorf("CheckPluginsHealth() published %v without state changes", events)
+	}
+}
@@ -0,0 +1,574 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// scopes of the inventory rediscovery of a server
+const (
+	RediscoverScopeFull     = "Full"
+	RediscoverScopeSystems  = "Systems"
+	RediscoverScopeStorage  = "Storage"
+	RediscoverScopeChassis  = "Chassis"
+	RediscoverScopeManagers = "Managers"
+)
+
+// RediscoverScopes lists the scopes supported by the rediscover actions
+var RediscoverScopes = []string{RediscoverScopeFull, RediscoverScopeSystems, RediscoverScopeStorage, RediscoverScopeChassis, RediscoverScopeManagers}
+
+// RediscoverRequest is the payload of the rediscover actions of an aggregation source and of an aggregate
+type RediscoverRequest struct {
+	// Scope limits the rediscovery to a part of the inventory, the whole inventory is retrieved when empty
+	Scope string `json:"Scope,omitempty"`
+}
+
+// ParseRediscoverRequest parses the request body of a rediscover action, an empty body is a full rediscovery.
+// It returns the name of the invalid property along with the error.
+func ParseRediscoverRequest(requestBody []byte) (RediscoverRequest, string, error) {
+	var rediscoverRequest RediscoverRequest
+	if len(strings.TrimSpace(string(requestBody))) != 0 {
+		if err := json.Unmarshal(requestBody, &rediscoverRequest); err != nil {
+			return rediscoverRequest, "", err
+		}
+	}
+	if rediscoverRequest.Scope == "" {
+		rediscoverRequest.Scope = RediscoverScopeFull
+	}
+	for _, scope := range RediscoverScopes {
+		if rediscoverRequest.Scope == scope {
+			return rediscoverRequest, "", nil
+		}
+	}
+	return rediscoverRequest, "Scope", fmt.Errorf("the value %v of Scope is not one of %v", rediscoverRequest.Scope, RediscoverScopes)
+}
+
+// RediscoverAggregationSource retrieves again the inventory of the server added as the aggregation source
+func (e *ExternalInterface) RediscoverAggregationSource(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	rediscoverRequest, invalidProperty, err := ParseRediscoverRequest(req.RequestBody)
+	if err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		log.Println(errMsg)
+		if invalidProperty != "" {
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{rediscoverRequest.Scope, invalidProperty}, taskInfo)
+		}
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+
+	aggregationSourceURI := strings.TrimSuffix(strings.Split(req.URL, "/Actions/")[0], "/")
+	aggregationSource, dbErr := agmodel.GetAggregationSourceInfo(aggregationSourceURI)
+	if dbErr != nil {
+		log.Printf("error getting AggregationSource : %v", dbErr)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, dbErr.Error(), []interface{}{"AggregationSource", aggregationSourceURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, dbErr.Error(), nil, taskInfo)
+	}
+	// only the servers have an inventory, the plugins are added with their plugin type
+	if links, ok := aggregationSource.Links.(map[string]interface{}); ok {
+		if oem, ok := links["Oem"].(map[string]interface{}); ok {
+			if _, ok := oem["PluginType"]; ok {
+				errMsg := "error: rediscovery is not supported for the aggregation source of a plugin"
+				log.Println(errMsg)
+				return common.GeneralError(http.StatusBadRequest, response.ActionNotSupported, errMsg, []interface{}{"AggregationSource.Rediscover"}, taskInfo)
+			}
+		}
+	}
+	deviceUUID := aggregationSourceURI[strings.LastIndex(aggregationSourceURI, "/")+1:]
+
+	resp := e.rediscoverServer(deviceUUID, rediscoverRequest.Scope, taskInfo)
+	if resp.StatusCode != http.StatusOK {
+		return resp
+	}
+	var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, common.OK, 100, http.MethodPost)
+	err = e.UpdateTask(task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.UpdateTask(task)
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// RediscoverElementsOfAggregate retrieves again the inventory of the servers of the systems in the aggregate,
+// with a sub task for every server
+func (e *ExternalInterface) RediscoverElementsOfAggregate(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	var resp response.RPC
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+
+	rediscoverRequest, invalidProperty, err := ParseRediscoverRequest(req.RequestBody)
+	if err != nil {
+		errMsg := "error while trying to validate request fields: " + err.Error()
+		log.Println(errMsg)
+		if invalidProperty != "" {
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errMsg, []interface{}{rediscoverRequest.Scope, invalidProperty}, taskInfo)
+		}
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+	}
+
+	url := strings.Split(req.URL, "/redfish/v1/AggregationService/Aggregates/")
+	aggregateID := strings.Split(url[1], "/")[0]
+	aggregateURL := "/redfish/v1/AggregationService/Aggregates/" + aggregateID
+	aggregate, dbErr := agmodel.GetAggregate(aggregateURL)
+	if dbErr != nil {
+		log.Printf("error getting aggregate : %v", dbErr)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, dbErr.Error(), []interface{}{"Aggregate", req.URL}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, dbErr.Error(), nil, taskInfo)
+	}
+	deviceUUIDs := getServersOfElements(aggregate.Elements)
+
+	// subTaskChan is buffered for all the servers, so that the sub tasks still running
+	// when the task gets cancelled do not block
+	subTaskChan := make(chan int32, len(deviceUUIDs))
+	batchSize := config.Data.ServerRediscoveryBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	slots := make(chan struct{}, batchSize)
+	for _, deviceUUID := range deviceUUIDs {
+		go e.rediscoverAggregateServer(taskID, string(req.RequestBody), sessionUserName, deviceUUID, rediscoverRequest.Scope, subTaskChan, slots)
+	}
+
+	resp.StatusCode = http.StatusOK
+	var partialResultFlag bool
+	for i := range deviceUUIDs {
+		if statusCode := <-subTaskChan; statusCode != http.StatusOK {
+			partialResultFlag = true
+			if resp.StatusCode < statusCode {
+				resp.StatusCode = statusCode
+			}
+		}
+		if i < len(deviceUUIDs)-1 {
+			percentComplete := int32((i + 1) * 100 / len(deviceUUIDs))
+			var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Running, common.OK, percentComplete, http.MethodPost)
+			err := e.UpdateTask(task)
+			if err != nil && err.Error() == common.Cancelling {
+				task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+				e.UpdateTask(task)
+				return resp
+			}
+		}
+	}
+	if partialResultFlag {
+		errMsg := "one or more of the rediscover actions failed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID
+		log.Printf(errMsg)
+		return common.GeneralError(resp.StatusCode, response.GeneralError, errMsg, nil, taskInfo)
+	}
+	log.Println("all rediscover actions are successfully completed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	resp = rediscoverSuccessResponse()
+	var task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Completed, common.OK, 100, http.MethodPost)
+	err = e.UpdateTask(task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, targetURI, string(req.RequestBody), resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.UpdateTask(task)
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// rediscoverAggregateServer rediscovers a server of the aggregate under a sub task,
+// at most as many servers as there are slots are rediscovered at a time
+func (e *ExternalInterface) rediscoverAggregateServer(taskID, reqBody, sessionUserName, deviceUUID, scope string, subTaskChan chan<- int32, slots chan struct{}) {
+	slots <- struct{}{}
+	// the status is sent even when the sub task update ends the goroutine
+	statusCode := int32(http.StatusInternalServerError)
+	defer func() {
+		<-slots
+		subTaskChan <- statusCode
+	}()
+	subTaskURI, err := e.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		log.Println("error while trying to create sub task")
+		return
+	}
+	subTaskID := strings.TrimSuffix(subTaskURI, "/")
+	subTaskID = subTaskID[strings.LastIndex(subTaskID, "/")+1:]
+	aggregationSourceURI := "/redfish/v1/AggregationService/AggregationSources/" + deviceUUID
+	taskInfo := &common.TaskUpdateInfo{TaskID: subTaskID, TargetURI: aggregationSourceURI, UpdateTask: e.UpdateTask, TaskRequest: reqBody}
+
+	resp := e.rediscoverServer(deviceUUID, scope, taskInfo)
+	statusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return
+	}
+	var task = fillTaskData(subTaskID, aggregationSourceURI, reqBody, resp, common.Completed, common.OK, 100, http.MethodPost)
+	e.UpdateTask(task)
+}
+
+// rediscoverServer retrieves again the part of the inventory of the server in the scope.
+// The systems of the server are marked as being rediscovered while it runs, so that
+// they cannot be deleted meanwhile, and the rediscovery is refused when another
+// operation is in progress on one of them.
+func (e *ExternalInterface) rediscoverServer(deviceUUID, scope string, taskInfo *common.TaskUpdateInfo) response.RPC {
+	log.Printf("info: rediscovery(scope: %v) of the BMC with ID %v is started.", scope, deviceUUID)
+	systemURIs, errResp := e.retrieveServerInventory(deviceUUID, scope, taskInfo)
+	if errResp != nil {
+		return *errResp
+	}
+	if scope == RediscoverScopeFull || scope == RediscoverScopeSystems {
+		e.publishResourceUpdatedEvent(systemURIs, "SystemsCollection")
+	}
+	log.Printf("info: rediscovery(scope: %v) of the BMC with ID %v is now complete.", scope, deviceUUID)
+	UpdateDynamicAggregates()
+	return rediscoverSuccessResponse()
+}
+
+// retrieveServerInventory retrieves again the part of the inventory of the server in the scope,
+// the systems, chassis and managers the server no longer lists are deleted along with their resources.
+// The resources retrieved are staged until the whole retrieval succeeds, they replace then the ones
+// in the scope at once, so that a failed retrieval leaves the inventory as it was.
+// It returns the URIs of the systems retrieved, or the error response.
+func (e *ExternalInterface) retrieveServerInventory(deviceUUID, scope string, taskInfo *common.TaskUpdateInfo) ([]string, *response.RPC) {
+	target, dbErr := agmodel.GetTarget(deviceUUID)
+	if dbErr != nil {
+		log.Println(dbErr.Error())
+		resp := common.GeneralError(http.StatusNotFound, response.ResourceNotFound, dbErr.Error(), []interface{}{"AggregationSource", deviceUUID}, taskInfo)
+		return nil, &resp
+	}
+	// the error is not assigned to dbErr, which holds an error interface
+	systemURIs, getErr := agmodel.GetAllMatchingDetails("ComputerSystem", deviceUUID, common.InMemory)
+	if getErr != nil {
+		errMsg := "error while trying to get the systems of the BMC: " + getErr.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return nil, &resp
+	}
+	if errResp := lockSystemsOperation(systemURIs, "InventoryRediscovery", taskInfo); errResp != nil {
+		return nil, errResp
+	}
+	defer unlockSystemsOperation(systemURIs)
+
+	req, err := e.getServerPluginRequest(target)
+	if err != nil {
+		errMsg := "error while trying to contact the plugin of the BMC: " + err.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return nil, &resp
+	}
+	req.DeviceUUID = deviceUUID
+	req.UpdateFlag = true
+
+	var h respHolder
+	h.TraversedLinks = make(map[string]bool)
+	h.Staged = make(map[string]stagedResource)
+	switch scope {
+	case RediscoverScopeFull, RediscoverScopeSystems:
+		err = h.rediscoverSystems(req)
+	case RediscoverScopeStorage:
+		for _, systemURI := range systemURIs {
+			req.OID = strings.Replace(systemURI, deviceUUID+":", "", 1) + "/Storage"
+			if _, _, err = h.getStorageInfo(0, 100, req); err != nil {
+				break
+			}
+		}
+	}
+	if err == nil && (scope == RediscoverScopeFull || scope == RediscoverScopeChassis) {
+		err = h.rediscoverCollection(req, "/redfish/v1/Chassis", "Chassis")
+	}
+	if err == nil && (scope == RediscoverScopeFull || scope == RediscoverScopeManagers) {
+		err = h.rediscoverCollection(req, "/redfish/v1/Managers", "Managers")
+	}
+	// failures to retrieve individual resources are not fatal, like when the server is added,
+	// the failures to retrieve the collections of the scope fail the rediscovery
+	if err != nil || (h.ErrorMessage != "" && h.StatusCode != http.StatusServiceUnavailable && h.StatusCode != http.StatusNotFound &&
+		h.StatusCode != http.StatusInternalServerError && h.StatusCode != http.StatusBadRequest) {
+		errMsg := "error while trying to rediscover the BMC: " + h.ErrorMessage
+		log.Println(errMsg)
+		var resp response.RPC
+		if h.StatusCode == 0 {
+			resp = common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		} else {
+			resp = common.GeneralError(h.StatusCode, h.StatusMessage, errMsg, h.MsgArgs, taskInfo)
+		}
+		return nil, &resp
+	}
+	if err = h.commit(func() {
+		for _, prefix := range scopePrefixes(deviceUUID, scope, systemURIs) {
+			deleteSubordinateResourceExcept(deviceUUID, prefix, func(key, uri string) bool {
+				return h.retrieved(deviceUUID, key, uri)
+			})
+		}
+	}); err != nil {
+		errMsg := "error while trying to save the rediscovered inventory of the BMC: " + err.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return nil, &resp
+	}
+	return h.SystemURL, nil
+}
+
+// scopePrefixes returns the URI prefixes of the resources of the server in the scope of a rediscovery
+func scopePrefixes(deviceUUID, scope string, systemURIs []string) []string {
+	switch scope {
+	case RediscoverScopeSystems:
+		return []string{"/redfish/v1/Systems/"}
+	case RediscoverScopeStorage:
+		var prefixes []string
+		for _, systemURI := range systemURIs {
+			prefixes = append(prefixes, systemURI+"/Storage")
+		}
+		return prefixes
+	case RediscoverScopeChassis:
+		return []string{"/redfish/v1/Chassis/"}
+	case RediscoverScopeManagers:
+		return []string{"/redfish/v1/Managers/"}
+	}
+	return []string{""}
+}
+
+// rediscoverSystems retrieves again the systems the server lists and deletes the ones it no longer lists
+func (h *respHolder) rediscoverSystems(req getResourceRequest) error {
+	req.OID = "/redfish/v1/Systems"
+	members, err := h.getCollectionMembers(req)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		req.OID = member
+		if _, _, err := h.getSystemInfo("", 0, 100, req); err != nil {
+			return err
+		}
+	}
+	return h.onCommit(func() error {
+		deleteRemovedResources(req.DeviceUUID, "ComputerSystem", members)
+		return nil
+	})
+}
+
+// rediscoverCollection retrieves again the members of the collection the server lists and deletes
+// the ones it no longer lists, the failures to retrieve the members are recorded in the holder
+func (h *respHolder) rediscoverCollection(req getResourceRequest, collectionURI, table string) error {
+	req.OID = collectionURI
+	members, err := h.getCollectionMembers(req)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		req.OID = member
+		h.getIndivdualInfo("", 0, 100, req)
+	}
+	return h.onCommit(func() error {
+		deleteRemovedResources(req.DeviceUUID, table, members)
+		return nil
+	})
+}
+
+// getCollectionMembers retrieves the URIs of the members of the collection from the server
+func (h *respHolder) getCollectionMembers(req getResourceRequest) ([]string, error) {
+	body, _, getResponse, err := contactPlugin(req, "error while trying to get the "+req.OID+" collection details: ")
+	if err != nil {
+		h.lock.Lock()
+		h.ErrorMessage = err.Error()
+		h.StatusMessage = getResponse.StatusMessage
+		h.StatusCode = getResponse.StatusCode
+		h.keepUnder(req.OID, h.StatusCode)
+		h.MsgArgs = getResponse.MsgArgs
+		h.lock.Unlock()
+		log.Println(err)
+		return nil, err
+	}
+	var collection struct {
+		Members []struct {
+			OdataID string `json:"@odata.id"`
+		} `json:"Members"`
+	}
+	if err := json.Unmarshal(body, &collection); err != nil {
+		h.lock.Lock()
+		h.ErrorMessage = "error while trying unmarshal " + req.OID + " " + err.Error()
+		h.StatusMessage = response.InternalError
+		h.StatusCode = http.StatusInternalServerError
+		h.keepUnder(req.OID, h.StatusCode)
+		h.lock.Unlock()
+		log.Println(h.ErrorMessage)
+		return nil, err
+	}
+	members := make([]string, 0, len(collection.Members))
+	for _, member := range collection.Members {
+		members = append(members, member.OdataID)
+	}
+	return members, nil
+}
+
+// deleteRemovedResources deletes the members of a collection of the server stored in the table
+// which the server no longer lists, along with the resources under them
+func deleteRemovedResources(deviceUUID, table string, members []string) {
+	listed := make(map[string]bool)
+	for _, member := range members {
+		member = strings.TrimSuffix(member, "/")
+		listed[keyFormation(member, member[strings.LastIndex(member, "/")+1:], deviceUUID)] = true
+	}
+	keys, err := agmodel.GetAllMatchingDetails(table, deviceUUID+":", common.InMemory)
+	if err != nil {
+		log.Printf("error while trying to fetch the %v of the BMC with ID %v: %v", table, deviceUUID, err)
+		return
+	}
+	for _, key := range keys {
+		// the resources stored in the same table under the members are left to their member
+		keyDetails := strings.SplitN(key, deviceUUID+":", 2)
+		if listed[key] || len(keyDetails) != 2 || strings.Contains(keyDetails[1], "/") {
+			continue
+		}
+		log.Printf("info: %v is no longer listed by the BMC with ID %v, it is removed", key, deviceUUID)
+		if table == "ComputerSystem" {
+			err = agmodel.DeleteComputeSystemData(key)
+			agmodel.DeleteSystemResetInfo(key)
+		} else {
+			err = agmodel.Delete(table, key, common.InMemory)
+		}
+		if err != nil {
+			log.Printf("error: delete of %v from %v in %v DB failed due to the error: %v", key, table, common.InMemory, err)
+		}
+		deleteSubordinateResourceUnder(deviceUUID, key+"/")
+	}
+}
+
+// lockSystemOperation records the operation in progress on the system, it fails when another operation is in progress
+func lockSystemOperation(systemURI, operation string, taskInfo *common.TaskUpdateInfo) *response.RPC {
+	systemOperation, dbErr := agmodel.GetSystemOperationInfo(systemURI)
+	if dbErr != nil && errors.DBKeyNotFound != dbErr.ErrNo() {
+		errMsg := "error while trying to get the operation in progress on the system " + systemURI + ": " + dbErr.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return &resp
+	}
+	if systemOperation.Operation != "" {
+		errMsg := fmt.Sprintf(" %v operation is in progress on the system %v", systemOperation.Operation, systemURI)
+		log.Println("error:" + errMsg)
+		resp := common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, taskInfo)
+		return &resp
+	}
+	systemOperation.Operation = operation
+	if dbErr = systemOperation.AddSystemOperationInfo(systemURI); dbErr != nil {
+		errMsg := "error while trying to record the operation on the system " + systemURI + ": " + dbErr.Error()
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return &resp
+	}
+	return nil
+}
+
+// lockSystemsOperation records the operation in progress on all the systems, none is locked when it fails
+func lockSystemsOperation(systemURIs []string, operation string, taskInfo *common.TaskUpdateInfo) *response.RPC {
+	for index, systemURI := range systemURIs {
+		if errResp := lockSystemOperation(systemURI, operation, taskInfo); errResp != nil {
+			unlockSystemsOperation(systemURIs[:index])
+			return errResp
+		}
+	}
+	return nil
+}
+
+// unlockSystemsOperation removes the operation in progress on the systems
+func unlockSystemsOperation(systemURIs []string) {
+	for _, systemURI := range systemURIs {
+		agmodel.DeleteSystemOperationInfo(systemURI)
+	}
+}
+
+// getServerPluginRequest prepares the request to retrieve the resources of the server through its plugin
+func (e *ExternalInterface) getServerPluginRequest(target *agmodel.Target) (getResourceRequest, error) {
+	var req getResourceRequest
+	decryptedPasswordByte, err := e.DecryptPassword(target.Password)
+	if err != nil {
+		return req, err
+	}
+	device := *target
+	device.Password = decryptedPasswordByte
+	plugin, errs := agmodel.GetPluginData(target.PluginID)
+	if errs != nil {
+		return req, errs
+	}
+	req.ContactClient = e.ContactClient
+	req.GetPluginStatus = e.GetPluginStatus
+	req.UpdateTask = e.UpdateTask
+	req.Plugin = plugin
+	req.StatusPoll = true
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		req.HTTPMethodType = http.MethodPost
+		req.DeviceInfo = map[string]interface{}{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+		req.OID = "/ODIM/v1/Sessions"
+		_, token, _, err := contactPlugin(req, "error while getting the details "+req.OID+": ")
+		if err != nil {
+			return req, err
+		}
+		req.Token = token
+	} else {
+		req.LoginCredentials = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	req.HTTPMethodType = http.MethodGet
+	req.DeviceInfo = device
+	return req, nil
+}
+
+// getServersOfElements returns the ID of the servers of the systems, every server only once
+func getServersOfElements(elements []string) []string {
+	var deviceUUIDs []string
+	found := make(map[string]bool)
+	for _, element := range elements {
+		systemID := element[strings.LastIndex(element, "/")+1:]
+		deviceUUID := strings.Split(systemID, ":")[0]
+		if deviceUUID == "" || found[deviceUUID] {
+			continue
+		}
+		found[deviceUUID] = true
+		deviceUUIDs = append(deviceUUIDs, deviceUUID)
+	}
+	return deviceUUIDs
+}
+
+func rediscoverSuccessResponse() response.RPC {
+	resp := response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header: map[string]string{
+			"Cache-Control":     "no-cache",
+			"Connection":        "keep-alive",
+			"Content-type":      "application/json; charset=utf-8",
+			"Transfer-Encoding": "chunked",
+			"OData-Version":     "4.0",
+		},
+	}
+	args := response.Args{
+		Code:    resp.StatusMessage,
+		Message: "Request completed successfully",
+	}
+	resp.Body = args.CreateGenericErrorResponse()
+	return resp
+}
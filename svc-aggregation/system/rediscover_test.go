@@ -0,0 +1,291 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+func TestParseRediscoverRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            []byte
+		wantScope       string
+		invalidProperty string
+		wantErr         bool
+	}{
+		{name: "without request body", body: nil, wantScope: RediscoverScopeFull},
+		{name: "without scope", body: []byte(`{}`), wantScope: RediscoverScopeFull},
+		{name: "storage scope", body: []byte(`{"Scope":"Storage"}`), wantScope: RediscoverScopeStorage},
+		{name: "unknown scope", body: []byte(`{"Scope":"Fans"}`), wantScope: "Fans", invalidProperty: "Scope", wantErr: true},
+		{name: "invalid request body", body: []byte(`Scope`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, invalidProperty, err := ParseRediscoverRequest(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRediscoverRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Scope != tt.wantScope || invalidProperty != tt.invalidProperty {
+				t.Errorf("ParseRediscoverRequest() = %v, %v, want %v, %v", got.Scope, invalidProperty, tt.wantScope, tt.invalidProperty)
+			}
+		})
+	}
+}
+
+func TestGetServersOfElements(t *testing.T) {
+	elements := []string{
+		"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1",
+		"/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48:1",
+		"/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:2",
+	}
+	want := []string{"6d4a0a66-7efa-578e-83cf-44dc68d2874e", "c14d91b5-3333-48bb-a7b7-75f74a137d48"}
+	if got := getServersOfElements(elements); !reflect.DeepEqual(got, want) {
+		t.Errorf("getServersOfElements() = %v, want %v", got, want)
+	}
+}
+
+func TestExternalInterface_RediscoverAggregationSource(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	device := agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		Password:       []byte("imKp3Q6Cx989b6JSPHnRhritEcXWtaB3zqVBkSwhCenJYfgAYBf9FlAocE"),
+		UserName:       "admin",
+		DeviceUUID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+		PluginID:       "GRF",
+	}
+	mockDeviceData(device.DeviceUUID, device)
+	mockPluginData(t, "GRF")
+	reqData, _ := json.Marshal(map[string]interface{}{"@odata.id": "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"})
+	mockSystemResourceData(reqData, "ComputerSystem", "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1")
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "100.0.0.1",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "GRF"}},
+	}, "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e")
+	agmodel.AddAggregationSource(agmodel.AggregationSource{
+		HostName: "100.0.0.2:45001",
+		UserName: "admin",
+		Links:    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "GRF", "PluginType": "Compute"}},
+	}, "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11")
+	systemOperation := agmodel.SystemOperation{Operation: "Delete"}
+	systemOperation.AddSystemOperationInfo("/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1")
+
+	p := &ExternalInterface{
+		ContactClient:   mockContactClient,
+		Auth:            mockIsAuthorized,
+		UpdateTask:      mockUpdateTask,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+		PublishEventMB:  mockPublishEventMB,
+	}
+	tests := []struct {
+		name     string
+		url      string
+		reqBody  []byte
+		wantCode int32
+	}{
+		{
+			name:     "invalid scope",
+			url:      "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+			reqBody:  []byte(`{"Scope":"Fans"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "non existing aggregation source",
+			url:      "/redfish/v1/AggregationService/AggregationSources/nonExisting/Actions/AggregationSource.Rediscover/",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "aggregation source of a plugin",
+			url:      "/redfish/v1/AggregationService/AggregationSources/e7c4b1d8-8c85-4a2b-8f3c-6f3d4a9b2c11/Actions/AggregationSource.Rediscover/",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "system being deleted",
+			url:      "/redfish/v1/AggregationService/AggregationSources/6d4a0a66-7efa-578e-83cf-44dc68d2874e/Actions/AggregationSource.Rediscover/",
+			reqBody:  []byte(`{"Scope":"Systems"}`),
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.RediscoverAggregationSource("123", "admin", &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          tt.url,
+				RequestBody:  tt.reqBody,
+			})
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("RediscoverAggregationSource() status code = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+		})
+	}
+	// the operation in progress must be kept
+	if operation, _ := agmodel.GetSystemOperationInfo("/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"); operation.Operation != "Delete" {
+		t.Errorf("RediscoverAggregationSource() changed the operation in progress to %v", operation.Operation)
+	}
+}
+
+func TestExternalInterface_RediscoverElementsOfAggregate(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	err := agmodel.CreateAggregate(agmodel.Aggregate{
+		Elements: []string{
+			"/redfish/v1/Systems/c14d91b5-3333-48bb-a7b7-75f74a137d48:1",
+		},
+	}, "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	p := &ExternalInterface{
+		ContactClient:   mockContactClient,
+		Auth:            mockIsAuthorized,
+		CreateChildTask: mockCreateChildTask,
+		UpdateTask:      mockUpdateTask,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+	}
+	tests := []struct {
+		name     string
+		url      string
+		reqBody  []byte
+		wantCode int32
+	}{
+		{
+			name:     "invalid scope",
+			url:      "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover/",
+			reqBody:  []byte(`{"Scope":"Fans"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "non existing aggregate",
+			url:      "/redfish/v1/AggregationService/Aggregates/nonExisting/Actions/Aggregate.Rediscover/",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "server of the element not added",
+			url:      "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover/",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.RediscoverElementsOfAggregate("123", "admin", &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          tt.url,
+				RequestBody:  tt.reqBody,
+			})
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("RediscoverElementsOfAggregate() status code = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_RetrieveServerInventoryScopes(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	if err := ioutil.WriteFile(schemaFile, []byte(`{"searchKeys":[{"SystemType":{"type":"string"}}]}`), 0600); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	config.Data.SearchAndFilterSchemaPath = schemaFile
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	deviceUUID := "7e5b1b77-8f0b-489f-94d0-55ed79e3985f"
+	target := agmodel.Target{
+		ManagerAddress: "100.0.0.2",
+		Password:       []byte("password"),
+		UserName:       "admin",
+		DeviceUUID:     deviceUUID,
+		PluginID:       "GRF",
+	}
+	mockDeviceData(deviceUUID, target)
+	mockPluginData(t, "GRF")
+
+	bmc := &mockBMC{resources: make(map[string]string)}
+	bmc.set("/ODIM/v1/Systems", `{"Members":[{"@odata.id":"/ODIM/v1/Systems/1"}]}`)
+	bmc.set("/ODIM/v1/Systems/1", `{"@odata.id":"/ODIM/v1/Systems/1","Id":"1","UUID":"1-uuid","SystemType":"Physical",
+		"Storage":{"@odata.id":"/ODIM/v1/Systems/1/Storage"}}`)
+	bmc.set("/ODIM/v1/Systems/1/Storage", `{"@odata.id":"/ODIM/v1/Systems/1/Storage","Members":[{"@odata.id":"/ODIM/v1/Systems/1/Storage/1"}]}`)
+	bmc.set("/ODIM/v1/Systems/1/Storage/1", `{"@odata.id":"/ODIM/v1/Systems/1/Storage/1","Id":"1"}`)
+	bmc.set("/ODIM/v1/Chassis", `{"Members":[{"@odata.id":"/ODIM/v1/Chassis/1"}]}`)
+	bmc.set("/ODIM/v1/Chassis/1", `{"@odata.id":"/ODIM/v1/Chassis/1","Id":"1","Power":{"@odata.id":"/ODIM/v1/Chassis/1/Power"}}`)
+	bmc.set("/ODIM/v1/Chassis/1/Power", `{"@odata.id":"/ODIM/v1/Chassis/1/Power","Id":"Power"}`)
+	bmc.set("/ODIM/v1/Managers", `{"Members":[{"@odata.id":"/ODIM/v1/Managers/1"},{"@odata.id":"/ODIM/v1/Managers/2"}]}`)
+	bmc.set("/ODIM/v1/Managers/1", `{"@odata.id":"/ODIM/v1/Managers/1","Id":"1"}`)
+	bmc.set("/ODIM/v1/Managers/2", `{"@odata.id":"/ODIM/v1/Managers/2","Id":"2"}`)
+	p := &ExternalInterface{
+		ContactClient:   bmc.contactClient,
+		UpdateTask:      mockUpdateTask,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+	}
+	if _, errResp := p.retrieveServerInventory(deviceUUID, RediscoverScopeFull, nil); errResp != nil {
+		t.Fatalf("retrieveServerInventory() of the whole server failed: %v", errResp.Body)
+	}
+	inventory := getInventorySnapshot(deviceUUID)
+
+	// a failed rediscovery leaves the inventory of its scope as it is
+	for scope, failingURI := range map[string]string{
+		RediscoverScopeChassis: "/ODIM/v1/Chassis",
+		RediscoverScopeStorage: "/ODIM/v1/Systems/1/Storage",
+		RediscoverScopeSystems: "/ODIM/v1/Systems",
+	} {
+		bmc.fail(failingURI, true)
+		if _, errResp := p.retrieveServerInventory(deviceUUID, scope, nil); errResp == nil {
+			t.Errorf("retrieveServerInventory() of the scope %v succeeded while %v failed", scope, failingURI)
+		}
+		bmc.fail(failingURI, false)
+		if after := getInventorySnapshot(deviceUUID); !reflect.DeepEqual(inventory, after) {
+			t.Errorf("failed retrieveServerInventory() of the scope %v changed the inventory %v into %v", scope, inventory, after)
+		}
+	}
+
+	// the manager no longer listed is removed by the rediscovery of the managers only
+	bmc.set("/ODIM/v1/Managers", `{"Members":[{"@odata.id":"/ODIM/v1/Managers/1"}]}`)
+	if _, errResp := p.retrieveServerInventory(deviceUUID, RediscoverScopeManagers, nil); errResp != nil {
+		t.Fatalf("retrieveServerInventory() of the managers failed: %v", errResp.Body)
+	}
+	delete(inventory, "/redfish/v1/Managers/"+deviceUUID+":2")
+	if after := getInventorySnapshot(deviceUUID); !reflect.DeepEqual(inventory, after) {
+		t.Errorf("retrieveServerInventory() of the managers changed the inventory %v into %v", inventory, after)
+	}
+}
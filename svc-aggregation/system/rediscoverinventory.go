@@ -147,7 +147,7 @@ func (e *ExternalInterface) RediscoverSystemInventory(deviceUUID, systemURL stri
 		//rediscovering the Chassis Information
 		req.OID = "/redfish/v1/Chassis"
 		chassisEstimatedWork := int32(15)
-		progress = h.getAllRootInfo("", progress, chassisEstimatedWork, req)
+		progress, _ = h.getAllRootInfo("", progress, chassisEstimatedWork, req)
 	}
 
 	var responseBody = map[string]string{
@@ -161,6 +161,7 @@ func (e *ExternalInterface) RediscoverSystemInventory(deviceUUID, systemURL stri
 	}
 
 	log.Printf("info: rediscovery of the BMC with ID %v is now complete.", deviceUUID)
+	UpdateDynamicAggregates()
 }
 
 //RediscoverResources is a function to rediscover the server inventory,
@@ -327,6 +328,19 @@ func deleteResourceResetInfo(pattern string) {
 
 // deleteSubordinateResource will delete all the subordinate resources assosiated with the pattern
 func deleteSubordinateResource(deviceUUID string) {
+	deleteSubordinateResourceUnder(deviceUUID, "")
+}
+
+// deleteSubordinateResourceUnder will delete the subordinate resources of the BMC whose URI starts with the given prefix
+func deleteSubordinateResourceUnder(deviceUUID, uriPrefix string) {
+	deleteSubordinateResourceExcept(deviceUUID, uriPrefix, func(key, uri string) bool {
+		return false
+	})
+}
+
+// deleteSubordinateResourceExcept will delete the subordinate resources of the BMC whose URI starts with the given
+// prefix, but the ones kept, by their key made of the table and the URI
+func deleteSubordinateResourceExcept(deviceUUID, uriPrefix string, kept func(key, uri string) bool) {
 	log.Printf("info: initiated removal of subordinate resource for the BMC with ID %v from the in-memory DB.", deviceUUID)
 	keys, err := agmodel.GetAllMatchingDetails("*", deviceUUID, common.InMemory)
 	if err != nil {
@@ -335,6 +349,9 @@ func deleteSubordinateResource(deviceUUID string) {
 	}
 	for _, key := range keys {
 		resourceDetails := strings.SplitN(key, ":", 2)
+		if len(resourceDetails) != 2 || !strings.HasPrefix(resourceDetails[1], uriPrefix) || kept(key, resourceDetails[1]) {
+			continue
+		}
 		switch resourceDetails[0] {
 		case "ComputerSystem", "SystemReset", "SystemOperation", "Chassis", "Managers", "FirmwareInventory", "SoftwareInventory":
 			continue
@@ -227,6 +227,9 @@ func mockContactClient(url, method, token string, odataID string, body interface
 
 	} else if url == host+"/ODIM/v1/Managers/1" {
 		body := `{"@odata.id":"/ODIM/v1/Managers/1", "UUID": "1s7sda8asd-asdas8as0", "Id": "1"}`
+		if host == "https://100.0.0.9:9091" {
+			body = `{"@odata.id":"/ODIM/v1/Managers/1", "UUID": "8as0asd9a-1s7sda8asd", "Id": "1"}`
+		}
 		return &http.Response{
 			StatusCode: http.StatusOK,
 			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
@@ -51,7 +51,12 @@ func (e *ExternalInterface) UpdateAggregationSource(req *aggregatorproto.Aggrega
 		log.Println(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
-	if len(updateRequest) <= 0 {
+	// Links are not plain strings, the plugin requested in it is taken out of the request
+	requestedPluginID, errResp := getRequestedPluginID(updateRequest)
+	if errResp != nil {
+		return *errResp
+	}
+	if len(updateRequest) <= 0 && requestedPluginID == "" {
 		param := "HostName UserName Password "
 		errMsg := "error:  field " + param + " Missing"
 		log.Printf(errMsg)
@@ -99,26 +104,23 @@ func (e *ExternalInterface) UpdateAggregationSource(req *aggregatorproto.Aggrega
 	var data = strings.Split(req.URL, "/redfish/v1/AggregationService/AggregationSources/")
 	links := aggregationSource.Links.(map[string]interface{})
 	oem := links["Oem"].(map[string]interface{})
+	if requestedPluginID == "" {
+		requestedPluginID = oem["PluginID"].(string)
+	}
 	if _, ok := oem["PluginType"]; ok {
-		resp = e.updateManagerAggregationSource(data[1], aggregationSource, updateRequest, hostNameUpdated)
+		if requestedPluginID != oem["PluginID"].(string) {
+			errMsg := "error: plugin of the aggregation source of a plugin cannot be changed"
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"PluginID", "PluginType"}, nil)
+		}
+		resp = e.updateManagerAggregationSource(req.URL, aggregationSource, updateRequest, hostNameUpdated)
 	} else {
-		resp = e.updateBMCAggregationSource(data[1], aggregationSource, updateRequest, hostNameUpdated)
+		resp = e.updateBMCAggregationSource(req.URL, aggregationSource, updateRequest, hostNameUpdated, requestedPluginID)
 	}
 
 	if resp.StatusMessage != "" {
 		return resp
 	}
-	// Update the aggregation source info
-	aggregationSource.HostName = updateRequest["HostName"].(string)
-	aggregationSource.UserName = updateRequest["UserName"].(string)
-	aggregationSource.Password = updateRequest["Password"].([]byte)
-
-	dbErr = agmodel.UpdateAggregtionSource(aggregationSource, req.URL)
-	if dbErr != nil {
-		errMsg := "error while trying to update aggregation source info: " + dbErr.Error()
-		fmt.Println(errMsg)
-		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
-	}
 
 	commonResponse := response.Response{
 		OdataType:    "#AggregationSource.v1_0_0.AggregationSource",
@@ -149,7 +151,7 @@ func (e *ExternalInterface) UpdateAggregationSource(req *aggregatorproto.Aggrega
 	resp.StatusMessage = response.Success
 	return resp
 }
-func (e *ExternalInterface) updateManagerAggregationSource(aggregationSourceID string, aggregationSource agmodel.AggregationSource, updateRequest map[string]interface{}, hostNameUpdated bool) response.RPC {
+func (e *ExternalInterface) updateManagerAggregationSource(aggregationSourceURI string, aggregationSource agmodel.AggregationSource, updateRequest map[string]interface{}, hostNameUpdated bool) response.RPC {
 	links := aggregationSource.Links.(map[string]interface{})
 	oem := links["Oem"].(map[string]interface{})
 	pluginID := oem["PluginID"].(string)
@@ -257,8 +259,9 @@ func (e *ExternalInterface) updateManagerAggregationSource(aggregationSourceID s
 
 	plugin.Password = ciphertext
 	plugin.ManagerUUID = managerUUID
-	updateRequest["Password"] = ciphertext
-	dbErr := agmodel.UpdatePluginData(plugin, pluginID)
+	setAggregationSourceDetails(&aggregationSource, updateRequest, ciphertext)
+	// the plugin and its aggregation source are updated together, or none of them is
+	dbErr := agmodel.UpdatePluginAndAggregationSource(plugin, pluginID, aggregationSource, aggregationSourceURI)
 	if dbErr != nil {
 		errMsg := "error while trying to update plugin info: " + dbErr.Error()
 		log.Println(errMsg)
@@ -270,17 +273,34 @@ func (e *ExternalInterface) updateManagerAggregationSource(aggregationSourceID s
 	}
 }
 
-func (e *ExternalInterface) updateBMCAggregationSource(aggregationSourceID string, aggregationSource agmodel.AggregationSource, updateRequest map[string]interface{}, hostNameUpdated bool) response.RPC {
+// updateBMCAggregationSource updates the BMC details, pluginID is the plugin which is to manage the BMC.
+// When it is not the plugin managing the BMC now, the BMC is moved to it along with its event subscriptions.
+func (e *ExternalInterface) updateBMCAggregationSource(aggregationSourceURI string, aggregationSource agmodel.AggregationSource, updateRequest map[string]interface{}, hostNameUpdated bool, pluginID string) response.RPC {
+	aggregationSourceID := strings.TrimPrefix(aggregationSourceURI, "/redfish/v1/AggregationService/AggregationSources/")
 	// Get the plugin  from db
 	links := aggregationSource.Links.(map[string]interface{})
 	oem := links["Oem"].(map[string]interface{})
-	pluginID := oem["PluginID"].(string)
+	currentPluginID := oem["PluginID"].(string)
 	plugin, errs := agmodel.GetPluginData(pluginID)
 	if errs != nil {
 		errMsg := errs.Error()
 		log.Printf(errMsg)
 		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"plugin", pluginID}, nil)
 	}
+	migration := pluginID != currentPluginID
+	if migration {
+		if plugin.Draining {
+			errMsg := fmt.Sprintf("error: plugin %v is draining, no BMC can be moved to it", pluginID)
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusConflict, response.ResourceInUse, errMsg, nil, nil)
+		}
+		// the current plugin may be gone, which is why the BMC is being moved
+		if currentPlugin, errs := agmodel.GetPluginData(currentPluginID); errs == nil && currentPlugin.PluginType != plugin.PluginType {
+			errMsg := fmt.Sprintf("error: plugin %v is of type %v, the BMC is managed by a plugin of type %v", pluginID, plugin.PluginType, currentPlugin.PluginType)
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusBadRequest, response.PropertyValueConflict, errMsg, []interface{}{"PluginID", "PluginType"}, nil)
+		}
+	}
 	var pluginContactRequest getResourceRequest
 	pluginContactRequest.ContactClient = e.ContactClient
 	pluginContactRequest.GetPluginStatus = e.GetPluginStatus
@@ -409,19 +429,120 @@ func (e *ExternalInterface) updateBMCAggregationSource(aggregationSourceID strin
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
 	saveSystem.Password = ciphertext
-	updateRequest["Password"] = ciphertext
-	dbErr := agmodel.UpdateSystemData(saveSystem, aggregationSourceID)
+	previousAggregationSource := aggregationSource
+	setAggregationSourceDetails(&aggregationSource, updateRequest, ciphertext)
+	if migration {
+		systemURIs, dbErr := agmodel.GetAllMatchingDetails("ComputerSystem", aggregationSourceID, common.InMemory)
+		if dbErr != nil {
+			errMsg := "error while trying to get the systems of the BMC: " + dbErr.Error()
+			log.Println(errMsg)
+			return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		}
+		if errResp := lockSystemsOperation(systemURIs, "PluginMigration", nil); errResp != nil {
+			return *errResp
+		}
+		defer unlockSystemsOperation(systemURIs)
+	}
+	previousSystem, err := agmodel.GetTarget(aggregationSourceID)
+	if err != nil {
+		errMsg := "error while trying to get system info: " + err.Error()
+		log.Println(errMsg)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+	}
+	if migration {
+		oem["PluginID"] = pluginID
+	}
+	// the plugin is switched along with the other BMC details and the aggregation source in a single transaction
+	dbErr := agmodel.UpdateSystemAndAggregationSource(saveSystem, aggregationSource, aggregationSourceURI)
 	if dbErr != nil {
+		oem["PluginID"] = currentPluginID
 		errMsg := "error while trying to update system info: " + dbErr.Error()
 		log.Println(errMsg)
 		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
 	}
+	if migration {
+		if errResp := e.moveEventSubscriptions(aggregationSourceID, currentPluginID); errResp != nil {
+			// the BMC is given back to the plugin which managed it, which subscribes again to its events
+			oem["PluginID"] = currentPluginID
+			previous := agmodel.SaveSystem{
+				ManagerAddress: previousSystem.ManagerAddress,
+				Password:       previousSystem.Password,
+				UserName:       previousSystem.UserName,
+				DeviceUUID:     previousSystem.DeviceUUID,
+				PluginID:       previousSystem.PluginID,
+			}
+			if dbErr := agmodel.UpdateSystemAndAggregationSource(previous, previousAggregationSource, aggregationSourceURI); dbErr != nil {
+				log.Printf("error while trying to give the BMC with ID %v back to the plugin %v: %v", aggregationSourceID, currentPluginID, dbErr)
+			} else {
+				e.moveEventSubscriptions(aggregationSourceID, pluginID)
+			}
+			return *errResp
+		}
+		log.Printf("info: BMC with ID %v is moved from the plugin %v to the plugin %v", aggregationSourceID, currentPluginID, pluginID)
+	}
 
 	return response.RPC{
 		StatusCode: http.StatusOK,
 	}
 }
 
+// setAggregationSourceDetails sets the BMC details of the update request in the aggregation source
+func setAggregationSourceDetails(aggregationSource *agmodel.AggregationSource, updateRequest map[string]interface{}, password []byte) {
+	aggregationSource.HostName = updateRequest["HostName"].(string)
+	aggregationSource.UserName = updateRequest["UserName"].(string)
+	aggregationSource.Password = password
+}
+
+// moveEventSubscriptions asks the event service to subscribe again to the events of the BMC
+// through the plugin which now manages it, it returns the error response when they are not moved
+func (e *ExternalInterface) moveEventSubscriptions(deviceUUID, previousPluginID string) *response.RPC {
+	subResponse, err := e.MoveEventSubscriptions(deviceUUID, previousPluginID)
+	if err != nil {
+		errMsg := fmt.Sprintf("error while trying to move the event subscriptions of the BMC with ID %v: %v", deviceUUID, err)
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, nil)
+		return &resp
+	}
+	if subResponse.StatusCode != http.StatusOK {
+		errMsg := fmt.Sprintf("error while trying to move the event subscriptions of the BMC with ID %v: %v", deviceUUID, string(subResponse.Body))
+		log.Println(errMsg)
+		resp := common.GeneralError(subResponse.StatusCode, response.GeneralError, errMsg, nil, nil)
+		return &resp
+	}
+	return nil
+}
+
+// getRequestedPluginID takes Links out of the update request and returns the plugin requested in Links.Oem.PluginID
+func getRequestedPluginID(updateRequest map[string]interface{}) (string, *response.RPC) {
+	links, ok := updateRequest["Links"]
+	if !ok {
+		return "", nil
+	}
+	delete(updateRequest, "Links")
+	linksMap, ok := links.(map[string]interface{})
+	if !ok {
+		errMsg := "error: Links must be an object"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyValueTypeError, errMsg, []interface{}{fmt.Sprintf("%v", links), "Links"}, nil)
+		return "", &resp
+	}
+	oem, ok := linksMap["Oem"].(map[string]interface{})
+	if !ok {
+		errMsg := "error:  field Links.Oem Missing"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Links.Oem"}, nil)
+		return "", &resp
+	}
+	pluginID, ok := oem["PluginID"].(string)
+	if !ok || pluginID == "" {
+		errMsg := "error:  field Links.Oem.PluginID Missing"
+		log.Println(errMsg)
+		resp := common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errMsg, []interface{}{"Links.Oem.PluginID"}, nil)
+		return "", &resp
+	}
+	return pluginID, nil
+}
+
 func validateManagerAddress(managerAddress string) error {
 	// if the manager address is of the form <IP/FQDN>:<port>
 	// will split address to obtain only IP/FQDN. If obtained
@@ -39,6 +39,7 @@ import (
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
 	"github.com/ODIM-Project/ODIM/svc-aggregation/agresponse"
@@ -510,3 +511,120 @@ func TestExternalInterface_UpdateAggregationSource(t *testing.T) {
 		})
 	}
 }
+
+func TestExternalInterface_UpdateAggregationSourcePlugin(t *testing.T) {
+	mockPluginData(t, "ILO")
+	mockPluginData(t, "GRF")
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	mockDeviceData("123456", agmodel.Target{
+		ManagerAddress: "100.0.0.1",
+		UserName:       "admin",
+		Password:       []byte("admin12345"),
+		PluginID:       "ILO",
+		DeviceUUID:     "123456",
+	})
+	mockDeviceData("123458", agmodel.Target{
+		ManagerAddress: "100.0.0.2",
+		UserName:       "admin",
+		Password:       []byte("admin12345"),
+		PluginID:       "ILO",
+		DeviceUUID:     "123458",
+	})
+	mockSystemResourceData([]byte(`{"@odata.id":"/redfish/v1/Systems/123458:1"}`), "ComputerSystem", "/redfish/v1/Systems/123458:1")
+	systemOperation := agmodel.SystemOperation{Operation: "Delete"}
+	systemOperation.AddSystemOperationInfo("/redfish/v1/Systems/123458:1")
+	for id, oem := range map[string]map[string]interface{}{
+		"123455": {"PluginID": "GRF", "PluginType": "Compute"},
+		"123456": {"PluginID": "ILO"},
+		"123458": {"PluginID": "ILO"},
+	} {
+		err := agmodel.AddAggregationSource(agmodel.AggregationSource{
+			HostName: "100.0.0.1",
+			UserName: "admin",
+			Password: []byte("admin12345"),
+			Links:    map[string]interface{}{"Oem": oem},
+		}, "/redfish/v1/AggregationService/AggregationSources/"+id)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+	var movedFrom []string
+	p := &ExternalInterface{
+		ContactClient:   testUpdateContactClient,
+		Auth:            mockIsAuthorized,
+		GetPluginStatus: GetPluginStatusForTesting,
+		EncryptPassword: stubDevicePassword,
+		DecryptPassword: stubDevicePassword,
+		MoveEventSubscriptions: func(uuid, pluginID string) (*eventsproto.EventSubResponse, error) {
+			movedFrom = append(movedFrom, uuid+" "+pluginID)
+			return &eventsproto.EventSubResponse{StatusCode: http.StatusOK}, nil
+		},
+	}
+	tests := []struct {
+		name     string
+		id       string
+		reqBody  string
+		wantCode int32
+	}{
+		{name: "plugin of a BMC changed", id: "123456", reqBody: `{"Links":{"Oem":{"PluginID":"GRF"}}}`, wantCode: http.StatusOK},
+		{name: "plugin not added", id: "123456", reqBody: `{"Links":{"Oem":{"PluginID":"CFM"}}}`, wantCode: http.StatusNotFound},
+		{name: "invalid Links", id: "123456", reqBody: `{"Links":"GRF"}`, wantCode: http.StatusBadRequest},
+		{name: "PluginID missing", id: "123456", reqBody: `{"Links":{"Oem":{}}}`, wantCode: http.StatusBadRequest},
+		{name: "plugin of a plugin changed", id: "123455", reqBody: `{"Links":{"Oem":{"PluginID":"ILO"}}}`, wantCode: http.StatusBadRequest},
+		{name: "system of the BMC being deleted", id: "123458", reqBody: `{"Links":{"Oem":{"PluginID":"GRF"}}}`, wantCode: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.UpdateAggregationSource(&aggregatorproto.AggregatorRequest{
+				URL:         "/redfish/v1/AggregationService/AggregationSources/" + tt.id,
+				RequestBody: []byte(tt.reqBody),
+			})
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("ExternalInterface.UpdateAggregationSource() status code = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+		})
+	}
+	if target, _ := agmodel.GetTarget("123456"); target == nil || target.PluginID != "GRF" {
+		t.Errorf("plugin of the BMC is not changed: %v", target)
+	}
+	if target, _ := agmodel.GetTarget("123458"); target == nil || target.PluginID != "ILO" {
+		t.Errorf("plugin of the BMC being deleted is changed: %v", target)
+	}
+	aggregationSource, _ := agmodel.GetAggregationSourceInfo("/redfish/v1/AggregationService/AggregationSources/123456")
+	if pluginID := aggregationSource.Links.(map[string]interface{})["Oem"].(map[string]interface{})["PluginID"]; pluginID != "GRF" {
+		t.Errorf("Links.Oem.PluginID of the aggregation source = %v, want GRF", pluginID)
+	}
+	if !reflect.DeepEqual(movedFrom, []string{"123456 ILO"}) {
+		t.Errorf("event subscriptions moved = %v, want [123456 ILO]", movedFrom)
+	}
+
+	// the BMC is given back to its plugin when its event subscriptions are not moved
+	movedFrom = nil
+	p.MoveEventSubscriptions = func(uuid, pluginID string) (*eventsproto.EventSubResponse, error) {
+		movedFrom = append(movedFrom, uuid+" "+pluginID)
+		if len(movedFrom) == 1 {
+			return &eventsproto.EventSubResponse{StatusCode: http.StatusInternalServerError, Body: []byte("subscription failed")}, nil
+		}
+		return &eventsproto.EventSubResponse{StatusCode: http.StatusOK}, nil
+	}
+	got := p.UpdateAggregationSource(&aggregatorproto.AggregatorRequest{
+		URL:         "/redfish/v1/AggregationService/AggregationSources/123456",
+		RequestBody: []byte(`{"Links":{"Oem":{"PluginID":"ILO"}}}`),
+	})
+	if got.StatusCode != http.StatusInternalServerError {
+		t.Errorf("ExternalInterface.UpdateAggregationSource() status code = %v, want %v", got.StatusCode, http.StatusInternalServerError)
+	}
+	if target, _ := agmodel.GetTarget("123456"); target == nil || target.PluginID != "GRF" {
+		t.Errorf("plugin of the BMC is changed though its event subscriptions are not moved: %v", target)
+	}
+	aggregationSource, _ = agmodel.GetAggregationSourceInfo("/redfish/v1/AggregationService/AggregationSources/123456")
+	if pluginID := aggregationSource.Links.(map[string]interface{})["Oem"].(map[string]interface{})["PluginID"]; pluginID != "GRF" {
+		t.Errorf("Links.Oem.PluginID of the aggregation source = %v, want GRF", pluginID)
+	}
+	if !reflect.DeepEqual(movedFrom, []string{"123456 GRF", "123456 ILO"}) {
+		t.Errorf("event subscriptions moved = %v, want [123456 GRF 123456 ILO]", movedFrom)
+	}
+}
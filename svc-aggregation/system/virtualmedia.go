@@ -0,0 +1,347 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-aggregation/agmodel"
+)
+
+// defaultMediaType is the type of the virtual media the media actions of an aggregate use when the request has none
+const defaultMediaType = "CD"
+
+// InsertMediaRequest is the payload of the insert media action of an aggregate, the properties other
+// than MediaType are the parameters of the VirtualMedia.InsertMedia action of every system
+type InsertMediaRequest struct {
+	// MediaType selects the virtual media of the systems the media is inserted in, CD when empty
+	MediaType            string `json:"MediaType,omitempty"`
+	Image                string `json:"Image"`
+	Inserted             *bool  `json:"Inserted,omitempty"`
+	WriteProtected       *bool  `json:"WriteProtected,omitempty"`
+	UserName             string `json:"UserName,omitempty"`
+	Password             string `json:"Password,omitempty"`
+	TransferMethod       string `json:"TransferMethod,omitempty"`
+	TransferProtocolType string `json:"TransferProtocolType,omitempty"`
+}
+
+// EjectMediaRequest is the payload of the eject media action of an aggregate
+type EjectMediaRequest struct {
+	// MediaType selects the virtual media of the systems the media is ejected from, CD when empty
+	MediaType string `json:"MediaType,omitempty"`
+}
+
+// virtualMedia is the part of a virtual media used to select the one of a system
+type virtualMedia struct {
+	MediaTypes []string `json:"MediaTypes"`
+	Inserted   bool     `json:"Inserted"`
+}
+
+// ParseInsertMediaRequest parses the request body of the insert media action of an aggregate.
+// It returns the name of the invalid property along with the error, and the message of the error response.
+func ParseInsertMediaRequest(requestBody []byte) (InsertMediaRequest, string, string, error) {
+	var insertRequest InsertMediaRequest
+	if err := json.Unmarshal(requestBody, &insertRequest); err != nil {
+		return insertRequest, "", response.MalformedJSON, err
+	}
+	invalidProperties, err := common.RequestParamsCaseValidator(requestBody, insertRequest)
+	if err != nil {
+		return insertRequest, "", response.InternalError, err
+	}
+	if invalidProperties != "" {
+		return insertRequest, invalidProperties, response.PropertyUnknown, fmt.Errorf("one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase")
+	}
+	if insertRequest.Image == "" {
+		return insertRequest, "Image", response.PropertyMissing, fmt.Errorf("property Image missing in the insert media request")
+	}
+	if insertRequest.MediaType == "" {
+		insertRequest.MediaType = defaultMediaType
+	}
+	return insertRequest, "", "", nil
+}
+
+// ParseEjectMediaRequest parses the request body of the eject media action of an aggregate, an empty body ejects
+// the CD. It returns the name of the invalid property along with the error, and the message of the error response.
+func ParseEjectMediaRequest(requestBody []byte) (EjectMediaRequest, string, string, error) {
+	var ejectRequest EjectMediaRequest
+	if len(strings.TrimSpace(string(requestBody))) != 0 {
+		if err := json.Unmarshal(requestBody, &ejectRequest); err != nil {
+			return ejectRequest, "", response.MalformedJSON, err
+		}
+		invalidProperties, err := common.RequestParamsCaseValidator(requestBody, ejectRequest)
+		if err != nil {
+			return ejectRequest, "", response.InternalError, err
+		}
+		if invalidProperties != "" {
+			return ejectRequest, invalidProperties, response.PropertyUnknown, fmt.Errorf("one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase")
+		}
+	}
+	if ejectRequest.MediaType == "" {
+		ejectRequest.MediaType = defaultMediaType
+	}
+	return ejectRequest, "", "", nil
+}
+
+// InsertMediaElementsOfAggregate inserts the media of the request in a virtual media of the managers
+// of every system of the aggregate, with a sub task for every system
+func (e *ExternalInterface) InsertMediaElementsOfAggregate(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	insertRequest, invalidProperty, statusMessage, err := ParseInsertMediaRequest(req.RequestBody)
+	// the password of the image server is not kept in the tasks
+	taskRequest := string(req.RequestBody)
+	if insertRequest.Password != "" {
+		maskedRequest := insertRequest
+		maskedRequest.Password = "******"
+		maskedBody, _ := json.Marshal(maskedRequest)
+		taskRequest = string(maskedBody)
+	}
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: req.URL, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	if err != nil {
+		return MediaRequestError(invalidProperty, statusMessage, err, taskInfo)
+	}
+	mediaType := insertRequest.MediaType
+	insertRequest.MediaType = ""
+	postBody, _ := json.Marshal(insertRequest)
+	return e.mediaActionElementsOfAggregate(taskID, sessionUserName, taskRequest, req.URL, "VirtualMedia.InsertMedia", mediaType, postBody)
+}
+
+// EjectMediaElementsOfAggregate ejects the media of a virtual media of the managers of every system
+// of the aggregate, with a sub task for every system
+func (e *ExternalInterface) EjectMediaElementsOfAggregate(taskID, sessionUserName string, req *aggregatorproto.AggregatorRequest) response.RPC {
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: req.URL, UpdateTask: e.UpdateTask, TaskRequest: string(req.RequestBody)}
+	ejectRequest, invalidProperty, statusMessage, err := ParseEjectMediaRequest(req.RequestBody)
+	if err != nil {
+		return MediaRequestError(invalidProperty, statusMessage, err, taskInfo)
+	}
+	return e.mediaActionElementsOfAggregate(taskID, sessionUserName, string(req.RequestBody), req.URL, "VirtualMedia.EjectMedia", ejectRequest.MediaType, []byte("{}"))
+}
+
+// MediaRequestError returns the error response for an invalid request of a media action of an aggregate,
+// the task is failed with it when the task details are given
+func MediaRequestError(invalidProperty, statusMessage string, err error, taskInfo *common.TaskUpdateInfo) response.RPC {
+	errMsg := "error while trying to validate request fields: " + err.Error()
+	log.Println(errMsg)
+	switch statusMessage {
+	case response.PropertyUnknown, response.PropertyMissing:
+		return common.GeneralError(http.StatusBadRequest, statusMessage, errMsg, []interface{}{invalidProperty}, taskInfo)
+	case response.InternalError:
+		return common.GeneralError(http.StatusInternalServerError, statusMessage, errMsg, nil, taskInfo)
+	}
+	return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errMsg, nil, taskInfo)
+}
+
+// mediaActionElementsOfAggregate runs the action of a virtual media of every system of the aggregate
+// in the URL, the systems are handled at the same time under their own sub task
+func (e *ExternalInterface) mediaActionElementsOfAggregate(taskID, sessionUserName, taskRequest, targetURI, action, mediaType string, postBody []byte) response.RPC {
+	var resp response.RPC
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+	url := strings.Split(targetURI, "/redfish/v1/AggregationService/Aggregates/")
+	aggregateID := strings.Split(url[len(url)-1], "/")[0]
+	aggregateURL := "/redfish/v1/AggregationService/Aggregates/" + aggregateID
+	aggregate, dbErr := agmodel.GetAggregate(aggregateURL)
+	if dbErr != nil {
+		log.Printf("error getting aggregate : %v", dbErr)
+		if errors.DBKeyNotFound == dbErr.ErrNo() {
+			return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, dbErr.Error(), []interface{}{"Aggregate", targetURI}, taskInfo)
+		}
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, dbErr.Error(), nil, taskInfo)
+	}
+
+	// subTaskChan is buffered for all the systems, so that the sub tasks still running
+	// when the task gets cancelled do not block
+	subTaskChan := make(chan int32, len(aggregate.Elements))
+	for _, element := range aggregate.Elements {
+		go e.mediaActionSystem(taskID, taskRequest, sessionUserName, element, action, mediaType, postBody, subTaskChan)
+	}
+
+	resp.StatusCode = http.StatusOK
+	var partialResultFlag bool
+	for i := range aggregate.Elements {
+		if statusCode := <-subTaskChan; statusCode != http.StatusOK {
+			partialResultFlag = true
+			if resp.StatusCode < statusCode {
+				resp.StatusCode = statusCode
+			}
+		}
+		if i < len(aggregate.Elements)-1 {
+			percentComplete := int32((i + 1) * 100 / len(aggregate.Elements))
+			var task = fillTaskData(taskID, targetURI, taskRequest, resp, common.Running, common.OK, percentComplete, http.MethodPost)
+			err := e.UpdateTask(task)
+			if err != nil && err.Error() == common.Cancelling {
+				task = fillTaskData(taskID, targetURI, taskRequest, resp, common.Cancelled, common.OK, percentComplete, http.MethodPost)
+				e.UpdateTask(task)
+				return resp
+			}
+		}
+	}
+	if partialResultFlag {
+		errMsg := "one or more of the " + action + " actions failed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID
+		log.Printf(errMsg)
+		return common.GeneralError(resp.StatusCode, response.GeneralError, errMsg, nil, taskInfo)
+	}
+	log.Println("all " + action + " actions are successfully completed. for more information please check SubTasks in URI: /redfish/v1/TaskService/Tasks/" + taskID)
+	resp = rediscoverSuccessResponse()
+	var task = fillTaskData(taskID, targetURI, taskRequest, resp, common.Completed, common.OK, 100, http.MethodPost)
+	err := e.UpdateTask(task)
+	if err != nil && err.Error() == common.Cancelling {
+		task = fillTaskData(taskID, targetURI, taskRequest, resp, common.Cancelled, common.Critical, 100, http.MethodPost)
+		e.UpdateTask(task)
+		runtime.Goexit()
+	}
+	return resp
+}
+
+// mediaActionSystem runs the action on the virtual media of the type given of the system under a sub task,
+// the virtual media is read again from the BMC once done and saved in the inventory
+func (e *ExternalInterface) mediaActionSystem(taskID, taskRequest, sessionUserName, element, action, mediaType string, postBody []byte, subTaskChan chan<- int32) {
+	// the status is sent even when the sub task update ends the goroutine
+	statusCode := int32(http.StatusInternalServerError)
+	defer func() {
+		subTaskChan <- statusCode
+	}()
+	subTaskURI, err := e.CreateChildTask(sessionUserName, taskID)
+	if err != nil {
+		log.Println("error while trying to create sub task")
+		return
+	}
+	subTaskID := strings.TrimSuffix(subTaskURI, "/")
+	subTaskID = subTaskID[strings.LastIndex(subTaskID, "/")+1:]
+	taskInfo := &common.TaskUpdateInfo{TaskID: subTaskID, TargetURI: element, UpdateTask: e.UpdateTask, TaskRequest: taskRequest}
+
+	systemID := element[strings.LastIndex(element, "/")+1:]
+	deviceUUID := strings.Split(systemID, ":")[0]
+	target, err := agmodel.GetTarget(deviceUUID)
+	if err != nil {
+		errMsg := "error while trying to get the server of the system " + element + ": " + err.Error()
+		log.Println(errMsg)
+		statusCode = http.StatusNotFound
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"System", element}, taskInfo)
+		return
+	}
+	virtualMediaURI, err := findVirtualMedia(element, deviceUUID, mediaType, action == "VirtualMedia.EjectMedia")
+	if err != nil {
+		errMsg := "error while trying to find the virtual media of the system " + element + ": " + err.Error()
+		log.Println(errMsg)
+		statusCode = http.StatusNotFound
+		common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errMsg, []interface{}{"VirtualMedia", element}, taskInfo)
+		return
+	}
+	req, err := e.getServerPluginRequest(target)
+	if err != nil {
+		errMsg := "error while trying to contact the plugin of the BMC: " + err.Error()
+		log.Println(errMsg)
+		common.GeneralError(http.StatusInternalServerError, response.InternalError, errMsg, nil, taskInfo)
+		return
+	}
+	device := req.DeviceInfo.(agmodel.Target)
+	device.PostBody = postBody
+	req.DeviceInfo = device
+	req.DeviceUUID = deviceUUID
+	req.TaskRequest = taskRequest
+	req.HTTPMethodType = http.MethodPost
+	req.OID = strings.Replace(virtualMediaURI, deviceUUID+":", "", 1) + "/Actions/" + action
+	_, _, getResponse, err := contactPlugin(req, "error while trying to run "+action+" on "+virtualMediaURI+": ")
+	// the BMCs answer the media actions with no content
+	if err != nil && getResponse.StatusCode != http.StatusNoContent && getResponse.StatusCode != http.StatusAccepted {
+		log.Println(err.Error())
+		statusCode = getResponse.StatusCode
+		common.GeneralError(getResponse.StatusCode, getResponse.StatusMessage, err.Error(), getResponse.MsgArgs, taskInfo)
+		return
+	}
+
+	req.HTTPMethodType = http.MethodGet
+	device.PostBody = nil
+	req.DeviceInfo = device
+	req.OID = strings.Replace(virtualMediaURI, deviceUUID+":", "", 1)
+	if body, _, _, err := contactPlugin(req, "error while trying to get "+virtualMediaURI+": "); err != nil {
+		log.Printf("warning: unable to read %v again after %v: %v", virtualMediaURI, action, err)
+	} else if err := agmodel.GenericSave([]byte(updateResourceDataWithUUID(string(body), deviceUUID)), "VirtualMedia", virtualMediaURI); err != nil {
+		log.Printf("warning: unable to save %v after %v: %v", virtualMediaURI, action, err)
+	}
+
+	resp := rediscoverSuccessResponse()
+	resp.Header["Location"] = virtualMediaURI
+	statusCode = resp.StatusCode
+	var task = fillTaskData(subTaskID, element, taskRequest, resp, common.Completed, common.OK, 100, http.MethodPost)
+	e.UpdateTask(task)
+}
+
+// findVirtualMedia returns the URI of the first virtual media of the type given of the managers of the system.
+// The managers are the ones in Links.ManagedBy of the system, all the managers of its server when it has none.
+// The virtual media with a media inserted are preferred for the eject action.
+func findVirtualMedia(systemURI, deviceUUID, mediaType string, inserted bool) (string, error) {
+	var system struct {
+		Links struct {
+			ManagedBy []struct {
+				Oid string `json:"@odata.id"`
+			} `json:"ManagedBy"`
+		} `json:"Links"`
+	}
+	data, dbErr := agmodel.GetResource("ComputerSystem", systemURI)
+	if dbErr != nil {
+		return "", dbErr
+	}
+	if err := json.Unmarshal([]byte(data), &system); err != nil {
+		return "", err
+	}
+	var patterns []string
+	for _, manager := range system.Links.ManagedBy {
+		patterns = append(patterns, strings.TrimSuffix(manager.Oid, "/")+"/VirtualMedia/")
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"/redfish/v1/Managers/" + deviceUUID + ":"}
+	}
+	var candidates []string
+	for _, pattern := range patterns {
+		keys, dbErr := agmodel.GetAllMatchingDetails("VirtualMedia", pattern, common.InMemory)
+		if dbErr != nil {
+			return "", dbErr
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			data, dbErr := agmodel.GetResource("VirtualMedia", key)
+			if dbErr != nil {
+				continue
+			}
+			var media virtualMedia
+			if err := json.Unmarshal([]byte(data), &media); err != nil {
+				continue
+			}
+			for _, supportedType := range media.MediaTypes {
+				if !strings.EqualFold(supportedType, mediaType) {
+					continue
+				}
+				if !inserted || media.Inserted {
+					return key, nil
+				}
+				candidates = append(candidates, key)
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no virtual media of type %v found", mediaType)
+	}
+	return candidates[0], nil
+}
@@ -0,0 +1,166 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package system
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+)
+
+func TestParseInsertMediaRequest(t *testing.T) {
+	tests := []struct {
+		name              string
+		body              []byte
+		wantMediaType     string
+		invalidProperty   string
+		wantStatusMessage string
+	}{
+		{name: "insert the CD", body: []byte(`{"Image":"http://10.0.0.1/os.iso"}`), wantMediaType: "CD"},
+		{name: "insert the USB stick", body: []byte(`{"Image":"http://10.0.0.1/os.img","MediaType":"USBStick"}`), wantMediaType: "USBStick"},
+		{name: "missing image", body: []byte(`{"Inserted":true}`), wantMediaType: "", invalidProperty: "Image", wantStatusMessage: response.PropertyMissing},
+		{name: "unknown property", body: []byte(`{"image":"http://10.0.0.1/os.iso"}`), invalidProperty: "image ", wantStatusMessage: response.PropertyUnknown},
+		{name: "invalid request body", body: []byte(`Image`), wantStatusMessage: response.MalformedJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, invalidProperty, statusMessage, err := ParseInsertMediaRequest(tt.body)
+			if (err != nil) != (tt.wantStatusMessage != "") || statusMessage != tt.wantStatusMessage {
+				t.Errorf("ParseInsertMediaRequest() error = %v, %v, want %v", err, statusMessage, tt.wantStatusMessage)
+				return
+			}
+			if got.MediaType != tt.wantMediaType || invalidProperty != tt.invalidProperty {
+				t.Errorf("ParseInsertMediaRequest() = %v, %v, want %v, %v", got.MediaType, invalidProperty, tt.wantMediaType, tt.invalidProperty)
+			}
+		})
+	}
+}
+
+func TestParseEjectMediaRequest(t *testing.T) {
+	tests := []struct {
+		name              string
+		body              []byte
+		wantMediaType     string
+		wantStatusMessage string
+	}{
+		{name: "without request body", body: nil, wantMediaType: "CD"},
+		{name: "eject the DVD", body: []byte(`{"MediaType":"DVD"}`), wantMediaType: "DVD"},
+		{name: "unknown property", body: []byte(`{"mediatype":"DVD"}`), wantStatusMessage: response.PropertyUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, statusMessage, err := ParseEjectMediaRequest(tt.body)
+			if (err != nil) != (tt.wantStatusMessage != "") || statusMessage != tt.wantStatusMessage {
+				t.Errorf("ParseEjectMediaRequest() error = %v, %v, want %v", err, statusMessage, tt.wantStatusMessage)
+				return
+			}
+			if err == nil && got.MediaType != tt.wantMediaType {
+				t.Errorf("ParseEjectMediaRequest() = %v, want %v", got.MediaType, tt.wantMediaType)
+			}
+		})
+	}
+}
+
+func TestFindVirtualMedia(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.InMemory)
+	}()
+	systemURI := "/redfish/v1/Systems/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"
+	system := []byte(`{"Links":{"ManagedBy":[{"@odata.id":"/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1"}]}}`)
+	mockSystemResourceData(system, "ComputerSystem", systemURI)
+	floppy := []byte(`{"MediaTypes":["Floppy"]}`)
+	mockSystemResourceData(floppy, "VirtualMedia", "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/1")
+	cd := []byte(`{"MediaTypes":["CD","DVD"],"Inserted":false}`)
+	mockSystemResourceData(cd, "VirtualMedia", "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/2")
+	insertedCD := []byte(`{"MediaTypes":["CD"],"Inserted":true}`)
+	mockSystemResourceData(insertedCD, "VirtualMedia", "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/3")
+
+	tests := []struct {
+		name      string
+		mediaType string
+		inserted  bool
+		want      string
+		wantErr   bool
+	}{
+		{name: "CD to insert", mediaType: "CD", want: "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/2"},
+		{name: "CD to eject", mediaType: "CD", inserted: true, want: "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/3"},
+		{name: "DVD to eject", mediaType: "DVD", inserted: true, want: "/redfish/v1/Managers/6d4a0a66-7efa-578e-83cf-44dc68d2874e:1/VirtualMedia/2"},
+		{name: "no USB stick", mediaType: "USBStick", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findVirtualMedia(systemURI, "6d4a0a66-7efa-578e-83cf-44dc68d2874e", tt.mediaType, tt.inserted)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("findVirtualMedia() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExternalInterface_InsertMediaElementsOfAggregate(t *testing.T) {
+	common.MuxLock.Lock()
+	config.SetUpMockConfig(t)
+	common.MuxLock.Unlock()
+	defer func() {
+		common.TruncateDB(common.OnDisk)
+		common.TruncateDB(common.InMemory)
+	}()
+	p := &ExternalInterface{
+		ContactClient:   mockContactClient,
+		Auth:            mockIsAuthorized,
+		CreateChildTask: mockCreateChildTask,
+		UpdateTask:      mockUpdateTask,
+		DecryptPassword: stubDevicePassword,
+		GetPluginStatus: GetPluginStatusForTesting,
+	}
+	tests := []struct {
+		name     string
+		url      string
+		reqBody  []byte
+		wantCode int32
+	}{
+		{
+			name:     "missing image",
+			url:      "/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia/",
+			reqBody:  []byte(`{"MediaType":"CD"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "non existing aggregate",
+			url:      "/redfish/v1/AggregationService/Aggregates/nonExisting/Actions/Aggregate.InsertMedia/",
+			reqBody:  []byte(`{"Image":"http://10.0.0.1/os.iso"}`),
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.InsertMediaElementsOfAggregate("123", "admin", &aggregatorproto.AggregatorRequest{
+				SessionToken: "validToken",
+				URL:          tt.url,
+				RequestBody:  tt.reqBody,
+			})
+			if got.StatusCode != tt.wantCode {
+				t.Errorf("InsertMediaElementsOfAggregate() status code = %v, want %v", got.StatusCode, tt.wantCode)
+			}
+		})
+	}
+}
@@ -16,9 +16,13 @@
 package handle
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
@@ -32,6 +36,7 @@ type AggregatorRPCs struct {
 	ResetRPC                                func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	SetDefaultBootOrderRPC                  func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	AddAggregationSourceRPC                 func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	AddAggregationSourcesRPC                func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAllAggregationSourceRPC              func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAggregationSourceRPC                 func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	UpdateAggregationSourceRPC              func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
@@ -44,6 +49,11 @@ type AggregatorRPCs struct {
 	RemoveElementsFromAggregateRPC          func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	ResetAggregateElementsRPC               func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	SetDefaultBootOrderAggregateElementsRPC func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	RediscoverAggregationSourceRPC          func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	RediscoverAggregateElementsRPC          func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	InsertMediaAggregateElementsRPC         func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	EjectMediaAggregateElementsRPC          func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
+	DrainAggregationSourceRPC               func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetAllConnectionMethodsRPC              func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 	GetConnectionMethodRPC                  func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)
 }
@@ -238,6 +248,126 @@ func (a *AggregatorRPCs) AddAggregationSource(ctx iris.Context) {
 	ctx.Write(resp.Body)
 }
 
+// AddAggregationSources is the handler for adding AggregationSources in bulk.
+// The request body is either the JSON request or, when the Content-Type is text/csv,
+// a list with a header row naming the HostName, UserName, Password and PluginID columns
+func (a *AggregatorRPCs) AddAggregationSources(ctx iris.Context) {
+	var req interface{}
+	var err error
+	if strings.HasPrefix(strings.ToLower(ctx.GetContentTypeRequested()), "text/csv") {
+		var body []byte
+		body, err = ctx.GetBody()
+		if err == nil {
+			req, err = aggregationSourcesFromCSV(body, ctx.URLParamIntDefault("MaxConcurrency", 0))
+		}
+	} else {
+		err = ctx.ReadJSON(&req)
+	}
+	if err != nil {
+		errorMessage := "error while trying to get the aggregation sources from the request body: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusBadRequest) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+
+	if sessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	// marshalling the req to make aggregator add request
+	// Since aggregator add request accepts []byte stream
+	request, err := json.Marshal(req)
+	if err != nil {
+		errorMessage := "error while trying to create JSON request body: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	addRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		RequestBody:  request,
+	}
+	resp, err := a.AddAggregationSourcesRPC(addRequest)
+	if err != nil {
+		errorMessage := "RPC error: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError)
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// aggregationSourcesFromCSV converts the CSV list of aggregation sources into the JSON
+// form of the bulk add request. Columns are matched by the names in the header row,
+// and the PluginID, PreferredAuthType and PluginType columns go into Links.Oem
+func aggregationSourcesFromCSV(data []byte, maxConcurrency int) (map[string]interface{}, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("a header row and at least one aggregation source are required")
+	}
+	oemColumns := map[string]bool{"pluginid": true, "preferredauthtype": true, "plugintype": true}
+	columns := map[string]string{
+		"hostname":          "HostName",
+		"username":          "UserName",
+		"password":          "Password",
+		"pluginid":          "PluginID",
+		"preferredauthtype": "PreferredAuthType",
+		"plugintype":        "PluginType",
+	}
+	header := records[0]
+	for _, column := range header {
+		if _, ok := columns[strings.ToLower(strings.TrimSpace(column))]; !ok {
+			return nil, fmt.Errorf("unknown column %v in the header row", column)
+		}
+	}
+	var aggregationSources []interface{}
+	for _, record := range records[1:] {
+		aggregationSource := map[string]interface{}{}
+		oem := map[string]interface{}{}
+		for i, value := range record {
+			column := strings.ToLower(strings.TrimSpace(header[i]))
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if oemColumns[column] {
+				oem[columns[column]] = value
+			} else {
+				aggregationSource[columns[column]] = value
+			}
+		}
+		if len(oem) > 0 {
+			aggregationSource["Links"] = map[string]interface{}{"Oem": oem}
+		}
+		aggregationSources = append(aggregationSources, aggregationSource)
+	}
+	req := map[string]interface{}{"AggregationSources": aggregationSources}
+	if maxConcurrency > 0 {
+		req["MaxConcurrency"] = maxConcurrency
+	}
+	return req, nil
+}
+
 // GetAllAggregationSource is the handler for getting all  AggregationSource details
 func (a *AggregatorRPCs) GetAllAggregationSource(ctx iris.Context) {
 	req := aggregatorproto.AggregatorRequest{
@@ -682,6 +812,73 @@ func (a *AggregatorRPCs) SetDefaultBootOrderAggregateElements(ctx iris.Context)
 	ctx.Write(resp.Body)
 }
 
+// RediscoverAggregationSource is the handler for retrieving again the inventory of an aggregation source
+func (a *AggregatorRPCs) RediscoverAggregationSource(ctx iris.Context) {
+	a.postAction(ctx, a.RediscoverAggregationSourceRPC)
+}
+
+// RediscoverAggregateElements is the handler for retrieving again the inventory of the elements of an aggregate
+func (a *AggregatorRPCs) RediscoverAggregateElements(ctx iris.Context) {
+	a.postAction(ctx, a.RediscoverAggregateElementsRPC)
+}
+
+// InsertMediaAggregateElements is the handler for inserting a media in the virtual media of the elements of an aggregate
+func (a *AggregatorRPCs) InsertMediaAggregateElements(ctx iris.Context) {
+	a.postAction(ctx, a.InsertMediaAggregateElementsRPC)
+}
+
+// EjectMediaAggregateElements is the handler for ejecting the media of the virtual media of the elements of an aggregate
+func (a *AggregatorRPCs) EjectMediaAggregateElements(ctx iris.Context) {
+	a.postAction(ctx, a.EjectMediaAggregateElementsRPC)
+}
+
+// DrainAggregationSource is the handler for draining the plugin of an aggregation source
+func (a *AggregatorRPCs) DrainAggregationSource(ctx iris.Context) {
+	a.postAction(ctx, a.DrainAggregationSourceRPC)
+}
+
+// postAction passes the request of an action to the RPC, the request body is optional
+func (a *AggregatorRPCs) postAction(ctx iris.Context, actionRPC func(aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error)) {
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+	if sessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+	body, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the action request body: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusBadRequest) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	actionRequest := aggregatorproto.AggregatorRequest{
+		SessionToken: sessionToken,
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  body,
+	}
+
+	resp, err := actionRPC(actionRequest)
+	if err != nil {
+		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
 // GetAllConnectionMethods is the handler for get all connection methods
 func (a *AggregatorRPCs) GetAllConnectionMethods(ctx iris.Context) {
 	req := aggregatorproto.AggregatorRequest{
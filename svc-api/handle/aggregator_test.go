@@ -16,6 +16,7 @@ package handle
 import (
 	"errors"
 	"net/http"
+	"reflect"
 	"testing"
 
 	aggregatorproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/aggregator"
@@ -350,6 +351,58 @@ func TestAddAggregationSource(t *testing.T) {
 	test.POST("/redfish/v1/AggregationService/AggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(addAggregationSourceRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestAddAggregationSources(t *testing.T) {
+	var a AggregatorRPCs
+	a.AddAggregationSourcesRPC = testAddAggregationSourceRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Actions")
+	redfishRoutes.Post("/AggregationService.AddAggregationSources/", a.AddAggregationSources)
+	test := httptest.New(t, testApp)
+	addRequest := map[string]interface{}{
+		"AggregationSources": []interface{}{addAggregationSourceRequest},
+	}
+	csvRequest := "HostName,UserName,Password,PluginID\n9.9.9.0,admin,Password1234,GRF\n9.9.9.1,admin,Password1234,GRF\n"
+	test.POST("/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithJSON(addRequest).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithHeader("Content-Type", "text/csv").WithBytes([]byte(csvRequest)).Expect().Status(http.StatusAccepted)
+	test.POST("/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources").WithHeader("X-Auth-Token", "ValidToken").WithHeader("Content-Type", "text/csv").WithBytes([]byte("Host,UserName\n9.9.9.0,admin\n")).Expect().Status(http.StatusBadRequest)
+	test.POST("/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources").WithHeader("X-Auth-Token", "").WithJSON(addRequest).Expect().Status(http.StatusUnauthorized)
+	test.POST("/redfish/v1/AggregationService/Actions/AggregationService.AddAggregationSources").WithHeader("X-Auth-Token", "token").WithJSON(addRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestAggregationSourcesFromCSV(t *testing.T) {
+	csvRequest := "HostName, UserName,Password,PluginID,PreferredAuthType\n9.9.9.0,admin,Password1234,GRF,\n9.9.9.1:8080,admin,Password1234,ILO,BasicAuth\n"
+	req, err := aggregationSourcesFromCSV([]byte(csvRequest), 4)
+	if err != nil {
+		t.Fatalf("aggregationSourcesFromCSV() error = %v", err)
+	}
+	want := map[string]interface{}{
+		"AggregationSources": []interface{}{
+			map[string]interface{}{
+				"HostName": "9.9.9.0",
+				"UserName": "admin",
+				"Password": "Password1234",
+				"Links":    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "GRF"}},
+			},
+			map[string]interface{}{
+				"HostName": "9.9.9.1:8080",
+				"UserName": "admin",
+				"Password": "Password1234",
+				"Links":    map[string]interface{}{"Oem": map[string]interface{}{"PluginID": "ILO", "PreferredAuthType": "BasicAuth"}},
+			},
+		},
+		"MaxConcurrency": 4,
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("aggregationSourcesFromCSV() = %v, want %v", req, want)
+	}
+	if _, err := aggregationSourcesFromCSV([]byte("HostName,UserName,Password,PluginID\n"), 0); err == nil {
+		t.Errorf("aggregationSourcesFromCSV() expected an error for a list without aggregation sources")
+	}
+	if _, err := aggregationSourcesFromCSV([]byte("HostName,Port\n9.9.9.0,443\n"), 0); err == nil {
+		t.Errorf("aggregationSourcesFromCSV() expected an error for an unknown column")
+	}
+}
+
 func TestGetAllAggregationSource(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllAggregationSourceRPC = testGetAllAggregationSourceRPC
@@ -673,6 +726,148 @@ func TestSetDefaultBootOrderAggregateElements(t *testing.T) {
 	).WithHeader("X-Auth-Token", "token").WithJSON(aggregateRequest).Expect().Status(http.StatusInternalServerError)
 }
 
+func TestRediscoverAggregationSource(t *testing.T) {
+	var a AggregatorRPCs
+	a.RediscoverAggregationSourceRPC = testGetAggregateRPCCall
+	var rediscoverRequest = map[string]interface{}{
+		"Scope": "Storage",
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/AggregationSources/{id}/Actions/AggregationSource.Rediscover")
+	redfishRoutes.Post("/", a.RediscoverAggregationSource)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Rediscover",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(rediscoverRequest).Expect().Status(http.StatusOK)
+
+	// test without request body, the whole inventory is rediscovered
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Rediscover",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Rediscover",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(rediscoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Rediscover",
+	).WithHeader("X-Auth-Token", "").WithJSON(rediscoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Rediscover",
+	).WithHeader("X-Auth-Token", "token").WithJSON(rediscoverRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestRediscoverAggregateElements(t *testing.T) {
+	var a AggregatorRPCs
+	a.RediscoverAggregateElementsRPC = testGetAggregateRPCCall
+	var rediscoverRequest = map[string]interface{}{
+		"Scope": "Full",
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Actions/Aggregate.Rediscover")
+	redfishRoutes.Post("/", a.RediscoverAggregateElements)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(rediscoverRequest).Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(rediscoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover",
+	).WithHeader("X-Auth-Token", "").WithJSON(rediscoverRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.Rediscover",
+	).WithHeader("X-Auth-Token", "token").WithJSON(rediscoverRequest).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestInsertMediaAggregateElements(t *testing.T) {
+	var a AggregatorRPCs
+	a.InsertMediaAggregateElementsRPC = testGetAggregateRPCCall
+	var insertMediaRequest = map[string]interface{}{
+		"Image": "http://10.0.0.1/os.iso",
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Actions/Aggregate.InsertMedia")
+	redfishRoutes.Post("/", a.InsertMediaAggregateElements)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(insertMediaRequest).Expect().Status(http.StatusOK)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.InsertMedia",
+	).WithHeader("X-Auth-Token", "").WithJSON(insertMediaRequest).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestEjectMediaAggregateElements(t *testing.T) {
+	var a AggregatorRPCs
+	a.EjectMediaAggregateElementsRPC = testGetAggregateRPCCall
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/Aggregates/{id}/Actions/Aggregate.EjectMedia")
+	redfishRoutes.Post("/", a.EjectMediaAggregateElements)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.EjectMedia",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/Aggregates/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/Aggregate.EjectMedia",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
+
+func TestDrainAggregationSource(t *testing.T) {
+	var a AggregatorRPCs
+	a.DrainAggregationSourceRPC = testGetAggregateRPCCall
+	var drainRequest = map[string]interface{}{
+		"TargetPluginID": "GRF2",
+	}
+	testApp := iris.New()
+	redfishRoutes := testApp.Party("/redfish/v1/AggregationService/AggregationSources/{id}/Actions/AggregationSource.Drain")
+	redfishRoutes.Post("/", a.DrainAggregationSource)
+	test := httptest.New(t, testApp)
+	// test with valid token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Drain",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(drainRequest).Expect().Status(http.StatusOK)
+
+	// test without request body, the plugin is drained without moving its BMCs
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Drain",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusOK)
+
+	// test with Invalid token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Drain",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(drainRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test without token
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Drain",
+	).WithHeader("X-Auth-Token", "").WithJSON(drainRequest).Expect().Status(http.StatusUnauthorized)
+
+	// test for RPC Error
+	test.POST(
+		"/redfish/v1/AggregationService/AggregationSources/7ff3bd97-c41c-5de0-937d-85d390691b73/Actions/AggregationSource.Drain",
+	).WithHeader("X-Auth-Token", "token").WithJSON(drainRequest).Expect().Status(http.StatusInternalServerError)
+}
+
 func TestGetAllConnectionMethods(t *testing.T) {
 	var a AggregatorRPCs
 	a.GetAllConnectionMethodsRPC = testGetAggregateRPCCall
@@ -220,7 +220,7 @@ func GetMetadata(ctx iris.Context) {
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/AccountService_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "AccountService"},
-					models.Include{Namespace: "AccountService.v1_6_0"},
+					models.Include{Namespace: "AccountService.v1_7_0"},
 				},
 			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/ManagerAccountCollection_v1.xml",
@@ -231,7 +231,7 @@ func GetMetadata(ctx iris.Context) {
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/ManagerAccount_v1.xml",
 				TopInclude: []models.Include{
 					models.Include{Namespace: "ManagerAccount"},
-					models.Include{Namespace: "ManagerAccount.v1_4_0"},
+					models.Include{Namespace: "ManagerAccount.v1_6_0"},
 				},
 			},
 			models.Reference{URI: "http://redfish.dmtf.org/schemas/v1/SessionService_v1.xml",
@@ -998,6 +998,12 @@ func AggregateMethodNotAllowed(ctx iris.Context) {
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.SetDefaultBootOrder/":
 		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.Rediscover/":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.InsertMedia/":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
+	case "/redfish/v1/AggregationService/Aggregates/" + aggregateID + "Actions/Aggregate.EjectMedia/":
+		ctx.ResponseWriter().Header().Set("Allow", "POST")
 	}
 	fillMethodNotAllowedErrorResponse(ctx)
 	return
@@ -16,6 +16,8 @@
 package handle
 
 import (
+	"bytes"
+	"encoding/json"
 	"log"
 	"net/http"
 
@@ -27,9 +29,13 @@ import (
 
 // ManagersRPCs defines all the RPC methods in account service
 type ManagersRPCs struct {
-	GetManagersCollectionRPC func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
-	GetManagersRPC           func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
-	GetManagersResourceRPC   func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	GetManagersCollectionRPC  func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	GetManagersRPC            func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	GetManagersResourceRPC    func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	ResetManagerRPC           func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	UpdateManagersResourceRPC func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	VirtualMediaInsertRPC     func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
+	VirtualMediaEjectRPC      func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)
 }
 
 //GetManagersCollection fetches all managers
@@ -123,3 +129,85 @@ func (mgr *ManagersRPCs) GetManagersResource(ctx iris.Context) {
 	ctx.StatusCode(int(resp.StatusCode))
 	ctx.Write(resp.Body)
 }
+
+// ResetManager defines the ResetManager iris handler.
+// The method extracts the session token, the manager ID and the request body and creates the RPC request.
+// The reset is done as a task, the response is the task monitor of the task.
+func (mgr *ManagersRPCs) ResetManager(ctx iris.Context) {
+	callManagerTaskRPC(ctx, mgr.ResetManagerRPC)
+}
+
+// UpdateManagersResource defines the UpdateManagersResource iris handler.
+// The method extracts the session token, the manager ID, the request url and the request body and creates
+// the RPC request. The update is done as a task, the response is the task monitor of the task.
+func (mgr *ManagersRPCs) UpdateManagersResource(ctx iris.Context) {
+	callManagerTaskRPC(ctx, mgr.UpdateManagersResourceRPC)
+}
+
+// VirtualMediaInsert defines the VirtualMediaInsert iris handler.
+// The method extracts the session token, the manager ID, the virtual media ID and the request body and creates
+// the RPC request. The media is inserted as a task, the response is the task monitor of the task.
+func (mgr *ManagersRPCs) VirtualMediaInsert(ctx iris.Context) {
+	callManagerTaskRPC(ctx, mgr.VirtualMediaInsertRPC)
+}
+
+// VirtualMediaEject defines the VirtualMediaEject iris handler.
+// The method extracts the session token, the manager ID and the virtual media ID and creates the RPC request.
+// The media is ejected as a task, the response is the task monitor of the task.
+func (mgr *ManagersRPCs) VirtualMediaEject(ctx iris.Context) {
+	callManagerTaskRPC(ctx, mgr.VirtualMediaEjectRPC)
+}
+
+// callManagerTaskRPC calls the RPC changing a manager with the request and feeds its response to the iris
+func callManagerTaskRPC(ctx iris.Context, managerRPC func(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error)) {
+	request, err := ctx.GetBody()
+	if err != nil {
+		errorMessage := "error while trying to read the manager request body: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusBadRequest) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+	// the actions without parameters are posted without a request body
+	if len(bytes.TrimSpace(request)) == 0 {
+		request = []byte("{}")
+	}
+	var reqBody interface{}
+	if err := json.Unmarshal(request, &reqBody); err != nil {
+		errorMessage := "error while trying to get JSON body from the manager request body: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusBadRequest) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+	req := managersproto.ManagerRequest{
+		SessionToken: ctx.Request().Header.Get("X-Auth-Token"),
+		ManagerID:    ctx.Params().Get("id"),
+		ResourceID:   ctx.Params().Get("rid"),
+		URL:          ctx.Request().RequestURI,
+		RequestBody:  request,
+	}
+	if req.SessionToken == "" {
+		errorMessage := "error: no X-Auth-Token found in request header"
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+	resp, err := managerRPC(req)
+	if err != nil {
+		errorMessage := "error:  RPC error:" + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
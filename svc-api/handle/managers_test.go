@@ -216,3 +216,92 @@ func TestGetManagerResource_RPCError(t *testing.T) {
 		"/redfish/v1/Managers/3A/NetworkInterfaces/1B",
 	).WithHeader("X-Auth-Token", "InvalidToken").Expect().Status(http.StatusInternalServerError)
 }
+
+func mockManagerTaskRequest(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	if req.SessionToken == "token" {
+		return &managersproto.ManagerResponse{}, fmt.Errorf("RPC Error")
+	}
+	if req.SessionToken != "ValidToken" {
+		return &managersproto.ManagerResponse{
+			StatusCode:    401,
+			StatusMessage: "Unauthorized",
+			Body:          []byte(`{"Response":"Unauthorized"}`),
+		}, nil
+	}
+	return &managersproto.ManagerResponse{
+		StatusCode:    202,
+		StatusMessage: "TaskStarted",
+		Header:        map[string]string{"Location": "/taskmon/task12345"},
+		Body:          []byte(`{"Response":"TaskStarted"}`),
+	}, nil
+}
+
+func TestResetManager(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.ResetManagerRPC = mockManagerTaskRequest
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Post("/{id}/Actions/Manager.Reset", mgr.ResetManager)
+	test := httptest.New(t, mockApp)
+	body := map[string]string{"ResetType": "GracefulRestart"}
+	test.POST(
+		"/redfish/v1/Managers/1A/Actions/Manager.Reset",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusAccepted).Header("Location").Equal("/taskmon/task12345")
+	test.POST(
+		"/redfish/v1/Managers/1A/Actions/Manager.Reset",
+	).WithHeader("X-Auth-Token", "").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+	test.POST(
+		"/redfish/v1/Managers/1A/Actions/Manager.Reset",
+	).WithHeader("X-Auth-Token", "ValidToken").WithText("{").Expect().Status(http.StatusBadRequest)
+	test.POST(
+		"/redfish/v1/Managers/1A/Actions/Manager.Reset",
+	).WithHeader("X-Auth-Token", "token").WithJSON(body).Expect().Status(http.StatusInternalServerError)
+}
+
+func TestUpdateManagersResource(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.UpdateManagersResourceRPC = mockManagerTaskRequest
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Patch("/{id}/NetworkProtocol", mgr.UpdateManagersResource)
+	test := httptest.New(t, mockApp)
+	body := map[string]interface{}{"NTP": map[string]bool{"ProtocolEnabled": true}}
+	test.PATCH(
+		"/redfish/v1/Managers/1A/NetworkProtocol",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusAccepted)
+	test.PATCH(
+		"/redfish/v1/Managers/1A/NetworkProtocol",
+	).WithHeader("X-Auth-Token", "InvalidToken").WithJSON(body).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestVirtualMediaInsert(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.VirtualMediaInsertRPC = mockManagerTaskRequest
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.InsertMedia", mgr.VirtualMediaInsert)
+	test := httptest.New(t, mockApp)
+	body := map[string]interface{}{"Image": "http://10.0.0.1/os.iso", "Inserted": true}
+	test.POST(
+		"/redfish/v1/Managers/1A/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia",
+	).WithHeader("X-Auth-Token", "ValidToken").WithJSON(body).Expect().Status(http.StatusAccepted).Header("Location").Equal("/taskmon/task12345")
+	test.POST(
+		"/redfish/v1/Managers/1A/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia",
+	).WithHeader("X-Auth-Token", "ValidToken").WithText("{").Expect().Status(http.StatusBadRequest)
+}
+
+func TestVirtualMediaEject(t *testing.T) {
+	var mgr ManagersRPCs
+	mgr.VirtualMediaEjectRPC = mockManagerTaskRequest
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1/Managers")
+	redfishRoutes.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.EjectMedia", mgr.VirtualMediaEject)
+	test := httptest.New(t, mockApp)
+	// the eject action is posted without a request body
+	test.POST(
+		"/redfish/v1/Managers/1A/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia",
+	).WithHeader("X-Auth-Token", "ValidToken").Expect().Status(http.StatusAccepted)
+	test.POST(
+		"/redfish/v1/Managers/1A/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia",
+	).WithHeader("X-Auth-Token", "").Expect().Status(http.StatusUnauthorized)
+}
@@ -31,8 +31,9 @@ type SessionRPCs struct {
 	CreateSessionRPC        func(sessionproto.SessionCreateRequest) (*sessionproto.SessionCreateResponse, error)
 	DeleteSessionRPC        func(string, string) (*sessionproto.SessionResponse, error)
 	GetSessionRPC           func(string, string) (*sessionproto.SessionResponse, error)
-	GetAllActiveSessionsRPC func(string, string) (*sessionproto.SessionResponse, error)
+	GetAllActiveSessionsRPC func(string, string, string) (*sessionproto.SessionResponse, error)
 	GetSessionServiceRPC    func() (*sessionproto.SessionResponse, error)
+	DeleteUserSessionsRPC   func(string, string) (*sessionproto.SessionResponse, error)
 }
 
 // CreateSession defines the Create session iris handler
@@ -56,6 +57,8 @@ func (s *SessionRPCs) CreateSession(ctx iris.Context) {
 	request, err := json.Marshal(req)
 	createRequest := sessionproto.SessionCreateRequest{
 		RequestBody: request,
+		ClientIP:    ctx.RemoteAddr(),
+		UserAgent:   ctx.GetHeader("User-Agent"),
 	}
 
 	resp, err := s.CreateSessionRPC(createRequest)
@@ -147,7 +150,38 @@ func (s *SessionRPCs) GetAllActiveSessions(ctx iris.Context) {
 	sessionID := ctx.Params().Get("sessionID")
 	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
 
-	resp, err := s.GetAllActiveSessionsRPC(sessionID, sessionToken)
+	resp, err := s.GetAllActiveSessionsRPC(sessionID, sessionToken, ctx.URLParam("$filter"))
+	if err != nil && resp == nil {
+		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusInternalServerError) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+	log.Println("RPC response: ", resp.StatusCode)
+	common.SetResponseHeader(ctx, resp.Header)
+	ctx.StatusCode(int(resp.StatusCode))
+	ctx.Write(resp.Body)
+}
+
+// DeleteUserSessions defines the iris handler for terminating all the sessions of an account
+// This method extracts the account id and sessiontoken
+// create a rpc request and send a request to session micro service
+// and feed the response to iris
+func (s *SessionRPCs) DeleteUserSessions(ctx iris.Context) {
+	accountID := ctx.Params().Get("id")
+	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
+	if sessionToken == "" {
+		errorMessage := "error: session token is missing"
+		log.Println(errorMessage)
+		response := common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil)
+		ctx.StatusCode(http.StatusUnauthorized) // TODO: add error headers
+		ctx.JSON(&response.Body)
+		return
+	}
+
+	resp, err := s.DeleteUserSessionsRPC(accountID, sessionToken)
 	if err != nil && resp == nil {
 		errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
 		log.Println(errorMessage)
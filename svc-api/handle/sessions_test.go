@@ -51,12 +51,27 @@ func mockGetSessionRPC(sessionID, sessionToken string) (*sessionproto.SessionRes
 	}, nil
 }
 
-func mockGetAllActiveSessionsRPC(sessionID, sessionToken string) (*sessionproto.SessionResponse, error) {
+func mockGetAllActiveSessionsRPC(sessionID, sessionToken, filter string) (*sessionproto.SessionResponse, error) {
+	if filter != "" && filter != "UserName eq 'admin'" {
+		return &sessionproto.SessionResponse{
+			StatusCode: http.StatusBadRequest,
+		}, nil
+	}
 	return &sessionproto.SessionResponse{
 		StatusCode: http.StatusOK,
 	}, nil
 }
 
+func mockGetAllActiveSessionsRPCError(sessionID, sessionToken, filter string) (*sessionproto.SessionResponse, error) {
+	return nil, errors.New("RPC Error")
+}
+
+func mockDeleteUserSessionsRPC(userName, sessionToken string) (*sessionproto.SessionResponse, error) {
+	return &sessionproto.SessionResponse{
+		StatusCode: http.StatusNoContent,
+	}, nil
+}
+
 func mockGetSessionServiceRPC() (*sessionproto.SessionResponse, error) {
 	return &sessionproto.SessionResponse{
 		StatusCode: http.StatusOK,
@@ -159,11 +174,17 @@ func TestSessionRPCs_GetAllAciveSessions(t *testing.T) {
 	e.GET(
 		"/redfish/v1/SessionService/Sessions",
 	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
+	e.GET(
+		"/redfish/v1/SessionService/Sessions",
+	).WithQuery("$filter", "UserName eq 'admin'").WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusOK)
+	e.GET(
+		"/redfish/v1/SessionService/Sessions",
+	).WithQuery("$filter", "RoleId eq 'admin'").WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusBadRequest)
 }
 
 func TestSessionRPCs_GetAllAciveSessionsRPCError(t *testing.T) {
 	var s SessionRPCs
-	s.GetAllActiveSessionsRPC = mockSessionRPCError
+	s.GetAllActiveSessionsRPC = mockGetAllActiveSessionsRPCError
 
 	mockApp := iris.New()
 	redfishRoutes := mockApp.Party("/redfish/v1")
@@ -199,3 +220,32 @@ func TestSessionRPCs_GetSessionServiceRPCError(t *testing.T) {
 		"/redfish/v1/SessionService",
 	).Expect().Status(http.StatusInternalServerError)
 }
+
+func TestSessionRPCs_DeleteUserSessions(t *testing.T) {
+	var s SessionRPCs
+	s.DeleteUserSessionsRPC = mockDeleteUserSessionsRPC
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Accounts/{id}/Actions/ManagerAccount.TerminateSessions", s.DeleteUserSessions)
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/Actions/ManagerAccount.TerminateSessions",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusNoContent)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/Actions/ManagerAccount.TerminateSessions",
+	).Expect().Status(http.StatusUnauthorized)
+}
+
+func TestSessionRPCs_DeleteUserSessionsRPCError(t *testing.T) {
+	var s SessionRPCs
+	s.DeleteUserSessionsRPC = mockSessionRPCError
+
+	mockApp := iris.New()
+	redfishRoutes := mockApp.Party("/redfish/v1")
+	redfishRoutes.Post("/AccountService/Accounts/{id}/Actions/ManagerAccount.TerminateSessions", s.DeleteUserSessions)
+	e := httptest.New(t, mockApp)
+	e.POST(
+		"/redfish/v1/AccountService/Accounts/admin/Actions/ManagerAccount.TerminateSessions",
+	).WithHeader("X-Auth-Token", "token").Expect().Status(http.StatusInternalServerError)
+}
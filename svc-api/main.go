@@ -14,6 +14,7 @@
 package main
 
 import (
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"log"
@@ -23,11 +24,10 @@ import (
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
-	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
+	"github.com/ODIM-Project/ODIM/svc-api/middleware"
 	"github.com/ODIM-Project/ODIM/svc-api/router"
-	"github.com/ODIM-Project/ODIM/svc-api/rpc"
 	iris "github.com/kataras/iris/v12"
 )
 
@@ -46,8 +46,9 @@ func main() {
 			r.RequestURI = path
 			r.URL.Path = path
 		}
+		// authToken is the token of the session serving the request authenticated by the gateway
+		var authToken string
 		basicAuth := r.Header.Get("Authorization")
-		var basicAuthToken string
 		if basicAuth != "" {
 			var urlNoBasicAuth = []string{"/redfish/v1", "/redfish/v1/SessionService"}
 			var authRequired bool
@@ -81,17 +82,7 @@ func main() {
 					return
 				}
 
-				//Converting the request into a map
-				sessionReq := map[string]interface{}{
-					"UserName": username,
-					"Password": password,
-				}
-				//Marshalling input to get bytes since session create request accepts bytes
-				sessionReqData, err := json.Marshal(sessionReq)
-
-				var req sessionproto.SessionCreateRequest
-				req.RequestBody = sessionReqData
-				resp, err := rpc.DoSessionCreationRequest(req)
+				resp, err := middleware.GetBasicAuthToken(username, password)
 				if err != nil && resp == nil {
 					errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
 					log.Println(errorMessage)
@@ -101,32 +92,53 @@ func main() {
 					w.Write([]byte(body))
 					return
 				}
-				if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+				if resp.StatusCode != http.StatusOK {
 					w.Header().Set("Content-type", "application/json; charset=utf-8")
 					w.WriteHeader(int(resp.StatusCode))
-					if resp.StatusCode == http.StatusServiceUnavailable {
+					var msgArgs []interface{}
+					if resp.StatusMessage == response.CouldNotEstablishConnection {
 						log.Println("error: unable to establish connection with db")
-						w.Write(resp.Body)
-						return
+						msgArgs = []interface{}{config.Data.DBConf.OnDiskHost + ":" + config.Data.DBConf.OnDiskPort}
 					}
-					errorMessage := "error: failed to create a sesssion"
+					errorMessage := "error: failed to authenticate the basic auth credentials"
 					log.Println(errorMessage)
-					body, _ := json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, nil, nil).Body)
+					body, _ := json.Marshal(common.GeneralError(resp.StatusCode, resp.StatusMessage, errorMessage, msgArgs, nil).Body)
 					w.Write([]byte(body))
 					return
 				}
-				var sessionHeader map[string]string
-				var sessionID string
-				sessionHeader = resp.Header
-				basicAuthToken = sessionHeader["X-Auth-Token"]
-				sessionLocation := sessionHeader["Link"]
-				sessionLocationSlice := strings.Split(sessionLocation, "/")
-				if len(sessionLocationSlice) > 1 {
-					sessionID = sessionLocationSlice[len(sessionLocationSlice)-2]
-				}
-				r.Header.Set("X-Auth-Token", basicAuthToken)
-				r.Header.Set("Session-ID", sessionID)
+				r.Header.Set("X-Auth-Token", resp.SessionToken)
+				authToken = resp.SessionToken
+			}
+		} else if r.Header.Get("X-Auth-Token") == "" && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+			// client presented a certificate verified in the TLS handshake, the request is served with
+			// the session of the account mapped to the certificate, if there is no such account
+			// the request continues as an unauthenticated one
+			resp, err := middleware.GetClientCertificateToken(r.TLS.VerifiedChains[0])
+			if err != nil && resp == nil {
+				errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
+				log.Println(errorMessage)
+				w.Header().Set("Content-type", "application/json; charset=utf-8")
+				w.WriteHeader(http.StatusInternalServerError)
+				body, _ := json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+				w.Write([]byte(body))
+				return
 			}
+			if resp.StatusCode == http.StatusOK {
+				r.Header.Set("X-Auth-Token", resp.SessionToken)
+				authToken = resp.SessionToken
+			} else {
+				log.Printf("warning: client certificate of %v is not mapped to a session: %v", r.RemoteAddr, resp.StatusMessage)
+			}
+		}
+		if authToken != "" {
+			// the session of the credentials may have timed out or have been deleted since they were cached,
+			// they are authenticated again on their next request
+			recorder := &middleware.StatusRecorder{ResponseWriter: w}
+			next(recorder, r)
+			if recorder.StatusCode == http.StatusUnauthorized {
+				middleware.EvictAuthToken(authToken)
+			}
+			return
 		}
 		// r.URL.Path = strings.ToLower(path)
 		next(w, r)
@@ -149,6 +161,15 @@ func main() {
 		ServerAddress: config.Data.APIGatewayConf.Host,
 		ServerPort:    config.Data.APIGatewayConf.Port,
 	}
+	if clientCertificate := config.Data.AuthConf.ClientCertificate; clientCertificate.Enabled {
+		// the client certificates are verified against the client CA, the clients without a certificate
+		// are served only when the unauthenticated clients are responded to
+		conf.ClientAuth = tls.VerifyClientCertIfGiven
+		if !*clientCertificate.RespondToUnauthenticatedClients {
+			conf.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		conf.ClientCACertificate = &clientCertificate.CACertificate
+	}
 	apiServer, err := conf.GetHTTPServerObj()
 	if err != nil {
 		log.Fatalf("fatal: error while initializing server: %v", err)
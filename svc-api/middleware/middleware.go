@@ -16,23 +16,157 @@
 package middleware
 
 import (
-	"github.com/ODIM-Project/ODIM/svc-api/rpc"
-	iris "github.com/kataras/iris/v12"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-api/rpc"
 )
 
-//SessionDelMiddleware is used to delete session created for basic auth
-func SessionDelMiddleware(ctx iris.Context) {
-	ctx.Next()
-
-	sessionID := ctx.Request().Header.Get("Session-ID")
-	sessionToken := ctx.Request().Header.Get("X-Auth-Token")
-	if sessionID != "" {
-		resp, err := rpc.DeleteSessionRequest(sessionID, sessionToken)
-		if err != nil && resp == nil {
-			errorMessage := "error: something went wrong with the RPC calls: " + err.Error()
-			log.Println(errorMessage)
-			return
+// authCacheEntry is a verified credential with the token of the session serving it, and the version
+// of the cache it was verified in
+type authCacheEntry struct {
+	sessionToken string
+	version      string
+	expiry       time.Time
+}
+
+// authCache holds the verified basic auth credentials and client certificates keyed by their hash,
+// so the plain text credentials are never retained in memory. The entries are only used while the
+// version of the cache shared by all the instances of the gateway is the one they were verified in,
+// svc-account-session changes it when an account, a role or a session changes.
+var authCache = struct {
+	sync.RWMutex
+	entries map[string]authCacheEntry
+}{entries: make(map[string]authCacheEntry)}
+
+//GetBasicAuthToken is used to get the session token for the basic auth credentials.
+//Verified credentials are cached for BasicAuthCacheTimeOutInSecs, so the repeated
+//requests with the same credentials doesn't reach svc-account-session.
+func GetBasicAuthToken(userName, password string) (*authproto.BasicAuthResponse, error) {
+	key := hashCredentials(userName, password)
+	token, version, exist := getCachedToken(key)
+	if exist {
+		return &authproto.BasicAuthResponse{
+			StatusCode:    http.StatusOK,
+			StatusMessage: response.Success,
+			SessionToken:  token,
+		}, nil
+	}
+
+	resp, err := rpc.DoBasicAuthRequest(userName, password)
+	if err != nil {
+		return resp, err
+	}
+	cacheToken(key, version, resp.StatusCode, resp.SessionToken)
+	return resp, nil
+}
+
+//GetClientCertificateToken is used to get the session token for the verified client certificate chain
+//presented in the TLS handshake. Like basic auth credentials, the certificates are cached for
+//BasicAuthCacheTimeOutInSecs.
+func GetClientCertificateToken(chain []*x509.Certificate) (*authproto.ClientCertificateResponse, error) {
+	hash := sha256.Sum256(chain[0].Raw)
+	key := "certificate:" + hex.EncodeToString(hash[:])
+	token, version, exist := getCachedToken(key)
+	if exist {
+		return &authproto.ClientCertificateResponse{
+			StatusCode:    http.StatusOK,
+			StatusMessage: response.Success,
+			SessionToken:  token,
+		}, nil
+	}
+
+	var intermediates [][]byte
+	for _, cert := range chain[1:] {
+		intermediates = append(intermediates, cert.Raw)
+	}
+	resp, err := rpc.DoClientCertificateAuthRequest(chain[0].Raw, intermediates)
+	if err != nil {
+		return resp, err
+	}
+	cacheToken(key, version, resp.StatusCode, resp.SessionToken)
+	return resp, nil
+}
+
+// unknownAuthCacheVersion stands for the version of the cache when it can not be read, no credential is cached with it
+const unknownAuthCacheVersion = "unknown"
+
+// getCachedToken returns the cached session token of the credential, with the current version of the cache
+// the token of the credential is to be cached with once it is authenticated again
+func getCachedToken(key string) (string, string, bool) {
+	version, err := common.AuthCacheVersion()
+	if err != nil {
+		// the cached tokens may be stale, the credential is authenticated by svc-account-session
+		// and is not cached until the version can be read again
+		log.Println("warning: unable to read the version of the auth cache: " + err.Error())
+		return "", unknownAuthCacheVersion, false
+	}
+	authCache.RLock()
+	defer authCache.RUnlock()
+	entry, exist := authCache.entries[key]
+	if !exist || entry.version != version || time.Now().After(entry.expiry) {
+		return "", version, false
+	}
+	return entry.sessionToken, version, true
+}
+
+// cacheToken will cache the session token of a successful authentication with the version of the cache
+// read before the authentication, and evict the expired entries and the ones of the previous versions
+func cacheToken(key, version string, statusCode int32, sessionToken string) {
+	authCache.Lock()
+	defer authCache.Unlock()
+	now := time.Now()
+	for k, e := range authCache.entries {
+		if now.After(e.expiry) || e.version != version {
+			delete(authCache.entries, k)
 		}
 	}
+	if statusCode == http.StatusOK && version != unknownAuthCacheVersion {
+		authCache.entries[key] = authCacheEntry{
+			sessionToken: sessionToken,
+			version:      version,
+			expiry:       now.Add(time.Duration(config.Data.AuthConf.BasicAuthCacheTimeOutInSecs) * time.Second),
+		}
+	} else {
+		delete(authCache.entries, key)
+	}
+}
+
+// EvictAuthToken drops the cached credentials served by the session, it is called when svc-account-session
+// answers a request served with the session with 401, the session having timed out or having been deleted
+func EvictAuthToken(sessionToken string) {
+	authCache.Lock()
+	defer authCache.Unlock()
+	for k, e := range authCache.entries {
+		if e.sessionToken == sessionToken {
+			delete(authCache.entries, k)
+		}
+	}
+}
+
+// StatusRecorder records the status code of the response, so that the credentials of
+// the requests answered with 401 are evicted from the cache
+type StatusRecorder struct {
+	http.ResponseWriter
+	StatusCode int
+}
+
+// WriteHeader records the status code and writes it to the response
+func (r *StatusRecorder) WriteHeader(statusCode int) {
+	r.StatusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func hashCredentials(userName, password string) string {
+	hash := sha256.Sum256([]byte(userName + ":" + password))
+	return hex.EncodeToString(hash[:])
 }
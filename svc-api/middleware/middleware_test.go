@@ -0,0 +1,67 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+//Package middleware ...
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+)
+
+func TestAuthCache(t *testing.T) {
+	if err := common.SetUpMockConfig(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	defer common.TruncateDB(common.InMemory)
+	key := hashCredentials("admin", "Od!m12$4")
+	_, version, _ := getCachedToken(key)
+	cacheToken(key, version, http.StatusOK, "token")
+	if token, _, exist := getCachedToken(key); !exist || token != "token" {
+		t.Fatalf("getCachedToken() = %v, %v, want the cached token", token, exist)
+	}
+	if _, _, exist := getCachedToken(hashCredentials("admin", "password")); exist {
+		t.Errorf("getCachedToken() of other credentials found a token")
+	}
+
+	// a failed authentication drops the cached token
+	cacheToken(key, version, http.StatusUnauthorized, "")
+	if _, _, exist := getCachedToken(key); exist {
+		t.Errorf("getCachedToken() after a failed authentication found a token")
+	}
+
+	// the cache of every instance is dropped when an account, a role or a session changes
+	cacheToken(key, version, http.StatusOK, "token")
+	if err := common.InvalidateAuthCache(); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if _, _, exist := getCachedToken(key); exist {
+		t.Errorf("getCachedToken() after InvalidateAuthCache() found a token")
+	}
+	// a token authenticated before the invalidation is not used
+	cacheToken(key, version, http.StatusOK, "token")
+	if _, _, exist := getCachedToken(key); exist {
+		t.Errorf("getCachedToken() of a token authenticated before InvalidateAuthCache() found a token")
+	}
+
+	// the credentials served by a session which is not valid anymore are evicted
+	_, version, _ = getCachedToken(key)
+	cacheToken(key, version, http.StatusOK, "token")
+	EvictAuthToken("token")
+	if _, _, exist := getCachedToken(key); exist {
+		t.Errorf("getCachedToken() after EvictAuthToken() found a token")
+	}
+}
@@ -18,7 +18,6 @@ package router
 import (
 	srv "github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-api/handle"
-	"github.com/ODIM-Project/ODIM/svc-api/middleware"
 	"github.com/ODIM-Project/ODIM/svc-api/rpc"
 	iris "github.com/kataras/iris/v12"
 )
@@ -45,6 +44,7 @@ func Router() *iris.Application {
 		ResetRPC:                                rpc.DoResetRequest,
 		SetDefaultBootOrderRPC:                  rpc.DoSetDefaultBootOrderRequest,
 		AddAggregationSourceRPC:                 rpc.DoAddAggregationSource,
+		AddAggregationSourcesRPC:                rpc.DoAddAggregationSources,
 		GetAllAggregationSourceRPC:              rpc.DoGetAllAggregationSource,
 		GetAggregationSourceRPC:                 rpc.DoGetAggregationSource,
 		UpdateAggregationSourceRPC:              rpc.DoUpdateAggregationSource,
@@ -57,6 +57,11 @@ func Router() *iris.Application {
 		RemoveElementsFromAggregateRPC:          rpc.DoRemoveElementsFromAggregate,
 		ResetAggregateElementsRPC:               rpc.DoResetAggregateElements,
 		SetDefaultBootOrderAggregateElementsRPC: rpc.DoSetDefaultBootOrderAggregateElements,
+		RediscoverAggregationSourceRPC:          rpc.DoRediscoverAggregationSource,
+		RediscoverAggregateElementsRPC:          rpc.DoRediscoverAggregateElements,
+		InsertMediaAggregateElementsRPC:         rpc.DoInsertMediaAggregateElements,
+		EjectMediaAggregateElementsRPC:          rpc.DoEjectMediaAggregateElements,
+		DrainAggregationSourceRPC:               rpc.DoDrainAggregationSource,
 		GetAllConnectionMethodsRPC:              rpc.DoGetAllConnectionMethods,
 		GetConnectionMethodRPC:                  rpc.DoGetConnectionMethod,
 	}
@@ -66,6 +71,7 @@ func Router() *iris.Application {
 		DeleteSessionRPC:        rpc.DeleteSessionRequest,
 		GetSessionRPC:           rpc.GetSessionRequest,
 		GetAllActiveSessionsRPC: rpc.GetAllActiveSessionRequest,
+		DeleteUserSessionsRPC:   rpc.DeleteUserSessionsRequest,
 		GetSessionServiceRPC:    rpc.GetSessionServiceRequest,
 	}
 
@@ -113,9 +119,13 @@ func Router() *iris.Application {
 	}
 
 	manager := handle.ManagersRPCs{
-		GetManagersCollectionRPC: rpc.GetManagersCollection,
-		GetManagersRPC:           rpc.GetManagers,
-		GetManagersResourceRPC:   rpc.GetManagersResource,
+		GetManagersCollectionRPC:  rpc.GetManagersCollection,
+		GetManagersRPC:            rpc.GetManagers,
+		GetManagersResourceRPC:    rpc.GetManagersResource,
+		ResetManagerRPC:           rpc.ResetManager,
+		UpdateManagersResourceRPC: rpc.UpdateManagersResource,
+		VirtualMediaInsertRPC:     rpc.VirtualMediaInsert,
+		VirtualMediaEjectRPC:      rpc.VirtualMediaEject,
 	}
 
 	update := handle.UpdateRPCs{
@@ -164,13 +174,13 @@ func Router() *iris.Application {
 	session := v1.Party("/SessionService")
 	session.SetRegisterRule(iris.RouteSkip)
 	session.Get("/", s.GetSessionService)
-	session.Get("/Sessions", middleware.SessionDelMiddleware, s.GetAllActiveSessions)
-	session.Get("/Sessions/{sessionID}", middleware.SessionDelMiddleware, s.GetSession)
+	session.Get("/Sessions", s.GetAllActiveSessions)
+	session.Get("/Sessions/{sessionID}", s.GetSession)
 	session.Post("/Sessions", s.CreateSession)
-	session.Delete("/Sessions/{sessionID}", middleware.SessionDelMiddleware, s.DeleteSession)
+	session.Delete("/Sessions/{sessionID}", s.DeleteSession)
 	session.Any("/", handle.SsMethodNotAllowed)
 
-	account := v1.Party("/AccountService", middleware.SessionDelMiddleware)
+	account := v1.Party("/AccountService")
 	account.SetRegisterRule(iris.RouteSkip)
 	account.Get("/", a.GetAccountService)
 	account.Get("/Accounts", a.GetAllAccounts)
@@ -178,9 +188,10 @@ func Router() *iris.Application {
 	account.Post("/Accounts", a.CreateAccount)
 	account.Patch("/Accounts/{id}", a.UpdateAccount)
 	account.Delete("/Accounts/{id}", a.DeleteAccount)
+	account.Post("/Accounts/{id}/Actions/ManagerAccount.TerminateSessions", s.DeleteUserSessions)
 	account.Any("/", handle.AsMethodNotAllowed)
 
-	role := account.Party("/Roles", middleware.SessionDelMiddleware)
+	role := account.Party("/Roles")
 	role.SetRegisterRule(iris.RouteSkip)
 	role.Get("/", r.GetAllRoles)
 	role.Get("/{id}", r.GetRole)
@@ -188,7 +199,7 @@ func Router() *iris.Application {
 	role.Patch("/{id}", r.UpdateRole)
 	role.Delete("/{id}", r.DeleteRole)
 
-	task := v1.Party("/TaskService", middleware.SessionDelMiddleware)
+	task := v1.Party("/TaskService")
 	task.SetRegisterRule(iris.RouteSkip)
 	task.Get("/", ts.GetTaskService)
 	task.Get("/Tasks", ts.TaskCollection)
@@ -200,7 +211,7 @@ func Router() *iris.Application {
 	task.Any("/Tasks", handle.TsMethodNotAllowed)
 	task.Any("/Tasks/{TaskID}", handle.TsMethodNotAllowed)
 
-	systems := v1.Party("/Systems", middleware.SessionDelMiddleware)
+	systems := v1.Party("/Systems")
 	systems.SetRegisterRule(iris.RouteSkip)
 	systems.Get("/", system.GetSystemsCollection)
 	systems.Get("/{id}", system.GetSystem)
@@ -248,7 +259,7 @@ func Router() *iris.Application {
 	systems.Any("/{id}/Bios", handle.SystemsMethodNotAllowed)
 	systems.Any("/{id}/Processors/{rid}", handle.SystemsMethodNotAllowed)
 
-	storage := v1.Party("/Systems/{id}/Storage", middleware.SessionDelMiddleware)
+	storage := v1.Party("/Systems/{id}/Storage")
 	storage.SetRegisterRule(iris.RouteSkip)
 	storage.Get("/", system.GetSystemResource)
 	storage.Get("/{rid}", system.GetSystemResource)
@@ -263,20 +274,22 @@ func Router() *iris.Application {
 	storage.Any("/{id2}/Volumes", handle.SystemsMethodNotAllowed)
 	storage.Any("/{id2}/Volumes/{rid}", handle.SystemsMethodNotAllowed)
 
-	systemsAction := systems.Party("/{id}/Actions", middleware.SessionDelMiddleware)
+	systemsAction := systems.Party("/{id}/Actions")
 	systemsAction.SetRegisterRule(iris.RouteSkip)
 	systemsAction.Post("/ComputerSystem.Reset", system.ComputerSystemReset)
 	systemsAction.Post("/ComputerSystem.SetDefaultBootOrder", system.SetDefaultBootOrder)
 
-	aggregation := v1.Party("/AggregationService", middleware.SessionDelMiddleware)
+	aggregation := v1.Party("/AggregationService")
 	aggregation.SetRegisterRule(iris.RouteSkip)
 	aggregation.Get("/", pc.GetAggregationService)
 	aggregation.Post("/Actions/AggregationService.Reset/", pc.Reset)
 	aggregation.Any("/Actions/AggregationService.Reset/", handle.AggMethodNotAllowed)
 	aggregation.Post("/Actions/AggregationService.SetDefaultBootOrder/", pc.SetDefaultBootOrder)
 	aggregation.Any("/Actions/AggregationService.SetDefaultBootOrder/", handle.AggMethodNotAllowed)
+	aggregation.Post("/Actions/AggregationService.AddAggregationSources/", pc.AddAggregationSources)
+	aggregation.Any("/Actions/AggregationService.AddAggregationSources/", handle.AggMethodNotAllowed)
 	aggregation.Any("/", handle.AggMethodNotAllowed)
-	aggregationSource := aggregation.Party("/AggregationSources", middleware.SessionDelMiddleware)
+	aggregationSource := aggregation.Party("/AggregationSources")
 	aggregationSource.Post("/", pc.AddAggregationSource)
 	aggregationSource.Get("/", pc.GetAllAggregationSource)
 	aggregationSource.Any("/", handle.AggMethodNotAllowed)
@@ -284,14 +297,18 @@ func Router() *iris.Application {
 	aggregationSource.Patch("/{id}", pc.UpdateAggregationSource)
 	aggregationSource.Delete("/{id}", pc.DeleteAggregationSource)
 	aggregationSource.Any("/{id}", handle.AggMethodNotAllowed)
+	aggregationSource.Post("/{id}/Actions/AggregationSource.Rediscover/", pc.RediscoverAggregationSource)
+	aggregationSource.Any("/{id}/Actions/AggregationSource.Rediscover/", handle.AggMethodNotAllowed)
+	aggregationSource.Post("/{id}/Actions/AggregationSource.Drain/", pc.DrainAggregationSource)
+	aggregationSource.Any("/{id}/Actions/AggregationSource.Drain/", handle.AggMethodNotAllowed)
 
-	connectionMethods := aggregation.Party("/ConnectionMethods", middleware.SessionDelMiddleware)
+	connectionMethods := aggregation.Party("/ConnectionMethods")
 	connectionMethods.Get("/", pc.GetAllConnectionMethods)
 	connectionMethods.Get("/{id}", pc.GetConnectionMethod)
 	connectionMethods.Any("/", handle.AggMethodNotAllowed)
 	connectionMethods.Any("/{id}", handle.AggMethodNotAllowed)
 
-	aggregates := aggregation.Party("/Aggregates", middleware.SessionDelMiddleware)
+	aggregates := aggregation.Party("/Aggregates")
 	aggregates.Post("/", pc.CreateAggregate)
 	aggregates.Get("/", pc.GetAggregateCollection)
 	aggregates.Any("/", handle.AggregateMethodNotAllowed)
@@ -306,8 +323,14 @@ func Router() *iris.Application {
 	aggregates.Any("/{id}/Actions/Aggregate.Reset/", handle.AggregateMethodNotAllowed)
 	aggregates.Post("/{id}/Actions/Aggregate.SetDefaultBootOrder/", pc.SetDefaultBootOrderAggregateElements)
 	aggregates.Any("/{id}/Actions/Aggregate.SetDefaultBootOrder/", handle.AggregateMethodNotAllowed)
-
-	chassis := v1.Party("/Chassis", middleware.SessionDelMiddleware)
+	aggregates.Post("/{id}/Actions/Aggregate.Rediscover/", pc.RediscoverAggregateElements)
+	aggregates.Any("/{id}/Actions/Aggregate.Rediscover/", handle.AggregateMethodNotAllowed)
+	aggregates.Post("/{id}/Actions/Aggregate.InsertMedia/", pc.InsertMediaAggregateElements)
+	aggregates.Any("/{id}/Actions/Aggregate.InsertMedia/", handle.AggregateMethodNotAllowed)
+	aggregates.Post("/{id}/Actions/Aggregate.EjectMedia/", pc.EjectMediaAggregateElements)
+	aggregates.Any("/{id}/Actions/Aggregate.EjectMedia/", handle.AggregateMethodNotAllowed)
+
+	chassis := v1.Party("/Chassis")
 	chassis.SetRegisterRule(iris.RouteSkip)
 	chassis.Get("/", cha.GetChassisCollection)
 	chassis.Get("/{id}", cha.GetChassis)
@@ -336,7 +359,7 @@ func Router() *iris.Application {
 	chassisThermal.Any("#Fans/{id1}", handle.ChassisMethodNotAllowed)
 	chassisThermal.Any("#Temperatures/{id1}", handle.ChassisMethodNotAllowed)
 
-	events := v1.Party("/EventService", middleware.SessionDelMiddleware)
+	events := v1.Party("/EventService")
 	events.SetRegisterRule(iris.RouteSkip)
 	events.Get("/", evt.GetEventService)
 	events.Get("/Subscriptions", evt.GetEventSubscriptionsCollection)
@@ -349,7 +372,7 @@ func Router() *iris.Application {
 	events.Any("/Actions/EventService.SubmitTestEvent", handle.EvtMethodNotAllowed)
 	events.Any("/Subscriptions", handle.EvtMethodNotAllowed)
 
-	fabrics := v1.Party("/Fabrics", middleware.SessionDelMiddleware)
+	fabrics := v1.Party("/Fabrics")
 	fabrics.SetRegisterRule(iris.RouteSkip)
 	fabrics.Get("/", fab.GetFabricResource)
 	fabrics.Get("/{id}", fab.GetFabricResource)
@@ -377,23 +400,28 @@ func Router() *iris.Application {
 	fabrics.Delete("/{id}/AddressPools/{addresspool_uuid}", fab.DeleteFabricResource)
 	fabrics.Any("/", handle.FabricsMethodNotAllowed)
 
-	managers := v1.Party("/Managers", middleware.SessionDelMiddleware)
+	managers := v1.Party("/Managers")
 	managers.SetRegisterRule(iris.RouteSkip)
 	managers.Get("/", manager.GetManagersCollection)
 	managers.Get("/{id}", manager.GetManager)
 	managers.Get("/{id}/EthernetInterfaces", manager.GetManagersResource)
 	managers.Get("/{id}/EthernetInterfaces/{rid}", manager.GetManagersResource)
+	managers.Patch("/{id}/EthernetInterfaces/{rid}", manager.UpdateManagersResource)
 	managers.Get("/{id}/NetworkProtocol", manager.GetManagersResource)
+	managers.Patch("/{id}/NetworkProtocol", manager.UpdateManagersResource)
 	managers.Get("/{id}/NetworkProtocol/{rid}", manager.GetManagersResource)
 	managers.Get("/{id}/HostInterfaces", manager.GetManagersResource)
 	managers.Get("/{id}/HostInterfaces/{rid}", manager.GetManagersResource)
 	managers.Get("/{id}/VirtualMedia", manager.GetManagersResource)
 	managers.Get("/{id}/VirtualMedia/{rid}", manager.GetManagersResource)
+	managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.InsertMedia", manager.VirtualMediaInsert)
+	managers.Post("/{id}/VirtualMedia/{rid}/Actions/VirtualMedia.EjectMedia", manager.VirtualMediaEject)
 	managers.Get("/{id}/LogServices", manager.GetManagersResource)
 	managers.Get("/{id}/LogServices/{rid}", manager.GetManagersResource)
 	managers.Get("/{id}/LogServices/{rid}/Entries", manager.GetManagersResource)
 	managers.Get("/{id}/LogServices/{rid}/Entries/{rid2}", manager.GetManagersResource)
 	managers.Post("/{id}/LogServices/{rid}/Actions/LogService.ClearLog", manager.GetManagersResource)
+	managers.Post("/{id}/Actions/Manager.Reset", manager.ResetManager)
 	managers.Any("/{id}/LogServices", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/LogServices/{rid}", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}/LogServices/{rid}/Entries", handle.ManagersMethodNotAllowed)
@@ -403,7 +431,7 @@ func Router() *iris.Application {
 	managers.Any("/", handle.ManagersMethodNotAllowed)
 	managers.Any("/{id}", handle.ManagersMethodNotAllowed)
 
-	updateService := v1.Party("/UpdateService", middleware.SessionDelMiddleware)
+	updateService := v1.Party("/UpdateService")
 	updateService.SetRegisterRule(iris.RouteSkip)
 	updateService.Get("/", update.GetUpdateService)
 	updateService.Post("/Actions/UpdateService.SimpleUpdate", update.SimpleUpdate)
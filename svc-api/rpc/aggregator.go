@@ -79,6 +79,20 @@ func DoAddAggregationSource(req aggregatorproto.AggregatorRequest) (*aggregatorp
 	return resp, err
 }
 
+// DoAddAggregationSources defines the RPC call function for
+// the AddAggregationSources from aggregator micro service
+func DoAddAggregationSources(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.AddAggregationSources(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
+
 // DoGetAllAggregationSource defines the RPC call function for
 // the GetAllAggregationSource from aggregator micro service
 func DoGetAllAggregationSource(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
@@ -274,3 +288,73 @@ func DoGetConnectionMethod(req aggregatorproto.AggregatorRequest) (*aggregatorpr
 
 	return resp, err
 }
+
+// DoRediscoverAggregationSource defines the RPC call function for
+// the rediscovery of an aggregation source from aggregator micro service
+func DoRediscoverAggregationSource(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.RediscoverAggregationSource(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
+
+// DoRediscoverAggregateElements defines the RPC call function for
+// the rediscovery of the elements of an aggregate from aggregator micro service
+func DoRediscoverAggregateElements(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.RediscoverElementsOfAggregate(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
+
+// DoInsertMediaAggregateElements defines the RPC call function for
+// the insertion of a media in the virtual media of the elements of an aggregate from aggregator micro service
+func DoInsertMediaAggregateElements(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.InsertMediaElementsOfAggregate(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
+
+// DoEjectMediaAggregateElements defines the RPC call function for
+// the ejection of the media of the virtual media of the elements of an aggregate from aggregator micro service
+func DoEjectMediaAggregateElements(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.EjectMediaElementsOfAggregate(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
+
+// DoDrainAggregationSource defines the RPC call function for
+// the drain of the plugin of an aggregation source from aggregator micro service
+func DoDrainAggregationSource(req aggregatorproto.AggregatorRequest) (*aggregatorproto.AggregatorResponse, error) {
+
+	aggregator := aggregatorproto.NewAggregatorService(services.Aggregator, services.Service.Client())
+
+	resp, err := aggregator.DrainAggregationSource(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("RPC error: %v", err)
+	}
+
+	return resp, err
+}
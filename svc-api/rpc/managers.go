@@ -52,3 +52,43 @@ func GetManagersResource(req managersproto.ManagerRequest) (*managersproto.Manag
 	}
 	return resp, nil
 }
+
+// ResetManager will do the rpc call to reset the manager of a server
+func ResetManager(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	asService := managersproto.NewManagersService(services.Managers, services.Service.Client())
+	resp, err := asService.ResetManager(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// UpdateManagersResource will do the rpc call to update a resource of the manager of a server
+func UpdateManagersResource(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	asService := managersproto.NewManagersService(services.Managers, services.Service.Client())
+	resp, err := asService.UpdateManagersResource(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// VirtualMediaInsert will do the rpc call to insert a media in a virtual media of the manager of a server
+func VirtualMediaInsert(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	asService := managersproto.NewManagersService(services.Managers, services.Service.Client())
+	resp, err := asService.VirtualMediaInsert(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
+
+// VirtualMediaEject will do the rpc call to eject the media of a virtual media of the manager of a server
+func VirtualMediaEject(req managersproto.ManagerRequest) (*managersproto.ManagerResponse, error) {
+	asService := managersproto.NewManagersService(services.Managers, services.Service.Client())
+	resp, err := asService.VirtualMediaEject(context.TODO(), &req)
+	if err != nil {
+		return nil, fmt.Errorf("error: RPC error: %v", err)
+	}
+	return resp, nil
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 
+	authproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/auth"
 	sessionproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/session"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 )
@@ -70,8 +71,9 @@ func GetSessionRequest(sessionID, sessionToken string) (*sessionproto.SessionRes
 	return rsp, err
 }
 
-// GetAllActiveSessionRequest will do the rpc call to get session
-func GetAllActiveSessionRequest(sessionID, sessionToken string) (*sessionproto.SessionResponse, error) {
+// GetAllActiveSessionRequest will do the rpc call to get session,
+// filter holds the $filter query to be applied on the sessions
+func GetAllActiveSessionRequest(sessionID, sessionToken, filter string) (*sessionproto.SessionResponse, error) {
 
 	asService := sessionproto.NewSessionService(services.AccountSession, services.Service.Client())
 
@@ -79,6 +81,7 @@ func GetAllActiveSessionRequest(sessionID, sessionToken string) (*sessionproto.S
 	rsp, err := asService.GetAllActiveSessions(context.TODO(), &sessionproto.SessionRequest{
 		SessionId:    sessionID,
 		SessionToken: sessionToken,
+		Filter:       filter,
 	})
 	if err != nil && rsp == nil {
 		return nil, fmt.Errorf("error while trying to make get session service rpc call: %v", err)
@@ -87,6 +90,23 @@ func GetAllActiveSessionRequest(sessionID, sessionToken string) (*sessionproto.S
 	return rsp, err
 }
 
+// DeleteUserSessionsRequest will do the rpc call to terminate all the sessions of an account
+func DeleteUserSessionsRequest(userName, sessionToken string) (*sessionproto.SessionResponse, error) {
+
+	asService := sessionproto.NewSessionService(services.AccountSession, services.Service.Client())
+
+	// Call the DeleteUserSessions
+	rsp, err := asService.DeleteUserSessions(context.TODO(), &sessionproto.UserSessionsRequest{
+		UserName:     userName,
+		SessionToken: sessionToken,
+	})
+	if err != nil && rsp == nil {
+		return nil, fmt.Errorf("error while trying to make delete user sessions rpc call: %v", err)
+	}
+
+	return rsp, err
+}
+
 //GetSessionServiceRequest will do the rpc call to check session
 func GetSessionServiceRequest() (*sessionproto.SessionResponse, error) {
 	asService := sessionproto.NewSessionService(services.AccountSession, services.Service.Client())
@@ -99,3 +119,39 @@ func GetSessionServiceRequest() (*sessionproto.SessionResponse, error) {
 
 	return rsp, err
 }
+
+// DoBasicAuthRequest will do the rpc call to verify the basic auth credentials
+// and get the token of the session which serves the basic auth requests of the user
+func DoBasicAuthRequest(userName, password string) (*authproto.BasicAuthResponse, error) {
+
+	authService := authproto.NewAuthorizationService(services.AccountSession, services.Service.Client())
+
+	// Call the AuthenticateBasicAuth
+	rsp, err := authService.AuthenticateBasicAuth(context.TODO(), &authproto.BasicAuthRequest{
+		UserName: userName,
+		Password: password,
+	})
+	if err != nil && rsp == nil {
+		return nil, fmt.Errorf("error while trying to make basic auth rpc call: %v", err)
+	}
+
+	return rsp, err
+}
+
+// DoClientCertificateAuthRequest will do the rpc call to verify the client certificate
+// and get the token of the session which serves the requests of the account mapped to the certificate
+func DoClientCertificateAuthRequest(certificate []byte, intermediates [][]byte) (*authproto.ClientCertificateResponse, error) {
+
+	authService := authproto.NewAuthorizationService(services.AccountSession, services.Service.Client())
+
+	// Call the AuthenticateClientCertificate
+	rsp, err := authService.AuthenticateClientCertificate(context.TODO(), &authproto.ClientCertificateRequest{
+		Certificate:   certificate,
+		Intermediates: intermediates,
+	})
+	if err != nil && rsp == nil {
+		return nil, fmt.Errorf("error while trying to make client certificate auth rpc call: %v", err)
+	}
+
+	return rsp, err
+}
@@ -17,6 +17,7 @@ package evcommon
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
@@ -138,7 +139,6 @@ func (st *StartUpInteraface) GetAllPluginStatus() {
 }
 func (st *StartUpInteraface) getPluginStatus(plugin evmodel.Plugin) {
 	PluginsMap := make(map[string]bool)
-	StartUpResourceBatchSize := config.Data.PluginStatusPolling.StartUpResouceBatchSize
 	var pluginStatus = common.PluginStatus{
 		Method: http.MethodGet,
 		RequestBody: common.StatusRequest{
@@ -154,6 +154,10 @@ func (st *StartUpInteraface) getPluginStatus(plugin evmodel.Plugin) {
 		PluginPrefferedAuthType: plugin.PreferredAuthType,
 		CACertificate:           &config.Data.KeyCertConf.RootCACertificate,
 	}
+	if len(plugin.Instances) > 0 {
+		// recording the availability of every instance, for the plugin calls to fail over between them
+		go pmbhandle.CheckInstancesStatus(pluginStatus, pmbhandle.PluginAddresses(plugin.IP, plugin.Port, plugin.Instances))
+	}
 	status, _, topicsList, err := pluginStatus.CheckStatus()
 	if err != nil && !status {
 		PluginStartUp = false
@@ -162,30 +166,12 @@ func (st *StartUpInteraface) getPluginStatus(plugin evmodel.Plugin) {
 	}
 	log.Println("Status of plugin", plugin.ID, status)
 	PluginsMap[plugin.ID] = status
-	var allServers []SavedSystems
 	for pluginID, status := range PluginsMap {
 		if status && !PluginStartUp {
-			allServers, err = st.getAllServers(pluginID)
-			if err != nil {
+			if err := st.startUpPlugin(pluginID); err != nil {
 				log.Println("Error While getting the servers", pluginID, err)
 				continue
 			}
-			for {
-				if len(allServers) < StartUpResourceBatchSize {
-					err = callPluginStartUp(allServers, pluginID)
-					if err != nil {
-						log.Println("Error While trying call plugin startup", pluginID, err)
-					}
-					break
-				}
-				batchServers := allServers[:StartUpResourceBatchSize]
-				err = callPluginStartUp(batchServers, pluginID)
-				if err != nil {
-					log.Println("Error While trying call plugin startup", pluginID, err)
-					continue
-				}
-				allServers = allServers[StartUpResourceBatchSize:]
-			}
 			PluginStartUp = true
 		}
 	}
@@ -197,6 +183,55 @@ func (st *StartUpInteraface) getPluginStatus(plugin evmodel.Plugin) {
 	return
 }
 
+// startUpPlugin calls the plugin startup for all the servers of the plugin, in batches,
+// for the plugin to check and recreate their event subscriptions
+func (st *StartUpInteraface) startUpPlugin(pluginID string) error {
+	StartUpResourceBatchSize := config.Data.PluginStatusPolling.StartUpResouceBatchSize
+	allServers, err := st.getAllServers(pluginID)
+	if err != nil {
+		return err
+	}
+	for {
+		if len(allServers) < StartUpResourceBatchSize {
+			err = callPluginStartUp(allServers, pluginID)
+			if err != nil {
+				log.Println("Error While trying call plugin startup", pluginID, err)
+			}
+			return nil
+		}
+		batchServers := allServers[:StartUpResourceBatchSize]
+		// a failed batch is not retried, for the others to be called, it is called again at the next reconciliation
+		err = callPluginStartUp(batchServers, pluginID)
+		if err != nil {
+			log.Println("Error While trying call plugin startup", pluginID, err)
+		}
+		allServers = allServers[StartUpResourceBatchSize:]
+	}
+}
+
+// ReconcileSubscriptions calls the startup of the available plugins every SubscriptionReconciliationIntervalInMins,
+// for the plugins to recreate the event subscriptions the servers lost, after a reset or a firmware update,
+// and to remove the duplicated ones
+func (st *StartUpInteraface) ReconcileSubscriptions() {
+	for {
+		time.Sleep(time.Minute * time.Duration(config.Data.PluginStatusPolling.SubscriptionReconciliationIntervalInMins))
+		pluginList, err := evmodel.GetAllPlugins()
+		if err != nil {
+			log.Println("Error while getting the plugins for the subscription reconciliation", err)
+			continue
+		}
+		for i := 0; i < len(pluginList); i++ {
+			plugin := pluginList[i]
+			if !GetPluginStatus(&plugin) {
+				continue
+			}
+			if err := st.startUpPlugin(plugin.ID); err != nil {
+				log.Println("Error while reconciling the subscriptions of the servers of plugin", plugin.ID, err)
+			}
+		}
+	}
+}
+
 func (st *StartUpInteraface) getAllServers(pluginID string) ([]SavedSystems, error) {
 	var matchedServers []SavedSystems
 	allServers, err := evmodel.GetAllSystems()
@@ -277,17 +312,22 @@ func callPluginStartUp(servers []SavedSystems, pluginID string) error {
 	contactRequest.PostBody = startUpMap
 
 	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
-		var err error
-		contactRequest.HTTPMethodType = http.MethodPost
-		contactRequest.PostBody = map[string]interface{}{
+		var loginRequest PluginContactRequest
+		loginRequest.Plugin = plugin
+		loginRequest.URL = "/ODIM/v1/Sessions"
+		loginRequest.HTTPMethodType = http.MethodPost
+		loginRequest.PostBody = map[string]interface{}{
 			"Username": plugin.Username,
 			"Password": string(plugin.Password),
 		}
-		contactRequest.URL = "/ODIM/v1/Sessions"
-		response, err := callPlugin(contactRequest)
+		response, err := callPlugin(loginRequest)
 		if err != nil {
 			return err
 		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+			return fmt.Errorf("error while logging in to the plugin %s: status code %d", plugin.ID, response.StatusCode)
+		}
 		contactRequest.Token = response.Header.Get("X-Auth-Token")
 	} else {
 		contactRequest.LoginCredential = map[string]string{
@@ -299,6 +339,10 @@ func callPluginStartUp(servers []SavedSystems, pluginID string) error {
 	if err != nil {
 		return err
 	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("error while calling the startup of the plugin %s: status code %d", plugin.ID, response.StatusCode)
+	}
 
 	//return updateDeviceSubscriptionLocation(startUpMap[0].Device.ManagerAddress, response.Header.Get("location"))
 	bodyBytes, err := ioutil.ReadAll(response.Body)
@@ -314,7 +358,7 @@ func callPluginStartUp(servers []SavedSystems, pluginID string) error {
 func callPlugin(req PluginContactRequest) (*http.Response, error) {
 	var reqURL = "https://" + req.Plugin.IP + ":" + req.Plugin.Port + req.URL
 	if strings.EqualFold(req.Plugin.PreferredAuthType, "XAuthToken") {
-		return pmbhandle.ContactPlugin(reqURL, req.HTTPMethodType, "", "", req.PostBody, nil)
+		return pmbhandle.ContactPlugin(reqURL, req.HTTPMethodType, req.Token, "", req.PostBody, nil)
 	}
 	if strings.EqualFold(req.Plugin.PreferredAuthType, "BasicAuth") {
 		return pmbhandle.ContactPlugin(reqURL, req.HTTPMethodType, "", "", req.PostBody, req.LoginCredential)
@@ -301,11 +301,14 @@ func startTestServer() *httptest.Server {
 	ts := httptest.NewUnstartedServer(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.URL.Path == "/ODIM/v1/Sessions" {
-				w.WriteHeader(http.StatusOK)
 				w.Header().Set("X-Auth-Token", "token")
+				w.WriteHeader(http.StatusOK)
 				w.Write([]byte("OK"))
 			} else if r.URL.Path == "/ODIM/v1/Startup" {
-
+				if _, _, basicAuth := r.BasicAuth(); !basicAuth && r.Header.Get("X-Auth-Token") != "token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
 				w.WriteHeader(http.StatusOK)
 				w.Write(body)
 			} else if r.URL.Path == "/ODIM/v1/Status" {
@@ -463,6 +466,31 @@ func TestCallPluginStartUp(t *testing.T) {
 
 	err = callPluginStartUp(servers, "pluginBadData")
 	assert.NotNil(t, err, "error should not be nil")
+
+	mockData(t, common.OnDisk, "Plugin", "XAuthPlugin", evmodel.Plugin{
+		IP:                "localhost",
+		Port:              "1234",
+		Password:          getEncryptedKey(t, []byte("Password")),
+		Username:          "admin",
+		ID:                "XAuthPlugin",
+		PreferredAuthType: "XAuthToken",
+		PluginType:        "XAuthPlugin",
+	})
+	deviceSubscription, err := evmodel.GetDeviceSubscriptions("10.4.1.2")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	deviceSubscription.Location = "https://10.4.1.2/EventService/Subscriptions/1"
+	if err = evmodel.UpdateDeviceSubscriptionLocation(*deviceSubscription); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	err = callPluginStartUp(servers, "XAuthPlugin")
+	assert.Nil(t, err, "the startup of a plugin using a session should be called with the session token")
+	deviceSubscription, err = evmodel.GetDeviceSubscriptions("10.4.1.2")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	assert.Equal(t, "/redfish/v1/EventService/Subscriptions/2", deviceSubscription.Location, "the subscription location should be the one returned by the startup")
 }
 
 func TestGetandStoreToken(t *testing.T) {
@@ -0,0 +1,203 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+)
+
+// MoveEventSubscriptions subscribes again to the events of a server through the plugin which
+// now manages it. The subscription made on the server through the previous plugin is deleted
+// first, through the previous plugin when it is still available or else through the current one.
+func (p *PluginContact) MoveEventSubscriptions(req *eventsproto.MoveEventSubscriptionsRequest) response.RPC {
+	var resp response.RPC
+	target, err := evmodel.GetTarget(req.UUID)
+	if err != nil {
+		errorMessage := "error while getting device details: " + err.Error()
+		evcommon.GenErrorResponse(errorMessage, response.ResourceNotFound, http.StatusNotFound,
+			[]interface{}{"System", req.UUID}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	subscriptionDetails, err := evmodel.GetEvtSubscriptions(target.ManagerAddress)
+	if err != nil && !strings.Contains(err.Error(), "No data found for the key") {
+		errorMessage := "error while getting event subscription details: " + err.Error()
+		evcommon.GenErrorResponse(errorMessage, response.InternalError, http.StatusInternalServerError,
+			[]interface{}{}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	deviceSubscription, err := evmodel.GetDeviceSubscriptions(target.ManagerAddress)
+	if err != nil || len(subscriptionDetails) < 1 {
+		// nothing is subscribed on the server, so there is nothing to move
+		log.Printf("info: no event subscription to move for the server %v", target.ManagerAddress)
+		resp.StatusCode = http.StatusOK
+		resp.StatusMessage = response.Success
+		return resp
+	}
+
+	decryptedPasswordByte, err := common.DecryptWithPrivateKey(target.Password)
+	if err != nil {
+		errorMessage := "error while trying to decrypt device password: " + err.Error()
+		evcommon.GenErrorResponse(errorMessage, response.InternalError, http.StatusInternalServerError,
+			[]interface{}{}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	target.Password = decryptedPasswordByte
+	plugin, errs := evmodel.GetPluginData(target.PluginID)
+	if errs != nil {
+		errorMessage := "error while getting plugin data: " + errs.Error()
+		evcommon.GenErrorResponse(errorMessage, response.ResourceNotFound, http.StatusNotFound,
+			[]interface{}{"Plugin", target.PluginID}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+
+	postBody, err := json.Marshal(mergeEventSubscriptions(subscriptionDetails))
+	if err != nil {
+		errorMessage := "error while marshalling subscription details: " + err.Error()
+		evcommon.GenErrorResponse(errorMessage, response.InternalError, http.StatusInternalServerError,
+			[]interface{}{}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	target.PostBody = postBody
+
+	origin := deviceSubscription.OriginResources[0]
+	err = fmt.Errorf("plugin %v is not available", req.PluginID)
+	if previousPlugin, errs := evmodel.GetPluginData(req.PluginID); errs == nil {
+		err = p.deleteDeviceSubscription(origin, previousPlugin, target)
+	}
+	if err != nil {
+		log.Printf("warn: unable to delete the event subscription of the server %v through the plugin %v: %v",
+			target.ManagerAddress, req.PluginID, err)
+		if err = p.deleteDeviceSubscription(origin, plugin, target); err != nil {
+			log.Printf("warn: unable to delete the event subscription of the server %v: %v", target.ManagerAddress, err)
+		}
+	}
+
+	var contactRequest evcommon.PluginContactRequest
+	contactRequest.Plugin = plugin
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		token := p.getPluginToken(plugin)
+		if token == "" {
+			evcommon.GenErrorResponse("error: Unable to create session with plugin "+plugin.ID, response.NoValidSession, http.StatusUnauthorized,
+				[]interface{}{}, &resp)
+			return resp
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.LoginCredential = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	contactRequest.URL = "/ODIM/v1/Subscriptions"
+	contactRequest.HTTPMethodType = http.MethodPost
+	contactRequest.PostBody = target
+
+	pluginResp, location, _, err := p.PluginCall(contactRequest)
+	if err != nil || location == "" {
+		errorMessage := fmt.Sprintf("error while subscribing to the events of the server %v through the plugin %v: status code %v",
+			target.ManagerAddress, plugin.ID, pluginResp.StatusCode)
+		if err != nil {
+			errorMessage = "error while subscribing to the events of the server " + target.ManagerAddress + ": " + err.Error()
+		}
+		evcommon.GenErrorResponse(errorMessage, response.InternalError, http.StatusInternalServerError,
+			[]interface{}{}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	if !strings.Contains(location, target.ManagerAddress) {
+		location = "https://" + target.ManagerAddress + location
+	}
+	err = evmodel.UpdateDeviceSubscriptionLocation(evmodel.DeviceSubscription{
+		EventHostIP:     deviceSubscription.EventHostIP,
+		Location:        location,
+		OriginResources: deviceSubscription.OriginResources,
+	})
+	if err != nil {
+		errorMessage := "error while updating the event subscription of the server: " + err.Error()
+		evcommon.GenErrorResponse(errorMessage, response.InternalError, http.StatusInternalServerError,
+			[]interface{}{}, &resp)
+		log.Printf(errorMessage)
+		return resp
+	}
+	log.Printf("info: event subscription of the server %v is moved to the plugin %v", target.ManagerAddress, plugin.ID)
+	resp.StatusCode = http.StatusOK
+	resp.StatusMessage = response.Success
+	return resp
+}
+
+// deleteDeviceSubscription deletes the event subscription made on the server through the given plugin
+func (p *PluginContact) deleteDeviceSubscription(origin string, plugin *evmodel.Plugin, target *evmodel.Target) error {
+	resp, err := p.DeleteSubscriptions(origin, "", plugin, target)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("plugin %v responded with status code %v", plugin.ID, resp.StatusCode)
+	}
+	return nil
+}
+
+// mergeEventSubscriptions frames the subscription to make on a server which covers
+// all the event subscriptions of the server
+func mergeEventSubscriptions(subscriptions []evmodel.Subscription) evmodel.EvtSubPost {
+	subscriptionPost := evmodel.EvtSubPost{
+		HTTPHeaders: []evmodel.HTTPHeaders{{ContentType: "application/json"}},
+	}
+	var allEventTypes, allMessageIDs, allResourceTypes bool
+	for _, subscription := range subscriptions {
+		// a subscription without filter receives everything
+		allEventTypes = allEventTypes || len(subscription.EventTypes) == 0
+		allMessageIDs = allMessageIDs || len(subscription.MessageIds) == 0
+		allResourceTypes = allResourceTypes || len(subscription.ResourceTypes) == 0
+		subscriptionPost.EventTypes = append(subscriptionPost.EventTypes, subscription.EventTypes...)
+		subscriptionPost.MessageIds = append(subscriptionPost.MessageIds, subscription.MessageIds...)
+		subscriptionPost.ResourceTypes = append(subscriptionPost.ResourceTypes, subscription.ResourceTypes...)
+		subscriptionPost.Name = subscription.Name
+		subscriptionPost.Context = subscription.Context
+		subscriptionPost.Protocol = subscription.Protocol
+		subscriptionPost.Destination = subscription.Destination
+	}
+	if allEventTypes {
+		subscriptionPost.EventTypes = []string{}
+	}
+	if allMessageIDs {
+		subscriptionPost.MessageIds = []string{}
+	}
+	if allResourceTypes {
+		subscriptionPost.ResourceTypes = []string{}
+	}
+	eventTypesCount := len(subscriptionPost.EventTypes)
+	messageIDsCount := len(subscriptionPost.MessageIds)
+	resourceTypesCount := len(subscriptionPost.ResourceTypes)
+	removeDuplicatesFromSlice(&subscriptionPost.EventTypes, &eventTypesCount)
+	removeDuplicatesFromSlice(&subscriptionPost.MessageIds, &messageIDsCount)
+	removeDuplicatesFromSlice(&subscriptionPost.ResourceTypes, &resourceTypesCount)
+	return subscriptionPost
+}
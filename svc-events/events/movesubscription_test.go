@@ -0,0 +1,112 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	eventsproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/events"
+	"github.com/ODIM-Project/ODIM/svc-events/evcommon"
+	"github.com/ODIM-Project/ODIM/svc-events/evmodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveEventSubscriptions(t *testing.T) {
+	config.SetUpMockConfig(t)
+	// Intializing plugin token
+	evcommon.Token.Tokens = map[string]string{
+		"ILO": "token",
+	}
+	defer func() {
+		err := common.TruncateDB(common.InMemory)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		err = common.TruncateDB(common.OnDisk)
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}()
+
+	mockTargetandPlugin(t)
+
+	storeTestEventDetails(t)
+
+	pc := PluginContact{
+		ContactClient: mockContactClient,
+	}
+
+	// positive test case, the server was managed by the ILO plugin and is now managed by GRF
+	resp := pc.MoveEventSubscriptions(&eventsproto.MoveEventSubscriptionsRequest{
+		UUID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+		PluginID: "ILO",
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+	deviceSubscription, err := evmodel.GetDeviceSubscriptions("10.4.1.2")
+	assert.Nil(t, err, "error should be nil")
+	assert.Equal(t, "https://10.4.1.2/ODIM/v1/Subscriptions/12", deviceSubscription.Location, "Location should be the one of the new subscription")
+
+	// the previous plugin is not available anymore
+	resp = pc.MoveEventSubscriptions(&eventsproto.MoveEventSubscriptionsRequest{
+		UUID:     "6d4a0a66-7efa-578e-83cf-44dc68d2874e",
+		PluginID: "non-existent",
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+
+	// server without event subscriptions
+	resp = pc.MoveEventSubscriptions(&eventsproto.MoveEventSubscriptionsRequest{
+		UUID:     "d72dade0-c35a-984c-4859-1108132d72da",
+		PluginID: "GRF",
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "Status Code should be StatusOK")
+
+	// server not added
+	resp = pc.MoveEventSubscriptions(&eventsproto.MoveEventSubscriptionsRequest{
+		UUID:     "de018110-4859-984c-c35a-0a32d772d6c5",
+		PluginID: "GRF",
+	})
+	assert.Equal(t, http.StatusNotFound, int(resp.StatusCode), "Status Code should be StatusNotFound")
+}
+
+func TestMergeEventSubscriptions(t *testing.T) {
+	subscriptions := []evmodel.Subscription{
+		{
+			Name:          "Subscription",
+			Destination:   "https://10.24.1.15:9090/events",
+			Context:       "context",
+			Protocol:      "Redfish",
+			EventTypes:    []string{"Alert"},
+			MessageIds:    []string{"IndicatorChanged"},
+			ResourceTypes: []string{"ComputerSystem"},
+		},
+		{
+			Name:          "Subscription",
+			Destination:   "https://10.24.1.15:9090/events",
+			Context:       "context",
+			Protocol:      "Redfish",
+			EventTypes:    []string{"Alert", "StatusChange"},
+			MessageIds:    []string{},
+			ResourceTypes: []string{"ComputerSystem"},
+		},
+	}
+	subscriptionPost := mergeEventSubscriptions(subscriptions)
+	assert.Equal(t, []string{"Alert", "StatusChange"}, subscriptionPost.EventTypes, "EventTypes should be merged")
+	assert.Equal(t, []string{}, subscriptionPost.MessageIds, "MessageIds should not be filtered")
+	assert.Equal(t, []string{"ComputerSystem"}, subscriptionPost.ResourceTypes, "ResourceTypes should be merged")
+	assert.Equal(t, "https://10.24.1.15:9090/events", subscriptionPost.Destination, "Destination should be kept")
+}
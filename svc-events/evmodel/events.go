@@ -139,6 +139,7 @@ type Plugin struct {
 	ID                string
 	PluginType        string
 	PreferredAuthType string
+	Instances         []string
 }
 
 // Fabric is the model for fabrics information
@@ -69,6 +69,7 @@ func main() {
 		EMBConsume:      consumer.Consume,
 	}
 	go startUPInterface.GetAllPluginStatus()
+	go startUPInterface.ReconcileSubscriptions()
 	// Run server
 	if err := services.Service.Run(); err != nil {
 		log.Fatal(err)
@@ -341,6 +341,30 @@ func (e *Events) DeleteEventSubscription(ctx context.Context, req *eventsproto.E
 	return nil
 }
 
+// MoveEventSubscriptions defines the operations which handles the RPC request response
+// for the move event subscriptions RPC call to events micro service.
+// The functionality is to subscribe again to the events of a server through the plugin which now manages it.
+func (e *Events) MoveEventSubscriptions(ctx context.Context, req *eventsproto.MoveEventSubscriptionsRequest, resp *eventsproto.EventSubResponse) error {
+	var err error
+	pc := events.PluginContact{
+		ContactClient: e.ContactClientRPC,
+	}
+	data := pc.MoveEventSubscriptions(req)
+	resp.Body, err = json.Marshal(data.Body)
+	if err != nil {
+		errorMessage := "error while trying marshal the response body for move event subsciptions : " + err.Error()
+		resp.StatusCode = http.StatusInternalServerError
+		resp.StatusMessage = response.InternalError
+		resp.Body, _ = json.Marshal(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil).Body)
+		log.Printf(resp.StatusMessage)
+		return nil
+	}
+	resp.StatusCode = data.StatusCode
+	resp.StatusMessage = data.StatusMessage
+	resp.Header = data.Header
+	return nil
+}
+
 //CreateDefaultEventSubscription defines the operations which handles the RPC request response
 // after computer system restarts ,This will  triggered from   aggregation service whenever a computer system is added
 func (e *Events) CreateDefaultEventSubscription(ctx context.Context, req *eventsproto.DefaultEventSubRequest, resp *eventsproto.DefaultEventSubResponse) error {
@@ -14,13 +14,16 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	taskproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/task"
 	"github.com/ODIM-Project/ODIM/lib-utilities/services"
 	"github.com/ODIM-Project/ODIM/svc-managers/managers"
 	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
@@ -62,7 +65,10 @@ func registerHandlers() {
 	manager := new(rpc.Managers)
 
 	manager.IsAuthorizedRPC = services.IsAuthorized
+	manager.GetSessionUserName = services.GetSessionUserName
+	manager.CreateTask = services.CreateTask
 	manager.EI = managers.GetExternalInterface()
+	manager.EI.UpdateTask = updateTaskData
 
 	managersproto.RegisterManagersHandler(services.Service.Server(), manager)
 }
@@ -78,3 +84,25 @@ func addManagertoDB() error {
 	}
 	return mgr.AddManagertoDB()
 }
+
+// updateTaskData updates the task with the given data, the task is cancelled when the task service
+// asks for it and the Cancelling error is returned for the work of the task to stop, the changes
+// already made on the BMC are not reversed
+func updateTaskData(taskData common.TaskData) error {
+	respBody, _ := json.Marshal(taskData.Response.Body)
+	payLoad := &taskproto.Payload{
+		HTTPHeaders:   taskData.Response.Header,
+		HTTPOperation: taskData.HTTPMethod,
+		JSONBody:      taskData.TaskRequest,
+		StatusCode:    taskData.Response.StatusCode,
+		TargetURI:     taskData.TargetURI,
+		ResponseBody:  respBody,
+	}
+	err := services.UpdateTask(taskData.TaskID, taskData.TaskState, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now())
+	if err != nil && err.Error() == common.Cancelling {
+		if cerr := services.UpdateTask(taskData.TaskID, common.Cancelled, taskData.TaskStatus, taskData.PercentComplete, payLoad, time.Now()); cerr != nil {
+			return cerr
+		}
+	}
+	return err
+}
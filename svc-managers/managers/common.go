@@ -25,8 +25,9 @@ import (
 
 // ExternalInterface holds all the external connections managers package functions uses
 type ExternalInterface struct {
-	Device Device
-	DB     DB
+	Device     Device
+	DB         DB
+	UpdateTask func(common.TaskData) error
 }
 
 // Device struct to inject the contact device function into the handlers
@@ -38,15 +39,19 @@ type Device struct {
 
 // DB struct to inject the contact DB function into the handlers
 type DB struct {
-	GetAllKeysFromTable func(string) ([]string, error)
-	GetManagerData      func(string) (mgrmodel.RAManager, error)
-	GetManagerByURL     func(string) (string, *errors.Error)
-	GetPluginData       func(string) (mgrmodel.Plugin, *errors.Error)
-	UpdateManagersData  func(string, map[string]interface{}) error
-	GetResource         func(string, string) (string, *errors.Error)
+	GetAllKeysFromTable     func(string) ([]string, error)
+	GetManagerData          func(string) (mgrmodel.RAManager, error)
+	GetManagerByURL         func(string) (string, *errors.Error)
+	GetPluginData           func(string) (mgrmodel.Plugin, *errors.Error)
+	UpdateManagersData      func(string, map[string]interface{}) error
+	GetResource             func(string, string) (string, *errors.Error)
+	GetPluginInstanceStatus func(string) (pmbhandle.PluginInstanceStatus, error)
+	GetTarget               func(string) (*mgrmodel.DeviceTarget, *errors.Error)
+	SaveResource            func(string, string, string) error
 }
 
-// GetExternalInterface retrieves all the external connections managers package functions uses
+// GetExternalInterface retrieves all the external connections managers package functions uses,
+// the UpdateTask function is set by the service as it needs the task service
 func GetExternalInterface() *ExternalInterface {
 	return &ExternalInterface{
 		Device: Device{
@@ -55,12 +60,15 @@ func GetExternalInterface() *ExternalInterface {
 			DecryptDevicePassword: common.DecryptWithPrivateKey,
 		},
 		DB: DB{
-			GetAllKeysFromTable: mgrmodel.GetAllKeysFromTable,
-			GetManagerData:      mgrmodel.GetManagerData,
-			GetManagerByURL:     mgrmodel.GetManagerByURL,
-			GetPluginData:       mgrmodel.GetPluginData,
-			UpdateManagersData:  mgrmodel.UpdateManagersData,
-			GetResource:         mgrmodel.GetResource,
+			GetAllKeysFromTable:     mgrmodel.GetAllKeysFromTable,
+			GetManagerData:          mgrmodel.GetManagerData,
+			GetManagerByURL:         mgrmodel.GetManagerByURL,
+			GetPluginData:           mgrmodel.GetPluginData,
+			UpdateManagersData:      mgrmodel.UpdateManagersData,
+			GetResource:             mgrmodel.GetResource,
+			GetPluginInstanceStatus: pmbhandle.GetPluginInstanceStatus,
+			GetTarget:               mgrmodel.GetTarget,
+			SaveResource:            mgrmodel.SaveResource,
 		},
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -52,12 +53,13 @@ func mockGetExternalInterface() *ExternalInterface {
 			ContactClient: mockContactClient,
 		},
 		DB: DB{
-			GetAllKeysFromTable: mockGetAllKeysFromTable,
-			GetManagerData:      mockGetManagerData,
-			GetManagerByURL:     mockGetManagerByURL,
-			GetPluginData:       mockGetPluginData,
-			UpdateManagersData:  mockUpdateManagersData,
-			GetResource:         mockGetResource,
+			GetAllKeysFromTable:     mockGetAllKeysFromTable,
+			GetManagerData:          mockGetManagerData,
+			GetManagerByURL:         mockGetManagerByURL,
+			GetPluginData:           mockGetPluginData,
+			UpdateManagersData:      mockUpdateManagersData,
+			GetResource:             mockGetResource,
+			GetPluginInstanceStatus: mockGetPluginInstanceStatus,
 		},
 	}
 }
@@ -103,6 +105,13 @@ func mockGetManagerByURL(url string) (string, *errors.Error) {
 		managerData["Name"] = "noPlugin"
 	} else if url == "/redfish/v1/Managers/noToken" {
 		managerData["Name"] = "noToken"
+	} else if url == "/redfish/v1/Managers/haPlugin" {
+		managerData["Name"] = "haPlugin"
+	} else if url == "/redfish/v1/Managers/downPlugin" {
+		managerData["Status"] = map[string]string{
+			"State":  "UnavailableOffline",
+			"Health": "Critical",
+		}
 	}
 	data, _ := json.Marshal(managerData)
 	return string(data), nil
@@ -127,6 +136,16 @@ func mockGetPluginData(pluginID string) (mgrmodel.Plugin, *errors.Error) {
 			ID:                "noToken",
 			PreferredAuthType: "XAuthToken",
 		}, nil
+	} else if pluginID == "haPlugin" {
+		return mgrmodel.Plugin{
+			IP:                "localhost",
+			Port:              "9094",
+			Username:          "admin",
+			Password:          []byte("password"),
+			ID:                "haPlugin",
+			PreferredAuthType: "BasicAuth",
+			Instances:         []string{"localhost:9095", "localhost:9096"},
+		}, nil
 	} else if pluginID == "noPlugin" {
 		return mgrmodel.Plugin{}, errors.PackError(errors.DBKeyNotFound, "not found")
 	}
@@ -151,6 +170,17 @@ func mockGetResource(table, key string) (string, *errors.Error) {
 	return "body", nil
 }
 
+func mockGetPluginInstanceStatus(address string) (pmbhandle.PluginInstanceStatus, error) {
+	if address == "localhost:9096" {
+		return pmbhandle.PluginInstanceStatus{}, fmt.Errorf("not found")
+	}
+	return pmbhandle.PluginInstanceStatus{
+		Address:     address,
+		Available:   address == "localhost:9094",
+		LastChecked: "2020-10-12T07:50:46Z",
+	}, nil
+}
+
 func mockGetDeviceInfo(req mgrcommon.ResourceInfoRequest) (string, error) {
 	if req.URL == "/redfish/v1/Managers/deviceAbsent:1" || req.URL == "/redfish/v1/Managers/uuid1:1/Ethernet" {
 		return "", fmt.Errorf("error")
@@ -182,6 +212,13 @@ func mockContactClient(url, method, token string, odataID string, body interface
 			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
 		}, nil
 	}
+	if url == "https://localhost:9094/ODIM/v1/Managers/haPlugin" {
+		body := `{"@odata.id": "/ODIM/v1/Managers/haPlugin", "Name": "haPlugin"}`
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}, nil
+	}
 	if url == "https://localhost:9091/ODIM/v1/Managers/uuid/EthernetInterfaces" && token == "12345" {
 		body := `{"data": "/ODIM/v1/Managers/uuid/EthernetInterfaces"}`
 		return &http.Response{
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	dmtf "github.com/ODIM-Project/ODIM/lib-dmtf/model"
+	"github.com/ODIM-Project/ODIM/lib-rest-client/pmbhandle"
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	"github.com/ODIM-Project/ODIM/lib-utilities/config"
 	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
@@ -300,6 +301,15 @@ func (e *ExternalInterface) getPluginManagerResoure(managerID, reqURI string) re
 			errArgs, nil)
 		return resp
 	}
+	isPluginManager := strings.TrimSuffix(reqURI, "/") == "/redfish/v1/Managers/"+managerID
+	// the plugin found unavailable by the plugin health monitor is not contacted for its manager
+	if isPluginManager && isPluginUnavailable(managerData) {
+		resp = fillResponse([]byte(data))
+		if pluginManager, ok := resp.Body.(map[string]interface{}); ok && len(plugin.Instances) > 0 {
+			e.addPluginInstances(pluginManager, plugin)
+		}
+		return resp
+	}
 	var req mgrcommon.PluginContactRequest
 
 	req.ContactClient = e.Device.ContactClient
@@ -339,8 +349,59 @@ func (e *ExternalInterface) getPluginManagerResoure(managerID, reqURI string) re
 			return resp
 		}
 	}
-	return fillResponse(body)
+	resp = fillResponse(body)
+	if pluginManager, ok := resp.Body.(map[string]interface{}); ok && isPluginManager {
+		// the status of the plugin is the one recorded by the plugin health monitor
+		if status, ok := managerData["Status"]; ok {
+			pluginManager["Status"] = status
+		}
+		if len(plugin.Instances) > 0 {
+			e.addPluginInstances(pluginManager, plugin)
+		}
+	}
+	return resp
+
+}
 
+// isPluginUnavailable tells if the plugin health monitor found unavailable the plugin of the given manager
+func isPluginUnavailable(managerData map[string]interface{}) bool {
+	status, ok := managerData["Status"].(map[string]interface{})
+	return ok && status["State"] == "UnavailableOffline"
+}
+
+// addPluginInstances adds to the manager of a plugin running several instances
+// the address and the availability of each of its instances
+func (e *ExternalInterface) addPluginInstances(managerData map[string]interface{}, plugin mgrmodel.Plugin) {
+	var pluginInstances = make([]map[string]interface{}, 0)
+	for _, address := range pmbhandle.PluginAddresses(plugin.IP, plugin.Port, plugin.Instances) {
+		pluginInstance := map[string]interface{}{
+			"HostName": address,
+			"Status": map[string]string{
+				"State": "Enabled",
+			},
+		}
+		// the instance is considered available until the status polling finds otherwise
+		if status, err := e.DB.GetPluginInstanceStatus(address); err == nil {
+			pluginInstance["LastChecked"] = status.LastChecked
+			pluginInstance["Status"] = map[string]string{
+				"State":  "Enabled",
+				"Health": "OK",
+			}
+			if !status.Available {
+				pluginInstance["Status"] = map[string]string{
+					"State":  "UnavailableOffline",
+					"Health": "Critical",
+				}
+			}
+		}
+		pluginInstances = append(pluginInstances, pluginInstance)
+	}
+	oem, ok := managerData["Oem"].(map[string]interface{})
+	if !ok {
+		oem = make(map[string]interface{})
+	}
+	oem["PluginInstances"] = pluginInstances
+	managerData["Oem"] = oem
 }
 
 func fillResponse(body []byte) response.RPC {
@@ -182,6 +182,37 @@ func TestGetPluginManagerResourceSuccess(t *testing.T) {
 
 }
 
+func TestGetPluginManagerWithInstances(t *testing.T) {
+	config.SetUpMockConfig(t)
+	req := &managersproto.ManagerRequest{
+		ManagerID: "haPlugin",
+		URL:       "/redfish/v1/Managers/haPlugin",
+	}
+	e := mockGetExternalInterface()
+	response := e.GetManagers(req)
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	manager := response.Body.(map[string]interface{})
+	pluginInstances := manager["Oem"].(map[string]interface{})["PluginInstances"].([]map[string]interface{})
+	assert.Equal(t, 3, len(pluginInstances), "all the instances of the plugin should be listed")
+	assert.Equal(t, "localhost:9094", pluginInstances[0]["HostName"], "the instance the plugin was added with should be listed first")
+	assert.Equal(t, map[string]string{"State": "Enabled", "Health": "OK"}, pluginInstances[0]["Status"], "instance should be available")
+	assert.Equal(t, map[string]string{"State": "UnavailableOffline", "Health": "Critical"}, pluginInstances[1]["Status"], "instance should be unavailable")
+	assert.Equal(t, map[string]string{"State": "Enabled"}, pluginInstances[2]["Status"], "instance not checked yet should be enabled")
+}
+
+func TestGetPluginManagerUnavailable(t *testing.T) {
+	config.SetUpMockConfig(t)
+	req := &managersproto.ManagerRequest{
+		ManagerID: "downPlugin",
+		URL:       "/redfish/v1/Managers/downPlugin",
+	}
+	e := mockGetExternalInterface()
+	response := e.GetManagers(req)
+	assert.Equal(t, http.StatusOK, int(response.StatusCode), "Status code should be StatusOK.")
+	manager := response.Body.(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"State": "UnavailableOffline", "Health": "Critical"}, manager["Status"], "the recorded status of the plugin should be returned")
+}
+
 func TestGetPluginManagerResourceInvalidPluginFail(t *testing.T) {
 	mgrcommon.Token.Tokens = make(map[string]string)
 
@@ -0,0 +1,411 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+)
+
+// managerResetTypes are the values of ResetType supported by the Manager.Reset action
+var managerResetTypes = map[string]bool{
+	"ForceRestart":    true,
+	"GracefulRestart": true,
+}
+
+// managerResetPollingInterval is the interval between the checks of a BMC being reset, managerResetDownTime
+// is the time after which a BMC answering is taken as reset even if it was never seen down, and
+// managerResetTimeout is the time the BMC is waited for
+var (
+	managerResetPollingInterval = 10 * time.Second
+	managerResetDownTime        = time.Minute
+	managerResetTimeout         = 10 * time.Minute
+)
+
+// ResetManager resets the BMC of a server with the ResetType of the request, as the task with the given ID.
+// The task of the BMC is followed when it answers with one, then the BMC is waited for until it answers again
+// and the manager is read again from the BMC, it is marked as starting when the BMC does not come back in time.
+func (e *ExternalInterface) ResetManager(taskID string, req *managersproto.ManagerRequest) response.RPC {
+	targetURI := "/redfish/v1/Managers/" + req.ManagerID + "/Actions/Manager.Reset"
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, TaskRequest: string(req.RequestBody), UpdateTask: e.UpdateTask}
+	if !e.updateTaskProgress(taskID, targetURI, string(req.RequestBody), http.MethodPost, 0) {
+		return cancelledResponse(taskID)
+	}
+
+	var resetRequest mgrmodel.ManagerReset
+	if err := json.Unmarshal(req.RequestBody, &resetRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the manager reset request: " + err.Error()
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, taskInfo)
+	}
+	if resp, ok := validateRequestProperties(req.RequestBody, resetRequest, taskInfo); !ok {
+		return resp
+	}
+	if resetRequest.ResetType == "" {
+		errorMessage := "error: ResetType is missing in the manager reset request"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"ResetType"}, taskInfo)
+	}
+	if !managerResetTypes[resetRequest.ResetType] {
+		errorMessage := "error: ResetType " + resetRequest.ResetType + " is not supported by the manager reset"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyValueNotInList, errorMessage, []interface{}{resetRequest.ResetType, "ResetType"}, taskInfo)
+	}
+	uuid, managerID, ok := splitManagerID(req.ManagerID)
+	if !ok {
+		errorMessage := "error: the manager " + req.ManagerID + " is not the manager of a server"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Manager", req.ManagerID}, taskInfo)
+	}
+
+	postBody, _ := json.Marshal(resetRequest)
+	body, statusCode, err := e.callDevice(uuid, "/redfish/v1/Managers/"+managerID+"/Actions/Manager.Reset", http.MethodPost, postBody)
+	if err != nil {
+		return e.failTask(taskID, targetURI, string(req.RequestBody), http.MethodPost, statusCode, body, err)
+	}
+	if statusCode == http.StatusAccepted {
+		if resp, ok := e.followDeviceTask(taskID, targetURI, string(req.RequestBody), uuid, body); !ok {
+			return resp
+		}
+	}
+	back, cancelled := e.waitForManagerReset(taskID, targetURI, string(req.RequestBody), uuid, managerID)
+	if cancelled {
+		return cancelledResponse(taskID)
+	}
+	e.refreshManager(uuid, managerID)
+	if !back {
+		err := fmt.Errorf("error: the manager %v did not answer within %v after its reset", req.ManagerID, managerResetTimeout)
+		return e.failTask(taskID, targetURI, string(req.RequestBody), http.MethodPost, http.StatusInternalServerError, nil, err)
+	}
+
+	resp := successResponse()
+	e.completeTask(taskID, targetURI, string(req.RequestBody), http.MethodPost, resp)
+	return resp
+}
+
+// UpdateManagersResource patches the network protocol or an ethernet interface of the BMC of a server, as the
+// task with the given ID. The resource is read again from the BMC once patched and saved in the inventory.
+func (e *ExternalInterface) UpdateManagersResource(taskID string, req *managersproto.ManagerRequest) response.RPC {
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, TaskRequest: string(req.RequestBody), UpdateTask: e.UpdateTask}
+	if !e.updateTaskProgress(taskID, targetURI, string(req.RequestBody), http.MethodPatch, 0) {
+		return cancelledResponse(taskID)
+	}
+
+	var patchRequest map[string]interface{}
+	if err := json.Unmarshal(req.RequestBody, &patchRequest); err != nil {
+		errorMessage := "error while trying to unmarshal the manager resource update request: " + err.Error()
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, taskInfo)
+	}
+	uuid, managerID, ok := splitManagerID(req.ManagerID)
+	if !ok {
+		errorMessage := "error: the manager " + req.ManagerID + " is not the manager of a server"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Manager", req.ManagerID}, taskInfo)
+	}
+	urlData := strings.Split(strings.TrimSuffix(req.URL, "/"), "/")
+	var tableName string
+	if req.ResourceID == "" {
+		tableName = common.ManagersResource[urlData[len(urlData)-1]]
+	} else {
+		tableName = urlData[len(urlData)-2]
+	}
+
+	oid := strings.Replace(req.URL, uuid+":"+managerID, managerID, -1)
+	body, statusCode, err := e.callDevice(uuid, oid, http.MethodPatch, req.RequestBody)
+	if err != nil {
+		return e.failTask(taskID, targetURI, string(req.RequestBody), http.MethodPatch, statusCode, body, err)
+	}
+
+	resp := successResponse()
+	data, err := e.getResourceInfoFromDevice(req.URL, uuid, managerID)
+	if err != nil {
+		log.Printf("warning: unable to read %v again once updated: %v", req.URL, err)
+	} else {
+		if err := e.DB.SaveResource(tableName, req.URL, data); err != nil {
+			log.Printf("warning: unable to save %v once updated: %v", req.URL, err)
+		}
+		var resource map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &resource); err == nil {
+			resp.Body = resource
+		}
+	}
+	e.completeTask(taskID, targetURI, string(req.RequestBody), http.MethodPatch, resp)
+	return resp
+}
+
+// followDeviceTask waits for the end of the task the BMC answered the reset with, the task is failed when
+// the task of the BMC does not complete. The BMC is not answering while it restarts, the errors are retried
+// until managerResetTimeout.
+func (e *ExternalInterface) followDeviceTask(taskID, targetURI, taskRequest, uuid string, body []byte) (response.RPC, bool) {
+	var deviceTask struct {
+		OdataID         string `json:"@odata.id"`
+		TaskState       string `json:"TaskState"`
+		PercentComplete int32  `json:"PercentComplete"`
+	}
+	if err := json.Unmarshal(body, &deviceTask); err != nil || deviceTask.OdataID == "" {
+		log.Printf("warning: the task of the manager reset %v can not be followed, the BMC answered %v", taskID, string(body))
+		return response.RPC{}, true
+	}
+	taskURI := deviceTask.OdataID
+	for deadline := time.Now().Add(managerResetTimeout); ; {
+		switch deviceTask.TaskState {
+		case common.Completed:
+			return response.RPC{}, true
+		case common.Exception, common.Killed, common.Cancelled:
+			err := fmt.Errorf("error: the task %v of the manager reset ended in the state %v", taskURI, deviceTask.TaskState)
+			return e.failTask(taskID, targetURI, taskRequest, http.MethodPost, http.StatusInternalServerError, nil, err), false
+		}
+		if time.Now().After(deadline) {
+			err := fmt.Errorf("error: the task %v of the manager reset did not end within %v", taskURI, managerResetTimeout)
+			return e.failTask(taskID, targetURI, taskRequest, http.MethodPost, http.StatusInternalServerError, nil, err), false
+		}
+		// the first half of the reset is the task of the BMC, the second one is waiting for the BMC
+		if !e.updateTaskProgress(taskID, targetURI, taskRequest, http.MethodPost, deviceTask.PercentComplete/2) {
+			return cancelledResponse(taskID), false
+		}
+		time.Sleep(managerResetPollingInterval)
+		taskBody, _, err := e.callDevice(uuid, taskURI, http.MethodGet, nil)
+		if err != nil {
+			log.Printf("warning: unable to get the task %v of the manager reset: %v", taskURI, err)
+			continue
+		}
+		if err := json.Unmarshal(taskBody, &deviceTask); err != nil {
+			log.Printf("warning: unable to unmarshal the task %v of the manager reset: %v", taskURI, err)
+		}
+	}
+}
+
+// waitForManagerReset waits for the BMC to go down and answer again, it returns whether the BMC is back
+// and whether the task was cancelled. A BMC never seen down is taken as reset after managerResetDownTime.
+func (e *ExternalInterface) waitForManagerReset(taskID, targetURI, taskRequest, uuid, managerID string) (bool, bool) {
+	managerURI := "/redfish/v1/Managers/" + uuid + ":" + managerID
+	wentDown := false
+	start := time.Now()
+	for time.Since(start) < managerResetTimeout {
+		if !e.updateTaskProgress(taskID, targetURI, taskRequest, http.MethodPost, 50) {
+			return false, true
+		}
+		time.Sleep(managerResetPollingInterval)
+		if _, err := e.getResourceInfoFromDevice(managerURI, uuid, managerID); err != nil {
+			wentDown = true
+			continue
+		}
+		if wentDown || time.Since(start) >= managerResetDownTime {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// splitManagerID splits the ID of the manager of a server into the UUID of the server and the ID of the manager on the BMC
+func splitManagerID(id string) (string, string, bool) {
+	requestData := strings.SplitN(id, ":", 2)
+	if len(requestData) != 2 || requestData[0] == "" || requestData[1] == "" {
+		return "", "", false
+	}
+	return requestData[0], requestData[1], true
+}
+
+// validateRequestProperties checks the case of the properties of the request, the task is failed when one is unknown
+func validateRequestProperties(body []byte, request interface{}, taskInfo *common.TaskUpdateInfo) (response.RPC, bool) {
+	invalidProperties, err := common.RequestParamsCaseValidator(body, request)
+	if err != nil {
+		errorMessage := "error while validating request parameters: " + err.Error()
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, taskInfo), false
+	}
+	if invalidProperties != "" {
+		errorMessage := "error: one or more properties given in the request body are not valid, ensure properties are listed in uppercamelcase "
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{invalidProperties}, taskInfo), false
+	}
+	return response.RPC{}, true
+}
+
+// callDevice sends the request to the BMC of the server through its plugin, it returns the response of
+// the plugin and its status code
+func (e *ExternalInterface) callDevice(uuid, oid, method string, body []byte) ([]byte, int32, error) {
+	target, gerr := e.DB.GetTarget(uuid)
+	if gerr != nil {
+		return nil, http.StatusNotFound, fmt.Errorf("error while trying to get the server %v: %v", uuid, gerr.Error())
+	}
+	plugin, gerr := e.DB.GetPluginData(target.PluginID)
+	if gerr != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error while trying to get the plugin %v: %v", target.PluginID, gerr.Error())
+	}
+	decryptedPasswordByte, err := e.Device.DecryptDevicePassword(target.Password)
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("error while trying to decrypt device password: %v", err)
+	}
+	var contactRequest mgrcommon.PluginContactRequest
+	contactRequest.ContactClient = e.Device.ContactClient
+	contactRequest.Plugin = plugin
+	if strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		token := mgrcommon.GetPluginToken(contactRequest)
+		if token == "" {
+			return nil, http.StatusUnauthorized, fmt.Errorf("error: Unable to create session with plugin %v", plugin.ID)
+		}
+		contactRequest.Token = token
+	} else {
+		contactRequest.BasicAuth = map[string]string{
+			"UserName": plugin.Username,
+			"Password": string(plugin.Password),
+		}
+	}
+	contactRequest.DeviceInfo = map[string]interface{}{
+		"ManagerAddress": target.ManagerAddress,
+		"UserName":       target.UserName,
+		"Password":       decryptedPasswordByte,
+		"PostBody":       body,
+	}
+	contactRequest.OID = oid
+	contactRequest.HTTPMethodType = method
+	errorMessage := "error while sending " + method + " " + oid + ": "
+	respBody, _, status, err := mgrcommon.ContactPlugin(contactRequest, errorMessage)
+	if err != nil && status.StatusCode == http.StatusUnauthorized && strings.EqualFold(plugin.PreferredAuthType, "XAuthToken") {
+		respBody, _, status, err = mgrcommon.RetryManagersOperation(contactRequest, errorMessage)
+	}
+	return respBody, status.StatusCode, err
+}
+
+// refreshManager reads again the manager of the server from its BMC and saves it in the inventory
+func (e *ExternalInterface) refreshManager(uuid, managerID string) {
+	managerURI := "/redfish/v1/Managers/" + uuid + ":" + managerID
+	data, gerr := e.DB.GetManagerByURL(managerURI)
+	if gerr != nil {
+		log.Printf("warning: unable to get the manager %v: %v", managerURI, gerr.Error())
+		return
+	}
+	var managerData map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &managerData); err != nil {
+		log.Printf("warning: unable to unmarshal the manager %v: %v", managerURI, err)
+		return
+	}
+	deviceData, err := e.getResourceInfoFromDevice(managerURI, uuid, managerID)
+	if err != nil {
+		// the manager is read again by the next request getting it
+		log.Printf("warning: manager %v is unreachable after its reset: %v", managerURI, err)
+		managerData["Status"] = map[string]string{
+			"State": "Starting",
+		}
+	} else if err := json.Unmarshal([]byte(deviceData), &managerData); err != nil {
+		log.Printf("warning: unable to unmarshal the manager %v: %v", managerURI, err)
+		return
+	}
+	if err := e.DB.UpdateManagersData(managerURI, managerData); err != nil {
+		log.Printf("warning: unable to save the manager %v: %v", managerURI, err)
+	}
+}
+
+func successResponse() response.RPC {
+	args := response.Args{
+		Code:    response.Success,
+		Message: "Request completed successfully",
+	}
+	return response.RPC{
+		StatusCode:    http.StatusOK,
+		StatusMessage: response.Success,
+		Header: map[string]string{
+			"Cache-Control":     "no-cache",
+			"Connection":        "keep-alive",
+			"Content-type":      "application/json; charset=utf-8",
+			"Transfer-Encoding": "chunked",
+			"OData-Version":     "4.0",
+		},
+		Body: args.CreateGenericErrorResponse(),
+	}
+}
+
+// updateTaskProgress updates the progress of the running task, it returns false when the task is cancelled
+// and its work must stop, the task is already marked as cancelled by UpdateTask
+func (e *ExternalInterface) updateTaskProgress(taskID, targetURI, taskRequest, method string, percentComplete int32) bool {
+	err := e.UpdateTask(common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       targetURI,
+		TaskRequest:     taskRequest,
+		TaskState:       common.Running,
+		TaskStatus:      common.OK,
+		PercentComplete: percentComplete,
+		HTTPMethod:      method,
+	})
+	if err != nil && err.Error() == common.Cancelling {
+		log.Printf("info: task %v is cancelled, its work is stopped", taskID)
+		return false
+	}
+	if err != nil {
+		log.Printf("error while contacting task-service with UpdateTask RPC : %v", err)
+	}
+	return true
+}
+
+// cancelledResponse is the response of a task cancelled before the end of its work
+func cancelledResponse(taskID string) response.RPC {
+	errorMessage := "error: the task " + taskID + " was cancelled"
+	log.Println(errorMessage)
+	return common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil)
+}
+
+// failTask fails the task with the response of the plugin, or with an internal error when the plugin did not answer
+func (e *ExternalInterface) failTask(taskID, targetURI, taskRequest, method string, statusCode int32, body []byte, err error) response.RPC {
+	log.Println(err.Error())
+	var resp response.RPC
+	if body == nil || json.Unmarshal(body, &resp.Body) != nil {
+		if statusCode == 0 {
+			statusCode = http.StatusInternalServerError
+		}
+		resp = common.GeneralError(statusCode, response.InternalError, err.Error(), nil, nil)
+	}
+	resp.StatusCode = statusCode
+	resp.Header = map[string]string{"Content-type": "application/json; charset=utf-8"}
+	e.UpdateTask(common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       targetURI,
+		TaskRequest:     taskRequest,
+		Response:        resp,
+		TaskState:       common.Exception,
+		TaskStatus:      common.Critical,
+		PercentComplete: 100,
+		HTTPMethod:      method,
+	})
+	return resp
+}
+
+func (e *ExternalInterface) completeTask(taskID, targetURI, taskRequest, method string, resp response.RPC) {
+	err := e.UpdateTask(common.TaskData{
+		TaskID:          taskID,
+		TargetURI:       targetURI,
+		TaskRequest:     taskRequest,
+		Response:        resp,
+		TaskState:       common.Completed,
+		TaskStatus:      common.OK,
+		PercentComplete: 100,
+		HTTPMethod:      method,
+	})
+	if err != nil {
+		log.Printf("error while contacting task-service with UpdateTask RPC : %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	"github.com/ODIM-Project/ODIM/lib-utilities/errors"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrcommon"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockManagerUpdate records the calls of the manager changes to the plugin, the database and the task service
+type mockManagerUpdate struct {
+	pluginCalls []string
+	saved       map[string]string
+	tasks       []common.TaskData
+	// managerDown is the number of reads of the manager failing while the BMC restarts
+	managerDown  int
+	managerReads int
+	// cancelAt is the number of the task update answered with the cancellation of the task
+	cancelAt int
+}
+
+// mockDeviceTasks are the tasks of the BMCs answering the reset with a task
+var mockDeviceTasks = map[string]string{
+	"https://localhost:9093/ODIM/v1/Managers/3/Actions/Manager.Reset": `{"@odata.id": "/redfish/v1/TaskService/Tasks/1", "TaskState": "Running"}`,
+	"https://localhost:9093/ODIM/v1/Managers/4/Actions/Manager.Reset": `{"@odata.id": "/redfish/v1/TaskService/Tasks/2", "TaskState": "New"}`,
+}
+
+func (m *mockManagerUpdate) externalInterface() *ExternalInterface {
+	e := mockGetExternalInterface()
+	e.Device.ContactClient = m.contactClient
+	e.Device.DecryptDevicePassword = func(password []byte) ([]byte, error) {
+		return password, nil
+	}
+	e.DB.GetTarget = func(uuid string) (*mgrmodel.DeviceTarget, *errors.Error) {
+		if uuid == "unknown" {
+			return nil, errors.PackError(errors.DBKeyNotFound, "not found")
+		}
+		return &mgrmodel.DeviceTarget{ManagerAddress: "10.24.0.12", UserName: "admin", PluginID: "somePlugin"}, nil
+	}
+	e.DB.SaveResource = func(table, key, data string) error {
+		m.saved[table+":"+key] = data
+		return nil
+	}
+	e.Device.GetDeviceInfo = func(req mgrcommon.ResourceInfoRequest) (string, error) {
+		m.managerReads++
+		if m.managerReads <= m.managerDown {
+			return "", fmt.Errorf("error: the BMC is not answering")
+		}
+		return mockGetDeviceInfo(req)
+	}
+	e.UpdateTask = func(task common.TaskData) error {
+		m.tasks = append(m.tasks, task)
+		if len(m.tasks) == m.cancelAt {
+			return fmt.Errorf(common.Cancelling)
+		}
+		return nil
+	}
+	return e
+}
+
+func (m *mockManagerUpdate) contactClient(url, method, token string, odataID string, body interface{}, loginCredential map[string]string) (*http.Response, error) {
+	m.pluginCalls = append(m.pluginCalls, method+" "+url)
+	statusCode, respBody := http.StatusNoContent, `{}`
+	switch url {
+	case "https://localhost:9093/ODIM/v1/Managers/2/Actions/Manager.Reset":
+		statusCode = http.StatusBadRequest
+	case "https://localhost:9093/ODIM/v1/TaskService/Tasks/1":
+		statusCode, respBody = http.StatusOK, `{"@odata.id": "/redfish/v1/TaskService/Tasks/1", "TaskState": "Completed", "PercentComplete": 100}`
+	case "https://localhost:9093/ODIM/v1/TaskService/Tasks/2":
+		statusCode, respBody = http.StatusOK, `{"@odata.id": "/redfish/v1/TaskService/Tasks/2", "TaskState": "Exception"}`
+	}
+	if task, ok := mockDeviceTasks[url]; ok {
+		statusCode, respBody = http.StatusAccepted, task
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(respBody)),
+	}, nil
+}
+
+func (m *mockManagerUpdate) lastTask() common.TaskData {
+	return m.tasks[len(m.tasks)-1]
+}
+
+// setManagerResetTiming shortens the waits for the BMCs being reset, it returns the function restoring them
+func setManagerResetTiming(downTime, timeout time.Duration) func() {
+	interval, down, max := managerResetPollingInterval, managerResetDownTime, managerResetTimeout
+	managerResetPollingInterval, managerResetDownTime, managerResetTimeout = time.Millisecond, downTime, timeout
+	return func() {
+		managerResetPollingInterval, managerResetDownTime, managerResetTimeout = interval, down, max
+	}
+}
+
+func TestResetManager(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer setManagerResetTiming(0, time.Second)()
+	tests := []struct {
+		name       string
+		managerID  string
+		body       string
+		statusCode int32
+		taskState  string
+	}{
+		{"reset", "uuid:1", `{"ResetType": "GracefulRestart"}`, http.StatusOK, common.Completed},
+		{"unsupported reset type", "uuid:1", `{"ResetType": "On"}`, http.StatusBadRequest, common.Exception},
+		{"missing reset type", "uuid:1", `{}`, http.StatusBadRequest, common.Exception},
+		{"unknown property", "uuid:1", `{"resettype": "ForceRestart"}`, http.StatusBadRequest, common.Exception},
+		{"manager of no server", config.Data.RootServiceUUID, `{"ResetType": "ForceRestart"}`, http.StatusNotFound, common.Exception},
+		{"unknown server", "unknown:1", `{"ResetType": "ForceRestart"}`, http.StatusNotFound, common.Exception},
+		{"reset rejected by the BMC", "uuid:2", `{"ResetType": "ForceRestart"}`, http.StatusBadRequest, common.Exception},
+		{"reset as a task of the BMC", "uuid:3", `{"ResetType": "ForceRestart"}`, http.StatusOK, common.Completed},
+		{"task of the BMC failed", "uuid:4", `{"ResetType": "ForceRestart"}`, http.StatusInternalServerError, common.Exception},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockManagerUpdate{saved: make(map[string]string)}
+			e := mock.externalInterface()
+			resp := e.ResetManager("task1", &managersproto.ManagerRequest{
+				ManagerID:   tt.managerID,
+				RequestBody: []byte(tt.body),
+			})
+			assert.Equal(t, tt.statusCode, resp.StatusCode, "status code")
+			assert.Equal(t, common.Running, mock.tasks[0].TaskState, "the task should be started first")
+			assert.Equal(t, tt.taskState, mock.lastTask().TaskState, "task state")
+			assert.Equal(t, int32(100), mock.lastTask().PercentComplete, "task completion")
+			assert.Equal(t, tt.statusCode, mock.lastTask().Response.StatusCode, "task response status code")
+		})
+	}
+}
+
+func TestResetManagerWaitsForTheBMC(t *testing.T) {
+	config.SetUpMockConfig(t)
+	defer setManagerResetTiming(time.Second, 100*time.Millisecond)()
+	request := &managersproto.ManagerRequest{ManagerID: "uuid:3", RequestBody: []byte(`{"ResetType": "ForceRestart"}`)}
+
+	mock := &mockManagerUpdate{saved: make(map[string]string), managerDown: 2}
+	resp := mock.externalInterface().ResetManager("task1", request)
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "status code")
+	assert.Equal(t, []string{
+		"POST https://localhost:9093/ODIM/v1/Managers/3/Actions/Manager.Reset",
+		"GET https://localhost:9093/ODIM/v1/TaskService/Tasks/1",
+	}, mock.pluginCalls, "the task of the BMC should be followed")
+	assert.Equal(t, 4, mock.managerReads, "the manager should be read until the BMC is back, then refreshed")
+	assert.Equal(t, common.Completed, mock.lastTask().TaskState, "task state")
+
+	mock = &mockManagerUpdate{saved: make(map[string]string), managerDown: 1000}
+	resp = mock.externalInterface().ResetManager("task2", request)
+	assert.Equal(t, http.StatusInternalServerError, int(resp.StatusCode), "status code of a BMC not coming back")
+	assert.Equal(t, common.Exception, mock.lastTask().TaskState, "task state")
+
+	mock = &mockManagerUpdate{saved: make(map[string]string), managerDown: 1000, cancelAt: 3}
+	mock.externalInterface().ResetManager("task3", request)
+	assert.Len(t, mock.tasks, 3, "the task should not be updated once cancelled")
+	assert.Equal(t, common.Running, mock.lastTask().TaskState, "the task is marked as cancelled by the task service")
+	assert.Zero(t, mock.managerReads, "the BMC should not be waited for once the task is cancelled")
+}
+
+func TestUpdateManagersResource(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mock := &mockManagerUpdate{saved: make(map[string]string)}
+	e := mock.externalInterface()
+	resp := e.UpdateManagersResource("task1", &managersproto.ManagerRequest{
+		ManagerID:   "uuid:1",
+		ResourceID:  "NIC.1",
+		URL:         "/redfish/v1/Managers/uuid:1/EthernetInterfaces/NIC.1",
+		RequestBody: []byte(`{"HostName": "bmc1"}`),
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "status code")
+	assert.Equal(t, []string{"PATCH https://localhost:9093/ODIM/v1/Managers/1/EthernetInterfaces/NIC.1"}, mock.pluginCalls, "plugin calls")
+	assert.Contains(t, mock.saved, "EthernetInterfaces:/redfish/v1/Managers/uuid:1/EthernetInterfaces/NIC.1", "the interface should be saved once updated")
+	assert.Equal(t, common.Completed, mock.lastTask().TaskState, "task state")
+
+	mock = &mockManagerUpdate{saved: make(map[string]string)}
+	e = mock.externalInterface()
+	resp = e.UpdateManagersResource("task2", &managersproto.ManagerRequest{
+		ManagerID:   "uuid:1",
+		URL:         "/redfish/v1/Managers/uuid:1/NetworkProtocol",
+		RequestBody: []byte(`{"NTP":`),
+	})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "status code of a malformed request")
+	assert.Empty(t, mock.pluginCalls, "the malformed request should not be sent to the plugin")
+	assert.Equal(t, common.Exception, mock.lastTask().TaskState, "task state")
+}
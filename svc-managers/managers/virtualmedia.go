@@ -0,0 +1,122 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
+	"github.com/ODIM-Project/ODIM/svc-managers/mgrmodel"
+)
+
+// VirtualMediaInsert inserts the media of the request in a virtual media of the BMC of a server, as the task
+// with the given ID. The virtual media is read again from the BMC once inserted and saved in the inventory.
+func (e *ExternalInterface) VirtualMediaInsert(taskID string, req *managersproto.ManagerRequest) response.RPC {
+	targetURI := req.URL
+	var insertRequest mgrmodel.VirtualMediaInsert
+	err := json.Unmarshal(req.RequestBody, &insertRequest)
+	// the password of the image server is not kept in the task
+	taskRequest := string(req.RequestBody)
+	if err == nil && insertRequest.Password != "" {
+		maskedRequest := insertRequest
+		maskedRequest.Password = "******"
+		maskedBody, _ := json.Marshal(maskedRequest)
+		taskRequest = string(maskedBody)
+	}
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, TaskRequest: taskRequest, UpdateTask: e.UpdateTask}
+	if !e.updateTaskProgress(taskID, targetURI, taskRequest, http.MethodPost, 0) {
+		return cancelledResponse(taskID)
+	}
+
+	if err != nil {
+		errorMessage := "error while trying to unmarshal the insert media request: " + err.Error()
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, taskInfo)
+	}
+	if resp, ok := validateRequestProperties(req.RequestBody, insertRequest, taskInfo); !ok {
+		return resp
+	}
+	if insertRequest.Image == "" {
+		errorMessage := "error: Image is missing in the insert media request"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyMissing, errorMessage, []interface{}{"Image"}, taskInfo)
+	}
+	postBody, _ := json.Marshal(insertRequest)
+	return e.virtualMediaAction(taskID, taskRequest, req, "VirtualMedia.InsertMedia", postBody)
+}
+
+// VirtualMediaEject ejects the media inserted in a virtual media of the BMC of a server, as the task with the
+// given ID. The virtual media is read again from the BMC once ejected and saved in the inventory.
+func (e *ExternalInterface) VirtualMediaEject(taskID string, req *managersproto.ManagerRequest) response.RPC {
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, TaskRequest: string(req.RequestBody), UpdateTask: e.UpdateTask}
+	if !e.updateTaskProgress(taskID, targetURI, string(req.RequestBody), http.MethodPost, 0) {
+		return cancelledResponse(taskID)
+	}
+
+	// the eject action has no parameters
+	var ejectRequest map[string]interface{}
+	if len(strings.TrimSpace(string(req.RequestBody))) != 0 {
+		if err := json.Unmarshal(req.RequestBody, &ejectRequest); err != nil {
+			errorMessage := "error while trying to unmarshal the eject media request: " + err.Error()
+			log.Println(errorMessage)
+			return common.GeneralError(http.StatusBadRequest, response.MalformedJSON, errorMessage, nil, taskInfo)
+		}
+	}
+	if len(ejectRequest) != 0 {
+		var properties []string
+		for property := range ejectRequest {
+			properties = append(properties, property)
+		}
+		errorMessage := "error: the eject media request has no parameters"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusBadRequest, response.PropertyUnknown, errorMessage, []interface{}{strings.Join(properties, ", ")}, taskInfo)
+	}
+	return e.virtualMediaAction(taskID, string(req.RequestBody), req, "VirtualMedia.EjectMedia", []byte("{}"))
+}
+
+// virtualMediaAction posts the action on the virtual media of the request to the BMC of the server,
+// then reads the virtual media again from the BMC and saves it in the inventory
+func (e *ExternalInterface) virtualMediaAction(taskID, taskRequest string, req *managersproto.ManagerRequest, action string, body []byte) response.RPC {
+	targetURI := req.URL
+	taskInfo := &common.TaskUpdateInfo{TaskID: taskID, TargetURI: targetURI, TaskRequest: taskRequest, UpdateTask: e.UpdateTask}
+	uuid, managerID, ok := splitManagerID(req.ManagerID)
+	if !ok {
+		errorMessage := "error: the manager " + req.ManagerID + " is not the manager of a server"
+		log.Println(errorMessage)
+		return common.GeneralError(http.StatusNotFound, response.ResourceNotFound, errorMessage, []interface{}{"Manager", req.ManagerID}, taskInfo)
+	}
+	virtualMediaURI := "/redfish/v1/Managers/" + req.ManagerID + "/VirtualMedia/" + req.ResourceID
+	oid := "/redfish/v1/Managers/" + managerID + "/VirtualMedia/" + req.ResourceID + "/Actions/" + action
+	respBody, statusCode, err := e.callDevice(uuid, oid, http.MethodPost, body)
+	if err != nil {
+		return e.failTask(taskID, targetURI, taskRequest, http.MethodPost, statusCode, respBody, err)
+	}
+
+	data, err := e.getResourceInfoFromDevice(virtualMediaURI, uuid, managerID)
+	if err != nil {
+		log.Printf("warning: unable to read %v again after %v: %v", virtualMediaURI, action, err)
+	} else if err := e.DB.SaveResource("VirtualMedia", virtualMediaURI, data); err != nil {
+		log.Printf("warning: unable to save %v after %v: %v", virtualMediaURI, action, err)
+	}
+	resp := successResponse()
+	e.completeTask(taskID, targetURI, taskRequest, http.MethodPost, resp)
+	return resp
+}
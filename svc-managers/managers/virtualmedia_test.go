@@ -0,0 +1,90 @@
+//(C) Copyright [2020] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package managers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ODIM-Project/ODIM/lib-utilities/common"
+	"github.com/ODIM-Project/ODIM/lib-utilities/config"
+	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVirtualMediaInsert(t *testing.T) {
+	config.SetUpMockConfig(t)
+	tests := []struct {
+		name       string
+		body       string
+		statusCode int32
+	}{
+		{"insert", `{"Image": "http://10.0.0.1/os.iso", "Inserted": true, "Password": "secret"}`, http.StatusOK},
+		{"missing image", `{"Inserted": true}`, http.StatusBadRequest},
+		{"unknown property", `{"image": "http://10.0.0.1/os.iso"}`, http.StatusBadRequest},
+		{"malformed request", `{"Image":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockManagerUpdate{saved: make(map[string]string)}
+			e := mock.externalInterface()
+			resp := e.VirtualMediaInsert("task1", &managersproto.ManagerRequest{
+				ManagerID:   "uuid:1",
+				ResourceID:  "CD1",
+				URL:         "/redfish/v1/Managers/uuid:1/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia",
+				RequestBody: []byte(tt.body),
+			})
+			assert.Equal(t, tt.statusCode, resp.StatusCode, "status code")
+			assert.Equal(t, tt.statusCode, mock.lastTask().Response.StatusCode, "task response status code")
+			for _, task := range mock.tasks {
+				assert.False(t, strings.Contains(task.TaskRequest, "secret"), "the password should not be kept in the task")
+			}
+			if tt.statusCode != http.StatusOK {
+				assert.Empty(t, mock.pluginCalls, "the invalid request should not be sent to the plugin")
+				return
+			}
+			assert.Equal(t, []string{"POST https://localhost:9093/ODIM/v1/Managers/1/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia"}, mock.pluginCalls, "plugin calls")
+			assert.Contains(t, mock.saved, "VirtualMedia:/redfish/v1/Managers/uuid:1/VirtualMedia/CD1", "the virtual media should be saved once inserted")
+			assert.Equal(t, common.Completed, mock.lastTask().TaskState, "task state")
+		})
+	}
+}
+
+func TestVirtualMediaEject(t *testing.T) {
+	config.SetUpMockConfig(t)
+	mock := &mockManagerUpdate{saved: make(map[string]string)}
+	e := mock.externalInterface()
+	resp := e.VirtualMediaEject("task1", &managersproto.ManagerRequest{
+		ManagerID:  "uuid:1",
+		ResourceID: "CD1",
+		URL:        "/redfish/v1/Managers/uuid:1/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia",
+	})
+	assert.Equal(t, http.StatusOK, int(resp.StatusCode), "status code")
+	assert.Equal(t, []string{"POST https://localhost:9093/ODIM/v1/Managers/1/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia"}, mock.pluginCalls, "plugin calls")
+	assert.Equal(t, common.Completed, mock.lastTask().TaskState, "task state")
+
+	mock = &mockManagerUpdate{saved: make(map[string]string)}
+	e = mock.externalInterface()
+	resp = e.VirtualMediaEject("task2", &managersproto.ManagerRequest{
+		ManagerID:   "uuid:1",
+		ResourceID:  "CD1",
+		URL:         "/redfish/v1/Managers/uuid:1/VirtualMedia/CD1/Actions/VirtualMedia.EjectMedia",
+		RequestBody: []byte(`{"Image": "http://10.0.0.1/os.iso"}`),
+	})
+	assert.Equal(t, http.StatusBadRequest, int(resp.StatusCode), "status code of a request with parameters")
+	assert.Empty(t, mock.pluginCalls, "the invalid request should not be sent to the plugin")
+	assert.Equal(t, common.Exception, mock.lastTask().TaskState, "task state")
+}
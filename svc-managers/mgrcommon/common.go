@@ -171,11 +171,15 @@ func ContactPlugin(req PluginContactRequest, errorMessage string) ([]byte, strin
 		return nil, "", resp, fmt.Errorf(errorMessage)
 	}
 
-	if !(response.StatusCode == http.StatusOK || response.StatusCode == http.StatusCreated) {
+	// the actions on the BMCs answer without content or with a task of the BMC
+	if !(response.StatusCode == http.StatusOK || response.StatusCode == http.StatusCreated ||
+		response.StatusCode == http.StatusAccepted || response.StatusCode == http.StatusNoContent) {
 		resp.StatusCode = int32(response.StatusCode)
 		log.Println(errorMessage)
 		return body, "", resp, fmt.Errorf(errorMessage)
 	}
+	// the status tells the callers whether the BMC answered with a task
+	resp.StatusCode = int32(response.StatusCode)
 	data := string(body)
 	//replacing the resposne with north bound translation URL
 	for key, value := range config.Data.URLTranslation.NorthBoundURL {
@@ -40,6 +40,7 @@ type Plugin struct {
 	ID                string
 	PluginType        string
 	PreferredAuthType string
+	Instances         []string
 }
 
 //GetSystemByUUID fetches computer system details by UUID from database
@@ -166,6 +166,34 @@ func UpdateManagersData(key string, managerData map[string]interface{}) error {
 	return nil
 }
 
+// ManagerReset is the request body of the Manager.Reset action
+type ManagerReset struct {
+	ResetType string `json:"ResetType"`
+}
+
+// VirtualMediaInsert is the request body of the VirtualMedia.InsertMedia action
+type VirtualMediaInsert struct {
+	Image                string `json:"Image"`
+	Inserted             *bool  `json:"Inserted,omitempty"`
+	WriteProtected       *bool  `json:"WriteProtected,omitempty"`
+	UserName             string `json:"UserName,omitempty"`
+	Password             string `json:"Password,omitempty"`
+	TransferMethod       string `json:"TransferMethod,omitempty"`
+	TransferProtocolType string `json:"TransferProtocolType,omitempty"`
+}
+
+// SaveResource adds or replaces the resource data with the given key in the database
+func SaveResource(table, key, data string) error {
+	conn, err := common.GetDBConnection(common.InMemory)
+	if err != nil {
+		return fmt.Errorf("error while trying to connect to DB: %v", err)
+	}
+	if err = conn.AddResourceData(table, key, data); err != nil {
+		return fmt.Errorf("error while trying to save %v resource: %v", table, err)
+	}
+	return nil
+}
+
 //GenericSave will save any resource data into the database
 func GenericSave(body []byte, table string, key string) error {
 
@@ -19,16 +19,20 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/ODIM-Project/ODIM/lib-utilities/common"
 	managersproto "github.com/ODIM-Project/ODIM/lib-utilities/proto/managers"
+	"github.com/ODIM-Project/ODIM/lib-utilities/response"
 	"github.com/ODIM-Project/ODIM/svc-managers/managers"
 )
 
 // Managers struct helps to register service
 type Managers struct {
-	IsAuthorizedRPC func(sessionToken string, privileges, oemPrivileges []string) (int32, string)
-	EI              *managers.ExternalInterface
+	IsAuthorizedRPC    func(sessionToken string, privileges, oemPrivileges []string) (int32, string)
+	GetSessionUserName func(sessionToken string) (string, error)
+	CreateTask         func(sessionUserName string) (string, error)
+	EI                 *managers.ExternalInterface
 }
 
 //GetManagersCollection defines the operation which hasnled the RPC request response
@@ -110,6 +114,107 @@ func (m *Managers) GetManagersResource(ctx context.Context, req *managersproto.M
 	return nil
 }
 
+// ResetManager defines the operations which handles the RPC request response
+// for the reset of the BMC of a server. The reset is done as a task,
+// the response is the task monitor of the task.
+func (m *Managers) ResetManager(ctx context.Context, req *managersproto.ManagerRequest, resp *managersproto.ManagerResponse) error {
+	taskID, ok := m.startTask(req, resp)
+	if !ok {
+		return nil
+	}
+	go m.EI.ResetManager(taskID, req)
+	return nil
+}
+
+// UpdateManagersResource defines the operations which handles the RPC request response
+// for the update of the network protocol or of an ethernet interface of the BMC of a server.
+// The update is done as a task, the response is the task monitor of the task.
+func (m *Managers) UpdateManagersResource(ctx context.Context, req *managersproto.ManagerRequest, resp *managersproto.ManagerResponse) error {
+	taskID, ok := m.startTask(req, resp)
+	if !ok {
+		return nil
+	}
+	go m.EI.UpdateManagersResource(taskID, req)
+	return nil
+}
+
+// VirtualMediaInsert defines the operations which handles the RPC request response
+// for the insertion of a media in a virtual media of the BMC of a server.
+// The media is inserted as a task, the response is the task monitor of the task.
+func (m *Managers) VirtualMediaInsert(ctx context.Context, req *managersproto.ManagerRequest, resp *managersproto.ManagerResponse) error {
+	taskID, ok := m.startTask(req, resp)
+	if !ok {
+		return nil
+	}
+	go m.EI.VirtualMediaInsert(taskID, req)
+	return nil
+}
+
+// VirtualMediaEject defines the operations which handles the RPC request response
+// for the ejection of the media of a virtual media of the BMC of a server.
+// The media is ejected as a task, the response is the task monitor of the task.
+func (m *Managers) VirtualMediaEject(ctx context.Context, req *managersproto.ManagerRequest, resp *managersproto.ManagerResponse) error {
+	taskID, ok := m.startTask(req, resp)
+	if !ok {
+		return nil
+	}
+	go m.EI.VirtualMediaEject(taskID, req)
+	return nil
+}
+
+// startTask authorizes the request changing a manager and creates its task,
+// it fills the response with the task monitor of the task or with the error
+func (m *Managers) startTask(req *managersproto.ManagerRequest, resp *managersproto.ManagerResponse) (string, bool) {
+	authStatusCode, authStatusMessage := m.IsAuthorizedRPC(req.SessionToken, []string{common.PrivilegeConfigureManager}, []string{})
+	if authStatusCode != http.StatusOK {
+		errorMessage := "error while trying to authenticate session"
+		fillManagerResponse(common.GeneralError(authStatusCode, authStatusMessage, errorMessage, nil, nil), resp)
+		log.Printf(errorMessage)
+		return "", false
+	}
+	sessionUserName, err := m.GetSessionUserName(req.SessionToken)
+	if err != nil {
+		errorMessage := "error while trying to get the session username: " + err.Error()
+		fillManagerResponse(common.GeneralError(http.StatusUnauthorized, response.NoValidSession, errorMessage, nil, nil), resp)
+		log.Printf(errorMessage)
+		return "", false
+	}
+	taskURI, err := m.CreateTask(sessionUserName)
+	if err != nil {
+		errorMessage := "error while trying to create task: " + err.Error()
+		fillManagerResponse(common.GeneralError(http.StatusInternalServerError, response.InternalError, errorMessage, nil, nil), resp)
+		log.Printf(errorMessage)
+		return "", false
+	}
+	taskID := strings.TrimPrefix(strings.TrimSuffix(taskURI, "/"), "/redfish/v1/TaskService/Tasks/")
+	commonResponse := response.Response{
+		OdataType:    "#Task.v1_4_2.Task",
+		ID:           taskID,
+		Name:         "Task " + taskID,
+		OdataContext: "/redfish/v1/$metadata#Task.Task",
+		OdataID:      taskURI,
+	}
+	commonResponse.MessageArgs = []string{taskID}
+	commonResponse.CreateGenericResponse(response.TaskStarted)
+	fillManagerResponse(response.RPC{
+		StatusCode:    http.StatusAccepted,
+		StatusMessage: response.TaskStarted,
+		Header: map[string]string{
+			"Content-type": "application/json; charset=utf-8",
+			"Location":     "/taskmon/" + taskID,
+		},
+		Body: commonResponse,
+	}, resp)
+	return taskID, true
+}
+
+func fillManagerResponse(rpcResp response.RPC, resp *managersproto.ManagerResponse) {
+	resp.StatusCode = rpcResp.StatusCode
+	resp.StatusMessage = rpcResp.StatusMessage
+	resp.Header = rpcResp.Header
+	resp.Body = generateResponse(rpcResp.Body)
+}
+
 func generateResponse(input interface{}) []byte {
 	bytes, err := json.Marshal(input)
 	if err != nil {
@@ -277,3 +277,43 @@ func TestGetManagerResourcewithValidtoken(t *testing.T) {
 	assert.Nil(t, err, "The two words should be the same.")
 	assert.Equal(t, int(resp.StatusCode), http.StatusOK, "Status code should be StatusOK.")
 }
+
+func TestResetManager(t *testing.T) {
+	common.SetUpMockConfig()
+	var ctx context.Context
+	tasks := make(chan common.TaskData, 2)
+	mgr := new(Managers)
+	mgr.IsAuthorizedRPC = mockIsAuthorized
+	mgr.GetSessionUserName = func(sessionToken string) (string, error) {
+		return "admin", nil
+	}
+	mgr.CreateTask = func(sessionUserName string) (string, error) {
+		return "/redfish/v1/TaskService/Tasks/task12345", nil
+	}
+	mgr.EI = mockGetExternalInterface()
+	mgr.EI.UpdateTask = func(task common.TaskData) error {
+		tasks <- task
+		return nil
+	}
+
+	req := &managersproto.ManagerRequest{
+		ManagerID:    "uuid:1",
+		SessionToken: "InvalidToken",
+		RequestBody:  []byte(`{"ResetType": "PowerCycle"}`),
+	}
+	var resp = &managersproto.ManagerResponse{}
+	mgr.ResetManager(ctx, req, resp)
+	assert.Equal(t, http.StatusUnauthorized, int(resp.StatusCode), "Status code should be StatusUnauthorized.")
+
+	req.SessionToken = "validToken"
+	resp = &managersproto.ManagerResponse{}
+	err := mgr.ResetManager(ctx, req, resp)
+	assert.Nil(t, err, "There should be no error")
+	assert.Equal(t, http.StatusAccepted, int(resp.StatusCode), "Status code should be StatusAccepted.")
+	assert.Equal(t, "/taskmon/task12345", resp.Header["Location"], "Location should be the task monitor")
+	// the unsupported reset type fails the task
+	assert.Equal(t, common.Running, (<-tasks).TaskState, "The task should be running first")
+	task := <-tasks
+	assert.Equal(t, "task12345", task.TaskID, "The task of the reset should be updated")
+	assert.Equal(t, common.Exception, task.TaskState, "The task should fail")
+}